@@ -0,0 +1,75 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+// botAuthor returns the configured bot pattern matching the commit author, or "" for humans
+func botAuthor(commit git.Commit, patterns []string) string {
+	name := strings.ToLower(commit.AuthorName)
+	email := strings.ToLower(commit.AuthorEmail)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if strings.Contains(name, pattern) || strings.Contains(email, pattern) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// isBotCommit reports whether a commit is left out of the generated list because of its author
+func (c *Manager) isBotCommit(commit git.Commit) bool {
+	if c.settings.Changelog.BotCommits == config.BotCommitsInclude {
+		return false
+	}
+	return botAuthor(commit, c.settings.Changelog.BotAuthors) != ""
+}
+
+// botSummaryEntry returns a single "Dependency updates" entry counting bot commits per
+// author when bot commits are aggregated; ok is false otherwise
+func (c *Manager) botSummaryEntry(commits []git.Commit) (ChangeEntry, bool) {
+	if c.settings.Changelog.BotCommits != config.BotCommitsAggregate {
+		return ChangeEntry{}, false
+	}
+
+	counts := make(map[string]int)
+	total := 0
+	for _, commit := range commits {
+		// Dependency bumps are listed individually in the Dependencies section
+		if isDependencyCommit(commit) {
+			continue
+		}
+		if bot := botAuthor(commit, c.settings.Changelog.BotAuthors); bot != "" {
+			counts[bot]++
+			total++
+		}
+	}
+	if total == 0 {
+		return ChangeEntry{}, false
+	}
+
+	var bots []string
+	for bot := range counts {
+		bots = append(bots, bot)
+	}
+	sort.Strings(bots)
+
+	var parts []string
+	for _, bot := range bots {
+		parts = append(parts, fmt.Sprintf("%s: %d", bot, counts[bot]))
+	}
+
+	return ChangeEntry{
+		Emoji: "⬆️",
+		Text: fmt.Sprintf("Dependency updates: %d automated %s (%s)",
+			total, pluralize(total, "commit", "commits"), strings.Join(parts, ", ")),
+	}, true
+}
@@ -0,0 +1,58 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestBotAuthor(t *testing.T) {
+	patterns := config.DefaultSettings().Changelog.BotAuthors
+
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		expected string
+	}{
+		{"human", git.Commit{AuthorName: "Jane Doe", AuthorEmail: "jane@example.com"}, ""},
+		{"dependabot", git.Commit{AuthorName: "dependabot[bot]", AuthorEmail: "49699333+dependabot[bot]@users.noreply.github.com"}, "dependabot"},
+		{"renovate by email", git.Commit{AuthorName: "Mend", AuthorEmail: "bot@renovateapp.com"}, "renovate"},
+		{"github actions", git.Commit{AuthorName: "GitHub-Actions[bot]"}, "github-actions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := botAuthor(tt.commit, patterns); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderBotSummary(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.Changelog.BotCommits = config.BotCommitsAggregate
+	manager := &Manager{settings: settings}
+
+	commits := []git.Commit{
+		{AuthorName: "dependabot[bot]", Message: "chore(deps): bump a"},
+		{AuthorName: "dependabot[bot]", Message: "chore(deps): bump b"},
+		{AuthorName: "renovate[bot]", Message: "chore(deps): update c"},
+		{AuthorName: "Jane Doe", Message: "feat: add export"},
+	}
+
+	expected := "- ⬆️ Dependency updates: 3 automated commits (dependabot: 2, renovate: 1)"
+	if got, _ := manager.botSummaryEntry(commits); got.Markdown() != expected {
+		t.Errorf("Expected %q, got %q", expected, got.Markdown())
+	}
+
+	if manager.isBotCommit(commits[3]) || !manager.isBotCommit(commits[0]) {
+		t.Errorf("Expected only bot authored commits to be filtered")
+	}
+
+	settings.Changelog.BotCommits = config.BotCommitsExclude
+	if got, ok := manager.botSummaryEntry(commits); ok {
+		t.Errorf("Expected no summary when excluding bot commits, got %q", got.Markdown())
+	}
+}
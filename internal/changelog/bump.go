@@ -0,0 +1,55 @@
+package changelog
+
+import "bump-tui/internal/git"
+
+// Bump types suggested from conventional commits
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
+
+// PendingCommits returns the commits since fromVersion that the next release would include
+func (c *Manager) PendingCommits(fromVersion string) ([]git.Commit, error) {
+	return c.collectCommits(fromVersion, true)
+}
+
+// SuggestBump infers the bump type from the conventional commits since fromVersion:
+// breaking changes suggest a major bump, features a minor bump and anything else a
+// patch. Commits that aren't conventional are classified by the AI, when
+// ai.classify_bumps is on and a generator is available, and can raise the bump. An
+// empty result means no release is needed because every commit was skipped.
+func (c *Manager) SuggestBump(fromVersion string) (string, error) {
+	needed, _, err := c.ReleaseNeeded(fromVersion)
+	if err != nil {
+		return "", err
+	}
+	if !needed {
+		return "", nil
+	}
+
+	commits, err := c.collectCommits(fromVersion, true)
+	if err != nil {
+		return "", err
+	}
+
+	bump := BumpPatch
+	var manual []git.Commit
+	for _, commit := range commits {
+		parsed, ok := parseConventionalCommit(commit)
+		if !ok {
+			if !c.isUnlistedCommit(commit) {
+				manual = append(manual, commit)
+			}
+			continue
+		}
+		if parsed.Breaking {
+			c.bumpClassifiedBy = ""
+			return BumpMajor, nil
+		}
+		if parsed.Type == "feat" {
+			bump = BumpMinor
+		}
+	}
+	return c.suggestFromManual(fromVersion, bump, manual), nil
+}
@@ -0,0 +1,47 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/gitfixture"
+)
+
+func TestSuggestBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []string
+		expected string
+	}{
+		{"fix", []string{"fix: handle empty input", "docs: describe flags"}, BumpPatch},
+		{"feat", []string{"fix: handle empty input", "feat(api): add export"}, BumpMinor},
+		{"bang", []string{"feat: add export", "refactor(api)!: rename endpoints"}, BumpMajor},
+		{"breaking footer", []string{"feat: drop v1\n\nBREAKING CHANGE: use /v2"}, BumpMajor},
+		{"not conventional", []string{"Update readme"}, BumpPatch},
+		{"all skipped", []string{"chore: bump deps [skip changelog]", "ci: tweak [skip release]"}, ""},
+		{"some skipped", []string{"feat: add export [skip changelog]", "fix: typo"}, BumpPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := gitfixture.New(t)
+			repo.WriteFile("README.md", "# app\n")
+			repo.Commit("chore: initial commit")
+			repo.Tag("1.0.0")
+			for _, message := range tt.commits {
+				repo.Commit(message)
+			}
+			repo.Chdir()
+
+			manager := NewManager()
+			// Commits that aren't conventional stay a patch without the AI
+			manager.settings.AI.ClassifyBumps = false
+			bump, err := manager.SuggestBump("1.0.0")
+			if err != nil {
+				t.Fatalf("SuggestBump failed: %v", err)
+			}
+			if bump != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, bump)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package changelog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheEntry is a generated changelog stored under .git/bump-cache/
+type cacheEntry struct {
+	// Provider is the generator that produced the changelog, e.g. "claude-cli"
+	Provider    string    `json:"provider"`
+	PromptHash  string    `json:"prompt_hash"`
+	CommitRange string    `json:"commit_range"`
+	Changes     string    `json:"changes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// commitRange identifies the commits a changelog covers by resolved hashes, so a
+// moved tag or new commit on HEAD never reuses a stale result
+func (c *Manager) commitRange(fromVersion string) string {
+	until, err := c.gitManager.ResolveRef(c.untilRef())
+	if err != nil {
+		return ""
+	}
+
+	from := "root"
+	if since := c.sinceRef(fromVersion); since != "" {
+		if hash, err := c.gitManager.ResolveRef(since); err == nil {
+			from = hash
+		}
+	}
+
+	return fmt.Sprintf("%s..%s", from, until)
+}
+
+// cachePath returns the cache file for a (provider, prompt, commit range) key
+func (c *Manager) cachePath(provider, promptHash, commitRange string) (string, error) {
+	gitDir, err := c.gitManager.GetGitDir()
+	if err != nil {
+		return "", err
+	}
+
+	key := sha256.Sum256([]byte(provider + "\n" + promptHash + "\n" + commitRange))
+	return filepath.Join(gitDir, "bump-cache", hex.EncodeToString(key[:])+".json"), nil
+}
+
+func hashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadCached returns a previously generated changelog for the key, if present
+func (c *Manager) loadCached(provider, prompt, commitRange string) (string, bool) {
+	if commitRange == "" {
+		return "", false
+	}
+
+	path, err := c.cachePath(provider, hashPrompt(prompt), commitRange)
+	if err != nil {
+		return "", false
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil || entry.Changes == "" {
+		return "", false
+	}
+
+	return entry.Changes, true
+}
+
+// storeCached saves a generated changelog; failures are logged but never fatal
+func (c *Manager) storeCached(provider, prompt, commitRange, changes string) {
+	if commitRange == "" {
+		return
+	}
+
+	promptHash := hashPrompt(prompt)
+	path, err := c.cachePath(provider, promptHash, commitRange)
+	if err != nil {
+		log.Printf("Warning: unable to cache changelog: %v", err)
+		return
+	}
+
+	content, err := json.MarshalIndent(cacheEntry{
+		Provider:    provider,
+		PromptHash:  promptHash,
+		CommitRange: commitRange,
+		Changes:     changes,
+		CreatedAt:   time.Now(),
+	}, "", "  ")
+	if err != nil {
+		log.Printf("Warning: unable to cache changelog: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Printf("Warning: unable to create changelog cache: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		log.Printf("Warning: unable to cache changelog: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bump-tui/internal/gitfixture"
+)
+
+func TestChangelogCache(t *testing.T) {
+	repo := gitfixture.New(t)
+	repo.WriteFile("README.md", "# app\n")
+	repo.Commit("chore: initial commit")
+	repo.Tag("1.0.0")
+	repo.WriteFile("export.go", "package app\n")
+	repo.Commit("feat: add export")
+	repo.Chdir()
+
+	manager := NewManager()
+	commitRange := manager.commitRange("1.0.0")
+	if commitRange == "" {
+		t.Fatalf("Expected a commit range")
+	}
+	if _, ok := manager.loadCached("claude-cli", "prompt", commitRange); ok {
+		t.Fatalf("Expected an empty cache to miss")
+	}
+
+	manager.storeCached("claude-cli", "prompt", commitRange, "- Added export")
+	if changes, ok := manager.loadCached("claude-cli", "prompt", commitRange); !ok || changes != "- Added export" {
+		t.Errorf("Expected a hit, got %q (%v)", changes, ok)
+	}
+	entries, _ := filepath.Glob(filepath.Join(repo.Dir, ".git", "bump-cache", "*.json"))
+	if len(entries) != 1 {
+		t.Errorf("Expected one entry in .git/bump-cache, got %v", entries)
+	}
+
+	tests := []struct {
+		name        string
+		provider    string
+		prompt      string
+		commitRange string
+	}{
+		{"other provider", "openai", "prompt", commitRange},
+		{"prompt changed", "claude-cli", "prompt with new rules", commitRange},
+		{"range changed", "claude-cli", "prompt", "root.." + commitRange},
+		{"unknown range", "claude-cli", "prompt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changes, ok := manager.loadCached(tt.provider, tt.prompt, tt.commitRange); ok {
+				t.Errorf("Expected a miss, got %q", changes)
+			}
+		})
+	}
+
+	// A new commit moves the end of the range
+	repo.WriteFile("import.go", "package app\n")
+	repo.Commit("feat: add import")
+	moved := manager.commitRange("1.0.0")
+	if moved == commitRange {
+		t.Fatalf("Expected the range to follow HEAD")
+	}
+	if _, ok := manager.loadCached("claude-cli", "prompt", moved); ok {
+		t.Errorf("Expected a miss after a new commit")
+	}
+
+	// Unreadable entries are misses
+	if err := os.WriteFile(entries[0], []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manager.loadCached("claude-cli", "prompt", commitRange); ok {
+		t.Errorf("Expected a corrupt entry to miss")
+	}
+}
@@ -0,0 +1,175 @@
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+// Kinds of change the AI sorts commits that aren't conventional into
+const (
+	ChangeBreaking = "breaking"
+	ChangeFeature  = "feature"
+	ChangeFix      = "fix"
+)
+
+// classifySchema asks for one kind per commit, keyed by the hash given in the prompt
+const classifySchema = `{"type":"object","properties":{"commits":{"type":"array","items":{"type":"object","properties":{"hash":{"type":"string","minLength":1},"kind":{"type":"string","enum":["breaking","feature","fix"]}},"required":["hash","kind"],"additionalProperties":false}}},"required":["commits"],"additionalProperties":false}`
+
+// classifyBump asks the AI generators of the chain, in order, to classify commits
+// that aren't conventional from their messages and diff summaries, and returns the
+// bump the most significant change calls for with the generator that classified them.
+// Cached classifications of the same commits are reused.
+func (c *Manager) classifyBump(fromVersion string, commits []git.Commit) (string, string, error) {
+	prompt := c.classifyPrompt(commits)
+	commitRange := c.commitRange(fromVersion)
+
+	err := fmt.Errorf("no AI generator is available")
+	for _, generator := range c.settings.AI.Generators {
+		if !aiGenerator(generator) || !c.generatorAvailable(generator) {
+			continue
+		}
+
+		output, cached := c.loadCached(generator, prompt, commitRange)
+		if !cached {
+			if output, err = c.classifyWith(generator, prompt); err != nil {
+				err = fmt.Errorf("%s: %v", generator, err)
+				continue
+			}
+		}
+		kinds, parseErr := parseClassification(output, commits)
+		if parseErr != nil {
+			err = fmt.Errorf("%s: %v", generator, parseErr)
+			continue
+		}
+		if !cached {
+			c.storeCached(generator, prompt, commitRange, output)
+		}
+		return bumpForKinds(kinds), generator, nil
+	}
+	return "", "", err
+}
+
+// classifyWith asks one generator for the classification within its timeout
+func (c *Manager) classifyWith(generator, prompt string) (string, error) {
+	timeout := c.generatorTimeout(generator)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := c.complete(ctx, generator, prompt, classifySchema)
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("timed out after %s", timeout)
+	}
+	return output, err
+}
+
+// classifyPrompt lists each commit with its hash, message, changed files and the size
+// of its diff
+func (c *Manager) classifyPrompt(commits []git.Commit) string {
+	var list strings.Builder
+	for _, commit := range commits {
+		list.WriteString(fmt.Sprintf("- %s: %s%s", commit.Hash, commit.Message, formatCommitFiles(commit.Files)))
+		if stat, err := c.gitManager.CommitShortStat(commit.Hash); err == nil && stat.Files > 0 {
+			list.WriteString(fmt.Sprintf(" [%d files changed, +%d/-%d lines]", stat.Files, stat.Insertions, stat.Deletions))
+		}
+		list.WriteString("\n")
+		if body := strings.TrimSpace(commit.Body); body != "" {
+			list.WriteString(fmt.Sprintf("  %s\n", strings.ReplaceAll(body, "\n", "\n  ")))
+		}
+	}
+
+	return fmt.Sprintf(`Classify each of these commits for semantic versioning. The commit messages don't follow conventional commits, so judge from the message, the files changed and the size of the diff.
+
+Commits:
+%s
+Kinds:
+- breaking: removes or changes existing behavior, APIs, options or file formats in a way users must adapt to
+- feature: adds new functionality users can opt into
+- fix: anything else, such as bug fixes, refactoring, documentation, tests and dependency updates
+
+When unsure, prefer the less significant kind.
+
+Output format: only a JSON object, without markdown fences or any text around it:
+{"commits": [{"hash": "a1b2c3d", "kind": "fix"}]}
+`, list.String())
+}
+
+// parseClassification validates a classification response, requiring a known kind
+// for every commit and nothing else
+func parseClassification(output string, commits []git.Commit) (map[string]string, error) {
+	var response struct {
+		Commits []struct {
+			Hash string `json:"hash"`
+			Kind string `json:"kind"`
+		} `json:"commits"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(stripCodeFence(output))))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, fmt.Errorf("the response is not valid classification JSON: %v", err)
+	}
+
+	kinds := make(map[string]string)
+	for i, classified := range response.Commits {
+		switch classified.Kind {
+		case ChangeBreaking, ChangeFeature, ChangeFix:
+		default:
+			return nil, fmt.Errorf("commits[%d] has unknown kind %q", i, classified.Kind)
+		}
+		kinds[strings.TrimSpace(classified.Hash)] = classified.Kind
+	}
+	for _, commit := range commits {
+		if kinds[commit.Hash] == "" {
+			return nil, fmt.Errorf("commit %s isn't classified", commit.Hash)
+		}
+	}
+	return kinds, nil
+}
+
+// bumpForKinds returns the bump of the most significant kind of change
+func bumpForKinds(kinds map[string]string) string {
+	bump := BumpPatch
+	for _, kind := range kinds {
+		switch kind {
+		case ChangeBreaking:
+			return BumpMajor
+		case ChangeFeature:
+			bump = BumpMinor
+		}
+	}
+	return bump
+}
+
+// suggestFromManual raises a bump suggested from conventional commits with the AI's
+// classification of the manual commits, keeping the suggestion when classification
+// fails
+func (c *Manager) suggestFromManual(fromVersion, bump string, manual []git.Commit) string {
+	c.bumpClassifiedBy = ""
+	if bump == BumpMajor || len(manual) == 0 || !c.settings.AI.ClassifyBumps {
+		return bump
+	}
+
+	classified, generator, err := c.classifyBump(fromVersion, manual)
+	if err != nil {
+		log.Printf("Warning: unable to classify commits that aren't conventional: %v", err)
+		return bump
+	}
+	c.bumpClassifiedBy = generator
+	if classified == BumpMajor || (classified == BumpMinor && bump == BumpPatch) {
+		return classified
+	}
+	return bump
+}
+
+// BumpClassifiedBy returns the AI generator that classified the commits that aren't
+// conventional for the last suggested bump, or "" when conventional commits alone
+// decided it
+func (c *Manager) BumpClassifiedBy() string {
+	return c.bumpClassifiedBy
+}
@@ -0,0 +1,116 @@
+package changelog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestParseClassification(t *testing.T) {
+	commits := []git.Commit{{Hash: "a1b2c3d"}, {Hash: "e4f5a6b"}}
+
+	tests := []struct {
+		name      string
+		output    string
+		expected  string
+		expectErr bool
+	}{
+		{"fixes", `{"commits": [{"hash": "a1b2c3d", "kind": "fix"}, {"hash": "e4f5a6b", "kind": "fix"}]}`, BumpPatch, false},
+		{"feature", `{"commits": [{"hash": "a1b2c3d", "kind": "fix"}, {"hash": "e4f5a6b", "kind": "feature"}]}`, BumpMinor, false},
+		{"breaking in a fence", "```json\n{\"commits\": [{\"hash\": \"a1b2c3d\", \"kind\": \"breaking\"}, {\"hash\": \"e4f5a6b\", \"kind\": \"feature\"}]}\n```", BumpMajor, false},
+		{"missing commit", `{"commits": [{"hash": "a1b2c3d", "kind": "fix"}]}`, "", true},
+		{"unknown kind", `{"commits": [{"hash": "a1b2c3d", "kind": "chore"}, {"hash": "e4f5a6b", "kind": "fix"}]}`, "", true},
+		{"prose", "Both commits look like fixes.", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kinds, err := parseClassification(tt.output, commits)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", kinds)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if bump := bumpForKinds(kinds); bump != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, bump)
+			}
+		})
+	}
+}
+
+func TestSuggestFromManual(t *testing.T) {
+	// Outside a repository there is no commit range, so nothing is cached
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	kind := "feature"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []map[string]string `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Messages) == 0 || !strings.Contains(request.Messages[0]["content"], "a1b2c3d: Add CSV export (files: export.go)") {
+			http.Error(w, `{"error": {"message": "unexpected prompt"}}`, http.StatusBadRequest)
+			return
+		}
+		content := `{"commits": [{"hash": "a1b2c3d", "kind": "` + kind + `"}]}`
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": content}}},
+		})
+	}))
+	defer server.Close()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	settings := config.DefaultSettings()
+	settings.AI.Generators = []string{config.GeneratorOpenAI, config.GeneratorRegex}
+	settings.AI.ClassifyBumps = true
+	manager := &Manager{settings: settings, gitManager: git.NewManager(), openAIURL: server.URL}
+	manual := []git.Commit{{Hash: "a1b2c3d", Message: "Add CSV export", Files: []string{"export.go"}}}
+
+	if bump := manager.suggestFromManual("", BumpPatch, manual); bump != BumpMinor {
+		t.Errorf("Expected the feature to raise the bump to minor, got %s", bump)
+	}
+	if generator := manager.BumpClassifiedBy(); generator != config.GeneratorOpenAI {
+		t.Errorf("Expected the classifying generator to be recorded, got %q", generator)
+	}
+
+	// The classification never lowers the conventional commits' suggestion
+	kind = "fix"
+	if bump := manager.suggestFromManual("", BumpMinor, manual); bump != BumpMinor {
+		t.Errorf("Expected minor to be kept, got %s", bump)
+	}
+
+	// A failed classification keeps the suggestion
+	kind = "unknown"
+	if bump := manager.suggestFromManual("", BumpPatch, manual); bump != BumpPatch || manager.BumpClassifiedBy() != "" {
+		t.Errorf("Expected the patch suggestion without a classifier, got %s by %q", bump, manager.BumpClassifiedBy())
+	}
+
+	settings.AI.ClassifyBumps = false
+	kind = "breaking"
+	if bump := manager.suggestFromManual("", BumpPatch, manual); bump != BumpPatch {
+		t.Errorf("Expected no classification when disabled, got %s", bump)
+	}
+}
@@ -0,0 +1,101 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+// lintCommitMessage returns the conventional commit rules a commit subject breaks
+func lintCommitMessage(commit git.Commit, rules config.CommitLintSettings) []string {
+	var problems []string
+
+	if rules.MaxSubjectLength > 0 && len([]rune(commit.Message)) > rules.MaxSubjectLength {
+		problems = append(problems, fmt.Sprintf("subject is longer than %d characters", rules.MaxSubjectLength))
+	}
+
+	parsed, ok := parseConventionalCommit(commit)
+	if !ok {
+		return append(problems, "not in \"type(scope): description\" form")
+	}
+
+	if !containsFold(rules.Types, parsed.Type) {
+		problems = append(problems, fmt.Sprintf("type %q is not one of %s", parsed.Type, strings.Join(rules.Types, ", ")))
+	}
+	if parsed.Scope == "" {
+		if rules.RequireScope {
+			problems = append(problems, "scope is missing")
+		}
+	} else if len(rules.Scopes) > 0 && !containsFold(rules.Scopes, parsed.Scope) {
+		problems = append(problems, fmt.Sprintf("scope %q is not one of %s", parsed.Scope, strings.Join(rules.Scopes, ", ")))
+	}
+
+	return problems
+}
+
+// LintCommitMessage returns the commit_lint rules a full commit message breaks, e.g.
+// from a commit-msg hook. Comment lines are ignored, and merge, revert, fixup! and
+// squash! messages written by git are accepted as they are.
+func LintCommitMessage(message string, rules config.CommitLintSettings) []string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	subject := ""
+	if len(lines) > 0 {
+		subject = strings.TrimSpace(lines[0])
+	}
+	if subject == "" {
+		return []string{"subject is empty"}
+	}
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(subject, prefix) {
+			return nil
+		}
+	}
+
+	commit := git.Commit{Message: subject, Body: strings.TrimSpace(strings.Join(lines[1:], "\n"))}
+	return lintCommitMessage(commit, rules)
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCommitMessages lints the commits since fromVersion against the commit_lint
+// settings. Offending commits are reported as warnings and never fail validation.
+func (c *Manager) ValidateCommitMessages(fromVersion string) git.ValidationResult {
+	result := git.ValidationResult{
+		Step:    git.ValidationStep{Name: "commit_lint", Description: "Linting commit messages..."},
+		Success: true,
+	}
+
+	commits, err := c.gitManager.GetCommitsInRange(c.sinceRef(fromVersion), c.untilRef())
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Unable to read commits: %v", err))
+		return result
+	}
+	commits, _ = filterSkipped(commits)
+
+	for _, commit := range commits {
+		// Bots follow their own message conventions
+		if botAuthor(commit, c.settings.Changelog.BotAuthors) != "" {
+			continue
+		}
+		if problems := lintCommitMessage(commit, c.settings.CommitLint); len(problems) > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q: %s", commit.Hash, commit.Message, strings.Join(problems, "; ")))
+		}
+	}
+
+	return result
+}
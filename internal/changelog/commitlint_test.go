@@ -0,0 +1,66 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestLintCommitMessage(t *testing.T) {
+	rules := config.DefaultSettings().CommitLint
+	scoped := rules
+	scoped.RequireScope = true
+	scoped.Scopes = []string{"api", "cli"}
+
+	tests := []struct {
+		name     string
+		message  string
+		rules    config.CommitLintSettings
+		problems int
+	}{
+		{"valid commit", "feat(api): add export", rules, 0},
+		{"not conventional", "Add export", rules, 1},
+		{"unknown type", "feature: add export", rules, 1},
+		{"subject too long", "fix: " + strings.Repeat("x", 80), rules, 1},
+		{"missing required scope", "fix: handle nil", scoped, 1},
+		{"scope not allowed", "fix(ui): handle nil", scoped, 1},
+		{"allowed scope", "fix(CLI): handle nil", scoped, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := lintCommitMessage(git.Commit{Message: tt.message}, tt.rules)
+			if len(problems) != tt.problems {
+				t.Errorf("Expected %d problems, got %v", tt.problems, problems)
+			}
+		})
+	}
+}
+
+func TestLintCommitMessageFile(t *testing.T) {
+	rules := config.DefaultSettings().CommitLint
+
+	tests := []struct {
+		name     string
+		message  string
+		problems int
+	}{
+		{"valid with body", "feat: add export\n\nExports as CSV.\n", 0},
+		{"comments ignored", "# Please enter the commit message\nfix: handle nil\n# On branch main\n", 0},
+		{"not conventional", "Add export\n", 1},
+		{"empty", "# Please enter the commit message\n\n", 1},
+		{"merge", "Merge branch 'main' into feature\n", 0},
+		{"fixup", "fixup! feat: add export\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := LintCommitMessage(tt.message, rules)
+			if len(problems) != tt.problems {
+				t.Errorf("Expected %d problems, got %v", tt.problems, problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommitDraft is a conventional commit being composed by `bump-tui commit`
+type CommitDraft struct {
+	Type    string
+	Scope   string
+	Subject string
+	Body    string
+	// Breaking marks the commit with "!"; BreakingNote, when set, becomes a
+	// "BREAKING CHANGE:" footer describing the migration
+	Breaking     bool
+	BreakingNote string
+}
+
+// Message formats the draft as "type(scope)!: subject", followed by the body and
+// the breaking change footer, each after a blank line
+func (d CommitDraft) Message() string {
+	header := d.Type
+	if d.Scope != "" {
+		header += fmt.Sprintf("(%s)", d.Scope)
+	}
+	if d.Breaking {
+		header += "!"
+	}
+
+	parts := []string{fmt.Sprintf("%s: %s", header, strings.TrimSpace(d.Subject))}
+	if body := strings.TrimSpace(d.Body); body != "" {
+		parts = append(parts, body)
+	}
+	if note := strings.TrimSpace(d.BreakingNote); d.Breaking && note != "" {
+		parts = append(parts, "BREAKING CHANGE: "+note)
+	}
+	return strings.Join(parts, "\n\n")
+}
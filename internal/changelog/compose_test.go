@@ -0,0 +1,37 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestCommitDraftMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		draft    CommitDraft
+		expected string
+	}{
+		{"subject only", CommitDraft{Type: "fix", Subject: " handle nil "}, "fix: handle nil"},
+		{"scope and body", CommitDraft{Type: "feat", Scope: "api", Subject: "add export", Body: "Exports as CSV.\n"},
+			"feat(api): add export\n\nExports as CSV."},
+		{"breaking with note", CommitDraft{Type: "feat", Subject: "drop v1", Breaking: true, BreakingNote: "use /v2"},
+			"feat!: drop v1\n\nBREAKING CHANGE: use /v2"},
+		{"note without breaking", CommitDraft{Type: "fix", Subject: "typo", BreakingNote: "ignored"}, "fix: typo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if message := tt.draft.Message(); message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, message)
+			}
+		})
+	}
+
+	// The composed message parses back to the draft
+	draft := CommitDraft{Type: "feat", Scope: "cli", Subject: "add commit", Breaking: true}
+	parsed, ok := parseConventionalCommit(git.Commit{Message: draft.Message()})
+	if !ok || parsed.Type != "feat" || parsed.Scope != "cli" || !parsed.Breaking || parsed.Description != "add commit" {
+		t.Errorf("Expected the message to parse back, got %+v", parsed)
+	}
+}
@@ -0,0 +1,80 @@
+package changelog
+
+import (
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+var (
+	// conventionalRe parses "type(scope)!: description" commit subjects
+	conventionalRe = regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?(!)?: (.+)$`)
+	// footerRe matches git trailer style footers such as "Refs: #12" or "BREAKING CHANGE: ..."
+	footerRe = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*): (.*)$`)
+)
+
+// conventionalCommit is a parsed conventional commit subject and body
+type conventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	// Footers maps footer tokens to their (possibly multi-line) values
+	Footers map[string][]string
+}
+
+// parseConventionalCommit parses a commit; ok is false when the subject isn't conventional
+func parseConventionalCommit(commit git.Commit) (conventionalCommit, bool) {
+	footers := parseFooters(commit.Body)
+
+	matches := conventionalRe.FindStringSubmatch(strings.TrimSpace(commit.Message))
+	if matches == nil {
+		return conventionalCommit{Description: strings.TrimSpace(commit.Message), Footers: footers}, false
+	}
+
+	parsed := conventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       matches[2],
+		Breaking:    matches[3] == "!",
+		Description: matches[4],
+		Footers:     footers,
+	}
+	if len(footers["BREAKING CHANGE"]) > 0 {
+		parsed.Breaking = true
+	}
+
+	return parsed, true
+}
+
+// parseFooters extracts trailer style footers from a commit body. Continuation
+// lines are appended to the preceding footer value.
+func parseFooters(body string) map[string][]string {
+	footers := make(map[string][]string)
+	current := ""
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimRight(line, " \t\r")
+		if matches := footerRe.FindStringSubmatch(trimmed); matches != nil {
+			token := matches[1]
+			if token == "BREAKING-CHANGE" {
+				token = "BREAKING CHANGE"
+			}
+			footers[token] = append(footers[token], matches[2])
+			current = token
+			continue
+		}
+
+		if trimmed == "" {
+			current = ""
+			continue
+		}
+
+		if current != "" {
+			values := footers[current]
+			values[len(values)-1] += "\n" + strings.TrimSpace(trimmed)
+		}
+	}
+
+	return footers
+}
@@ -0,0 +1,113 @@
+package changelog
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		ok       bool
+		expected conventionalCommit
+	}{
+		{"type only", git.Commit{Message: "feat: add export"}, true, conventionalCommit{Type: "feat", Description: "add export"}},
+		{"scope and bang", git.Commit{Message: "Fix(parser)!: reject empty input"}, true, conventionalCommit{Type: "fix", Scope: "parser", Breaking: true, Description: "reject empty input"}},
+		{"breaking footer", git.Commit{Message: "refactor: drop v1 API", Body: "BREAKING-CHANGE: v1 is gone"}, true, conventionalCommit{Type: "refactor", Breaking: true, Description: "drop v1 API"}},
+		{"missing space", git.Commit{Message: "feat:add export"}, false, conventionalCommit{Description: "feat:add export"}},
+		{"empty scope", git.Commit{Message: "feat(): add export"}, false, conventionalCommit{Description: "feat(): add export"}},
+		{"plain subject", git.Commit{Message: "  Update README  "}, false, conventionalCommit{Description: "Update README"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, ok := parseConventionalCommit(tt.commit)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if parsed.Type != tt.expected.Type || parsed.Scope != tt.expected.Scope ||
+				parsed.Breaking != tt.expected.Breaking || parsed.Description != tt.expected.Description {
+				t.Errorf("Expected %+v, got %+v", tt.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestParseFooters(t *testing.T) {
+	footers := parseFooters("Some context.\n\nRefs: #12\nReviewed-by: Jane\n  and John\nBREAKING-CHANGE: config moved")
+
+	if got := footers["Refs"]; len(got) != 1 || got[0] != "#12" {
+		t.Errorf("Expected Refs #12, got %v", got)
+	}
+	if got := footers["Reviewed-by"]; len(got) != 1 || got[0] != "Jane\nand John" {
+		t.Errorf("Expected a continued Reviewed-by footer, got %q", got)
+	}
+	if got := footers["BREAKING CHANGE"]; len(got) != 1 || got[0] != "config moved" {
+		t.Errorf("Expected BREAKING-CHANGE under BREAKING CHANGE, got %v", got)
+	}
+}
+
+func FuzzParseConventionalCommit(f *testing.F) {
+	f.Add("feat(parser)!: add export", "Refs: #12\n\nBREAKING CHANGE: gone")
+	f.Add("fix: handle empty input", "")
+	f.Add("Update README", "Reviewed-by: Jane\n  and John")
+	f.Add("feat(a(b): c", "BREAKING-CHANGE:\n")
+	f.Add("\n: \n", "\r\n\r\n")
+
+	f.Fuzz(func(t *testing.T, message, body string) {
+		parsed, ok := parseConventionalCommit(git.Commit{Message: message, Body: body})
+		if len(parsed.Footers["BREAKING CHANGE"]) > 0 && ok && !parsed.Breaking {
+			t.Errorf("Expected a BREAKING CHANGE footer to mark %q as breaking", message)
+		}
+		if !ok {
+			if parsed.Description != strings.TrimSpace(message) {
+				t.Errorf("Expected the whole subject as description, got %q", parsed.Description)
+			}
+			return
+		}
+		if parsed.Type == "" || parsed.Type != strings.ToLower(parsed.Type) || parsed.Description == "" {
+			t.Errorf("Expected a lowercase type and a description from %q, got %+v", message, parsed)
+		}
+		if strings.Contains(parsed.Description, "\n") {
+			t.Errorf("Expected a single-line description from %q, got %q", message, parsed.Description)
+		}
+	})
+}
+
+// commitTypeRe matches the characters conventionalRe accepts as a commit type
+var commitTypeRe = regexp.MustCompile(`^\w+$`)
+
+func FuzzConventionalCommitRoundTrip(f *testing.F) {
+	f.Add("feat", "parser", "add export", false)
+	f.Add("FIX", "", "handle empty input", true)
+	f.Add("chore", "deps: go", "bump x/term", false)
+
+	f.Fuzz(func(t *testing.T, commitType, scope, description string, breaking bool) {
+		if !commitTypeRe.MatchString(commitType) || strings.ContainsAny(scope, ")\n") ||
+			description == "" || description != strings.TrimSpace(description) || strings.Contains(description, "\n") {
+			return
+		}
+
+		subject := commitType
+		if scope != "" {
+			subject += "(" + scope + ")"
+		}
+		if breaking {
+			subject += "!"
+		}
+		subject += ": " + description
+
+		parsed, ok := parseConventionalCommit(git.Commit{Message: subject})
+		if !ok {
+			t.Fatalf("Expected %q to parse", subject)
+		}
+		if parsed.Type != strings.ToLower(commitType) || parsed.Scope != scope ||
+			parsed.Breaking != breaking || parsed.Description != description {
+			t.Errorf("Expected %q to round-trip, got %+v", subject, parsed)
+		}
+	})
+}
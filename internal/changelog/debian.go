@@ -0,0 +1,164 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DebianChangelogPath is where Debian packaging expects the changelog
+const DebianChangelogPath = "debian/changelog"
+
+// debianDateLayout is the RFC 2822 date of the maintainer trailer line
+const debianDateLayout = "Mon, 02 Jan 2006 15:04:05 -0700"
+
+// debianWidth is the line width dch wraps entries at
+const debianWidth = 80
+
+// debianHeaderRe matches the first line of an entry, e.g. "bump-tui (1.2.0-1) unstable; urgency=medium"
+var debianHeaderRe = regexp.MustCompile(`^(\S+) \(([^)]+)\)`)
+
+// DebianEntry holds the fields of a debian/changelog entry besides the changes
+type DebianEntry struct {
+	Package      string
+	Version      string
+	Distribution string
+	Urgency      string
+	Maintainer   string
+	Date         time.Time
+}
+
+// FormatDebianEntry renders release notes as a debian/changelog entry in dch format.
+// Every changelog bullet becomes a "*" item; headings and other lines are dropped.
+func FormatDebianEntry(entry DebianEntry, changes string) string {
+	var lines []string
+	for _, category := range ParseEntry(changes).Categories {
+		for _, item := range category.Items {
+			if !item.Bullet {
+				continue
+			}
+			text := strings.Split(item.Text, "\n")
+			lines = append(lines, wrapDebian(plainText(text[0]), "  * ", "    ")...)
+			for _, nested := range text[1:] {
+				nested = strings.TrimSpace(nested)
+				if strings.HasPrefix(nested, "- ") || strings.HasPrefix(nested, "* ") {
+					lines = append(lines, wrapDebian(plainText(nested[2:]), "    - ", "      ")...)
+				} else if nested != "" {
+					lines = append(lines, wrapDebian(plainText(nested), "    ", "    ")...)
+				}
+			}
+		}
+	}
+	if len(lines) == 0 {
+		lines = []string{fmt.Sprintf("  * New upstream release %s.", entry.Version)}
+	}
+
+	return fmt.Sprintf("%s (%s) %s; urgency=%s\n\n%s\n\n -- %s  %s\n",
+		entry.Package, entry.Version, entry.Distribution, entry.Urgency,
+		strings.Join(lines, "\n"),
+		entry.Maintainer, entry.Date.Format(debianDateLayout))
+}
+
+// plainText strips markdown emphasis, which has no meaning in packaging changelogs
+func plainText(text string) string {
+	return strings.NewReplacer("**", "", "__", "").Replace(strings.TrimSpace(text))
+}
+
+// wrapDebian word-wraps text to debianWidth, starting with first and indenting
+// continuation lines with rest
+func wrapDebian(text, first, rest string) []string {
+	var lines []string
+	line := first
+	empty := true
+	for _, word := range strings.Fields(text) {
+		if !empty && len(line)+1+len(word) > debianWidth {
+			lines = append(lines, line)
+			line = rest
+			empty = true
+		}
+		if !empty {
+			line += " "
+		}
+		line += word
+		empty = false
+	}
+	return append(lines, line)
+}
+
+// WriteDebianChangelog prepends an entry for version to debian/changelog, signed by
+// maintainer ("Name <email>"). The package name comes from the debian settings or the
+// file's latest entry; the Debian revision is appended to the version. With replace,
+// an existing entry for the version is replaced instead of refusing a duplicate.
+func (c *Manager) WriteDebianChangelog(version, changes, maintainer string, replace bool) error {
+	settings := c.settings.Debian
+
+	existing := ""
+	if content, err := os.ReadFile(DebianChangelogPath); err == nil {
+		existing = string(content)
+	}
+
+	pkg := settings.Package
+	if pkg == "" {
+		if matches := debianHeaderRe.FindStringSubmatch(existing); len(matches) >= 2 {
+			pkg = matches[1]
+		}
+	}
+	if pkg == "" {
+		return fmt.Errorf("no Debian package name: set debian.package or add an entry to %s", DebianChangelogPath)
+	}
+
+	entry := DebianEntry{
+		Package:      pkg,
+		Version:      version + "-" + settings.Revision,
+		Distribution: settings.Distribution,
+		Urgency:      settings.Urgency,
+		Maintainer:   maintainer,
+		Date:         time.Now(),
+	}
+
+	start, end := findDebianEntry(existing, entry.Version)
+	if start >= 0 {
+		if !replace {
+			return fmt.Errorf("%w: %s in %s", ErrEntryExists, entry.Version, DebianChangelogPath)
+		}
+		existing = existing[:start] + existing[end:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(DebianChangelogPath), 0755); err != nil {
+		return fmt.Errorf("failed to create debian directory: %v", err)
+	}
+
+	content := FormatDebianEntry(entry, changes)
+	if strings.TrimSpace(existing) != "" {
+		content += "\n" + strings.TrimLeft(existing, "\n")
+	}
+	return os.WriteFile(DebianChangelogPath, []byte(content), 0644)
+}
+
+// findDebianEntry returns the byte range of the entry for version, through the
+// maintainer trailer line and the blank line after it, or -1, -1
+func findDebianEntry(content, version string) (int, int) {
+	offset := 0
+	start := -1
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if start < 0 {
+			if matches := debianHeaderRe.FindStringSubmatch(line); len(matches) >= 3 && matches[2] == version {
+				start = offset
+			}
+		} else if strings.HasPrefix(line, " -- ") {
+			end := offset + len(line)
+			if strings.HasPrefix(content[end:], "\n") {
+				end++
+			}
+			return start, end
+		}
+		offset += len(line)
+	}
+	if start >= 0 {
+		return start, len(content)
+	}
+	return -1, -1
+}
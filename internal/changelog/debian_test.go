@@ -0,0 +1,63 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDebianEntry(t *testing.T) {
+	entry := DebianEntry{
+		Package:      "bump-tui",
+		Version:      "1.2.0-1",
+		Distribution: "unstable",
+		Urgency:      "medium",
+		Maintainer:   "Ada Lovelace <ada@example.com>",
+		Date:         time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	changes := "## Features\n- Added **Debian** changelog output\n  - Wraps long lines\n\n## Bug Fixes\n- " +
+		strings.Repeat("word ", 20)
+
+	expected := "bump-tui (1.2.0-1) unstable; urgency=medium\n\n" +
+		"  * Added Debian changelog output\n" +
+		"    - Wraps long lines\n" +
+		"  * word word word word word word word word word word word word word word word\n" +
+		"    word word word word word\n\n" +
+		" -- Ada Lovelace <ada@example.com>  Tue, 02 Jan 2024 15:04:05 +0000\n"
+
+	if got := FormatDebianEntry(entry, changes); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestFindDebianEntry(t *testing.T) {
+	content := "pkg (1.1.0-1) unstable; urgency=medium\n\n  * Two\n\n -- A <a@b>  Tue, 02 Jan 2024 15:04:05 +0000\n\n" +
+		"pkg (1.0.0-1) unstable; urgency=medium\n\n  * One\n\n -- A <a@b>  Mon, 01 Jan 2024 15:04:05 +0000\n"
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"1.1.0-1", "pkg (1.0.0-1)"},
+		{"1.0.0-1", ""},
+	}
+
+	for _, tt := range tests {
+		start, end := findDebianEntry(content, tt.version)
+		if start < 0 {
+			t.Errorf("Expected an entry for %s", tt.version)
+			continue
+		}
+		rest := content[:start] + content[end:]
+		if tt.expected != "" && !strings.HasPrefix(rest, tt.expected) {
+			t.Errorf("Expected the remaining changelog to start with %q, got %q", tt.expected, rest)
+		}
+		if strings.Contains(rest, "("+tt.version+")") {
+			t.Errorf("Expected the entry for %s to be removed, got %q", tt.version, rest)
+		}
+	}
+
+	if start, _ := findDebianEntry(content, "2.0.0-1"); start != -1 {
+		t.Errorf("Expected no entry for 2.0.0-1, got offset %d", start)
+	}
+}
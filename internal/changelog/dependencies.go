@@ -0,0 +1,110 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+const dependenciesCategory = "📦 Dependencies"
+
+var (
+	// Dependabot: "chore(deps): bump lodash from 4.17.20 to 4.17.21 in /web"
+	dependabotSubjectRe = regexp.MustCompile(`(?i)^(?:\w+(?:\([^)]*\))?!?:\s*)?bump (\S+) from (\S+) to (\S+)`)
+	// Dependabot grouped updates list each package in the body: "Updates `lodash` from 4.17.20 to 4.17.21"
+	dependabotBodyRe = regexp.MustCompile("(?i)^updates `([^`]+)` from (\\S+) to (\\S+)")
+	// Renovate: "chore(deps): update dependency lodash to v4.17.21", "update rust crate serde to 1.0.200".
+	// Either a deps scope or a dependency keyword is required so regular "update" commits don't match.
+	renovateSubjectRe = regexp.MustCompile(`(?i)^(?:\w+(?:\(([^)]*)\))?!?:\s*)?update ((?:dependency|module|(?:[\w.-]+ )?(?:crate|package|image)) )?(\S+)(?: [\w ]+?)? to (v?\d\S*)`)
+	// Renovate bodies contain a table row per package: "| [lodash](url) | ... | `4.17.20` -> `4.17.21` |"
+	renovateTablePackageRe = regexp.MustCompile(`^\|\s*\[?([^\]|(\s]+)`)
+	renovateTableRangeRe   = regexp.MustCompile("`([^`]+)`\\s*->\\s*`([^`]+)`")
+)
+
+// dependencyUpdate is a single package version change parsed from a commit
+type dependencyUpdate struct {
+	Package string
+	From    string
+	To      string
+}
+
+// parseDependencyUpdates extracts package updates from Dependabot and Renovate commits.
+// Package lists in the body take precedence since they carry every package of grouped updates.
+func parseDependencyUpdates(commit git.Commit) []dependencyUpdate {
+	var updates []dependencyUpdate
+	for _, line := range strings.Split(commit.Body, "\n") {
+		line = strings.TrimSpace(line)
+		if matches := dependabotBodyRe.FindStringSubmatch(line); matches != nil {
+			updates = append(updates, dependencyUpdate{matches[1], trimVersion(matches[2]), trimVersion(matches[3])})
+			continue
+		}
+		if pkg := renovateTablePackageRe.FindStringSubmatch(line); pkg != nil {
+			if versions := renovateTableRangeRe.FindStringSubmatch(line); versions != nil {
+				updates = append(updates, dependencyUpdate{pkg[1], versions[1], versions[2]})
+			}
+		}
+	}
+	if len(updates) > 0 {
+		return updates
+	}
+
+	subject := strings.TrimSpace(commit.Message)
+	if matches := dependabotSubjectRe.FindStringSubmatch(subject); matches != nil {
+		return []dependencyUpdate{{matches[1], trimVersion(matches[2]), trimVersion(matches[3])}}
+	}
+	if matches := renovateSubjectRe.FindStringSubmatch(subject); matches != nil {
+		if matches[2] != "" || strings.HasPrefix(strings.ToLower(matches[1]), "deps") {
+			return []dependencyUpdate{{Package: matches[3], To: trimVersion(matches[4])}}
+		}
+	}
+	return nil
+}
+
+// trimVersion strips punctuation that trails versions in prose
+func trimVersion(version string) string {
+	return strings.TrimRight(version, ".,;")
+}
+
+// isDependencyCommit reports whether a commit is summarized in the Dependencies section
+func isDependencyCommit(commit git.Commit) bool {
+	return len(parseDependencyUpdates(commit)) > 0
+}
+
+// dependencyEntries collapses dependency update commits into one entry per package,
+// from its oldest to its newest version in the range
+func dependencyEntries(commits []git.Commit) []ChangeEntry {
+	var order []string
+	updates := make(map[string]*dependencyUpdate)
+	refs := make(map[string][]string)
+
+	// git log lists the newest commit first; walk oldest first so From/To span the range
+	for i := len(commits) - 1; i >= 0; i-- {
+		for _, update := range parseDependencyUpdates(commits[i]) {
+			refs[update.Package] = append(refs[update.Package], commitRefs(commits[i])...)
+			existing, ok := updates[update.Package]
+			if !ok {
+				u := update
+				updates[update.Package] = &u
+				order = append(order, update.Package)
+				continue
+			}
+			if existing.From == "" {
+				existing.From = update.From
+			}
+			existing.To = update.To
+		}
+	}
+
+	entries := make([]ChangeEntry, 0, len(order))
+	for _, pkg := range order {
+		update := updates[pkg]
+		text := fmt.Sprintf("`%s` %s → %s", update.Package, update.From, update.To)
+		if update.From == "" {
+			text = fmt.Sprintf("`%s` → %s", update.Package, update.To)
+		}
+		entries = append(entries, ChangeEntry{Category: dependenciesCategory, Text: text, Refs: refs[pkg]})
+	}
+	return entries
+}
@@ -0,0 +1,78 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestParseDependencyUpdates(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		expected []dependencyUpdate
+	}{
+		{
+			name:     "dependabot subject",
+			commit:   git.Commit{Message: "chore(deps): bump lodash from 4.17.20 to 4.17.21 in /web"},
+			expected: []dependencyUpdate{{"lodash", "4.17.20", "4.17.21"}},
+		},
+		{
+			name: "dependabot group body",
+			commit: git.Commit{
+				Message: "chore(deps): bump the npm group with 2 updates",
+				Body:    "Bumps the npm group with 2 updates.\n\nUpdates `react` from 18.2.0 to 18.3.1\n- [Release notes](https://example.com)\n\nUpdates `vite` from 5.0.0 to 5.2.0.",
+			},
+			expected: []dependencyUpdate{{"react", "18.2.0", "18.3.1"}, {"vite", "5.0.0", "5.2.0"}},
+		},
+		{
+			name:     "renovate subject",
+			commit:   git.Commit{Message: "fix(deps): update rust crate serde to 1.0.200"},
+			expected: []dependencyUpdate{{Package: "serde", To: "1.0.200"}},
+		},
+		{
+			name: "renovate table body",
+			commit: git.Commit{
+				Message: "chore(deps): update dependency github.com/spf13/cobra to v1.8.1",
+				Body:    "| Package | Change |\n|---|---|\n| [github.com/spf13/cobra](https://example.com) | `v1.8.0` -> `v1.8.1` |",
+			},
+			expected: []dependencyUpdate{{"github.com/spf13/cobra", "v1.8.0", "v1.8.1"}},
+		},
+		{
+			name:     "regular commit",
+			commit:   git.Commit{Message: "feat: update the settings screen to v2 layout"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDependencyUpdates(tt.commit)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderDependencies(t *testing.T) {
+	// Newest commit first, as returned by git log
+	commits := []git.Commit{
+		{Message: "chore(deps): bump lodash from 4.17.20 to 4.17.21"},
+		{Message: "feat: add export"},
+		{Message: "chore(deps): update dependency vite to v5.2.0"},
+		{Message: "chore(deps): bump lodash from 4.17.19 to 4.17.20"},
+	}
+
+	expected := "## 📦 Dependencies\n" +
+		"- `lodash` 4.17.19 → 4.17.21\n" +
+		"- `vite` → v5.2.0"
+	if got := (Changes{Entries: dependencyEntries(commits)}).Markdown(); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := dependencyEntries(commits[1:2]); len(got) != 0 {
+		t.Errorf("Expected no entries without dependency updates, got %+v", got)
+	}
+}
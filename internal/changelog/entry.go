@@ -0,0 +1,155 @@
+package changelog
+
+import (
+	"strings"
+)
+
+// Entry is a generated changelog entry split into categories, for structured edits
+// in the preview. Lines that aren't bullets (alerts, blank lines) are kept in place
+// so an unedited entry serializes back to the same markdown.
+type Entry struct {
+	Categories []Category
+}
+
+// Category is a "## Heading" and the lines under it. The first category has an
+// empty heading and holds anything before the first heading.
+type Category struct {
+	Heading string
+	Items   []EntryItem
+}
+
+// EntryItem is a top-level bullet, including its indented continuation lines, or a
+// single other line
+type EntryItem struct {
+	Text   string
+	Bullet bool
+}
+
+// BulletRef locates a bullet within an entry
+type BulletRef struct {
+	Category int
+	Item     int
+}
+
+// ParseEntry parses changelog markdown into categories and bullets
+func ParseEntry(markdown string) *Entry {
+	entry := &Entry{Categories: []Category{{}}}
+
+	for _, line := range strings.Split(strings.TrimRight(markdown, "\n"), "\n") {
+		current := &entry.Categories[len(entry.Categories)-1]
+
+		switch {
+		case strings.HasPrefix(line, "#"):
+			entry.Categories = append(entry.Categories, Category{Heading: line})
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			current.Items = append(current.Items, EntryItem{Text: line[2:], Bullet: true})
+		case strings.TrimSpace(line) != "" && (line[0] == ' ' || line[0] == '\t') &&
+			len(current.Items) > 0 && current.Items[len(current.Items)-1].Bullet:
+			// Indented lines belong to the bullet above, e.g. nested migration notes
+			current.Items[len(current.Items)-1].Text += "\n" + line
+		default:
+			current.Items = append(current.Items, EntryItem{Text: line})
+		}
+	}
+
+	return entry
+}
+
+// String serializes the entry back to markdown. Categories left without any
+// content after removing or moving bullets are dropped.
+func (e *Entry) String() string {
+	var lines []string
+	for i, category := range e.Categories {
+		if i > 0 && category.isEmpty() {
+			continue
+		}
+		if category.Heading != "" {
+			lines = append(lines, category.Heading)
+		}
+		for _, item := range category.Items {
+			if item.Bullet {
+				lines = append(lines, "- "+item.Text)
+			} else {
+				lines = append(lines, item.Text)
+			}
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// isEmpty reports whether a category has only blank lines
+func (c Category) isEmpty() bool {
+	for _, item := range c.Items {
+		if item.Bullet || strings.TrimSpace(item.Text) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Bullets returns every bullet in display order
+func (e *Entry) Bullets() []BulletRef {
+	var refs []BulletRef
+	for c, category := range e.Categories {
+		for i, item := range category.Items {
+			if item.Bullet {
+				refs = append(refs, BulletRef{Category: c, Item: i})
+			}
+		}
+	}
+	return refs
+}
+
+// Bullet returns the text of a bullet
+func (e *Entry) Bullet(ref BulletRef) string {
+	return e.Categories[ref.Category].Items[ref.Item].Text
+}
+
+// AddBullet appends a bullet after the last bullet of a category and returns its location
+func (e *Entry) AddBullet(category int, text string) BulletRef {
+	items := e.Categories[category].Items
+	at := 0
+	for i, item := range items {
+		if item.Bullet {
+			at = i + 1
+		}
+	}
+
+	updated := make([]EntryItem, 0, len(items)+1)
+	updated = append(updated, items[:at]...)
+	updated = append(updated, EntryItem{Text: text, Bullet: true})
+	updated = append(updated, items[at:]...)
+	e.Categories[category].Items = updated
+
+	return BulletRef{Category: category, Item: at}
+}
+
+// RemoveBullet deletes a bullet and returns its text
+func (e *Entry) RemoveBullet(ref BulletRef) string {
+	items := e.Categories[ref.Category].Items
+	text := items[ref.Item].Text
+
+	updated := make([]EntryItem, 0, len(items)-1)
+	updated = append(updated, items[:ref.Item]...)
+	updated = append(updated, items[ref.Item+1:]...)
+	e.Categories[ref.Category].Items = updated
+
+	return text
+}
+
+// MoveBullet moves a bullet to the end of another category's bullets and returns its new location
+func (e *Entry) MoveBullet(ref BulletRef, category int) BulletRef {
+	if ref.Category == category {
+		return ref
+	}
+	return e.AddBullet(category, e.RemoveBullet(ref))
+}
+
+// CategoryName returns a category's heading without the markdown prefix
+func (e *Entry) CategoryName(category int) string {
+	name := strings.TrimSpace(strings.TrimLeft(e.Categories[category].Heading, "#"))
+	if name == "" {
+		return "(no heading)"
+	}
+	return name
+}
@@ -0,0 +1,57 @@
+package changelog
+
+import (
+	"testing"
+)
+
+const sampleEntry = `## ⚠ Breaking Changes
+- **api:** remove v1 endpoints
+  - Migration: use /v2 instead
+
+## Features
+- ✨ add export
+- ✨ add import
+
+## Bug Fixes
+- 🐛 handle empty input`
+
+func TestParseEntryRoundTrip(t *testing.T) {
+	entry := ParseEntry(sampleEntry)
+	if got := entry.String(); got != sampleEntry {
+		t.Errorf("Expected unedited entry to round-trip, got:\n%s", got)
+	}
+
+	bullets := entry.Bullets()
+	if len(bullets) != 4 {
+		t.Fatalf("Expected 4 bullets, got %d", len(bullets))
+	}
+	if got := entry.Bullet(bullets[0]); got != "**api:** remove v1 endpoints\n  - Migration: use /v2 instead" {
+		t.Errorf("Expected nested note to stay with its bullet, got %q", got)
+	}
+}
+
+func TestEntryEdits(t *testing.T) {
+	entry := ParseEntry(sampleEntry)
+
+	// Move the only bug fix into Features, which drops the empty Bug Fixes heading
+	fix := entry.Bullets()[3]
+	moved := entry.MoveBullet(fix, 2)
+	if entry.Bullet(moved) != "🐛 handle empty input" {
+		t.Errorf("Expected moved bullet, got %q", entry.Bullet(moved))
+	}
+
+	entry.RemoveBullet(entry.Bullets()[1])
+	entry.AddBullet(1, "**api:** rename token field")
+
+	expected := `## ⚠ Breaking Changes
+- **api:** remove v1 endpoints
+  - Migration: use /v2 instead
+- **api:** rename token field
+
+## Features
+- ✨ add import
+- 🐛 handle empty input`
+	if got := entry.String(); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
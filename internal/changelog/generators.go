@@ -0,0 +1,192 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/plugin"
+)
+
+// DefaultGeneratorTimeout limits AI generators without an ai.timeouts entry
+const DefaultGeneratorTimeout = 3 * time.Minute
+
+// generatorTimeout returns how long a generator may take before the next one is tried
+func (c *Manager) generatorTimeout(generator string) time.Duration {
+	if seconds := c.settings.AI.Timeouts[generator]; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return DefaultGeneratorTimeout
+}
+
+// generatorAvailable reports whether a generator can run on this machine
+func (c *Manager) generatorAvailable(generator string) bool {
+	switch generator {
+	case config.GeneratorClaudeCLI:
+		return c.isClaudeAvailable()
+	case config.GeneratorOpenAI:
+		return openAIKey() != ""
+	}
+	if name, ok := config.PluginGenerator(generator); ok {
+		p := plugin.Find(c.plugins, name)
+		return p != nil && p.Generator
+	}
+	return generator == config.GeneratorRegex
+}
+
+// aiGenerator reports whether a generator sends prompts to an AI model, unlike the
+// regex generator and plugins
+func aiGenerator(generator string) bool {
+	return generator == config.GeneratorClaudeCLI || generator == config.GeneratorOpenAI
+}
+
+// AIAvailable reports whether an AI generator in the chain can run, so generation
+// may take a while and costs money
+func (c *Manager) AIAvailable() bool {
+	for _, generator := range c.settings.AI.Generators {
+		if aiGenerator(generator) && c.generatorAvailable(generator) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratorStatus tells whether a generator of the chain can run on this machine
+type GeneratorStatus struct {
+	Name      string
+	Available bool
+	// AI is set for generators that send prompts to an AI model
+	AI bool
+}
+
+// Generators returns the status of every generator in the chain, in order
+func (c *Manager) Generators() []GeneratorStatus {
+	var statuses []GeneratorStatus
+	for _, generator := range c.settings.AI.Generators {
+		statuses = append(statuses, GeneratorStatus{
+			Name:      generator,
+			Available: c.generatorAvailable(generator),
+			AI:        aiGenerator(generator),
+		})
+	}
+	return statuses
+}
+
+// runGenerators tries the generator chain in order and returns the first result with
+// the name of the generator that produced it. The regex generator ends every chain.
+func (c *Manager) runGenerators(fromVersion string, commits []git.Commit) ([]ChangeEntry, string) {
+	for _, generator := range c.settings.AI.Generators {
+		if generator == config.GeneratorRegex {
+			break
+		}
+		if !c.generatorAvailable(generator) {
+			continue
+		}
+
+		entries, err := c.runGenerator(generator, fromVersion, commits)
+		if err != nil {
+			log.Printf("Warning: %s changelog generation failed, trying the next generator: %v", generator, err)
+			continue
+		}
+		return entries, generator
+	}
+
+	return append(c.regexEntries(commits), c.groupEntries(commits)...), config.GeneratorRegex
+}
+
+// runGenerator runs one AI generator within its timeout
+func (c *Manager) runGenerator(generator, fromVersion string, commits []git.Commit) ([]ChangeEntry, error) {
+	timeout := c.generatorTimeout(generator)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var entries []ChangeEntry
+	var err error
+	if name, ok := config.PluginGenerator(generator); ok {
+		entries, err = c.generateWithPlugin(ctx, name, fromVersion, commits)
+	} else {
+		entries, err = c.generate(ctx, generator, fromVersion, commits)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	}
+	return entries, err
+}
+
+// generate asks an AI generator for the entries of commits, reusing a cached response
+// for the same prompt and commits instead of re-billing the API. Responses that don't
+// validate are an error, so the chain moves on.
+func (c *Manager) generate(ctx context.Context, generator, fromVersion string, commits []git.Commit) ([]ChangeEntry, error) {
+	if len(commits) == 0 {
+		return []ChangeEntry{minorUpdatesEntry}, nil
+	}
+
+	prompt, err := c.buildPrompt(commits)
+	if err != nil {
+		return nil, err
+	}
+
+	commitRange := c.commitRange(fromVersion)
+	if cached, ok := c.loadCached(generator, prompt, commitRange); ok {
+		if entries, err := c.parseGenerated(cached); err == nil {
+			c.fromCache = true
+			return entries, nil
+		}
+	}
+
+	schema := ""
+	if c.structuredOutput() {
+		schema = changeEntriesSchema
+	}
+	output, err := c.complete(ctx, generator, prompt, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := c.parseGenerated(output)
+	if err != nil {
+		return nil, err
+	}
+	c.storeCached(generator, prompt, commitRange, output)
+
+	return entries, nil
+}
+
+// generateWithPlugin asks a plugin for the changelog of commits as markdown bullets
+func (c *Manager) generateWithPlugin(ctx context.Context, name, fromVersion string, commits []git.Commit) ([]ChangeEntry, error) {
+	p := plugin.Find(c.plugins, name)
+	if p == nil {
+		return nil, fmt.Errorf("plugin %s is not loaded", name)
+	}
+
+	response, err := p.Call(ctx, plugin.Request{Action: plugin.ActionGenerate, PreviousVersion: fromVersion, Commits: commits})
+	if err != nil {
+		return nil, err
+	}
+	entries := parseChanges(response.Changelog)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the plugin's changelog has no bullets")
+	}
+	return entries, nil
+}
+
+// complete sends a prompt to an AI generator and returns its response. A schema asks
+// for JSON: as structured output from the Claude CLI, or a JSON object from OpenAI.
+func (c *Manager) complete(ctx context.Context, generator, prompt, schema string) (string, error) {
+	switch generator {
+	case config.GeneratorClaudeCLI:
+		return c.completeWithClaude(ctx, prompt, schema)
+	case config.GeneratorOpenAI:
+		return c.completeWithOpenAI(ctx, prompt, schema != "")
+	}
+	return "", fmt.Errorf("unknown generator %q", generator)
+}
+
+// LastGenerator returns the generator that produced the most recent changelog, e.g.
+// "claude-cli" or "regex"
+func (c *Manager) LastGenerator() string {
+	return c.lastGenerator
+}
@@ -0,0 +1,119 @@
+package changelog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestRunGenerators(t *testing.T) {
+	// Outside a repository there is no commit range, so nothing is cached
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var failing bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" || r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, `{"error": {"message": "bad request"}}`, http.StatusBadRequest)
+			return
+		}
+		var request struct {
+			Model          string            `json:"model"`
+			ResponseFormat map[string]string `json:"response_format"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if request.Model != "gpt-4o-mini" || request.ResponseFormat["type"] != "json_object" {
+			http.Error(w, `{"error": {"message": "unexpected request"}}`, http.StatusBadRequest)
+			return
+		}
+
+		content := `{"entries": [{"category": "Features", "text": "Export reports as CSV"}]}`
+		if failing {
+			content = "Sure! Here are the release notes."
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": content}}},
+			"usage":   map[string]int{"prompt_tokens": 1000, "completion_tokens": 100},
+		})
+	}))
+	defer server.Close()
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	settings := config.DefaultSettings()
+	settings.AI.Generators = []string{config.GeneratorOpenAI, config.GeneratorRegex}
+	manager := &Manager{settings: settings, gitManager: git.NewManager(), openAIURL: server.URL}
+	commits := []git.Commit{{Hash: "a1b2c3d4", Message: "feat: add csv export"}}
+
+	entries, generator := manager.runGenerators("", commits)
+	if generator != config.GeneratorOpenAI || len(entries) != 1 || entries[0].Text != "Export reports as CSV" {
+		t.Errorf("Expected the openai entry, got %s: %+v", generator, entries)
+	}
+	if usage := manager.LastUsage(); usage == nil || usage.InputTokens != 1000 || usage.CostUSD != 0.0045 {
+		t.Errorf("Expected recorded usage, got %+v", usage)
+	}
+
+	// Prose fails validation, so the chain moves on to the commit list
+	failing = true
+	entries, generator = manager.runGenerators("", commits)
+	if generator != config.GeneratorRegex || len(entries) != 1 || entries[0].Text != "add csv export" {
+		t.Errorf("Expected the regex fallback, got %s: %+v", generator, entries)
+	}
+}
+
+func TestGeneratorTimeout(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.AI.Timeouts = map[string]int{config.GeneratorOpenAI: 30}
+	manager := &Manager{settings: settings}
+
+	if got := manager.generatorTimeout(config.GeneratorOpenAI); got != 30*time.Second {
+		t.Errorf("Expected 30s, got %s", got)
+	}
+	if got := manager.generatorTimeout(config.GeneratorClaudeCLI); got != DefaultGeneratorTimeout {
+		t.Errorf("Expected the default timeout, got %s", got)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.AI.Generators = []string{config.GeneratorOpenAI, "plugin:notes", config.GeneratorRegex}
+	manager := &Manager{settings: settings}
+
+	t.Setenv("OPENAI_API_KEY", "")
+	statuses := manager.Generators()
+	expected := []GeneratorStatus{
+		{Name: config.GeneratorOpenAI, Available: false, AI: true},
+		{Name: "plugin:notes", Available: false},
+		{Name: config.GeneratorRegex, Available: true},
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d generators, got %+v", len(expected), statuses)
+	}
+	for i, status := range statuses {
+		if status != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], status)
+		}
+	}
+
+	t.Setenv("OPENAI_API_KEY", "test-key")
+	if status := manager.Generators()[0]; !status.Available {
+		t.Errorf("Expected openai to be available with an API key")
+	}
+}
@@ -0,0 +1,118 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+// matchGroup returns the first grouping rule matching a commit
+func matchGroup(commit git.Commit, rules []config.GroupRule) (config.GroupRule, bool) {
+	parsed, _ := parseConventionalCommit(commit)
+	for _, rule := range rules {
+		if len(rule.Types) > 0 && !containsFold(rule.Types, parsed.Type) {
+			continue
+		}
+		if len(rule.Scopes) > 0 && !containsFold(rule.Scopes, parsed.Scope) {
+			continue
+		}
+		if len(rule.Paths) > 0 && !filesUnder(commit.Files, rule.Paths) {
+			continue
+		}
+		return rule, true
+	}
+	return config.GroupRule{}, false
+}
+
+// filesUnder reports whether every file is one of the paths or inside one of them.
+// Commits without files, such as merges, never match.
+func filesUnder(files, paths []string) bool {
+	if len(files) == 0 {
+		return false
+	}
+	for _, file := range files {
+		under := false
+		for _, path := range paths {
+			path = strings.TrimSuffix(path, "/")
+			if file == path || strings.HasPrefix(file, path+"/") {
+				under = true
+				break
+			}
+		}
+		if !under {
+			return false
+		}
+	}
+	return true
+}
+
+// groupSection returns the custom section of a commit, or "" when no rule lists it
+// under one
+func (c *Manager) groupSection(commit git.Commit) string {
+	rule, ok := matchGroup(commit, c.settings.Changelog.Groups)
+	if !ok || rule.Skip {
+		return ""
+	}
+	return rule.Section
+}
+
+// isGroupSkipped reports whether a grouping rule leaves the commit out
+func (c *Manager) isGroupSkipped(commit git.Commit) bool {
+	rule, ok := matchGroup(commit, c.settings.Changelog.Groups)
+	return ok && rule.Skip
+}
+
+// groupEntries lists the commits with a custom section for the regex generator, in
+// the order the rules name the sections
+func (c *Manager) groupEntries(commits []git.Commit) []ChangeEntry {
+	var order []string
+	entries := make(map[string][]ChangeEntry)
+	for _, rule := range c.settings.Changelog.Groups {
+		if rule.Section != "" && entries[rule.Section] == nil {
+			order = append(order, rule.Section)
+			entries[rule.Section] = []ChangeEntry{}
+		}
+	}
+
+	for _, commit := range commits {
+		if c.isUnlistedCommit(commit) {
+			continue
+		}
+		if section := c.groupSection(commit); section != "" {
+			if entry, ok := c.commitEntry(commit); ok {
+				entry.Category = section
+				entries[section] = append(entries[section], entry)
+			}
+		}
+	}
+
+	var grouped []ChangeEntry
+	for _, section := range order {
+		grouped = append(grouped, entries[section]...)
+	}
+	return grouped
+}
+
+// groupHints tells the AI generator which sections the marked commits belong in
+func (c *Manager) groupHints(commits []git.Commit) string {
+	var lines []string
+	seen := make(map[string]bool)
+	for _, commit := range commits {
+		section := c.groupSection(commit)
+		if section == "" || seen[section] || c.isUnlistedCommit(commit) {
+			continue
+		}
+		seen[section] = true
+		if c.structuredOutput() {
+			lines = append(lines, fmt.Sprintf("- Give commits marked [section: %s] the category %q\n", section, section))
+		} else {
+			lines = append(lines, fmt.Sprintf("- List commits marked [section: %s] under a \"## %s\" heading of their own\n", section, section))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\nSection rules:\n" + strings.Join(lines, "")
+}
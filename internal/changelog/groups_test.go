@@ -0,0 +1,83 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestMatchGroup(t *testing.T) {
+	rules := []config.GroupRule{
+		{Scopes: []string{"api"}, Section: "API Changes"},
+		{Paths: []string{"docs/"}, Skip: true},
+		{Types: []string{"ci", "build"}, Section: "Tooling"},
+	}
+
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		expected string
+	}{
+		{"scope", git.Commit{Message: "feat(API): add pagination"}, "API Changes"},
+		{"docs only", git.Commit{Message: "fix: typo", Files: []string{"docs/a.md", "docs/b/c.md"}}, "skip"},
+		{"docs and code", git.Commit{Message: "fix: typo", Files: []string{"docs/a.md", "main.go"}}, ""},
+		{"prefix is not a directory", git.Commit{Message: "fix: typo", Files: []string{"docs-site/a.md"}}, ""},
+		{"type", git.Commit{Message: "ci: cache modules"}, "Tooling"},
+		{"non-conventional", git.Commit{Message: "Update things"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if rule, ok := matchGroup(tt.commit, rules); ok {
+				got = rule.Section
+				if rule.Skip {
+					got = "skip"
+				}
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGroupEntries(t *testing.T) {
+	settings := config.DefaultSettings()
+	settings.Changelog.Groups = []config.GroupRule{
+		{Scopes: []string{"api"}, Section: "API Changes"},
+		{Paths: []string{"docs"}, Skip: true},
+	}
+	manager := &Manager{settings: settings}
+
+	commits := []git.Commit{
+		{Message: "feat(api): add pagination"},
+		{Message: "docs: explain pagination", Files: []string{"docs/api.md"}},
+		{Message: "fix: handle empty input"},
+	}
+
+	changes := Changes{Entries: append(manager.regexEntries(commits), manager.groupEntries(commits)...)}
+	expected := "- 🐛 handle empty input\n\n## API Changes\n- ✨ **api:** add pagination"
+	if got := changes.Markdown(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	for output, hint := range map[string]string{
+		config.AIOutputJSON:     `the category "API Changes"`,
+		config.AIOutputMarkdown: `under a "## API Changes" heading`,
+	} {
+		settings.AI.Output = output
+		prompt, err := manager.buildPrompt(commits)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(prompt, "- feat(api): add pagination [section: API Changes]") || !strings.Contains(prompt, hint) {
+			t.Errorf("Expected section hints in the %s prompt, got %q", output, prompt)
+		}
+		if strings.Contains(prompt, "explain pagination") {
+			t.Errorf("Expected skipped commits to be left out of the %s prompt", output)
+		}
+	}
+}
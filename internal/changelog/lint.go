@@ -0,0 +1,205 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// commonMisspellings maps frequent misspellings found in commit messages to their corrections
+var commonMisspellings = map[string]string{
+	"accomodate":    "accommodate",
+	"acheive":       "achieve",
+	"adress":        "address",
+	"agressive":     "aggressive",
+	"alot":          "a lot",
+	"arguement":     "argument",
+	"begining":      "beginning",
+	"beleive":       "believe",
+	"calender":      "calendar",
+	"compatability": "compatibility",
+	"compatable":    "compatible",
+	"completly":     "completely",
+	"concious":      "conscious",
+	"definately":    "definitely",
+	"dependancy":    "dependency",
+	"dependancies":  "dependencies",
+	"enviroment":    "environment",
+	"existant":      "existent",
+	"explicitely":   "explicitly",
+	"familar":       "familiar",
+	"finaly":        "finally",
+	"foward":        "forward",
+	"guarentee":     "guarantee",
+	"identifer":     "identifier",
+	"immediatly":    "immediately",
+	"independant":   "independent",
+	"intial":        "initial",
+	"lenght":        "length",
+	"neccessary":    "necessary",
+	"occurence":     "occurrence",
+	"occured":       "occurred",
+	"occuring":      "occurring",
+	"paramter":      "parameter",
+	"performace":    "performance",
+	"persistant":    "persistent",
+	"posible":       "possible",
+	"preceed":       "precede",
+	"recieve":       "receive",
+	"recieved":      "received",
+	"refered":       "referred",
+	"relevent":      "relevant",
+	"reponse":       "response",
+	"seperate":      "separate",
+	"seperator":     "separator",
+	"sucessful":     "successful",
+	"succesful":     "successful",
+	"suport":        "support",
+	"teh":           "the",
+	"transfered":    "transferred",
+	"truely":        "truly",
+	"untill":        "until",
+	"wich":          "which",
+	"withing":       "within",
+}
+
+var (
+	wordRe            = regexp.MustCompile(`[A-Za-z]+`)
+	headingNoSpaceRe  = regexp.MustCompile(`^(#{1,6})([^#\s])`)
+	headingLevelRe    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	altListMarkerRe   = regexp.MustCompile(`^(\s*)[*+](\s+)`)
+	inlineCodeSpansRe = regexp.MustCompile("`[^`]*`")
+)
+
+// Lint cleans up generated changelog markdown and returns the fixed text together
+// with a human readable description of every fix that was applied
+func Lint(changes string) (string, []string) {
+	var fixes []string
+
+	lines := strings.Split(strings.ReplaceAll(changes, "\r\n", "\n"), "\n")
+
+	trailing, markers, headingSpaces, headingLevels := 0, 0, 0, 0
+	spelling := make(map[string]string)
+	inFence := false
+	prevLevel := 0
+
+	var out []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			out = append(out, line)
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		// Trailing whitespace
+		if trimmed := strings.TrimRight(line, " \t"); trimmed != line {
+			line = trimmed
+			trailing++
+		}
+
+		// List markers: normalize '*' and '+' to '-'
+		if altListMarkerRe.MatchString(line) {
+			line = altListMarkerRe.ReplaceAllString(line, "${1}-${2}")
+			markers++
+		}
+
+		// Headings: require a space after the hashes and never skip a level
+		if headingNoSpaceRe.MatchString(line) {
+			line = headingNoSpaceRe.ReplaceAllString(line, "${1} ${2}")
+			headingSpaces++
+		}
+		if matches := headingLevelRe.FindStringSubmatch(line); matches != nil {
+			level := len(matches[1])
+			if prevLevel > 0 && level > prevLevel+1 {
+				level = prevLevel + 1
+				line = strings.Repeat("#", level) + " " + matches[2]
+				headingLevels++
+			}
+			prevLevel = level
+		}
+
+		line = fixSpelling(line, spelling)
+		out = append(out, line)
+	}
+
+	// Collapse runs of blank lines and trim blank lines at both ends
+	var collapsed []string
+	blank := 0
+	for _, line := range out {
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		collapsed = append(collapsed, line)
+	}
+	result := strings.Trim(strings.Join(collapsed, "\n"), "\n")
+
+	if trailing > 0 {
+		fixes = append(fixes, fmt.Sprintf("Removed trailing whitespace from %d lines", trailing))
+	}
+	if markers > 0 {
+		fixes = append(fixes, fmt.Sprintf("Normalized %d list markers to '-'", markers))
+	}
+	if headingSpaces > 0 {
+		fixes = append(fixes, fmt.Sprintf("Added missing space after %d heading markers", headingSpaces))
+	}
+	if headingLevels > 0 {
+		fixes = append(fixes, fmt.Sprintf("Adjusted %d headings that skipped a level", headingLevels))
+	}
+	if len(collapsed) != len(out) || result != strings.Join(collapsed, "\n") {
+		fixes = append(fixes, "Removed extra blank lines")
+	}
+
+	var misspelled []string
+	for wrong := range spelling {
+		misspelled = append(misspelled, wrong)
+	}
+	sort.Strings(misspelled)
+	for _, wrong := range misspelled {
+		fixes = append(fixes, fmt.Sprintf("Corrected spelling: %s → %s", wrong, spelling[wrong]))
+	}
+
+	return result, fixes
+}
+
+// fixSpelling corrects known misspellings in a line, leaving inline code untouched
+func fixSpelling(line string, corrected map[string]string) string {
+	codeSpans := inlineCodeSpansRe.FindAllStringIndex(line, -1)
+
+	return replaceOutside(line, codeSpans, func(segment string) string {
+		return wordRe.ReplaceAllStringFunc(segment, func(word string) string {
+			correction, ok := commonMisspellings[strings.ToLower(word)]
+			if !ok {
+				return word
+			}
+			corrected[strings.ToLower(word)] = correction
+			// Preserve a leading capital letter
+			if word[0] >= 'A' && word[0] <= 'Z' {
+				return strings.ToUpper(correction[:1]) + correction[1:]
+			}
+			return correction
+		})
+	})
+}
+
+// replaceOutside applies fn to the parts of s that are not covered by the given spans
+func replaceOutside(s string, spans [][]int, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		b.WriteString(fn(s[last:span[0]]))
+		b.WriteString(s[span[0]:span[1]])
+		last = span[1]
+	}
+	b.WriteString(fn(s[last:]))
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package changelog
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedFixes int
+	}{
+		{
+			name:          "clean changelog is unchanged",
+			input:         "## Features\n- Added support for .bump files",
+			expected:      "## Features\n- Added support for .bump files",
+			expectedFixes: 0,
+		},
+		{
+			name:          "trailing whitespace and list markers",
+			input:         "## Features  \n* First\n+ Second",
+			expected:      "## Features\n- First\n- Second",
+			expectedFixes: 2,
+		},
+		{
+			name:          "heading without space and skipped level",
+			input:         "##Features\n#### Details",
+			expected:      "## Features\n### Details",
+			expectedFixes: 2,
+		},
+		{
+			name:          "extra blank lines",
+			input:         "\n\n## Features\n\n\n- One\n\n",
+			expected:      "## Features\n\n- One",
+			expectedFixes: 1,
+		},
+		{
+			name:          "misspellings outside inline code",
+			input:         "- Recieve events `recieve()` untill shutdown",
+			expected:      "- Receive events `recieve()` until shutdown",
+			expectedFixes: 2,
+		},
+		{
+			name:          "fenced code is untouched",
+			input:         "```\n* teh  \n```",
+			expected:      "```\n* teh  \n```",
+			expectedFixes: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, fixes := Lint(tt.input)
+
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+
+			if len(fixes) != tt.expectedFixes {
+				t.Errorf("Expected %d fixes, got %d: %v", tt.expectedFixes, len(fixes), fixes)
+			}
+		})
+	}
+}
@@ -2,119 +2,271 @@ package changelog
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
+	"bump-tui/internal/config"
 	"bump-tui/internal/git"
+	"bump-tui/internal/github"
+	"bump-tui/internal/plugin"
 )
 
-type Manager struct {
-	gitManager *git.Manager
-}
+// maxPromptFiles limits how many changed paths are listed per commit in the AI prompt
+const maxPromptFiles = 5
+
+// ErrEntryExists is returned when the changelog already contains an entry for the version
+var ErrEntryExists = errors.New("changelog already contains an entry for this version")
 
-type ChangeEntry struct {
-	Type        string `json:"type"`
-	Scope       string `json:"scope,omitempty"`
-	Description string `json:"description"`
-	Emoji       string `json:"emoji"`
+type Manager struct {
+	gitManager    *git.Manager
+	githubManager *github.Manager
+	settings      *config.Settings
+	lastUsage     *Usage
+	fromCache     bool
+	lastGenerator string
+	lastQuality   []string
+	// bumpClassifiedBy is the generator that classified manual commits for the last SuggestBump
+	bumpClassifiedBy string
+	// plugins can generate changelogs as "plugin:<name>" generators
+	plugins []*plugin.Plugin
+	// pullRequests holds the merged pull request listings fetched this run, by search
+	pullRequests map[string][]github.PullRequest
+	// openAIURL overrides the OpenAI API base URL, for tests
+	openAIURL string
+	// Optional --since/--until overrides of the commit range
+	since string
+	until string
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		gitManager: git.NewManager(),
+		gitManager:    git.NewManager(),
+		githubManager: github.NewManager(),
+		settings:      config.DefaultSettings(),
 	}
 }
 
+// SetSettings applies project settings loaded from .bump.toml
+func (c *Manager) SetSettings(settings *config.Settings) {
+	c.settings = settings
+	c.gitManager.SetCommitStrategy(settings.Git.CommitStrategy)
+}
+
+// SetPlugins makes loaded plugins available as changelog generators
+func (c *Manager) SetPlugins(plugins []*plugin.Plugin) {
+	c.plugins = plugins
+}
+
+// GenerateChanges generates the changelog entry for the commits since fromVersion as markdown
 func (c *Manager) GenerateChanges(fromVersion string) (string, error) {
-	commits, err := c.gitManager.GetCommitsSince(fromVersion)
+	changes, err := c.GenerateEntries(fromVersion)
+	if err != nil {
+		return "", err
+	}
+	return changes.Markdown(), nil
+}
+
+// GenerateEntries generates the changelog entry for the commits since fromVersion in
+// structured form
+func (c *Manager) GenerateEntries(fromVersion string) (Changes, error) {
+	c.lastUsage = nil
+	c.fromCache = false
+	c.lastGenerator = config.GeneratorRegex
+	c.lastQuality = nil
+
+	commits, err := c.collectCommits(fromVersion, true)
 	if err != nil {
 		// If we can't get commits, return a default message
-		return "- Minor updates and improvements", nil
+		return localizeSections(Changes{Entries: []ChangeEntry{minorUpdatesEntry}}, c.settings.Messages.Texts()), nil
 	}
 
-	// Try Claude first if available
-	if c.isClaudeAvailable() {
-		if changelog, err := c.generateWithClaude(commits); err == nil {
-			return changelog, nil
-		}
-		// If Claude fails, continue to fallback
+	changes := Changes{Summary: c.statsLine(fromVersion, commits)}
+
+	// Breaking changes, security fixes and deprecations get dedicated sections at
+	// the top, regardless of generator
+	security := securityEntries(commits)
+	if c.settings.Changelog.HighlightSecurity && len(security) > 0 {
+		changes.Alerts = map[string]string{securityCategory: securityAlert(len(security))}
+	}
+	changes.Entries = append(changes.Entries, breakingChangeEntries(commits)...)
+	changes.Entries = append(changes.Entries, security...)
+	changes.Entries = append(changes.Entries, deprecationEntries(commits)...)
+
+	// Try the configured generators in order, ending with the regex-based generator
+	generated, generator := c.runGenerators(fromVersion, commits)
+	c.lastGenerator = generator
+	listCategory := ""
+	if generator != config.GeneratorRegex {
+		listCategory = generated[len(generated)-1].Category
+		// AI output is checked for dropped and invented entries
+		c.lastQuality = c.checkQuality(generated, commits)
 	}
 
-	// Fallback to existing regex-based system
-	return c.generateWithRegex(commits), nil
+	// Bot commits are optionally summarized in a single entry at the end of the
+	// generated list and dependency updates are grouped in a section below it
+	if summary, ok := c.botSummaryEntry(commits); ok {
+		summary.Category = listCategory
+		generated = append(generated, summary)
+	}
+	changes.Entries = append(changes.Entries, generated...)
+	changes.Entries = append(changes.Entries, dependencyEntries(commits)...)
+
+	changes.Entries = dedupeEntries(changes.Entries)
+	return localizeSections(changes, c.settings.Messages.Texts()), nil
 }
 
-func (c *Manager) generateWithRegex(commits []git.Commit) string {
-	var changes []string
-	for _, commit := range commits {
-		// Skip version bump commits
-		if strings.Contains(commit.Message, "bump version") ||
-			strings.Contains(commit.Message, "release") ||
-			strings.Contains(commit.Message, "chore(release)") {
-			continue
-		}
+// SetCommitRange overrides the commit range used for changelog generation. An empty
+// since falls back to the tag of the current version; an empty until means HEAD.
+func (c *Manager) SetCommitRange(since, until string) {
+	c.since = since
+	c.until = until
+}
 
-		if formatted := c.formatCommitMessage(commit.Message); formatted != "" {
-			changes = append(changes, formatted)
-		}
+// DescribeRange returns the commit range a changelog for fromVersion covers, e.g. "v1.2.0..HEAD"
+func (c *Manager) DescribeRange(fromVersion string) string {
+	since := c.sinceRef(fromVersion)
+	if since == "" {
+		return fmt.Sprintf("last %d commits up to %s", git.MaxCommitsToAnalyze, c.untilRef())
 	}
+	return fmt.Sprintf("%s..%s", since, c.untilRef())
+}
 
-	if len(changes) == 0 {
-		return "- Minor updates and improvements"
+// sinceRef returns the ref the commit range starts at: the --since override or the tag of fromVersion
+func (c *Manager) sinceRef(fromVersion string) string {
+	if c.since != "" {
+		return c.since
+	}
+	if fromVersion == "" {
+		return ""
 	}
 
-	return strings.Join(changes, "\n")
+	tagName := "v" + fromVersion
+	if _, err := c.gitManager.ResolveRef(tagName); err != nil {
+		return ""
+	}
+	return tagName
 }
 
-func (c *Manager) formatCommitMessage(message string) string {
-	if message == "" {
-		return ""
+// untilRef returns the ref the commit range ends at
+func (c *Manager) untilRef() string {
+	if c.until != "" {
+		return c.until
+	}
+	return "HEAD"
+}
+
+// collectCommits returns the commits a changelog for fromVersion is generated from.
+// Without fetch, squash merges only take their pull request titles from listings
+// already fetched this run, so nothing is requested from GitHub.
+func (c *Manager) collectCommits(fromVersion string, fetch bool) ([]git.Commit, error) {
+	commits, err := c.gitManager.GetCommitsInRange(c.sinceRef(fromVersion), c.untilRef())
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract first line only
-	firstLine := strings.Split(message, "\n")[0]
-	firstLine = strings.TrimSpace(firstLine)
+	// Commits marked [skip changelog] or [skip release] never reach the changelog
+	commits, _ = filterSkipped(commits)
+	commits = c.ingestPullRequests(fromVersion, commits, fetch)
 
-	// Parse conventional commit format: type(scope): description
-	conventionalRe := regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?: (.+)$`)
-	matches := conventionalRe.FindStringSubmatch(firstLine)
+	// Descriptions supplied in the reword step replace unparseable messages
+	rewords, err := c.loadRewords()
+	if err != nil {
+		log.Printf("Warning: ignoring saved rewords: %v", err)
+	}
+	return applyRewords(commits, rewords), nil
+}
 
-	if len(matches) >= 4 {
-		commitType := matches[1]
-		scope := matches[2]
-		description := matches[3]
+// isUnlistedCommit reports whether a commit stays out of the generated change list
+func (c *Manager) isUnlistedCommit(commit git.Commit) bool {
+	// Skip version bump commits
+	if strings.Contains(commit.Message, "bump version") ||
+		strings.Contains(commit.Message, "release") ||
+		strings.Contains(commit.Message, "chore(release)") {
+		return true
+	}
+	// Rendered in their own sections
+	if isSectionCommit(commit) {
+		return true
+	}
+	// Grouping rules can leave commits out, e.g. documentation-only changes
+	if c.isGroupSkipped(commit) {
+		return true
+	}
+	// Automated commits from bots are excluded or summarized separately
+	return c.isBotCommit(commit)
+}
 
-		emoji := c.getEmojiForType(commitType)
+// minorUpdatesEntry stands in for releases without any listed commits
+var minorUpdatesEntry = ChangeEntry{Text: "Minor updates and improvements"}
 
-		if scope != "" {
-			return fmt.Sprintf("- %s **%s:** %s", emoji, scope, description)
+// regexEntries lists the commits without a custom section, one entry per commit
+func (c *Manager) regexEntries(commits []git.Commit) []ChangeEntry {
+	var entries []ChangeEntry
+	for _, commit := range commits {
+		// Commits with a custom section are listed by groupEntries
+		if c.isUnlistedCommit(commit) || c.groupSection(commit) != "" {
+			continue
 		}
-		return fmt.Sprintf("- %s %s", emoji, description)
+
+		if entry, ok := c.commitEntry(commit); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		return []ChangeEntry{minorUpdatesEntry}
+	}
+	return entries
+}
+
+// commitEntry describes a commit by its subject, marked with an emoji for its type
+func (c *Manager) commitEntry(commit git.Commit) (ChangeEntry, bool) {
+	firstLine := strings.TrimSpace(strings.Split(commit.Message, "\n")[0])
+	if firstLine == "" {
+		return ChangeEntry{}, false
+	}
+
+	// Parse conventional commit format: type(scope): description
+	if parsed, ok := parseConventionalCommit(git.Commit{Message: firstLine}); ok {
+		return ChangeEntry{
+			Type:  parsed.Type,
+			Scope: parsed.Scope,
+			Text:  parsed.Description,
+			Refs:  commitRefs(commit),
+			Emoji: c.getEmojiForType(parsed.Type),
+		}, true
 	}
 
 	// Non-conventional commit, just add a generic emoji
-	return fmt.Sprintf("- 🔧 %s", firstLine)
+	return ChangeEntry{Text: firstLine, Refs: commitRefs(commit), Emoji: "🔧"}, true
 }
 
 func (c *Manager) getEmojiForType(commitType string) string {
 	emojiMap := map[string]string{
-		"feat":     "✨",
-		"fix":      "🐛",
-		"docs":     "📚",
-		"style":    "💎",
-		"refactor": "♻️",
-		"perf":     "⚡️",
-		"test":     "✅",
-		"build":    "📦",
-		"ci":       "👷",
-		"chore":    "🔧",
-		"revert":   "⏪",
-		"merge":    "🔀",
+		"feat":      "✨",
+		"fix":       "🐛",
+		"docs":      "📚",
+		"style":     "💎",
+		"refactor":  "♻️",
+		"perf":      "⚡️",
+		"test":      "✅",
+		"build":     "📦",
+		"ci":        "👷",
+		"chore":     "🔧",
+		"revert":    "⏪",
+		"merge":     "🔀",
+		"deprecate": "🗑️",
+		"security":  "🔒",
 	}
 
 	if emoji, exists := emojiMap[commitType]; exists {
@@ -124,18 +276,88 @@ func (c *Manager) getEmojiForType(commitType string) string {
 	return "🔧" // Default emoji
 }
 
+// UpdateChangelog adds a new release entry, refusing with ErrEntryExists if one is already present
 func (c *Manager) UpdateChangelog(version, changes string) error {
-	changelogDir := "docs"
-	changelogPath := filepath.Join(changelogDir, "CHANGELOG.md")
+	return c.writeEntry(version, changes, time.Now(), false)
+}
 
-	// Create docs directory if it doesn't exist
-	if err := os.MkdirAll(changelogDir, 0755); err != nil {
-		return fmt.Errorf("failed to create docs directory: %v", err)
+// ReplaceChangelogEntry writes a release entry, replacing an existing entry for the same version in place
+func (c *Manager) ReplaceChangelogEntry(version, changes string) error {
+	return c.writeEntry(version, changes, time.Now(), true)
+}
+
+// BackfillEntry writes an entry for a release made outside the tool, dated with the
+// release date instead of today
+func (c *Manager) BackfillEntry(version, changes string, date time.Time, replace bool) error {
+	return c.writeEntry(version, changes, date, replace)
+}
+
+// HasEntry reports whether the changelog already contains a heading for the version
+func (c *Manager) HasEntry(version string) bool {
+	content, err := os.ReadFile(c.changelogPath())
+	if err != nil {
+		return false
+	}
+	start, _ := findEntry(string(content), version)
+	return start >= 0
+}
+
+// EntryMatches reports whether the changelog entry for version already holds changes,
+// e.g. written by an aborted run, so writing it again would change nothing
+func (c *Manager) EntryMatches(version, changes string) bool {
+	content, err := os.ReadFile(c.changelogPath())
+	if err != nil {
+		return false
+	}
+	existing := string(content)
+	start, end := findEntry(existing, version)
+	return start >= 0 && sameEntry(existing[start:end], DetectStyle(existing).ApplySections(changes))
+}
+
+// EntryHash returns "sha256:" and the hex SHA-256 of the body of the changelog entry
+// for version: the text below its heading, without leading and trailing whitespace.
+// The heading is left out as its date format is up to the changelog's style.
+func (c *Manager) EntryHash(version string) (string, error) {
+	content, err := os.ReadFile(c.changelogPath())
+	if err != nil {
+		return "", fmt.Errorf("failed to read changelog: %v", err)
+	}
+	existing := string(content)
+	start, end := findEntry(existing, version)
+	if start < 0 {
+		return "", fmt.Errorf("%s has no entry for %s", c.changelogPath(), version)
+	}
+	sum := sha256.Sum256([]byte(entryBody(existing[start:end])))
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// sameEntry compares the body of an entry with changes, ignoring the heading, whose
+// date may differ between runs
+func sameEntry(entry, changes string) bool {
+	return entryBody(entry) == strings.TrimSpace(changes)
+}
+
+// entryBody returns an entry without its heading line, trimmed
+func entryBody(entry string) string {
+	body := ""
+	if newline := strings.Index(entry, "\n"); newline >= 0 {
+		body = entry[newline+1:]
 	}
+	return strings.TrimSpace(body)
+}
+
+// ChangelogPath returns the path of the changelog file that will be updated
+func (c *Manager) ChangelogPath() string {
+	return c.changelogPath()
+}
 
-	// Generate new content
-	date := time.Now().Format("2006-01-02")
-	newContent := fmt.Sprintf("# %s (%s)\n\n%s\n\n", version, date, changes)
+func (c *Manager) writeEntry(version, changes string, date time.Time, replace bool) error {
+	changelogPath := c.changelogPath()
+
+	// Create the changelog directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(changelogPath), 0755); err != nil {
+		return fmt.Errorf("failed to create docs directory: %v", err)
+	}
 
 	// Read existing content
 	existingContent := ""
@@ -143,14 +365,48 @@ func (c *Manager) UpdateChangelog(version, changes string) error {
 		existingContent = string(content)
 	}
 
+	// Match the heading, date and section format already used by the file
+	style := DetectStyle(existingContent)
+	if findFirstEntry(existingContent) < 0 && isVSCodeExtension() {
+		style = VSCodeStyle()
+	}
+	newContent := fmt.Sprintf("%s\n\n%s\n\n", style.FormatHeading(version, date), style.ApplySections(changes))
+
+	// Refuse to create a duplicate release section, e.g. left behind by an aborted run,
+	// unless it already holds exactly these changes
+	start, end := findEntry(existingContent, version)
+	if start >= 0 && sameEntry(existingContent[start:end], style.ApplySections(changes)) {
+		return nil
+	}
+	if start >= 0 && !replace {
+		return fmt.Errorf("%w: %s in %s", ErrEntryExists, version, changelogPath)
+	}
+
 	// Combine content
 	var finalContent string
-	if existingContent == "" {
+	if start >= 0 {
+		// Keep the blank line before the next entry; the last entry ends the file
+		finalContent = existingContent[:start] + newContent
+		if end < len(existingContent) {
+			finalContent += "\n" + existingContent[end:]
+		}
+	} else if existingContent == "" {
 		finalContent = "# Changelog\n\n" + newContent
+	} else if pos := findFirstEntry(existingContent); pos >= 0 {
+		// Insert above the most recent release, keeping any intro text in place
+		finalContent = existingContent[:pos] + newContent + "\n" + existingContent[pos:]
+	} else if pos := findUnreleasedEnd(existingContent); pos >= 0 {
+		// Keep a Changelog files keep the Unreleased section above the releases
+		before := strings.TrimRight(existingContent[:pos], "\n") + "\n\n"
+		finalContent = before + newContent + existingContent[pos:]
 	} else {
-		// Find position after "# Changelog" header
-		if pos := strings.Index(existingContent, "# Changelog"); pos >= 0 {
-			headerEnd := pos + len("# Changelog")
+		// Find position after "# Changelog" header, or "# Change Log" as generated for VS Code extensions
+		header := "# Changelog"
+		if !strings.Contains(existingContent, header) {
+			header = "# Change Log"
+		}
+		if pos := strings.Index(existingContent, header); pos >= 0 {
+			headerEnd := pos + len(header)
 			// Skip to end of line
 			if newlinePos := strings.Index(existingContent[headerEnd:], "\n"); newlinePos >= 0 {
 				headerEnd += newlinePos + 1
@@ -171,6 +427,23 @@ func (c *Manager) UpdateChangelog(version, changes string) error {
 	return nil
 }
 
+// changelogPath returns docs/CHANGELOG.md unless the project only has a root CHANGELOG.md
+// or is a VS Code extension
+func (c *Manager) changelogPath() string {
+	docsPath := filepath.Join("docs", "CHANGELOG.md")
+	if _, err := os.Stat(docsPath); err == nil {
+		return docsPath
+	}
+	if _, err := os.Stat("CHANGELOG.md"); err == nil {
+		return "CHANGELOG.md"
+	}
+	// vsce only packages a CHANGELOG.md in the extension root
+	if isVSCodeExtension() {
+		return "CHANGELOG.md"
+	}
+	return docsPath
+}
+
 func (c *Manager) PreviewChanges(fromVersion string) (string, error) {
 	return c.GenerateChanges(fromVersion)
 }
@@ -203,20 +476,95 @@ func (c *Manager) isClaudeAvailable() bool {
 func (c *Manager) formatCommitsForClaude(commits []git.Commit) string {
 	var commitText strings.Builder
 	for _, commit := range commits {
-		// Skip version bump commits
-		if strings.Contains(commit.Message, "bump version") ||
-			strings.Contains(commit.Message, "release") ||
-			strings.Contains(commit.Message, "chore(release)") {
+		if c.isUnlistedCommit(commit) {
 			continue
 		}
-		commitText.WriteString(fmt.Sprintf("- %s\n", commit.Message))
+		marker := ""
+		if section := c.groupSection(commit); section != "" {
+			marker = fmt.Sprintf(" [section: %s]", section)
+		}
+		commitText.WriteString(fmt.Sprintf("- %s%s%s\n", commit.Message, formatCommitFiles(commit.Files), marker))
 	}
 	return commitText.String()
 }
 
-func (c *Manager) buildSimplePrompt(commits []git.Commit) string {
+// formatCommitFiles lists a commit's changed paths as prompt context, e.g. " (files: a.go, b.go and 3 more)"
+func formatCommitFiles(files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+	if len(files) <= maxPromptFiles {
+		return fmt.Sprintf(" (files: %s)", strings.Join(files, ", "))
+	}
+	return fmt.Sprintf(" (files: %s and %d more)", strings.Join(files[:maxPromptFiles], ", "), len(files)-maxPromptFiles)
+}
+
+// BuildPrompt returns the prompt that would be sent to Claude for commits since fromVersion.
+// It stays off the network: squash merges keep their own subjects unless their pull
+// requests were already fetched this run.
+func (c *Manager) BuildPrompt(fromVersion string) (string, error) {
+	commits, err := c.collectCommits(fromVersion, false)
+	if err != nil {
+		return "", err
+	}
+	return c.buildPrompt(commits)
+}
+
+func (c *Manager) buildPrompt(commits []git.Commit) (string, error) {
 	commitMessages := c.formatCommitsForClaude(commits)
 
+	prompt := c.defaultPrompt(commitMessages)
+	if c.settings.AI.PromptTemplate != "" {
+		tmpl, err := template.New("prompt").Parse(c.settings.AI.PromptTemplate)
+		if err != nil {
+			return "", fmt.Errorf("invalid prompt template: %v", err)
+		}
+
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, struct{ Commits string }{Commits: commitMessages}); err != nil {
+			return "", fmt.Errorf("failed to render prompt template: %v", err)
+		}
+		prompt = rendered.String()
+		if c.structuredOutput() {
+			prompt = strings.TrimRight(prompt, "\n") + "\n\n" + structuredOutputFormat
+		}
+	}
+
+	if len(c.settings.AI.Instructions) > 0 {
+		var extra strings.Builder
+		extra.WriteString("\nAdditional project instructions:\n")
+		for _, instruction := range c.settings.AI.Instructions {
+			extra.WriteString(fmt.Sprintf("- %s\n", instruction))
+		}
+		prompt = strings.TrimRight(prompt, "\n") + "\n" + extra.String()
+	}
+
+	if hints := c.groupHints(commits); hints != "" {
+		prompt = strings.TrimRight(prompt, "\n") + "\n" + hints
+	}
+
+	if language := c.settings.Messages.Language; language != "" && language != config.DefaultLanguage {
+		prompt = strings.TrimRight(prompt, "\n") + fmt.Sprintf("\n\nWrite the changelog in %s, including the category headings.\n", c.settings.Messages.Texts().Name)
+	}
+
+	return prompt, nil
+}
+
+func (c *Manager) defaultPrompt(commitMessages string) string {
+	if c.structuredOutput() {
+		return fmt.Sprintf(`Please turn these git commit messages into changelog entries:
+
+%s
+Requirements:
+- Group changes by category (Features, Bug Fixes, Improvements, Other)
+- Rewrite commit messages to be user-friendly
+- Focus on what changed, not technical details
+- Skip merge commits and version bumps
+- Do not add breaking changes; they are listed separately
+
+%s`, commitMessages, structuredOutputFormat)
+	}
+
 	return fmt.Sprintf(`Please format these git commit messages into a clean changelog:
 
 %s
@@ -227,6 +575,7 @@ Requirements:
 - Rewrite commit messages to be user-friendly
 - Focus on what changed, not technical details
 - Skip merge commits and version bumps
+- Do not add a breaking changes section; one is generated separately
 
 Output format:
 ## Features
@@ -264,32 +613,95 @@ func (c *Manager) getClaudePath() string {
 	return "" // Not found
 }
 
-func (c *Manager) generateWithClaude(commits []git.Commit) (string, error) {
-	if len(commits) == 0 {
-		return "- Minor updates and improvements", nil
-	}
-
+// completeWithClaude sends a prompt to the Claude CLI and returns the response. With a
+// schema, the response is requested as structured output.
+func (c *Manager) completeWithClaude(ctx context.Context, prompt, schema string) (string, error) {
 	claudePath := c.getClaudePath()
 	if claudePath == "" {
 		return "", fmt.Errorf("claude not found")
 	}
 
-	prompt := c.buildSimplePrompt(commits)
+	args := []string{"-p", prompt, "--output-format", "json"}
+	if schema != "" {
+		args = append(args, "--json-schema", schema)
+	}
+	stdout, stderr, err := runClaude(ctx, claudePath, args)
+	if err != nil && schema != "" && strings.Contains(stderr, "json-schema") {
+		// Older Claude CLI versions lack structured output; the prompt still asks for JSON
+		stdout, _, err = runClaude(ctx, claudePath, args[:len(args)-2])
+	}
+	if err != nil {
+		return "", fmt.Errorf("claude command failed: %v", err)
+	}
+
+	output := strings.TrimSpace(stdout)
+
+	// Older Claude CLI versions ignore --output-format and print plain text
+	result, usage, err := parseClaudeJSON(output)
+	if errors.Is(err, errClaudeReported) {
+		return "", err
+	}
+	if err == nil {
+		output = strings.TrimSpace(result)
+		c.recordUsage(usage)
+	}
+
+	if output == "" {
+		return "", fmt.Errorf("claude returned empty output")
+	}
+	return output, nil
+}
 
-	cmd := exec.Command(claudePath, "-p", prompt)
+// runClaude runs the Claude CLI and returns its output and error output
+func runClaude(ctx context.Context, claudePath string, args []string) (string, string, error) {
+	cmd := exec.CommandContext(ctx, claudePath, args...)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("claude command failed: %v", err)
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+// LastFromCache reports whether the most recent changelog was served from the cache
+func (c *Manager) LastFromCache() bool {
+	return c.fromCache
+}
+
+// recordUsage stores the usage of the latest generation and adds its cost to the monthly ledger
+func (c *Manager) recordUsage(usage Usage) {
+	c.lastUsage = &usage
+	log.Printf("AI changelog generation usage: %s", usage)
+
+	ledger, err := LoadUsageLedger()
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := ledger.Record(usage.CostUSD, time.Now()); err != nil {
+		log.Printf("Warning: failed to record AI usage: %v", err)
 	}
+}
 
-	output := strings.TrimSpace(stdout.String())
-	if output == "" {
-		return "", fmt.Errorf("claude returned empty output")
+// LastUsage returns the usage of the most recent AI generation, or nil if the AI wasn't used
+func (c *Manager) LastUsage() *Usage {
+	return c.lastUsage
+}
+
+// EstimateUsage estimates tokens and cost of generating a changelog for commits since fromVersion
+func (c *Manager) EstimateUsage(fromVersion string) (Usage, error) {
+	prompt, err := c.BuildPrompt(fromVersion)
+	if err != nil {
+		return Usage{}, err
 	}
+	return estimateUsage(prompt, c.settings.AI.InputCostPerMTok, c.settings.AI.OutputCostPerMTok), nil
+}
 
-	return output, nil
+// BudgetStatus returns this month's recorded AI spend and the configured monthly budget (0 when unset)
+func (c *Manager) BudgetStatus() (float64, float64) {
+	ledger, err := LoadUsageLedger()
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return ledger.MonthTotal(time.Now()), c.settings.AI.MonthlyBudgetUSD
 }
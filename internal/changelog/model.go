@@ -0,0 +1,160 @@
+package changelog
+
+import (
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+var (
+	// scopeTextRe splits a rendered bullet into its bold scope and text, e.g. "**api:** add pagination"
+	scopeTextRe = regexp.MustCompile(`^\*\*([^*]+):\*\*\s+(.*)$`)
+	// pullRequestRefRe finds pull request references in commit subjects and bullets, e.g. "(#42)"
+	pullRequestRefRe = regexp.MustCompile(`#\d+\b`)
+)
+
+// ChangeEntry is a single change of a release. Both generators produce entries, which
+// are rendered to markdown as a separate step.
+type ChangeEntry struct {
+	// Category is the section the change is listed under, e.g. "Features"; empty for
+	// the list without a heading
+	Category string `json:"category,omitempty"`
+	// Type is the conventional commit type, when the change comes from one commit
+	Type  string `json:"type,omitempty"`
+	Scope string `json:"scope,omitempty"`
+	Text  string `json:"text"`
+	// Refs are the commits and pull requests behind the change, e.g. "a1b2c3d" or "#42"
+	Refs []string `json:"refs,omitempty"`
+	// Emoji marks the commit type in generated lists, e.g. "✨"
+	Emoji string `json:"emoji,omitempty"`
+	// Notes are the indented lines below the bullet, e.g. migration notes
+	Notes []string `json:"notes,omitempty"`
+}
+
+// Changes is the structured form of a release entry
+type Changes struct {
+	// Summary is a line above the changes, e.g. the stats line
+	Summary string        `json:"summary,omitempty"`
+	Entries []ChangeEntry `json:"entries"`
+	// Alerts are rendered above the heading of a category, keyed by category
+	Alerts map[string]string `json:"alerts,omitempty"`
+}
+
+// Categories returns the categories of the entries in order of first appearance
+func (c Changes) Categories() []string {
+	var categories []string
+	seen := make(map[string]bool)
+	for _, entry := range c.Entries {
+		if !seen[entry.Category] {
+			seen[entry.Category] = true
+			categories = append(categories, entry.Category)
+		}
+	}
+	return categories
+}
+
+// Markdown renders the changes with a "## Category" section per category
+func (c Changes) Markdown() string {
+	var blocks []string
+	if c.Summary != "" {
+		blocks = append(blocks, c.Summary)
+	}
+
+	for _, category := range c.Categories() {
+		var lines []string
+		if category != "" {
+			lines = append(lines, "## "+category)
+		}
+		for _, entry := range c.Entries {
+			if entry.Category == category {
+				lines = append(lines, entry.Markdown())
+			}
+		}
+
+		block := strings.Join(lines, "\n")
+		if alert := c.Alerts[category]; alert != "" {
+			block = alert + "\n\n" + block
+		}
+		blocks = append(blocks, block)
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// Markdown renders the entry as a bullet, followed by its notes
+func (e ChangeEntry) Markdown() string {
+	var bullet strings.Builder
+	bullet.WriteString("- ")
+	if e.Emoji != "" {
+		bullet.WriteString(e.Emoji + " ")
+	}
+	if e.Scope != "" {
+		bullet.WriteString("**" + e.Scope + ":** ")
+	}
+	bullet.WriteString(e.Text)
+	for _, note := range e.Notes {
+		bullet.WriteString("\n" + note)
+	}
+	return bullet.String()
+}
+
+// parseChanges reads the bullets of generated markdown, such as the AI generator's
+// output, into entries. Headings become categories; other lines are dropped.
+func parseChanges(markdown string) []ChangeEntry {
+	var entries []ChangeEntry
+	for _, category := range ParseEntry(markdown).Categories {
+		name := strings.TrimSpace(strings.TrimLeft(category.Heading, "#"))
+		for _, item := range category.Items {
+			if !item.Bullet {
+				continue
+			}
+			lines := strings.Split(item.Text, "\n")
+			entry := ChangeEntry{Category: name, Text: strings.TrimSpace(lines[0])}
+			if len(lines) > 1 {
+				entry.Notes = lines[1:]
+			}
+			if matches := scopeTextRe.FindStringSubmatch(entry.Text); matches != nil {
+				entry.Scope, entry.Text = matches[1], matches[2]
+			}
+			entry.Refs = pullRequestRefRe.FindAllString(entry.Text, -1)
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// commitRefs returns the short hash of a commit and the pull requests its subject mentions
+func commitRefs(commit git.Commit) []string {
+	var refs []string
+	if commit.Hash != "" {
+		hash := commit.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		refs = append(refs, hash)
+	}
+	return append(refs, pullRequestRefRe.FindAllString(commit.Message, -1)...)
+}
+
+// dedupeEntries merges entries with the same category, scope and text, such as
+// cherry-picked commits, keeping the first and collecting the refs of the others
+func dedupeEntries(entries []ChangeEntry) []ChangeEntry {
+	var deduped []ChangeEntry
+	index := make(map[string]int)
+	for _, entry := range entries {
+		key := entry.Category + "\x00" + strings.ToLower(entry.Scope) + "\x00" + strings.ToLower(entry.Text)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(deduped)
+			deduped = append(deduped, entry)
+			continue
+		}
+		for _, ref := range entry.Refs {
+			if !containsFold(deduped[i].Refs, ref) {
+				deduped[i].Refs = append(deduped[i].Refs, ref)
+			}
+		}
+	}
+	return deduped
+}
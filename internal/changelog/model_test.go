@@ -0,0 +1,58 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChanges(t *testing.T) {
+	markdown := "Here is the changelog:\n\n## Features\n- **api:** add pagination (#42)\n  - cursor based\n\n## Bug Fixes\n- handle empty input\n"
+
+	expected := []ChangeEntry{
+		{Category: "Features", Scope: "api", Text: "add pagination (#42)", Refs: []string{"#42"}, Notes: []string{"  - cursor based"}},
+		{Category: "Bug Fixes", Text: "handle empty input"},
+	}
+	got := parseChanges(markdown)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	rendered := Changes{Entries: got}.Markdown()
+	expectedMarkdown := "## Features\n- **api:** add pagination (#42)\n  - cursor based\n\n## Bug Fixes\n- handle empty input"
+	if rendered != expectedMarkdown {
+		t.Errorf("Expected %q, got %q", expectedMarkdown, rendered)
+	}
+}
+
+func TestChangesMarkdown(t *testing.T) {
+	changes := Changes{
+		Summary: "_2 commits_",
+		Entries: []ChangeEntry{
+			{Category: securityCategory, Text: "escape input"},
+			{Emoji: "✨", Text: "add export"},
+			{Category: securityCategory, Scope: "auth", Text: "rotate keys"},
+		},
+		Alerts: map[string]string{securityCategory: "> [!WARNING]"},
+	}
+
+	expected := "_2 commits_\n\n> [!WARNING]\n\n## 🔒 Security\n- escape input\n- **auth:** rotate keys\n\n- ✨ add export"
+	if got := changes.Markdown(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestDedupeEntries(t *testing.T) {
+	entries := []ChangeEntry{
+		{Text: "Fix crash", Refs: []string{"a1b2c3d"}},
+		{Text: "fix crash", Refs: []string{"e4f5a6b"}},
+		{Category: "Features", Text: "Fix crash"},
+	}
+
+	got := dedupeEntries(entries)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", got)
+	}
+	if !reflect.DeepEqual(got[0].Refs, []string{"a1b2c3d", "e4f5a6b"}) {
+		t.Errorf("Expected merged refs, got %v", got[0].Refs)
+	}
+}
@@ -0,0 +1,52 @@
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// NotesData is the data available to the changelog.notes_out path template
+type NotesData struct {
+	Version string
+	Tag     string
+}
+
+// NotesPath renders the changelog.notes_out path for version, or "" when notes aren't exported
+func (c *Manager) NotesPath(version string) (string, error) {
+	pattern := c.settings.Changelog.NotesOut
+	if pattern == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("notes_out").Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid changelog.notes_out template: %v", err)
+	}
+	var path bytes.Buffer
+	if err := tmpl.Execute(&path, NotesData{Version: version, Tag: "v" + version}); err != nil {
+		return "", fmt.Errorf("unable to render changelog.notes_out: %v", err)
+	}
+	return path.String(), nil
+}
+
+// WriteNotes writes the entry for version on its own to the changelog.notes_out path,
+// e.g. for `gh release create --notes-file`. It returns the path, or "" when notes
+// aren't exported.
+func (c *Manager) WriteNotes(version, changes string) (string, error) {
+	path, err := c.NotesPath(version)
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(strings.TrimRight(changes, "\n")+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to write release notes to %s: %v", path, err)
+	}
+	return path, nil
+}
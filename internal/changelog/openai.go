@@ -0,0 +1,105 @@
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// defaultOpenAIBaseURL is used unless OPENAI_BASE_URL points at a compatible API
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+func openAIKey() string {
+	return os.Getenv("OPENAI_API_KEY")
+}
+
+// openAIBaseURL returns the API base URL without a trailing slash
+func (c *Manager) openAIBaseURL() string {
+	if c.openAIURL != "" {
+		return c.openAIURL
+	}
+	if url := os.Getenv("OPENAI_BASE_URL"); url != "" {
+		return strings.TrimRight(url, "/")
+	}
+	return defaultOpenAIBaseURL
+}
+
+// openAIResponse is the subset of a chat completion response used here
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// completeWithOpenAI sends a prompt to the OpenAI chat completions API and returns the
+// response. With jsonObject, the response is requested as a JSON object.
+func (c *Manager) completeWithOpenAI(ctx context.Context, prompt string, jsonObject bool) (string, error) {
+	key := openAIKey()
+	if key == "" {
+		return "", fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+
+	request := map[string]any{
+		"model":    c.settings.AI.OpenAIModel,
+		"messages": []map[string]string{{"role": "user", "content": prompt}},
+	}
+	if jsonObject {
+		request["response_format"] = map[string]string{"type": "json_object"}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.openAIBaseURL()+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var response openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("unable to parse the openai response (%s): %v", resp.Status, err)
+	}
+	if response.Error != nil {
+		return "", fmt.Errorf("openai reported an error: %s", response.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("openai request failed: %s", resp.Status)
+	}
+	if len(response.Choices) == 0 || strings.TrimSpace(response.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("openai returned empty output")
+	}
+
+	settings := c.settings.AI
+	c.recordUsage(Usage{
+		InputTokens:  response.Usage.PromptTokens,
+		OutputTokens: response.Usage.CompletionTokens,
+		CostUSD: (float64(response.Usage.PromptTokens)*settings.InputCostPerMTok +
+			float64(response.Usage.CompletionTokens)*settings.OutputCostPerMTok) / 1_000_000,
+	})
+
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
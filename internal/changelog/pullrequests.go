@@ -0,0 +1,102 @@
+package changelog
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/github"
+)
+
+// squashSubjectRe matches squash-merge subjects of the form "Title (#123)"
+var squashSubjectRe = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+
+// usesSquashMerges reports whether at least half of the commits look like squash merges
+func usesSquashMerges(commits []git.Commit) bool {
+	if len(commits) == 0 {
+		return false
+	}
+
+	squashed := 0
+	for _, commit := range commits {
+		if squashSubjectRe.MatchString(commit.Message) {
+			squashed++
+		}
+	}
+	return squashed*2 >= len(commits)
+}
+
+// ingestPullRequests replaces squash-merge commits with the title and body of their
+// pull request, which usually carry the real change description. Commits are returned
+// unchanged whenever the PR data can't be fetched. Listings are fetched once per run;
+// without fetch, only a listing fetched earlier is used.
+func (c *Manager) ingestPullRequests(fromVersion string, commits []git.Commit, fetch bool) []git.Commit {
+	switch c.settings.Changelog.SquashPRs {
+	case config.SquashPRsNever:
+		return commits
+	case config.SquashPRsAlways:
+	default:
+		if !usesSquashMerges(commits) {
+			return commits
+		}
+	}
+
+	// Only search pull requests merged within the commit range
+	search := ""
+	if since := c.sinceRef(fromVersion); since != "" {
+		if date, err := c.gitManager.GetTagDate(since); err == nil {
+			search = fmt.Sprintf("merged:>=%s", date.UTC().Format("2006-01-02"))
+			if c.until != "" {
+				if end, err := c.gitManager.GetTagDate(c.until); err == nil {
+					search = fmt.Sprintf("merged:%s..%s", date.UTC().Format("2006-01-02"), end.UTC().Format("2006-01-02"))
+				}
+			}
+		}
+	}
+
+	pullRequests, fetched := c.pullRequests[search]
+	if !fetched {
+		if !fetch || !c.githubManager.IsAvailable() {
+			return commits
+		}
+		var err error
+		pullRequests, err = c.githubManager.ListMergedPullRequests(search)
+		if err != nil {
+			log.Printf("Warning: unable to fetch merged pull requests: %v", err)
+			return commits
+		}
+		if c.pullRequests == nil {
+			c.pullRequests = make(map[string][]github.PullRequest)
+		}
+		c.pullRequests[search] = pullRequests
+	}
+
+	titles := make(map[int]int, len(pullRequests))
+	for i, pr := range pullRequests {
+		titles[pr.Number] = i
+	}
+
+	ingested := make([]git.Commit, len(commits))
+	for i, commit := range commits {
+		ingested[i] = commit
+
+		matches := squashSubjectRe.FindStringSubmatch(commit.Message)
+		if matches == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(matches[1])
+		index, ok := titles[number]
+		if !ok {
+			continue
+		}
+
+		pr := pullRequests[index]
+		ingested[i].Message = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		ingested[i].Body = pr.Body
+	}
+
+	return ingested
+}
@@ -0,0 +1,220 @@
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/github"
+)
+
+// tagDateRunner answers the git commands used to find the date of tags
+type tagDateRunner map[string]string
+
+func (r tagDateRunner) Run(env []string, args ...string) (string, string, error) {
+	if len(args) == 4 && args[0] == "log" {
+		if date, ok := r[args[3]]; ok {
+			return date + "\n", "", nil
+		}
+	}
+	if len(args) == 3 && args[0] == "rev-parse" {
+		if _, ok := r[strings.TrimSuffix(args[2], "^{commit}")]; ok {
+			return "abc123\n", "", nil
+		}
+	}
+	return "", "", fmt.Errorf("unexpected git %s", strings.Join(args, " "))
+}
+
+// prLister stubs gh, listing pull requests and recording the searches
+type prLister struct {
+	pullRequests []github.PullRequest
+	unavailable  bool
+	err          error
+	searches     []string
+}
+
+func (l *prLister) Run(timeout time.Duration, args ...string) (string, string, error) {
+	if args[0] == "--version" {
+		if l.unavailable {
+			return "", "", errors.New("executable file not found")
+		}
+		return "gh version 2.60.0", "", nil
+	}
+	search := ""
+	if args[len(args)-2] == "--search" {
+		search = args[len(args)-1]
+	}
+	l.searches = append(l.searches, search)
+	if l.err != nil {
+		return "", "", l.err
+	}
+	output, err := json.Marshal(l.pullRequests)
+	return string(output), "", err
+}
+
+func TestUsesSquashMerges(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		expected bool
+	}{
+		{"no commits", nil, false},
+		{"all squashed", []string{"Add export (#12)", "Fix crash (#13)"}, true},
+		{"half squashed", []string{"Add export (#12)", "wip"}, true},
+		{"mostly direct", []string{"Add export (#12)", "wip", "more wip"}, false},
+		{"number not at the end", []string{"Fix (#12) regression", "Tidy up"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commits []git.Commit
+			for _, message := range tt.messages {
+				commits = append(commits, git.Commit{Message: message})
+			}
+			if got := usesSquashMerges(commits); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIngestPullRequests(t *testing.T) {
+	pullRequests := []github.PullRequest{
+		{Number: 12, Title: "feat: add CSV export", Body: "Exports reports as CSV."},
+		{Number: 13, Title: "fix: keep the cursor on reload", Body: ""},
+	}
+	squashed := []git.Commit{
+		{Hash: "a", Message: "Export (#12)"},
+		{Hash: "b", Message: "cursor (#13)"},
+		{Hash: "c", Message: "Docs (#99)"},
+	}
+	direct := []git.Commit{
+		{Hash: "a", Message: "Export (#12)"},
+		{Hash: "d", Message: "wip"},
+		{Hash: "e", Message: "more wip"},
+	}
+	ingested := []string{"feat: add CSV export (#12)", "fix: keep the cursor on reload (#13)", "Docs (#99)"}
+
+	tests := []struct {
+		name        string
+		squashPRs   string
+		commits     []git.Commit
+		fromVersion string
+		lister      *prLister
+		expected    []string
+		searches    []string
+	}{
+		{
+			name:      "auto with squash merges",
+			squashPRs: config.SquashPRsAuto,
+			commits:   squashed,
+			lister:    &prLister{pullRequests: pullRequests},
+			expected:  ingested,
+			searches:  []string{""},
+		},
+		{
+			name:        "searches from the previous tag",
+			squashPRs:   config.SquashPRsAuto,
+			commits:     squashed,
+			fromVersion: "1.0.0",
+			lister:      &prLister{pullRequests: pullRequests},
+			expected:    ingested,
+			searches:    []string{"merged:>=2026-03-01"},
+		},
+		{
+			name:      "auto without squash merges",
+			squashPRs: config.SquashPRsAuto,
+			commits:   direct,
+			lister:    &prLister{pullRequests: pullRequests},
+			expected:  []string{"Export (#12)", "wip", "more wip"},
+		},
+		{
+			name:      "always",
+			squashPRs: config.SquashPRsAlways,
+			commits:   direct,
+			lister:    &prLister{pullRequests: pullRequests},
+			expected:  []string{"feat: add CSV export (#12)", "wip", "more wip"},
+			searches:  []string{""},
+		},
+		{
+			name:      "never",
+			squashPRs: config.SquashPRsNever,
+			commits:   squashed,
+			lister:    &prLister{pullRequests: pullRequests},
+			expected:  []string{"Export (#12)", "cursor (#13)", "Docs (#99)"},
+		},
+		{
+			name:      "gh not installed",
+			squashPRs: config.SquashPRsAlways,
+			commits:   squashed,
+			lister:    &prLister{unavailable: true},
+			expected:  []string{"Export (#12)", "cursor (#13)", "Docs (#99)"},
+		},
+		{
+			name:      "listing fails",
+			squashPRs: config.SquashPRsAlways,
+			commits:   squashed,
+			lister:    &prLister{err: errors.New("HTTP 401")},
+			expected:  []string{"Export (#12)", "cursor (#13)", "Docs (#99)"},
+			searches:  []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := config.DefaultSettings()
+			settings.Changelog.SquashPRs = tt.squashPRs
+			gitManager := git.NewManager()
+			gitManager.SetRunner(tagDateRunner{"v1.0.0": "2026-03-01T12:00:00Z"})
+			githubManager := github.NewManager()
+			githubManager.SetRunner(tt.lister)
+			manager := &Manager{settings: settings, gitManager: gitManager, githubManager: githubManager}
+
+			result := manager.ingestPullRequests(tt.fromVersion, tt.commits, true)
+			var messages []string
+			for _, commit := range result {
+				messages = append(messages, commit.Message)
+			}
+			if strings.Join(messages, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, messages)
+			}
+			if strings.Join(tt.lister.searches, "|") != strings.Join(tt.searches, "|") {
+				t.Errorf("Expected searches %q, got %q", tt.searches, tt.lister.searches)
+			}
+			if tt.expected[0] == ingested[0] && result[0].Body != "Exports reports as CSV." {
+				t.Errorf("Expected the pull request body, got %q", result[0].Body)
+			}
+			if tt.commits[0].Message != "Export (#12)" {
+				t.Errorf("Expected the input commits to be left alone, got %q", tt.commits[0].Message)
+			}
+		})
+	}
+}
+
+func TestIngestPullRequestsWithoutFetch(t *testing.T) {
+	lister := &prLister{pullRequests: []github.PullRequest{{Number: 12, Title: "feat: add CSV export"}}}
+	githubManager := github.NewManager()
+	githubManager.SetRunner(lister)
+	manager := &Manager{settings: config.DefaultSettings(), gitManager: git.NewManager(), githubManager: githubManager}
+	commits := []git.Commit{{Hash: "a", Message: "Export (#12)"}}
+
+	// Previews and estimates don't list pull requests themselves
+	if result := manager.ingestPullRequests("", commits, false); result[0].Message != "Export (#12)" || len(lister.searches) != 0 {
+		t.Fatalf("Expected no fetch, got %q after %d listings", result[0].Message, len(lister.searches))
+	}
+
+	// but reuse a listing fetched earlier in the run, which is fetched only once
+	for _, fetch := range []bool{true, true, false} {
+		if result := manager.ingestPullRequests("", commits, fetch); result[0].Message != "feat: add CSV export (#12)" {
+			t.Errorf("Expected the pull request title, got %q", result[0].Message)
+		}
+	}
+	if len(lister.searches) != 1 {
+		t.Errorf("Expected one listing, got %d", len(lister.searches))
+	}
+}
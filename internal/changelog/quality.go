@@ -0,0 +1,103 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"bump-tui/internal/git"
+)
+
+// qualityStopWords are too common in commit subjects and bullets to link them
+var qualityStopWords = map[string]bool{
+	"add": true, "added": true, "adds": true, "and": true, "are": true, "fix": true,
+	"fixed": true, "fixes": true, "for": true, "from": true, "has": true, "into": true,
+	"new": true, "now": true, "the": true, "this": true, "updat": true,
+	"use": true, "was": true, "when": true, "with": true,
+}
+
+// droppedCheckTypes are the commit types a changelog must mention; other types, such as
+// chores and refactors, are often summarized away on purpose
+var droppedCheckTypes = map[string]bool{"feat": true, "fix": true, "perf": true, "revert": true}
+
+// checkQuality compares AI-generated entries with the commits they were generated from.
+// It reports user-facing commits no entry mentions and entries no commit accounts for,
+// matching by shared words since the AI rewrites subjects.
+func (c *Manager) checkQuality(entries []ChangeEntry, commits []git.Commit) []string {
+	var listed []git.Commit
+	for _, commit := range commits {
+		if !c.isUnlistedCommit(commit) {
+			listed = append(listed, commit)
+		}
+	}
+	if len(listed) == 0 || len(entries) == 1 && entries[0].Text == minorUpdatesEntry.Text {
+		return nil
+	}
+
+	commitWords := make([]map[string]bool, len(listed))
+	for i, commit := range listed {
+		parsed, _ := parseConventionalCommit(commit)
+		commitWords[i] = qualityWords(parsed.Scope + " " + parsed.Description)
+	}
+	entryWords := make([]map[string]bool, len(entries))
+	for i, entry := range entries {
+		entryWords[i] = qualityWords(entry.Scope + " " + entry.Text)
+	}
+
+	var issues []string
+	for i, commit := range listed {
+		parsed, ok := parseConventionalCommit(commit)
+		// Breaking changes are listed in their own section, outside the generated entries
+		if !ok || !droppedCheckTypes[parsed.Type] || parsed.Breaking {
+			continue
+		}
+		if !anyOverlap(commitWords[i], entryWords) {
+			issues = append(issues, fmt.Sprintf("No entry for commit %s", strings.TrimSpace(commit.Message)))
+		}
+	}
+	for i, entry := range entries {
+		if !anyOverlap(entryWords[i], commitWords) {
+			issues = append(issues, fmt.Sprintf("No commit for entry %q", entry.Text))
+		}
+	}
+	return issues
+}
+
+// qualityWords returns the significant words of text, lowercased and cut to five
+// letters so "export", "exports" and "exported" match
+func qualityWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) > 5 {
+			word = word[:5]
+		}
+		if len(word) >= 3 && !qualityStopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// anyOverlap reports whether words shares a word with any of the candidates. Text
+// without significant words is given the benefit of the doubt.
+func anyOverlap(words map[string]bool, candidates []map[string]bool) bool {
+	if len(words) == 0 {
+		return true
+	}
+	for _, candidate := range candidates {
+		for word := range words {
+			if candidate[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LastQualityIssues returns the discrepancies found between the most recent AI
+// changelog and its commits
+func (c *Manager) LastQualityIssues() []string {
+	return c.lastQuality
+}
@@ -0,0 +1,37 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestCheckQuality(t *testing.T) {
+	manager := &Manager{settings: config.DefaultSettings()}
+	commits := []git.Commit{
+		{Message: "feat(reports): add csv export"},
+		{Message: "fix: crash when the config file is empty"},
+		{Message: "perf: cache parsed templates"},
+		{Message: "chore: tidy imports"},
+		{Message: "feat(api)!: remove v1 endpoints"},
+	}
+	entries := []ChangeEntry{
+		{Category: "Features", Text: "Reports can now be exported as CSV"},
+		{Category: "Bug Fixes", Text: "No longer crashes on an empty configuration"},
+		{Category: "Features", Text: "Dark mode for the dashboard"},
+	}
+
+	expected := []string{
+		"No entry for commit perf: cache parsed templates",
+		`No commit for entry "Dark mode for the dashboard"`,
+	}
+	if got := manager.checkQuality(entries, commits); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if got := manager.checkQuality([]ChangeEntry{minorUpdatesEntry}, commits); got != nil {
+		t.Errorf("Expected no issues for the placeholder entry, got %q", got)
+	}
+}
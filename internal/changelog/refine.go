@@ -0,0 +1,93 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// refineSchema wraps a revised changelog in JSON mode, so no prose can surround it
+const refineSchema = `{"type":"object","properties":{"changelog":{"type":"string","minLength":1}},"required":["changelog"],"additionalProperties":false}`
+
+// Refine asks the AI generators of the chain, in order, to revise a changelog following
+// an instruction such as "make it terser", instead of generating it again. The revision
+// is returned as markdown.
+func (c *Manager) Refine(changes, instruction string) (string, error) {
+	c.lastUsage = nil
+
+	err := fmt.Errorf("no AI generator is available")
+	for _, generator := range c.settings.AI.Generators {
+		if !aiGenerator(generator) || !c.generatorAvailable(generator) {
+			continue
+		}
+
+		var refined string
+		refined, err = c.refineWith(generator, changes, instruction)
+		if err == nil {
+			return refined, nil
+		}
+		err = fmt.Errorf("%s: %v", generator, err)
+	}
+	return "", err
+}
+
+// refineWith asks one generator for a revision within its timeout
+func (c *Manager) refineWith(generator, changes, instruction string) (string, error) {
+	timeout := c.generatorTimeout(generator)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	schema := ""
+	if c.structuredOutput() {
+		schema = refineSchema
+	}
+	output, err := c.complete(ctx, generator, refinePrompt(changes, instruction, schema != ""), schema)
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		return "", err
+	}
+	return parseRefined(output, schema != "")
+}
+
+func refinePrompt(changes, instruction string, structured bool) string {
+	format := "Reply with only the revised changelog markdown, without code fences or any text around it."
+	if structured {
+		format = `Reply with only a JSON object holding the revised changelog markdown: {"changelog": "..."}`
+	}
+	return fmt.Sprintf(`Revise this changelog following the instruction below.
+
+Changelog:
+%s
+
+Instruction: %s
+
+Requirements:
+- Change only what the instruction asks for; keep every other line as it is
+- Keep the markdown headings and bullet points (-)
+- Do not add changes that aren't in the changelog
+
+%s
+`, strings.TrimSpace(changes), strings.TrimSpace(instruction), format)
+}
+
+// parseRefined extracts the revised markdown, rejecting responses without any bullets
+func parseRefined(output string, structured bool) (string, error) {
+	refined := stripCodeFence(output)
+	if structured {
+		var response struct {
+			Changelog string `json:"changelog"`
+		}
+		if err := json.Unmarshal([]byte(refined), &response); err != nil {
+			return "", fmt.Errorf("the response is not valid JSON: %v", err)
+		}
+		refined = stripCodeFence(response.Changelog)
+	}
+
+	if len(parseChanges(refined)) == 0 {
+		return "", fmt.Errorf("the revised changelog has no bullets")
+	}
+	return refined, nil
+}
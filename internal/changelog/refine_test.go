@@ -0,0 +1,36 @@
+package changelog
+
+import "testing"
+
+func TestParseRefined(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		structured bool
+		expected   string
+		expectErr  bool
+	}{
+		{"json", `{"changelog": "## Features\n- Export reports"}`, true, "## Features\n- Export reports", false},
+		{"markdown in a fence", "```markdown\n## Features\n- Export reports\n```", false, "## Features\n- Export reports", false},
+		{"json expected", "## Features\n- Export reports", true, "", true},
+		{"no bullets", `{"changelog": "I made it terser."}`, true, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRefined(tt.output, tt.structured)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"bump-tui/internal/git"
+)
+
+// rewordsFileName stores changelog descriptions for commits whose messages can't be
+// parsed, keyed by commit hash. Git history itself is never rewritten.
+const rewordsFileName = "bump-rewords.json"
+
+// rewordsPath returns the location of the rewords file inside the git directory
+func (c *Manager) rewordsPath() (string, error) {
+	gitDir, err := c.gitManager.GetGitDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, rewordsFileName), nil
+}
+
+// loadRewords reads the saved rewords, returning an empty map if none exist yet
+func (c *Manager) loadRewords() (map[string]string, error) {
+	rewords := map[string]string{}
+
+	path, err := c.rewordsPath()
+	if err != nil {
+		return rewords, err
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return rewords, nil
+	}
+	if err != nil {
+		return rewords, fmt.Errorf("failed to read rewords: %v", err)
+	}
+	if err := json.Unmarshal(content, &rewords); err != nil {
+		return map[string]string{}, fmt.Errorf("failed to parse rewords: %v", err)
+	}
+	return rewords, nil
+}
+
+// SaveReword stores the changelog description used for a commit instead of its
+// message. An empty description removes the reword.
+func (c *Manager) SaveReword(hash, description string) error {
+	rewords, err := c.loadRewords()
+	if err != nil {
+		return err
+	}
+
+	if description == "" {
+		delete(rewords, hash)
+	} else {
+		rewords[hash] = description
+	}
+
+	path, err := c.rewordsPath()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(rewords, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to save rewords: %v", err)
+	}
+	return nil
+}
+
+// UnparseableCommits returns the changelog commits since fromVersion that aren't
+// conventional commits and haven't been reworded yet
+func (c *Manager) UnparseableCommits(fromVersion string) ([]git.Commit, error) {
+	commits, err := c.collectCommits(fromVersion, true)
+	if err != nil {
+		return nil, err
+	}
+	rewords, err := c.loadRewords()
+	if err != nil {
+		return nil, err
+	}
+
+	var unparseable []git.Commit
+	for _, commit := range commits {
+		if c.isUnlistedCommit(commit) || rewords[commit.Hash] != "" {
+			continue
+		}
+		if _, ok := parseConventionalCommit(commit); !ok {
+			unparseable = append(unparseable, commit)
+		}
+	}
+	return unparseable, nil
+}
+
+// applyRewords replaces the subject of reworded commits with their saved description
+func applyRewords(commits []git.Commit, rewords map[string]string) []git.Commit {
+	if len(rewords) == 0 {
+		return commits
+	}
+
+	result := make([]git.Commit, len(commits))
+	for i, commit := range commits {
+		if description := rewords[commit.Hash]; description != "" {
+			commit.Message = description
+		}
+		result[i] = commit
+	}
+	return result
+}
@@ -0,0 +1,25 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestApplyRewords(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "abc1234", Message: "wip"},
+		{Hash: "def5678", Message: "feat: add export"},
+	}
+
+	reworded := applyRewords(commits, map[string]string{"abc1234": "fix: handle empty config files"})
+	if reworded[0].Message != "fix: handle empty config files" {
+		t.Errorf("Expected reworded message, got %q", reworded[0].Message)
+	}
+	if reworded[1].Message != "feat: add export" {
+		t.Errorf("Expected unchanged message, got %q", reworded[1].Message)
+	}
+	if commits[0].Message != "wip" {
+		t.Errorf("Expected input commits to be unchanged, got %q", commits[0].Message)
+	}
+}
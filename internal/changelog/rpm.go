@@ -0,0 +1,64 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// rpmDateLayout is the date format of %changelog entry headers
+const rpmDateLayout = "Mon Jan 02 2006"
+
+// rpmChangelogRe matches the %changelog section marker of a spec file
+var rpmChangelogRe = regexp.MustCompile(`(?m)^%changelog[ \t]*\n`)
+
+// FormatRPMEntry renders release notes as a spec file %changelog entry for
+// version-release, with one "-" line per changelog bullet
+func FormatRPMEntry(versionRelease, packager string, date time.Time, changes string) string {
+	lines := []string{fmt.Sprintf("* %s %s - %s", date.Format(rpmDateLayout), packager, versionRelease)}
+	for _, category := range ParseEntry(changes).Categories {
+		for _, item := range category.Items {
+			if !item.Bullet {
+				continue
+			}
+			text := strings.Split(item.Text, "\n")
+			lines = append(lines, "- "+plainText(text[0]))
+			for _, nested := range text[1:] {
+				if nested = strings.TrimSpace(nested); nested != "" {
+					// Keep nested lines inside the entry; a leading "*" would start a new one
+					lines = append(lines, "  "+strings.TrimPrefix(plainText(nested), "* "))
+				}
+			}
+		}
+	}
+	if len(lines) == 1 {
+		lines = append(lines, "- Update to "+strings.SplitN(versionRelease, "-", 2)[0])
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// WriteRPMChangelog adds an entry for version-1 at the top of the %changelog section
+// of a spec file. Spec files without a %changelog section, or that generate it with
+// %autochangelog, are left alone.
+func (c *Manager) WriteRPMChangelog(specPath, version, changes, packager string) error {
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+
+	spec := string(content)
+	loc := rpmChangelogRe.FindStringIndex(spec)
+	if loc == nil || strings.Contains(spec[loc[1]:], "%autochangelog") {
+		return nil
+	}
+
+	entry := FormatRPMEntry(version+"-1", packager, time.Now(), changes)
+	rest := spec[loc[1]:]
+	if strings.TrimSpace(rest) != "" {
+		entry += "\n"
+	}
+	updated := spec[:loc[1]] + entry + rest
+	return os.WriteFile(specPath, []byte(updated), 0644)
+}
@@ -0,0 +1,60 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteRPMChangelog(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{
+			name:     "entry goes above older entries",
+			spec:     "Version: 1.1.0\n\n%changelog\n* Mon Jan 01 2024 A <a@b> - 1.1.0-1\n- Old\n",
+			expected: "- Added RPM support\n  - Nested detail\n\n* Mon Jan 01 2024 A <a@b> - 1.1.0-1\n- Old\n",
+		},
+		{
+			name:     "empty changelog section",
+			spec:     "Version: 1.1.0\n\n%changelog\n",
+			expected: "- Added RPM support\n  - Nested detail\n",
+		},
+		{
+			name:     "autochangelog is left alone",
+			spec:     "Version: 1.1.0\n\n%changelog\n%autochangelog\n",
+			expected: "%changelog\n%autochangelog\n",
+		},
+		{
+			name:     "no changelog section",
+			spec:     "Version: 1.1.0\n",
+			expected: "Version: 1.1.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pkg.spec")
+			if err := os.WriteFile(path, []byte(tt.spec), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			c := NewManager()
+			changes := "## Features\n- Added **RPM** support\n  - Nested detail"
+			if err := c.WriteRPMChangelog(path, "1.2.0", changes, "Ada <ada@example.com>"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			content, _ := os.ReadFile(path)
+			if !strings.HasSuffix(string(content), tt.expected) {
+				t.Errorf("Expected the spec to end with %q, got %q", tt.expected, content)
+			}
+			if strings.Contains(tt.expected, "Added RPM support") && !strings.Contains(string(content), "Ada <ada@example.com> - 1.2.0-1\n") {
+				t.Errorf("Expected an entry header for 1.2.0-1, got %q", content)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+const (
+	// breakingChangesCategory is the callout rendered at the top of a release entry
+	breakingChangesCategory = "⚠ Breaking Changes"
+	deprecationsCategory    = "🗑️ Deprecations"
+	securityCategory        = "🔒 Security"
+)
+
+// sectionCommitTypes are commit types rendered in their own sections instead of the generated list
+var sectionCommitTypes = map[string]bool{
+	"deprecate": true,
+	"security":  true,
+}
+
+// isSectionCommit reports whether a commit is rendered by a dedicated section
+func isSectionCommit(commit git.Commit) bool {
+	if isDependencyCommit(commit) {
+		return true
+	}
+	parsed, ok := parseConventionalCommit(commit)
+	return ok && sectionCommitTypes[parsed.Type]
+}
+
+// breakingChangeEntries lists breaking changes announced with "!" or a BREAKING
+// CHANGE footer, including migration notes from the footer text
+func breakingChangeEntries(commits []git.Commit) []ChangeEntry {
+	var entries []ChangeEntry
+
+	for _, commit := range commits {
+		parsed, ok := parseConventionalCommit(commit)
+		if !ok || !parsed.Breaking {
+			continue
+		}
+
+		entry := sectionEntry(breakingChangesCategory, commit, parsed)
+		for _, note := range parsed.Footers["BREAKING CHANGE"] {
+			noteLines := strings.Split(strings.TrimSpace(note), "\n")
+			entry.Notes = append(entry.Notes, fmt.Sprintf("  - Migration: %s", noteLines[0]))
+			for _, continuation := range noteLines[1:] {
+				entry.Notes = append(entry.Notes, "    "+continuation)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// deprecationEntries lists "deprecate:" commits
+func deprecationEntries(commits []git.Commit) []ChangeEntry {
+	var entries []ChangeEntry
+	for _, commit := range commits {
+		if parsed, ok := parseConventionalCommit(commit); ok && parsed.Type == "deprecate" {
+			entries = append(entries, sectionEntry(deprecationsCategory, commit, parsed))
+		}
+	}
+	return entries
+}
+
+// securityEntries lists "security:" commits and commits carrying a Security trailer
+func securityEntries(commits []git.Commit) []ChangeEntry {
+	var entries []ChangeEntry
+	for _, commit := range commits {
+		parsed, ok := parseConventionalCommit(commit)
+		trailers := parsed.Footers["Security"]
+
+		if ok && parsed.Type == "security" {
+			entry := sectionEntry(securityCategory, commit, parsed)
+			for _, trailer := range trailers {
+				entry.Notes = append(entry.Notes, fmt.Sprintf("  - %s", trailer))
+			}
+			entries = append(entries, entry)
+			continue
+		}
+
+		for _, trailer := range trailers {
+			entry := sectionEntry(securityCategory, commit, parsed)
+			entry.Text = fmt.Sprintf("%s (%s)", entry.Text, trailer)
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// securityAlert is a GitHub alert placed above the security section so the note
+// stands out in release notes
+func securityAlert(count int) string {
+	return fmt.Sprintf("> [!WARNING]\n> This release contains %d security %s. Upgrading is strongly recommended.",
+		count, pluralize(count, "fix", "fixes"))
+}
+
+// localizeSections translates the headings of the sections bump generates, and the
+// stand-in for releases without listed commits, into the configured language
+func localizeSections(changes Changes, language config.Language) Changes {
+	headings := map[string]string{
+		breakingChangesCategory: language.BreakingChanges,
+		securityCategory:        language.Security,
+		deprecationsCategory:    language.Deprecations,
+		dependenciesCategory:    language.Dependencies,
+	}
+
+	entries := make([]ChangeEntry, len(changes.Entries))
+	for i, entry := range changes.Entries {
+		if heading, ok := headings[entry.Category]; ok {
+			entry.Category = heading
+		}
+		if entry.Category == "" && entry.Text == minorUpdatesEntry.Text {
+			entry.Text = language.MinorUpdates
+		}
+		entries[i] = entry
+	}
+	changes.Entries = entries
+
+	if len(changes.Alerts) > 0 {
+		alerts := make(map[string]string, len(changes.Alerts))
+		for category, alert := range changes.Alerts {
+			if heading, ok := headings[category]; ok {
+				category = heading
+			}
+			alerts[category] = alert
+		}
+		changes.Alerts = alerts
+	}
+	return changes
+}
+
+func sectionEntry(category string, commit git.Commit, parsed conventionalCommit) ChangeEntry {
+	return ChangeEntry{
+		Category: category,
+		Type:     parsed.Type,
+		Scope:    parsed.Scope,
+		Text:     parsed.Description,
+		Refs:     commitRefs(commit),
+	}
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,91 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+func TestRenderBreakingChanges(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "a1", Message: "feat(api)!: remove v1 endpoints", Body: "BREAKING CHANGE: clients must call /v2\nthe v1 routes return 410"},
+		{Hash: "b2", Message: "fix: parse config", Body: "BREAKING-CHANGE: config keys are now case sensitive"},
+		{Hash: "c3", Message: "feat: add export", Body: "Refs: #12"},
+		{Hash: "d4", Message: "not conventional!", Body: ""},
+	}
+
+	rendered := Changes{Entries: breakingChangeEntries(commits)}.Markdown()
+
+	expected := strings.Join([]string{
+		"## " + breakingChangesCategory,
+		"- **api:** remove v1 endpoints",
+		"  - Migration: clients must call /v2",
+		"    the v1 routes return 410",
+		"- parse config",
+		"  - Migration: config keys are now case sensitive",
+	}, "\n")
+
+	if rendered != expected {
+		t.Errorf("Unexpected breaking changes section:\n%s\n\nwant:\n%s", rendered, expected)
+	}
+
+	if entries := breakingChangeEntries(commits[2:]); len(entries) != 0 {
+		t.Errorf("Expected no entries without breaking changes, got %+v", entries)
+	}
+}
+
+func TestRenderSecurityAndDeprecations(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "a1", Message: "security(auth): rotate session keys"},
+		{Hash: "b2", Message: "fix: escape user input", Body: "Security: CVE-2025-0001"},
+		{Hash: "c3", Message: "deprecate(cli): --legacy flag"},
+		{Hash: "d4", Message: "feat: add export"},
+	}
+
+	security := Changes{Entries: securityEntries(commits)}
+	expectedSecurity := "## " + securityCategory + "\n- **auth:** rotate session keys\n- escape user input (CVE-2025-0001)"
+	if section := security.Markdown(); section != expectedSecurity {
+		t.Errorf("Unexpected security section:\n%s\n\nwant:\n%s", section, expectedSecurity)
+	}
+
+	security.Alerts = map[string]string{securityCategory: securityAlert(len(security.Entries))}
+	if section := security.Markdown(); !strings.HasPrefix(section, "> [!WARNING]\n> This release contains 2 security fixes.") {
+		t.Errorf("Expected highlighted security note, got:\n%s", section)
+	}
+
+	expectedDeprecations := "## " + deprecationsCategory + "\n- **cli:** --legacy flag"
+	if section := (Changes{Entries: deprecationEntries(commits)}).Markdown(); section != expectedDeprecations {
+		t.Errorf("Unexpected deprecations section:\n%s\n\nwant:\n%s", section, expectedDeprecations)
+	}
+
+	if !isSectionCommit(commits[0]) || isSectionCommit(commits[1]) {
+		t.Errorf("Expected only security/deprecate typed commits to be section commits")
+	}
+}
+
+func TestLocalizeSections(t *testing.T) {
+	changes := Changes{
+		Entries: []ChangeEntry{
+			{Category: securityCategory, Text: "rotate session keys"},
+			{Category: "Features", Text: "add export"},
+			minorUpdatesEntry,
+		},
+		Alerts: map[string]string{securityCategory: "> [!WARNING]"},
+	}
+
+	localized := localizeSections(changes, config.Languages["de"])
+	expected := "> [!WARNING]\n\n## 🔒 Sicherheit\n- rotate session keys\n\n## Features\n- add export\n\n- Kleinere Aktualisierungen und Verbesserungen"
+	if rendered := localized.Markdown(); rendered != expected {
+		t.Errorf("Unexpected localized entry:\n%s\n\nwant:\n%s", rendered, expected)
+	}
+	if changes.Entries[0].Category != securityCategory {
+		t.Errorf("Expected the original entries to be left alone, got %q", changes.Entries[0].Category)
+	}
+
+	english := localizeSections(changes, config.Languages[config.DefaultLanguage])
+	if rendered := english.Markdown(); rendered != changes.Markdown() {
+		t.Errorf("Expected English to match the built-in headings, got:\n%s", rendered)
+	}
+}
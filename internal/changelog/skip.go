@@ -0,0 +1,46 @@
+package changelog
+
+import (
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+// skipMarkers exclude a commit from the changelog and from deciding whether a release is needed
+var skipMarkers = []string{"[skip changelog]", "[skip release]"}
+
+// hasSkipMarker reports whether a commit subject or body contains a skip marker
+func hasSkipMarker(commit git.Commit) bool {
+	text := strings.ToLower(commit.Message + "\n" + commit.Body)
+	for _, marker := range skipMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterSkipped removes commits carrying a skip marker and returns how many were removed
+func filterSkipped(commits []git.Commit) ([]git.Commit, int) {
+	var kept []git.Commit
+	for _, commit := range commits {
+		if hasSkipMarker(commit) {
+			continue
+		}
+		kept = append(kept, commit)
+	}
+	return kept, len(commits) - len(kept)
+}
+
+// ReleaseNeeded reports whether the commits since fromVersion warrant a release. A range
+// made up solely of commits marked [skip changelog] or [skip release] needs no release.
+// The number of skipped commits is returned alongside.
+func (c *Manager) ReleaseNeeded(fromVersion string) (bool, int, error) {
+	commits, err := c.gitManager.GetCommitsInRange(c.sinceRef(fromVersion), c.untilRef())
+	if err != nil {
+		return false, 0, err
+	}
+
+	kept, skipped := filterSkipped(commits)
+	return len(kept) > 0 || skipped == 0, skipped, nil
+}
@@ -0,0 +1,45 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestHasSkipMarker(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		expected bool
+	}{
+		{"no marker", git.Commit{Message: "feat: add thing"}, false},
+		{"changelog marker in subject", git.Commit{Message: "docs: fix typo [skip changelog]"}, true},
+		{"release marker in body", git.Commit{Message: "ci: tweak workflow", Body: "Internal only.\n\n[skip release]"}, true},
+		{"case insensitive", git.Commit{Message: "chore: cleanup [Skip Changelog]"}, true},
+		{"unrelated ci marker", git.Commit{Message: "chore: cleanup [skip ci]"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasSkipMarker(tt.commit); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilterSkipped(t *testing.T) {
+	commits := []git.Commit{
+		{Message: "feat: add thing"},
+		{Message: "docs: fix typo [skip changelog]"},
+		{Message: "ci: tweak [skip release]"},
+	}
+
+	kept, skipped := filterSkipped(commits)
+	if len(kept) != 1 || kept[0].Message != "feat: add thing" {
+		t.Errorf("Expected only the feat commit to be kept, got %v", kept)
+	}
+	if skipped != 2 {
+		t.Errorf("Expected 2 skipped commits, got %d", skipped)
+	}
+}
@@ -0,0 +1,54 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+// renderStats summarizes the size of a release in one italic line, e.g.
+// "_12 commits • 34 files changed • +1024/−210 lines • 3 contributors_"
+func renderStats(commits []git.Commit, stat git.DiffStat) string {
+	contributors := make(map[string]bool)
+	for _, commit := range commits {
+		id := strings.ToLower(commit.AuthorEmail)
+		if id == "" {
+			id = commit.AuthorName
+		}
+		if id != "" {
+			contributors[id] = true
+		}
+	}
+
+	parts := []string{
+		plural(len(commits), "commit"),
+		plural(stat.Files, "file") + " changed",
+		fmt.Sprintf("+%d/−%d lines", stat.Insertions, stat.Deletions),
+	}
+	if len(contributors) > 0 {
+		parts = append(parts, plural(len(contributors), "contributor"))
+	}
+	return "_" + strings.Join(parts, " • ") + "_"
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// statsLine returns the stats line for the commits of a changelog, or "" when stats
+// are disabled or the range has no start to diff from, as for a first release
+func (c *Manager) statsLine(fromVersion string, commits []git.Commit) string {
+	since := c.sinceRef(fromVersion)
+	if !c.settings.Changelog.Stats || since == "" {
+		return ""
+	}
+	stat, err := c.gitManager.DiffShortStat(since, c.untilRef())
+	if err != nil {
+		return ""
+	}
+	return renderStats(commits, stat)
+}
@@ -0,0 +1,21 @@
+package changelog
+
+import (
+	"testing"
+
+	"bump-tui/internal/git"
+)
+
+func TestRenderStats(t *testing.T) {
+	commits := []git.Commit{
+		{AuthorName: "Jane Doe", AuthorEmail: "jane@example.com"},
+		{AuthorName: "Jane Doe", AuthorEmail: "Jane@Example.com"},
+		{AuthorName: "Sam", AuthorEmail: "sam@example.com"},
+	}
+
+	got := renderStats(commits, git.DiffStat{Files: 1, Insertions: 120, Deletions: 8})
+	expected := "_3 commits • 1 file changed • +120/−8 lines • 2 contributors_"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
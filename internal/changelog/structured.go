@@ -0,0 +1,95 @@
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+)
+
+// changeEntriesSchema is the JSON schema of AI responses in JSON mode, passed to the
+// Claude CLI for structured output
+const changeEntriesSchema = `{"type":"object","properties":{"entries":{"type":"array","minItems":1,"items":{"type":"object","properties":{"category":{"type":"string","minLength":1},"scope":{"type":"string"},"text":{"type":"string","minLength":1}},"required":["category","text"],"additionalProperties":false}}},"required":["entries"],"additionalProperties":false}`
+
+// structuredOutputFormat closes prompts in JSON mode
+const structuredOutputFormat = `Output format: only a JSON object, without markdown fences or any text around it:
+{"entries": [{"category": "Features", "scope": "api", "text": "New feature description"}]}
+Each entry is a single change. scope is optional; text is one plain sentence without a leading bullet.
+`
+
+// structuredOutput reports whether the AI is asked for JSON entries instead of markdown
+func (c *Manager) structuredOutput() bool {
+	return c.settings.AI.Output != config.AIOutputMarkdown
+}
+
+// parseGenerated turns an AI response into entries, failing when it doesn't hold any
+func (c *Manager) parseGenerated(output string) ([]ChangeEntry, error) {
+	if c.structuredOutput() {
+		return parseStructuredChanges(output)
+	}
+	entries := parseChanges(output)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("the response has no changelog bullets")
+	}
+	return entries, nil
+}
+
+// parseStructuredChanges validates a JSON response against the entry schema. Anything
+// else, such as prose, unknown fields or multi-line text, is rejected.
+func parseStructuredChanges(output string) ([]ChangeEntry, error) {
+	var response struct {
+		Entries []struct {
+			Category string `json:"category"`
+			Scope    string `json:"scope"`
+			Text     string `json:"text"`
+		} `json:"entries"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(stripCodeFence(output))))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, fmt.Errorf("the response is not valid changelog JSON: %v", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("the response has text after the changelog JSON")
+	}
+	if len(response.Entries) == 0 {
+		return nil, fmt.Errorf("the response has no changelog entries")
+	}
+
+	entries := make([]ChangeEntry, 0, len(response.Entries))
+	for i, raw := range response.Entries {
+		entry := ChangeEntry{
+			Category: strings.TrimSpace(strings.TrimLeft(raw.Category, "# ")),
+			Scope:    strings.TrimSpace(raw.Scope),
+			Text:     strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(raw.Text), "-* ")),
+		}
+		switch {
+		case entry.Category == "":
+			return nil, fmt.Errorf("entries[%d] has no category", i)
+		case entry.Text == "":
+			return nil, fmt.Errorf("entries[%d] has no text", i)
+		case strings.ContainsAny(entry.Text+entry.Category+entry.Scope, "\n\r"):
+			return nil, fmt.Errorf("entries[%d] spans several lines", i)
+		}
+		entry.Refs = pullRequestRefRe.FindAllString(entry.Text, -1)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// stripCodeFence removes a markdown code fence around a response, as CLIs without
+// structured output support sometimes add
+func stripCodeFence(output string) string {
+	output = strings.TrimSpace(output)
+	if !strings.HasPrefix(output, "```") {
+		return output
+	}
+	output = strings.TrimPrefix(output, "```")
+	if newline := strings.Index(output, "\n"); newline >= 0 {
+		output = output[newline+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(output), "```"))
+}
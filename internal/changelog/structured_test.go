@@ -0,0 +1,53 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStructuredChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  []ChangeEntry
+		expectErr bool
+	}{
+		{
+			name:   "entries",
+			output: `{"entries": [{"category": "Features", "scope": "api", "text": "Add pagination (#42)"}, {"category": "## Bug Fixes", "text": "- Fix a crash"}]}`,
+			expected: []ChangeEntry{
+				{Category: "Features", Scope: "api", Text: "Add pagination (#42)", Refs: []string{"#42"}},
+				{Category: "Bug Fixes", Text: "Fix a crash"},
+			},
+		},
+		{
+			name:     "code fence",
+			output:   "```json\n{\"entries\": [{\"category\": \"Other\", \"text\": \"Tidy docs\"}]}\n```",
+			expected: []ChangeEntry{{Category: "Other", Text: "Tidy docs"}},
+		},
+		{name: "prose", output: "Here is your changelog:\n## Features\n- Add pagination", expectErr: true},
+		{name: "trailing prose", output: `{"entries": [{"category": "Other", "text": "Tidy docs"}]} Hope this helps!`, expectErr: true},
+		{name: "unknown field", output: `{"entries": [{"category": "Other", "text": "Tidy docs", "emoji": "📚"}]}`, expectErr: true},
+		{name: "no entries", output: `{"entries": []}`, expectErr: true},
+		{name: "missing category", output: `{"entries": [{"text": "Tidy docs"}]}`, expectErr: true},
+		{name: "multi-line text", output: `{"entries": [{"category": "Other", "text": "Tidy\n## Docs"}]}`, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStructuredChanges(tt.output)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Style describes how release entries are formatted in a changelog file
+type Style struct {
+	// Number of '#' characters in a release heading
+	HeadingLevel int
+	// Version is wrapped in brackets, e.g. "## [1.2.3]"
+	Bracketed bool
+	// Version carries a "v" prefix, e.g. "# v1.2.3"
+	VPrefix bool
+	// Go time layout used for release dates, empty when entries have no date
+	DateFormat string
+	// Separator placed between the version and the date, e.g. " - "; empty means "(date)"
+	DateSeparator string
+	// Number of '#' characters in a section heading within a release entry
+	SectionLevel int
+	// Maps the tool's canonical section names to the names used in the file
+	SectionNames map[string]string
+}
+
+// DefaultStyle returns the format used when no existing changelog is found
+func DefaultStyle() Style {
+	return Style{
+		HeadingLevel: 1,
+		DateFormat:   "2006-01-02",
+		SectionLevel: 2,
+		SectionNames: map[string]string{},
+	}
+}
+
+var (
+	versionHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(\[)?(v)?(\d+\.\d+\.\d+[0-9A-Za-z.+-]*)(\])?(.*)$`)
+	anyHeadingRe     = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*$`)
+)
+
+// dateLayouts lists the date formats recognized in existing release headings
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"02.01.2006",
+	"01/02/2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"02 Jan 2006",
+}
+
+// sectionSynonyms maps canonical section names to alternatives commonly used by other tools
+var sectionSynonyms = map[string][]string{
+	"Features":     {"Added", "New Features", "Feature", "Features"},
+	"Bug Fixes":    {"Fixed", "Fixes", "Bugfixes", "Bug Fixes"},
+	"Improvements": {"Changed", "Enhancements", "Improvements"},
+	"Other":        {"Misc", "Miscellaneous", "Chores", "Other"},
+}
+
+// DetectStyle inspects existing changelog content and returns the style it uses.
+// Content without any recognizable release heading yields DefaultStyle.
+func DetectStyle(content string) Style {
+	style := DefaultStyle()
+
+	lines := strings.Split(content, "\n")
+	found := false
+	seenSections := make(map[string]bool)
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+
+		if matches := versionHeadingRe.FindStringSubmatch(line); matches != nil {
+			if !found {
+				found = true
+				style.HeadingLevel = len(matches[1])
+				style.Bracketed = matches[2] != "" && matches[5] != ""
+				style.VPrefix = matches[3] != ""
+				style.DateFormat, style.DateSeparator = detectDate(matches[6])
+				style.SectionLevel = style.HeadingLevel + 1
+				continue
+			}
+			if len(matches[1]) == style.HeadingLevel {
+				continue
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		if matches := anyHeadingRe.FindStringSubmatch(line); matches != nil {
+			level := len(matches[1])
+			if level <= style.HeadingLevel {
+				continue
+			}
+			if !seenSections[matches[2]] {
+				// The first section heading seen decides the section level
+				if len(seenSections) == 0 {
+					style.SectionLevel = level
+				}
+				seenSections[matches[2]] = true
+			}
+		}
+	}
+
+	if !found {
+		return DefaultStyle()
+	}
+
+	for canonical, synonyms := range sectionSynonyms {
+		for _, synonym := range synonyms {
+			if seenSections[synonym] {
+				style.SectionNames[canonical] = synonym
+				break
+			}
+		}
+	}
+
+	return style
+}
+
+// detectDate finds the date layout and separator used in the remainder of a release heading
+func detectDate(rest string) (string, string) {
+	separator := ""
+	value := strings.TrimSpace(rest)
+	for _, sep := range []string{" - ", " – ", " — "} {
+		if strings.HasPrefix(rest, sep) {
+			separator = sep
+			value = strings.TrimSpace(rest[len(sep):])
+			break
+		}
+	}
+	if separator == "" {
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "("), ")")
+	}
+
+	for _, layout := range dateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return layout, separator
+		}
+	}
+
+	return "", ""
+}
+
+// FormatHeading renders the release heading for a version
+func (s Style) FormatHeading(version string, date time.Time) string {
+	label := version
+	if s.VPrefix {
+		label = "v" + label
+	}
+	if s.Bracketed {
+		label = "[" + label + "]"
+	}
+
+	heading := fmt.Sprintf("%s %s", strings.Repeat("#", s.HeadingLevel), label)
+	if s.DateFormat == "" {
+		return heading
+	}
+
+	formatted := date.Format(s.DateFormat)
+	if s.DateSeparator != "" {
+		return heading + s.DateSeparator + formatted
+	}
+	return fmt.Sprintf("%s (%s)", heading, formatted)
+}
+
+// ApplySections rewrites section headings in generated changes to match the style
+func (s Style) ApplySections(changes string) string {
+	lines := strings.Split(changes, "\n")
+	for i, line := range lines {
+		matches := anyHeadingRe.FindStringSubmatch(strings.TrimRight(line, " \t\r"))
+		if matches == nil {
+			continue
+		}
+
+		name := matches[2]
+		if mapped, ok := s.SectionNames[name]; ok {
+			name = mapped
+		}
+		lines[i] = fmt.Sprintf("%s %s", strings.Repeat("#", s.SectionLevel), name)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var unreleasedHeadingRe = regexp.MustCompile(`(?i)^(#{1,6})\s+\[?unreleased\]?`)
+
+// findUnreleasedEnd returns the byte offset just past the "## [Unreleased]" section,
+// or -1 when content has no such section
+func findUnreleasedEnd(content string) int {
+	offset := 0
+	level := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if level == 0 {
+			if matches := unreleasedHeadingRe.FindStringSubmatch(line); matches != nil {
+				level = len(matches[1])
+			}
+		} else if matches := anyHeadingRe.FindStringSubmatch(strings.TrimRight(line, " \t\r\n")); matches != nil && len(matches[1]) <= level {
+			return offset
+		}
+		offset += len(line)
+	}
+	if level == 0 {
+		return -1
+	}
+	return len(content)
+}
+
+// findFirstEntry returns the byte offset of the first release heading in content, or -1
+func findFirstEntry(content string) int {
+	offset := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if versionHeadingRe.MatchString(strings.TrimRight(line, " \t\r\n")) {
+			return offset
+		}
+		offset += len(line)
+	}
+	return -1
+}
+
+// findEntry returns the byte range of the release entry for version, or -1, -1 if absent.
+// The entry runs until the next release heading of the same or a higher level.
+func findEntry(content, version string) (int, int) {
+	version = strings.TrimPrefix(version, "v")
+	start, level := -1, 0
+	offset := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		matches := versionHeadingRe.FindStringSubmatch(strings.TrimRight(line, " \t\r\n"))
+		if matches != nil {
+			if start >= 0 && len(matches[1]) <= level {
+				return start, offset
+			}
+			if start < 0 && matches[4] == version {
+				start, level = offset, len(matches[1])
+			}
+		}
+		offset += len(line)
+	}
+	if start >= 0 {
+		return start, len(content)
+	}
+	return -1, -1
+}
@@ -0,0 +1,310 @@
+package changelog
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectStyle(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		expectedHeading string
+		expectedSection string
+		expectedLevel   int
+	}{
+		{
+			name:            "empty changelog uses default style",
+			content:         "",
+			expectedHeading: "# 1.2.3 (2025-07-24)",
+			expectedSection: "## Features",
+			expectedLevel:   2,
+		},
+		{
+			name: "bump default format",
+			content: `# Changelog
+
+# 0.1.5 (2025-07-24)
+
+## Bug Fixes
+- Fixed something
+`,
+			expectedHeading: "# 1.2.3 (2025-07-24)",
+			expectedSection: "## Features",
+			expectedLevel:   2,
+		},
+		{
+			name: "keep a changelog format",
+			content: `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+
+## [1.0.0] - 2024-01-15
+
+### Added
+- New feature
+
+### Fixed
+- A bug
+`,
+			expectedHeading: "## [1.2.3] - 2025-07-24",
+			expectedSection: "### Added",
+			expectedLevel:   3,
+		},
+		{
+			name: "v prefix without date",
+			content: `## v0.9.0
+
+- Initial release
+`,
+			expectedHeading: "## v1.2.3",
+			expectedSection: "### Features",
+			expectedLevel:   3,
+		},
+		{
+			name: "long date format",
+			content: `# 0.9.0 (January 5, 2025)
+
+## Features
+- Something
+`,
+			expectedHeading: "# 1.2.3 (July 24, 2025)",
+			expectedSection: "## Features",
+			expectedLevel:   2,
+		},
+	}
+
+	date := time.Date(2025, time.July, 24, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := DetectStyle(tt.content)
+
+			if heading := style.FormatHeading("1.2.3", date); heading != tt.expectedHeading {
+				t.Errorf("Expected heading %q, got %q", tt.expectedHeading, heading)
+			}
+
+			if section := style.ApplySections("## Features"); section != tt.expectedSection {
+				t.Errorf("Expected section %q, got %q", tt.expectedSection, section)
+			}
+
+			if style.SectionLevel != tt.expectedLevel {
+				t.Errorf("Expected section level %d, got %d", tt.expectedLevel, style.SectionLevel)
+			}
+		})
+	}
+}
+
+func TestFindFirstEntry(t *testing.T) {
+	content := "# Changelog\n\nIntro text\n\n## [1.0.0] - 2024-01-15\n"
+	if pos := findFirstEntry(content); pos != len("# Changelog\n\nIntro text\n\n") {
+		t.Errorf("Expected first entry at %d, got %d", len("# Changelog\n\nIntro text\n\n"), pos)
+	}
+
+	if pos := findFirstEntry("# Changelog\n"); pos != -1 {
+		t.Errorf("Expected -1 for changelog without entries, got %d", pos)
+	}
+}
+
+func TestFindUnreleasedEnd(t *testing.T) {
+	section := "# Change Log\n\n## [Unreleased]\n\n- Initial release\n"
+	if pos := findUnreleasedEnd(section); pos != len(section) {
+		t.Errorf("Expected the section to end at %d, got %d", len(section), pos)
+	}
+
+	if pos := findUnreleasedEnd(section + "## Notes\n"); pos != len(section) {
+		t.Errorf("Expected the section to end at the next heading %d, got %d", len(section), pos)
+	}
+
+	if pos := findUnreleasedEnd("# Changelog\n"); pos != -1 {
+		t.Errorf("Expected -1 for changelog without an Unreleased section, got %d", pos)
+	}
+}
+
+func TestSameEntry(t *testing.T) {
+	changes := "### Features\n- Export reports"
+	tests := []struct {
+		name     string
+		entry    string
+		expected bool
+	}{
+		{"identical", "## [1.2.0] - 2024-01-01\n\n### Features\n- Export reports\n\n", true},
+		{"other date", "## [1.2.0] - 2025-06-30\n\n### Features\n- Export reports\n", true},
+		{"other changes", "## [1.2.0] - 2024-01-01\n\n### Features\n- Import reports\n\n", false},
+		{"heading only", "## [1.2.0] - 2024-01-01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameEntry(tt.entry, changes); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEntryHash(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	c := NewManager()
+	if _, err := c.EntryHash("1.2.0"); err == nil {
+		t.Errorf("Expected an error without a changelog")
+	}
+
+	content := "# Changelog\n\n## [1.2.0] - 2024-01-01\n\n### Features\n- Export reports\n\n## [1.1.0] - 2023-12-01\n\n- Import reports\n"
+	if err := os.WriteFile("CHANGELOG.md", []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	expected := "sha256:" + fmt.Sprintf("%x", sha256.Sum256([]byte("### Features\n- Export reports")))
+	if hash, err := c.EntryHash("1.2.0"); err != nil || hash != expected {
+		t.Errorf("Expected %s, got %s (%v)", expected, hash, err)
+	}
+
+	// Only the body counts, not the heading's date
+	redated := strings.Replace(content, "2024-01-01", "2024-02-02", 1)
+	if err := os.WriteFile("CHANGELOG.md", []byte(redated), 0644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	if hash, _ := c.EntryHash("1.2.0"); hash != expected {
+		t.Errorf("Expected the hash to ignore the heading, got %s", hash)
+	}
+
+	if _, err := c.EntryHash("2.0.0"); err == nil {
+		t.Errorf("Expected an error for a missing entry")
+	}
+}
+
+func TestFindEntry(t *testing.T) {
+	content := "# Changelog\n\n## [Unreleased]\n\n- Pending\n\n" +
+		"## [v1.2.0] - 2024-02-01\n\n### Features\n- Export reports\n\n### 1.1.1\n\n- Backported fix\n\n" +
+		"## 1.2.0-rc.1 - 2024-01-20\n\n- Preview\n\n" +
+		"# 1.1.0\n\n- Import reports\n"
+	entry := func(start, end int) string {
+		if start < 0 {
+			return ""
+		}
+		return content[start:end]
+	}
+
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{"bracketed with v prefix, keeping lower-level headings", "1.2.0", "## [v1.2.0] - 2024-02-01\n\n### Features\n- Export reports\n\n### 1.1.1\n\n- Backported fix\n\n"},
+		{"version given with v", "v1.2.0", "## [v1.2.0] - 2024-02-01\n\n### Features\n- Export reports\n\n### 1.1.1\n\n- Backported fix\n\n"},
+		{"prerelease isn't the release", "1.2.0-rc.1", "## 1.2.0-rc.1 - 2024-01-20\n\n- Preview\n\n"},
+		{"last entry runs to the end", "1.1.0", "# 1.1.0\n\n- Import reports\n"},
+		{"nested heading", "1.1.1", "### 1.1.1\n\n- Backported fix\n\n"},
+		{"missing", "2.0.0", ""},
+		{"unreleased isn't an entry", "Unreleased", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entry(findEntry(content, tt.version)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteEntryDuplicates(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	content := "# Changelog\n\nAll notable changes.\n\n## [1.2.0] - 2024-02-01\n\n- Export reports\n\n## [1.1.0] - 2024-01-01\n\n- Import reports\n"
+	if err := os.WriteFile("CHANGELOG.md", []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write changelog: %v", err)
+	}
+	read := func() string {
+		data, err := os.ReadFile("CHANGELOG.md")
+		if err != nil {
+			t.Fatalf("Failed to read changelog: %v", err)
+		}
+		return string(data)
+	}
+
+	c := NewManager()
+	if !c.HasEntry("1.2.0") || c.HasEntry("1.3.0") {
+		t.Fatalf("Expected only 1.2.0 to have an entry")
+	}
+
+	// Writing the same changes again, e.g. after an aborted run, changes nothing
+	if err := c.UpdateChangelog("1.2.0", "- Export reports"); err != nil || read() != content {
+		t.Fatalf("Expected an identical entry to be left alone, got %v:\n%s", err, read())
+	}
+
+	// Other changes for the same version are refused
+	if err := c.UpdateChangelog("1.2.0", "- Export reports as CSV"); !errors.Is(err, ErrEntryExists) {
+		t.Fatalf("Expected ErrEntryExists, got %v", err)
+	}
+	if read() != content {
+		t.Fatalf("Expected the refused write to leave the changelog alone, got:\n%s", read())
+	}
+
+	// unless the entry is replaced in place, keeping the intro and the other entries
+	if err := c.ReplaceChangelogEntry("1.2.0", "- Export reports as CSV"); err != nil {
+		t.Fatalf("ReplaceChangelogEntry failed: %v", err)
+	}
+	replaced := read()
+	if strings.Count(replaced, "[1.2.0]") != 1 || !strings.Contains(replaced, "- Export reports as CSV") || strings.Contains(replaced, "- Export reports\n") {
+		t.Errorf("Expected one replaced 1.2.0 entry, got:\n%s", replaced)
+	}
+	if !strings.HasPrefix(replaced, "# Changelog\n\nAll notable changes.\n\n## [1.2.0] - ") || !strings.HasSuffix(replaced, "## [1.1.0] - 2024-01-01\n\n- Import reports\n") {
+		t.Errorf("Expected the surrounding content to be kept, got:\n%s", replaced)
+	}
+	if !c.EntryMatches("1.2.0", "- Export reports as CSV") {
+		t.Errorf("Expected the replaced entry to match its changes")
+	}
+
+	// Replacing the last entry again leaves the file as it was
+	if err := c.ReplaceChangelogEntry("1.1.0", "- Import reports as JSON"); err != nil {
+		t.Fatalf("ReplaceChangelogEntry failed: %v", err)
+	}
+	once := read()
+	if err := c.ReplaceChangelogEntry("1.1.0", "- Import reports as CSV"); err != nil {
+		t.Fatalf("ReplaceChangelogEntry failed: %v", err)
+	}
+	if err := c.ReplaceChangelogEntry("1.1.0", "- Import reports as JSON"); err != nil {
+		t.Fatalf("ReplaceChangelogEntry failed: %v", err)
+	}
+	if twice := read(); twice != once || !strings.HasSuffix(twice, "- Import reports as JSON\n\n") {
+		t.Errorf("Expected replacing the last entry not to grow the file, got %q", twice)
+	}
+
+	// A new version goes above the most recent release
+	if err := c.UpdateChangelog("1.3.0", "- Print reports"); err != nil {
+		t.Fatalf("UpdateChangelog failed: %v", err)
+	}
+	if updated := read(); strings.Index(updated, "1.3.0") > strings.Index(updated, "[1.2.0]") || !strings.HasPrefix(updated, "# Changelog\n\nAll notable changes.\n\n") {
+		t.Errorf("Expected 1.3.0 above 1.2.0 below the intro, got:\n%s", updated)
+	}
+}
@@ -0,0 +1,160 @@
+package changelog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// charsPerToken is a rough average used to estimate prompt size before invoking the AI
+	charsPerToken = 4
+	// expectedOutputRatio estimates output tokens as a fraction of the prompt size
+	expectedOutputRatio = 0.5
+)
+
+// Usage describes the tokens consumed and cost of one AI generation
+type Usage struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	// Estimated is true when the numbers are a pre-invocation estimate
+	Estimated bool `json:"estimated"`
+}
+
+// String renders the usage for display in the TUI and logs
+func (u Usage) String() string {
+	prefix := ""
+	if u.Estimated {
+		prefix = "~"
+	}
+	return fmt.Sprintf("%s%d in / %s%d out tokens · %s$%.4f",
+		prefix, u.InputTokens, prefix, u.OutputTokens, prefix, u.CostUSD)
+}
+
+// errClaudeReported marks an error Claude reported in its JSON output, as opposed to
+// output that isn't JSON at all
+var errClaudeReported = errors.New("claude reported an error")
+
+// UsageLedger tracks AI spend per calendar month across runs
+type UsageLedger struct {
+	// Months maps "2006-01" to the total spend in USD
+	Months map[string]float64 `json:"months"`
+}
+
+// usageLedgerPath returns the per-user location of the usage ledger
+func usageLedgerPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate user config directory: %v", err)
+	}
+	return filepath.Join(dir, "bump-tui", "usage.json"), nil
+}
+
+// LoadUsageLedger reads the usage ledger, returning an empty ledger if none exists yet
+func LoadUsageLedger() (*UsageLedger, error) {
+	ledger := &UsageLedger{Months: map[string]float64{}}
+
+	path, err := usageLedgerPath()
+	if err != nil {
+		return ledger, err
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return ledger, fmt.Errorf("failed to read usage ledger: %v", err)
+	}
+
+	if err := json.Unmarshal(content, ledger); err != nil {
+		return &UsageLedger{Months: map[string]float64{}}, fmt.Errorf("failed to parse usage ledger: %v", err)
+	}
+	if ledger.Months == nil {
+		ledger.Months = map[string]float64{}
+	}
+
+	return ledger, nil
+}
+
+// Record adds a cost to the month containing now and persists the ledger
+func (l *UsageLedger) Record(cost float64, now time.Time) error {
+	l.Months[now.Format("2006-01")] += cost
+
+	path, err := usageLedgerPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create usage ledger directory: %v", err)
+	}
+
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// MonthTotal returns the spend recorded for the month containing now
+func (l *UsageLedger) MonthTotal(now time.Time) float64 {
+	return l.Months[now.Format("2006-01")]
+}
+
+// estimateUsage approximates the tokens and cost of sending prompt to the AI
+func estimateUsage(prompt string, inputCostPerMTok, outputCostPerMTok float64) Usage {
+	input := len(prompt) / charsPerToken
+	output := int(float64(input) * expectedOutputRatio)
+	return Usage{
+		InputTokens:  input,
+		OutputTokens: output,
+		CostUSD:      (float64(input)*inputCostPerMTok + float64(output)*outputCostPerMTok) / 1_000_000,
+		Estimated:    true,
+	}
+}
+
+// claudeJSONResult is the subset of `claude -p --output-format json` output used for usage tracking
+type claudeJSONResult struct {
+	Result       string   `json:"result"`
+	IsError      bool     `json:"is_error"`
+	TotalCostUSD *float64 `json:"total_cost_usd"`
+	CostUSD      *float64 `json:"cost_usd"`
+	Usage        struct {
+		InputTokens              int `json:"input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+	} `json:"usage"`
+	// StructuredOutput holds the response validated against --json-schema
+	StructuredOutput json.RawMessage `json:"structured_output"`
+}
+
+// parseClaudeJSON extracts the generated text and usage from Claude's JSON output
+func parseClaudeJSON(output string) (string, Usage, error) {
+	var result claudeJSONResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return "", Usage{}, err
+	}
+	if result.IsError {
+		return "", Usage{}, fmt.Errorf("%w: %s", errClaudeReported, result.Result)
+	}
+
+	usage := Usage{
+		InputTokens:  result.Usage.InputTokens + result.Usage.CacheCreationInputTokens + result.Usage.CacheReadInputTokens,
+		OutputTokens: result.Usage.OutputTokens,
+	}
+	if result.TotalCostUSD != nil {
+		usage.CostUSD = *result.TotalCostUSD
+	} else if result.CostUSD != nil {
+		usage.CostUSD = *result.CostUSD
+	}
+
+	if len(result.StructuredOutput) > 0 && string(result.StructuredOutput) != "null" {
+		return string(result.StructuredOutput), usage, nil
+	}
+	return result.Result, usage, nil
+}
@@ -0,0 +1,190 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseClaudeJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		result   string
+		usage    Usage
+		reported bool
+		notJSON  bool
+	}{
+		{
+			name:   "result with usage",
+			output: `{"type":"result","result":"- Added export","total_cost_usd":0.02,"cost_usd":0.5,"usage":{"input_tokens":100,"cache_creation_input_tokens":20,"cache_read_input_tokens":30,"output_tokens":40}}`,
+			result: "- Added export",
+			usage:  Usage{InputTokens: 150, OutputTokens: 40, CostUSD: 0.02},
+		},
+		{
+			name:   "legacy cost field",
+			output: `{"result":"text","cost_usd":0.5}`,
+			result: "text",
+			usage:  Usage{CostUSD: 0.5},
+		},
+		{
+			name:   "structured output preferred",
+			output: `{"result":"Here is the changelog","structured_output":{"bullets":["Added export"]}}`,
+			result: `{"bullets":["Added export"]}`,
+		},
+		{
+			name:   "null structured output",
+			output: `{"result":"text","structured_output":null}`,
+			result: "text",
+		},
+		{
+			name:     "is_error",
+			output:   `{"type":"result","is_error":true,"result":"Credit balance is too low"}`,
+			reported: true,
+		},
+		{
+			name:    "plain text from older CLI versions",
+			output:  "- Added export",
+			notJSON: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, usage, err := parseClaudeJSON(tt.output)
+			switch {
+			case tt.reported:
+				if !errors.Is(err, errClaudeReported) || !strings.Contains(err.Error(), "Credit balance is too low") {
+					t.Errorf("Expected Claude's error, got %v", err)
+				}
+			case tt.notJSON:
+				if err == nil || errors.Is(err, errClaudeReported) {
+					t.Errorf("Expected a parse error, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if result != tt.result {
+					t.Errorf("Expected result %q, got %q", tt.result, result)
+				}
+				if usage != tt.usage {
+					t.Errorf("Expected usage %+v, got %+v", tt.usage, usage)
+				}
+			}
+		})
+	}
+}
+
+func TestCompleteWithClaude(t *testing.T) {
+	bin := t.TempDir()
+	script := "#!/bin/sh\n[ \"$1\" = \"--version\" ] && exit 0\ncat \"$FAKE_CLAUDE_OUTPUT\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name      string
+		output    string
+		expected  string
+		expectErr string
+	}{
+		{"envelope", `{"result":"- Added export\n","usage":{"input_tokens":10,"output_tokens":5}}`, "- Added export", ""},
+		{"plain text", "- Added export\n", "- Added export", ""},
+		{"reported error", `{"is_error":true,"result":"Credit balance is too low"}`, "", "Credit balance is too low"},
+		{"empty result", `{"result":""}`, "", "empty output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "output")
+			if err := os.WriteFile(path, []byte(tt.output), 0644); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("FAKE_CLAUDE_OUTPUT", path)
+
+			output, err := (&Manager{}).completeWithClaude(context.Background(), "prompt", "")
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("Expected an error containing %q, got %v (output %q)", tt.expectErr, err, output)
+				}
+				return
+			}
+			if err != nil || output != tt.expected {
+				t.Errorf("Expected %q, got %q (%v)", tt.expected, output, err)
+			}
+		})
+	}
+}
+
+func TestEstimateUsage(t *testing.T) {
+	usage := estimateUsage(strings.Repeat("a", 4000), 3.0, 15.0)
+	expected := Usage{InputTokens: 1000, OutputTokens: 500, CostUSD: 0.0105, Estimated: true}
+	if usage != expected {
+		t.Errorf("Expected %+v, got %+v", expected, usage)
+	}
+	if got := usage.String(); got != "~1000 in / ~500 out tokens · ~$0.0105" {
+		t.Errorf("Unexpected rendering %q", got)
+	}
+
+	if usage := estimateUsage("", 3.0, 15.0); usage.InputTokens != 0 || usage.CostUSD != 0 {
+		t.Errorf("Expected an empty prompt to cost nothing, got %+v", usage)
+	}
+}
+
+func TestUsageLedger(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	ledger, err := LoadUsageLedger()
+	if err != nil {
+		t.Fatalf("Expected a missing ledger to load empty, got %v", err)
+	}
+
+	january := time.Date(2026, time.January, 31, 23, 0, 0, 0, time.UTC)
+	february := time.Date(2026, time.February, 1, 1, 0, 0, 0, time.UTC)
+	for _, record := range []struct {
+		cost float64
+		now  time.Time
+	}{{0.25, january}, {0.5, january}, {1, february}} {
+		if err := ledger.Record(record.cost, record.now); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	reloaded, err := LoadUsageLedger()
+	if err != nil {
+		t.Fatalf("Failed to reload the ledger: %v", err)
+	}
+	if got := reloaded.MonthTotal(january); got != 0.75 {
+		t.Errorf("Expected 0.75 for January, got %v", got)
+	}
+	if got := reloaded.MonthTotal(february); got != 1 {
+		t.Errorf("Expected 1 for February, got %v", got)
+	}
+	if got := reloaded.MonthTotal(january.AddDate(0, 2, 0)); got != 0 {
+		t.Errorf("Expected nothing for March, got %v", got)
+	}
+
+	path, err := usageLedgerPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	corrupt, err := LoadUsageLedger()
+	if err == nil {
+		t.Error("Expected a corrupt ledger to report an error")
+	}
+	if corrupt == nil || len(corrupt.Months) != 0 {
+		t.Errorf("Expected an empty ledger to keep recording into, got %+v", corrupt)
+	}
+}
@@ -0,0 +1,34 @@
+package changelog
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// isVSCodeExtension reports whether the project root holds a VS Code extension
+// manifest, whose CHANGELOG.md vsce publishes to the Marketplace
+func isVSCodeExtension() bool {
+	content, err := os.ReadFile("package.json")
+	if err != nil {
+		return false
+	}
+	var manifest struct {
+		Engines struct {
+			VSCode string `json:"vscode"`
+		} `json:"engines"`
+	}
+	return json.Unmarshal(content, &manifest) == nil && manifest.Engines.VSCode != ""
+}
+
+// VSCodeStyle is the Keep a Changelog format of the VS Code extension generator's
+// CHANGELOG.md, e.g. "## [1.2.0] - 2024-01-02", used when its changelog has no release yet
+func VSCodeStyle() Style {
+	return Style{
+		HeadingLevel:  2,
+		Bracketed:     true,
+		DateFormat:    "2006-01-02",
+		DateSeparator: " - ",
+		SectionLevel:  3,
+		SectionNames:  map[string]string{},
+	}
+}
@@ -0,0 +1,73 @@
+// Package ci detects continuous integration environments and sets up bump-tui to run
+// unattended in them
+package ci
+
+import (
+	"os"
+	"strings"
+
+	"bump-tui/internal/config"
+)
+
+// Environment is the CI system bump-tui is running in
+type Environment struct {
+	// Name is e.g. "GitHub Actions", "" outside CI
+	Name string
+}
+
+// Detect reads the CI system from the environment variables returned by getenv
+func Detect(getenv func(string) string) Environment {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return Environment{Name: "GitHub Actions"}
+	case getenv("GITLAB_CI") == "true":
+		return Environment{Name: "GitLab CI"}
+	case isTrue(getenv("CI")):
+		return Environment{Name: "CI"}
+	}
+	return Environment{}
+}
+
+// Active reports whether bump-tui runs in CI
+func (e Environment) Active() bool {
+	return e.Name != ""
+}
+
+// Configure sets the bot identity for git and the GitHub token for gh, through
+// environment variables inherited by every git and gh command. Variables that are
+// already set are left alone.
+func (e Environment) Configure(settings config.CISettings) error {
+	if !e.Active() {
+		return nil
+	}
+	if settings.BotName != "" && os.Getenv("GIT_AUTHOR_NAME") == "" && os.Getenv("GIT_COMMITTER_NAME") == "" {
+		for name, value := range map[string]string{
+			"GIT_AUTHOR_NAME":     settings.BotName,
+			"GIT_AUTHOR_EMAIL":    settings.BotEmail,
+			"GIT_COMMITTER_NAME":  settings.BotName,
+			"GIT_COMMITTER_EMAIL": settings.BotEmail,
+		} {
+			if value == "" {
+				continue
+			}
+			if err := os.Setenv(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	if settings.TokenEnv != "" && os.Getenv("GH_TOKEN") == "" {
+		if token := os.Getenv(settings.TokenEnv); token != "" {
+			return os.Setenv("GH_TOKEN", token)
+		}
+	}
+	return nil
+}
+
+// isTrue matches the values CI systems use for CI, e.g. "true" or "1"
+func isTrue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "1", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package ci
+
+import (
+	"os"
+	"testing"
+
+	"bump-tui/internal/config"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"local", map[string]string{}, ""},
+		{"github actions", map[string]string{"CI": "true", "GITHUB_ACTIONS": "true"}, "GitHub Actions"},
+		{"gitlab", map[string]string{"CI": "true", "GITLAB_CI": "true"}, "GitLab CI"},
+		{"generic", map[string]string{"CI": "1"}, "CI"},
+		{"disabled", map[string]string{"CI": "false"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environment := Detect(func(name string) string { return tt.env[name] })
+			if environment.Name != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, environment.Name)
+			}
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL", "GH_TOKEN"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	environment := Environment{Name: "GitHub Actions"}
+	if err := environment.Configure(config.DefaultSettings().CI); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if name := os.Getenv("GIT_COMMITTER_NAME"); name != "github-actions[bot]" {
+		t.Errorf("Expected the bot to commit, got %q", name)
+	}
+	if token := os.Getenv("GH_TOKEN"); token != "secret" {
+		t.Errorf("Expected GH_TOKEN from GITHUB_TOKEN, got %q", token)
+	}
+
+	// Without a bot email the emails from the git config are kept
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL"} {
+		os.Unsetenv(name)
+	}
+	settings := config.DefaultSettings().CI
+	settings.BotEmail = ""
+	if err := environment.Configure(settings); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if _, set := os.LookupEnv("GIT_COMMITTER_EMAIL"); set {
+		t.Errorf("Expected GIT_COMMITTER_EMAIL to stay unset, got %q", os.Getenv("GIT_COMMITTER_EMAIL"))
+	}
+
+	// An identity set by the pipeline wins
+	t.Setenv("GIT_AUTHOR_NAME", "Release Bot")
+	t.Setenv("GIT_COMMITTER_NAME", "Release Bot")
+	if err := environment.Configure(config.DefaultSettings().CI); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if name := os.Getenv("GIT_COMMITTER_NAME"); name != "Release Bot" {
+		t.Errorf("Expected the pipeline's identity to be kept, got %q", name)
+	}
+}
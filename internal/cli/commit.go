@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+)
+
+// RunCommit composes a conventional commit from prompts for its type, scope,
+// subject, body and breaking change, and commits the staged changes with it. The
+// types and scopes offered, and the subject checks, come from [commit_lint].
+func (p *Prompter) RunCommit() error {
+	if err := p.gitManager.IsGitRepository(); err != nil {
+		return err
+	}
+	settings, err := config.LoadSettings(".")
+	if err != nil {
+		return err
+	}
+	p.settings = settings
+
+	staged, err := p.gitManager.HasStagedChanges()
+	if err != nil {
+		return err
+	}
+	if !staged {
+		return fmt.Errorf("nothing is staged; stage the changes to commit with git add first")
+	}
+
+	rules := p.settings.CommitLint
+	var draft changelog.CommitDraft
+	if draft.Type, err = p.choose("Type", rules.Types, false); err != nil {
+		return err
+	}
+	if draft.Scope, err = p.choose("Scope", rules.Scopes, !rules.RequireScope); err != nil {
+		return err
+	}
+	for {
+		if draft.Subject, err = p.ask("Subject: "); err != nil {
+			return err
+		}
+		if strings.TrimSpace(draft.Subject) == "" {
+			continue
+		}
+		problems := changelog.LintCommitMessage(draft.Message(), rules)
+		if len(problems) == 0 {
+			break
+		}
+		p.printf("  %s\n", strings.Join(problems, "; "))
+	}
+	if draft.Body, err = p.askLines("Body (end with an empty line):"); err != nil {
+		return err
+	}
+	if draft.Breaking, err = p.confirm("Breaking change?"); err != nil {
+		return err
+	}
+	if draft.Breaking {
+		if draft.BreakingNote, err = p.ask("Describe the breaking change (optional): "); err != nil {
+			return err
+		}
+	}
+
+	message := draft.Message()
+	p.printf("\n%s\n\n", message)
+	ok, err := p.confirm("Commit?")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		p.printf("Nothing committed\n")
+		return nil
+	}
+	if err := p.gitManager.Commit(message); err != nil {
+		return err
+	}
+	p.printf("✅ Committed %s\n", strings.SplitN(message, "\n", 2)[0])
+	return nil
+}
+
+// choose asks for one of choices, by number or by name. Without choices any answer
+// is taken; optional accepts an empty answer.
+func (p *Prompter) choose(label string, choices []string, optional bool) (string, error) {
+	prompt := label + ": "
+	if len(choices) > 0 {
+		p.printf("\n%s:\n", label)
+		for i, choice := range choices {
+			p.printf("  %d) %s\n", i+1, choice)
+		}
+		prompt = fmt.Sprintf("Choice [1-%d]: ", len(choices))
+	}
+	if optional {
+		prompt = strings.TrimSuffix(prompt, ": ") + " (empty for none): "
+	}
+
+	for {
+		answer, err := p.ask(prompt)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			if optional {
+				return "", nil
+			}
+			continue
+		}
+		if len(choices) == 0 {
+			return answer, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1], nil
+		}
+		for _, choice := range choices {
+			if strings.EqualFold(choice, answer) {
+				return choice, nil
+			}
+		}
+		p.printf("Please enter a number from 1 to %d or one of the names\n", len(choices))
+	}
+}
+
+// askLines reads lines until an empty one, for multi-paragraph input
+func (p *Prompter) askLines(prompt string) (string, error) {
+	p.printf("%s\n", prompt)
+	var lines []string
+	for {
+		line, err := p.ask("> ")
+		if err == ErrNoInput && len(lines) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
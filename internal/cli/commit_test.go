@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunCommit(t *testing.T) {
+	repo := newRustProject(t)
+	repo.WriteFile("src/export.rs", "pub fn export(path: &str) {}\n")
+	repo.Git("add", ".")
+
+	// Type "feat" by number, a free-form scope, a subject rejected for its length,
+	// a body, and a breaking change with its note
+	input := strings.Join([]string{
+		"1", "export",
+		strings.Repeat("x", 80), "take the output path",
+		"Callers pass where to write the file.", "",
+		"y", "export() takes a path",
+		"y",
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{}, strings.NewReader(input), &out)
+	if err := prompter.RunCommit(); err != nil {
+		t.Fatalf("RunCommit failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "subject is longer than") {
+		t.Errorf("Expected the long subject to be rejected, got:\n%s", out.String())
+	}
+
+	expected := "feat(export)!: take the output path\n\nCallers pass where to write the file.\n\nBREAKING CHANGE: export() takes a path"
+	if message := repo.Git("log", "-1", "--format=%B"); message != expected {
+		t.Errorf("Expected the composed message, got %q", message)
+	}
+}
+
+func TestRunCommitNothingStaged(t *testing.T) {
+	newRustProject(t)
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{}, strings.NewReader(""), &out)
+	if err := prompter.RunCommit(); err == nil || !strings.Contains(err.Error(), "nothing is staged") {
+		t.Errorf("Expected nothing-staged error, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+// Hook managers `bump-tui hooks install -print` writes configuration for
+const (
+	HookManagerLefthook  = "lefthook"
+	HookManagerPreCommit = "pre-commit"
+)
+
+// hookMarker identifies hooks written by bump-tui, so only those are replaced or removed
+const hookMarker = "# Installed by bump-tui"
+
+// HookOptions select the git hooks `bump-tui hooks install` writes
+type HookOptions struct {
+	// PrePush validates the repository with `bump-tui validate --quick` before pushing
+	PrePush bool
+	// CommitMsg rejects commit messages that break the commit_lint rules
+	CommitMsg bool
+	// Force replaces hooks that weren't written by bump-tui
+	Force bool
+}
+
+// hookScripts returns the scripts of the selected hooks by hook name
+func (o HookOptions) hookScripts() map[string]string {
+	scripts := make(map[string]string)
+	if o.PrePush {
+		scripts["pre-push"] = hookScript("bump-tui validate --quick")
+	}
+	if o.CommitMsg {
+		scripts["commit-msg"] = hookScript(`bump-tui hooks commit-msg "$1"`)
+	}
+	return scripts
+}
+
+// hookScript is a hook running command; remove it with `bump-tui hooks uninstall`
+func hookScript(command string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s; remove with `bump-tui hooks uninstall`\nexec %s\n", hookMarker, command)
+}
+
+// InstallHooks writes the selected hooks to the repository's hooks directory. A hook
+// bump-tui didn't write is left alone, and reported, unless opts.Force is set.
+func InstallHooks(opts HookOptions, out io.Writer) error {
+	scripts := opts.hookScripts()
+	if len(scripts) == 0 {
+		return fmt.Errorf("no hooks selected")
+	}
+	dir, err := git.NewManager().HooksDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"pre-push", "commit-msg"} {
+		script, ok := scripts[name]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && !bytes.Contains(existing, []byte(hookMarker)) && !opts.Force {
+			return fmt.Errorf("%s already exists and wasn't installed by bump-tui; use -force to replace it", path)
+		}
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			return fmt.Errorf("unable to write %s: %v", path, err)
+		}
+		fmt.Fprintf(out, "Installed %s\n", path)
+	}
+	return nil
+}
+
+// UninstallHooks removes the hooks written by bump-tui and keeps any others
+func UninstallHooks(out io.Writer) error {
+	dir, err := git.NewManager().HooksDir()
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, name := range []string{"pre-push", "commit-msg"} {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(content, []byte(hookMarker)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("unable to remove %s: %v", path, err)
+		}
+		fmt.Fprintf(out, "Removed %s\n", path)
+		removed++
+	}
+	if removed == 0 {
+		fmt.Fprintln(out, "No bump-tui hooks are installed")
+	}
+	return nil
+}
+
+// HookConfig returns the configuration running the selected hooks through a hook
+// manager, to add to lefthook.yml or .pre-commit-config.yaml
+func HookConfig(manager string, opts HookOptions) (string, error) {
+	var b strings.Builder
+	switch manager {
+	case HookManagerLefthook:
+		if opts.PrePush {
+			b.WriteString("pre-push:\n  commands:\n    bump-validate:\n      run: bump-tui validate --quick\n")
+		}
+		if opts.CommitMsg {
+			b.WriteString("commit-msg:\n  commands:\n    bump-commit-msg:\n      run: bump-tui hooks commit-msg {1}\n")
+		}
+	case HookManagerPreCommit:
+		b.WriteString("repos:\n  - repo: local\n    hooks:\n")
+		if opts.PrePush {
+			b.WriteString("      - id: bump-validate\n        name: bump-tui validate\n        entry: bump-tui validate --quick\n" +
+				"        language: system\n        stages: [pre-push]\n        pass_filenames: false\n        always_run: true\n")
+		}
+		if opts.CommitMsg {
+			b.WriteString("      - id: bump-commit-msg\n        name: conventional commit message\n        entry: bump-tui hooks commit-msg\n" +
+				"        language: system\n        stages: [commit-msg]\n")
+		}
+	default:
+		return "", fmt.Errorf("unknown hook manager %q (use %s or %s)", manager, HookManagerLefthook, HookManagerPreCommit)
+	}
+	return b.String(), nil
+}
+
+// CheckCommitMessage lints the commit message in path against the commit_lint rules
+// of .bump.toml, for the commit-msg hook
+func CheckCommitMessage(path string) error {
+	message, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read the commit message: %v", err)
+	}
+	settings, err := config.LoadSettings(".")
+	if err != nil {
+		return err
+	}
+
+	problems := changelog.LintCommitMessage(string(message), settings.CommitLint)
+	if len(problems) > 0 {
+		return fmt.Errorf("the commit message isn't a conventional commit: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
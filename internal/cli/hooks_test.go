@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallHooks(t *testing.T) {
+	repo := newRustProject(t)
+	hooks := filepath.Join(repo.Dir, ".git", "hooks")
+
+	var out bytes.Buffer
+	if err := InstallHooks(HookOptions{PrePush: true, CommitMsg: true}, &out); err != nil {
+		t.Fatalf("Expected the hooks to install, got %v", err)
+	}
+	script, err := os.ReadFile(filepath.Join(hooks, "pre-push"))
+	if err != nil || !strings.Contains(string(script), "bump-tui validate --quick") {
+		t.Errorf("Expected a pre-push hook running validate, got %q (%v)", script, err)
+	}
+	if _, err := os.Stat(filepath.Join(hooks, "commit-msg")); err != nil {
+		t.Errorf("Expected a commit-msg hook, got %v", err)
+	}
+
+	// A hook written by someone else is kept unless forced
+	if err := os.WriteFile(filepath.Join(hooks, "commit-msg"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := InstallHooks(HookOptions{CommitMsg: true}, &out); err == nil {
+		t.Errorf("Expected installing over a foreign hook to fail")
+	}
+
+	if err := UninstallHooks(&out); err != nil {
+		t.Fatalf("Expected the hooks to uninstall, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(hooks, "pre-push")); !os.IsNotExist(err) {
+		t.Errorf("Expected the pre-push hook to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(hooks, "commit-msg")); err != nil {
+		t.Errorf("Expected the foreign commit-msg hook to be kept, got %v", err)
+	}
+}
+
+func TestHookConfig(t *testing.T) {
+	opts := HookOptions{PrePush: true, CommitMsg: true}
+	for _, manager := range []string{HookManagerLefthook, HookManagerPreCommit} {
+		snippet, err := HookConfig(manager, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", manager, err)
+		}
+		if !strings.Contains(snippet, "bump-tui validate --quick") || !strings.Contains(snippet, "bump-tui hooks commit-msg") {
+			t.Errorf("%s: expected both hooks, got:\n%s", manager, snippet)
+		}
+	}
+	if _, err := HookConfig("husky", opts); err == nil {
+		t.Errorf("Expected an unknown hook manager to fail")
+	}
+}
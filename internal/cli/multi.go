@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+	"bump-tui/internal/release"
+)
+
+// multiRepo is one repository of a multi-repo release and its progress
+type multiRepo struct {
+	name     string
+	path     string
+	prompter *Prompter
+	plan     release.Plan
+	// head is HEAD before the release, used to roll back local changes
+	head string
+	// started and tagged track local changes that a rollback has to undo
+	started bool
+	tagged  bool
+	status  string
+}
+
+// RunMulti releases every repository listed in a manifest together. All repositories
+// are validated and planned before anything changes; the local commits and tags are
+// rolled back everywhere if any repository fails before pushing.
+func RunMulti(manifestPath string, opts Options, in io.Reader, out io.Writer) error {
+	manifest, err := config.LoadRepoManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	coordinator := NewPrompter(opts, in, out)
+	workDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Chdir(workDir); err != nil {
+			coordinator.printf("Warning: unable to return to %s: %v\n", workDir, err)
+		}
+	}()
+
+	bump := opts.Bump
+	if bump == "" {
+		answer, err := coordinator.ask("Bump type for every repository (major, minor, patch or auto): ")
+		if err != nil {
+			return err
+		}
+		bump = answer
+	}
+
+	// Validate and plan every repository before changing anything
+	var repos []*multiRepo
+	for _, entry := range manifest.Repos {
+		coordinator.printf("\n==> %s (%s)\n", entry.Name, entry.Path)
+		if err := os.Chdir(entry.Path); err != nil {
+			return fmt.Errorf("%s: %v", entry.Name, err)
+		}
+
+		repoOpts := opts
+		repoOpts.Bump = bump
+		repoOpts.Yes = true
+		repo := &multiRepo{
+			name:     entry.Name,
+			path:     entry.Path,
+			prompter: NewPrompter(repoOpts, strings.NewReader(""), out),
+			status:   "planned",
+		}
+		if err := repo.prepare(); err != nil {
+			return fmt.Errorf("%s: %v", entry.Name, err)
+		}
+		repos = append(repos, repo)
+	}
+
+	coordinator.printf("\nRelease plan:\n")
+	coordinator.printProgress(repos)
+	proceed, err := coordinator.confirm(fmt.Sprintf("Release all %d repositories?", len(repos)))
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		coordinator.printf("Aborted, nothing was changed\n")
+		return nil
+	}
+
+	// Commit and tag locally everywhere, then check every push would be accepted
+	for _, repo := range repos {
+		if err := os.Chdir(repo.path); err != nil {
+			return coordinator.rollback(repos, fmt.Errorf("%s: %v", repo.name, err))
+		}
+		repo.started = true
+		if _, err := repo.prompter.releaseManager.Execute(repo.plan); err != nil {
+			repo.status = "failed"
+			return coordinator.rollback(repos, fmt.Errorf("%s: %v", repo.name, err))
+		}
+		repo.tagged = true
+		repo.status = "tagged"
+		coordinator.printProgress(repos)
+	}
+
+	for _, repo := range repos {
+		if err := os.Chdir(repo.path); err != nil {
+			return coordinator.rollback(repos, fmt.Errorf("%s: %v", repo.name, err))
+		}
+		if err := repo.prompter.gitManager.PushDryRun(repo.plan.Version); err != nil {
+			repo.status = "push rejected"
+			return coordinator.rollback(repos, fmt.Errorf("%s: %v", repo.name, err))
+		}
+	}
+
+	// Publish. A push failing now can't undo the pushes before it, so report where each repository stands
+	for i, repo := range repos {
+		if err := os.Chdir(repo.path); err != nil {
+			return coordinator.partialPush(repos, i, err)
+		}
+		if _, err := repo.prompter.releaseManager.Publish(repo.plan); err != nil {
+			repo.status = "push failed"
+			return coordinator.partialPush(repos, i, err)
+		}
+		repo.status = "pushed"
+		coordinator.printProgress(repos)
+	}
+
+	for _, repo := range repos {
+		if err := os.Chdir(repo.path); err != nil {
+			continue
+		}
+		coordinator.printf("\n==> %s\n", repo.name)
+		repo.prompter.postRelease(repo.plan.PreviousVersion, repo.plan.Version)
+	}
+
+	coordinator.printf("\nReleased %d repositories\n", len(repos))
+	return nil
+}
+
+// prepare initializes and validates the repository and plans its release, including the changelog
+func (r *multiRepo) prepare() error {
+	p := r.prompter
+	if err := p.initProject(); err != nil {
+		return err
+	}
+	if p.settings.Release.Workflow == config.WorkflowPullRequest || p.settings.Release.TagOnly {
+		return fmt.Errorf("multi-repo releases only support the direct workflow with version files")
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	currentVersion := p.versionManager.CurrentVersion.String()
+	newVersion, err := p.selectVersion()
+	if err != nil {
+		return err
+	}
+	if newVersion == "" {
+		return fmt.Errorf("nothing to release")
+	}
+	changes, err := p.changelogManager.GenerateChanges(currentVersion)
+	if err != nil {
+		return err
+	}
+	if p.settings.Changelog.Lint {
+		changes, _ = changelog.Lint(changes)
+	}
+	if p.changelogManager.HasEntry(newVersion) && !p.changelogManager.EntryMatches(newVersion, changes) {
+		return fmt.Errorf("%s already contains an entry for %s", p.changelogManager.ChangelogPath(), newVersion)
+	}
+
+	head, err := p.gitManager.ResolveRef("HEAD")
+	if err != nil {
+		return err
+	}
+
+	r.head = head
+	r.plan = release.Plan{
+		PreviousVersion: currentVersion,
+		Version:         newVersion,
+		Changes:         changes,
+		LocalOnly:       true,
+		Generator:       p.changelogManager.LastGenerator(),
+	}
+	return nil
+}
+
+// rollback undoes the local release commits and tags in every repository that was
+// started, then returns cause
+func (p *Prompter) rollback(repos []*multiRepo, cause error) error {
+	p.printf("\nRelease failed, rolling back every repository: %v\n", cause)
+	for _, repo := range repos {
+		if !repo.started {
+			continue
+		}
+		if err := os.Chdir(repo.path); err != nil {
+			p.printf("Warning: unable to roll back %s: %v\n", repo.name, err)
+			continue
+		}
+		if repo.tagged {
+			if err := repo.prompter.gitManager.DeleteTag(repo.plan.Version); err != nil {
+				p.printf("Warning: %s: %v\n", repo.name, err)
+			}
+			// Package tags left behind would point at a commit no branch contains and
+			// make the next run fail to create them
+			for _, tag := range repo.prompter.releaseManager.PackageTags(repo.plan.Version) {
+				if err := repo.prompter.gitManager.DeleteNamedTag(tag); err != nil {
+					p.printf("Warning: %s: %v\n", repo.name, err)
+				}
+			}
+		}
+		// Only the release commit's note goes; earlier releases keep theirs
+		if head, err := repo.prompter.gitManager.ResolveRef("HEAD"); err == nil && head != repo.head && repo.prompter.settings.Git.Notes {
+			if err := repo.prompter.gitManager.RemoveReleaseNote(head); err != nil {
+				p.printf("Warning: %s: %v\n", repo.name, err)
+			}
+		}
+		if err := repo.prompter.gitManager.ResetHard(repo.head); err != nil {
+			p.printf("Warning: %s: %v\n", repo.name, err)
+			continue
+		}
+		repo.status = "rolled back"
+	}
+	p.printProgress(repos)
+	return fmt.Errorf("multi-repo release aborted; no repository was pushed: %v", cause)
+}
+
+// partialPush reports a push failure after repos[:failed] were already pushed
+func (p *Prompter) partialPush(repos []*multiRepo, failed int, cause error) error {
+	p.printProgress(repos)
+	var pending []string
+	for _, repo := range repos[failed:] {
+		pending = append(pending, repo.name)
+	}
+	return fmt.Errorf("push of %s failed after %d of %d repositories were pushed; the release commits and tags of %s exist locally, push them manually: %v",
+		repos[failed].name, failed, len(repos), strings.Join(pending, ", "), cause)
+}
+
+// printProgress prints one line per repository with its version change and status
+func (p *Prompter) printProgress(repos []*multiRepo) {
+	width := 0
+	for _, repo := range repos {
+		if len(repo.name) > width {
+			width = len(repo.name)
+		}
+	}
+	for _, repo := range repos {
+		p.printf("  %-*s  %s → %s  %s\n", width, repo.name, repo.plan.PreviousVersion, repo.plan.Version, repo.status)
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bump-tui/internal/gitfixture"
+)
+
+// writeManifest lists repos in a multi-repo manifest as api, web, ...
+func writeManifest(t *testing.T, repos ...*gitfixture.Repo) string {
+	names := []string{"api", "web"}
+	var manifest strings.Builder
+	for i, repo := range repos {
+		fmt.Fprintf(&manifest, "[[repos]]\nname = %q\npath = %q\n\n", names[i], repo.Dir)
+	}
+	path := filepath.Join(t.TempDir(), "bump-repos.toml")
+	if err := os.WriteFile(path, []byte(manifest.String()), 0644); err != nil {
+		t.Fatalf("Failed to write the manifest: %v", err)
+	}
+	return path
+}
+
+// newRustMonorepo creates a released Rust crate at 1.0.0 with a core package tagged
+// on its own, and a fix in core since
+func newRustMonorepo(t *testing.T) *gitfixture.Repo {
+	repo := gitfixture.New(t)
+	repo.WriteFile("Cargo.toml", "[package]\nname = \"app\"\nversion = \"1.0.0\"\nedition = \"2021\"\n")
+	repo.WriteFile("core/Cargo.toml", "[package]\nname = \"core\"\nversion = \"1.0.0\"\nedition = \"2021\"\n")
+	repo.WriteFile(".bump.toml", "[ai]\ngenerators = [\"regex\"]\n\n[detection]\nrecursive = true\n\n[monorepo]\nchanged_only = true\n")
+	repo.Commit("chore: initial commit")
+	repo.Tag("1.0.0")
+	repo.Git("tag", "-a", "core/v1.0.0", "-m", "core/v1.0.0")
+	repo.WriteFile("core/src/lib.rs", "pub fn parse() {}\n")
+	repo.Commit("fix: handle empty input")
+	repo.Push()
+	return repo
+}
+
+func TestRunMulti(t *testing.T) {
+	api, web := newRustProject(t), newRustProject(t)
+
+	var out bytes.Buffer
+	if err := RunMulti(writeManifest(t, api, web), Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("RunMulti failed: %v\n%s", err, out.String())
+	}
+
+	for name, repo := range map[string]*gitfixture.Repo{"api": api, "web": web} {
+		if tags := repo.OriginGit("tag", "--list"); tags != "v1.0.0\nv1.1.0" {
+			t.Errorf("Expected %s to push v1.1.0, got %q", name, tags)
+		}
+		if pushed := repo.OriginGit("rev-parse", "main"); pushed != repo.Git("rev-parse", "HEAD") {
+			t.Errorf("Expected %s to push its release commit", name)
+		}
+	}
+	if !strings.Contains(out.String(), "Released 2 repositories") {
+		t.Errorf("Expected a summary, got:\n%s", out.String())
+	}
+}
+
+func TestRunMultiRollsBackRejectedPush(t *testing.T) {
+	api, web := newRustMonorepo(t), newRustProject(t)
+	heads := map[*gitfixture.Repo]string{api: api.Git("rev-parse", "HEAD"), web: web.Git("rev-parse", "HEAD")}
+	tags := map[*gitfixture.Repo]string{api: api.Git("tag", "--list"), web: web.Git("tag", "--list")}
+	// Someone already published v1.1.0 of web, so its origin rejects the new tag
+	web.OriginGit("tag", "v1.1.0", "v1.0.0^{commit}")
+	manifest := writeManifest(t, api, web)
+
+	var out bytes.Buffer
+	err := RunMulti(manifest, Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	if err == nil || !strings.Contains(err.Error(), "no repository was pushed") || !strings.Contains(err.Error(), "web: remote would reject") {
+		t.Fatalf("Expected web's rejected push to abort the release, got %v\n%s", err, out.String())
+	}
+
+	for repo, head := range heads {
+		if repo.Git("rev-parse", "HEAD") != head {
+			t.Errorf("Expected %s to be reset to its HEAD before the release", repo.Dir)
+		}
+		if local := repo.Git("tag", "--list"); local != tags[repo] {
+			t.Errorf("Expected the local release and package tags of %s to be deleted, got %q", repo.Dir, local)
+		}
+		if status := repo.Git("status", "--porcelain"); status != "" {
+			t.Errorf("Expected a clean working tree in %s, got %q", repo.Dir, status)
+		}
+		if !strings.Contains(repo.ReadFile("Cargo.toml"), `version = "1.0.0"`) {
+			t.Errorf("Expected Cargo.toml of %s back at 1.0.0", repo.Dir)
+		}
+		if repo.OriginGit("rev-parse", "main") != head {
+			t.Errorf("Expected nothing to be pushed from %s", repo.Dir)
+		}
+	}
+	if tags := api.OriginGit("tag", "--list"); tags != "core/v1.0.0\nv1.0.0" {
+		t.Errorf("Expected api's origin to stay untagged, got %q", tags)
+	}
+	if !strings.Contains(out.String(), "rolled back") {
+		t.Errorf("Expected the progress to show the rollback, got:\n%s", out.String())
+	}
+
+	// Once the conflicting tag is gone, the release runs again from a clean slate
+	web.OriginGit("tag", "-d", "v1.1.0")
+	out.Reset()
+	if err := RunMulti(manifest, Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("Expected the rerun to release both repositories, got %v\n%s", err, out.String())
+	}
+	if tags := api.OriginGit("tag", "--list"); tags != "core/v1.0.0\ncore/v1.1.0\nv1.0.0\nv1.1.0" {
+		t.Errorf("Expected api to push its release and package tags, got %q", tags)
+	}
+}
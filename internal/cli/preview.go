@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+	"bump-tui/internal/release"
+)
+
+// Report formats of `bump-tui preview -format`
+const (
+	PreviewMarkdown = "md"
+	PreviewJSON     = "json"
+)
+
+// PreviewMarker starts the markdown report, so CI can find and update an earlier
+// comment instead of adding another
+const PreviewMarker = "<!-- bump-tui preview -->"
+
+// ReleasePreview is what a release from the current commits would contain
+type ReleasePreview struct {
+	CurrentVersion string `json:"current_version"`
+	// Version is the version that would be released; empty when no release is needed
+	Version string `json:"version,omitempty"`
+	Bump    string `json:"bump,omitempty"`
+	// Suggested is set when the bump was inferred from conventional commits
+	Suggested bool `json:"suggested,omitempty"`
+	// ClassifiedBy is the AI generator that classified the commits that aren't
+	// conventional for the suggested bump
+	ClassifiedBy string         `json:"classified_by,omitempty"`
+	Workflow     string         `json:"workflow"`
+	Changelog    string         `json:"changelog,omitempty"`
+	Generator    string         `json:"generator,omitempty"`
+	Tags         []string       `json:"tags,omitempty"`
+	Files        []PreviewFile  `json:"files,omitempty"`
+	Checks       []PreviewCheck `json:"checks"`
+}
+
+// PreviewFile is a file the release would write
+type PreviewFile struct {
+	Path string `json:"path"`
+	// Created is set for files the release would create
+	Created bool `json:"created,omitempty"`
+}
+
+// PreviewCheck is the result of a validation step
+type PreviewCheck struct {
+	Name     string   `json:"name"`
+	Passed   bool     `json:"passed"`
+	Warnings []string `json:"warnings,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// RunPreview writes a report of the release the current commits would make to report,
+// e.g. for CI to post on a pull request. Nothing is changed and failed checks are
+// reported rather than returned as errors.
+func (p *Prompter) RunPreview(format string, report io.Writer) error {
+	if format != PreviewMarkdown && format != PreviewJSON {
+		return fmt.Errorf("unknown preview format %q (use %s or %s)", format, PreviewMarkdown, PreviewJSON)
+	}
+	if err := p.initProject(); err != nil {
+		return err
+	}
+
+	preview, err := p.preview()
+	if err != nil {
+		return err
+	}
+
+	if format == PreviewJSON {
+		content, err := json.MarshalIndent(preview, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(report, "%s\n", content)
+		return err
+	}
+	_, err = io.WriteString(report, preview.Markdown())
+	return err
+}
+
+// preview runs the checks and plans the release without writing anything
+func (p *Prompter) preview() (*ReleasePreview, error) {
+	currentVersion := p.versionManager.CurrentVersion.String()
+	preview := &ReleasePreview{CurrentVersion: currentVersion, Workflow: p.settings.Release.Workflow}
+
+	summary, err := p.validationSummary()
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range summary.Results {
+		preview.Checks = append(preview.Checks, PreviewCheck{
+			Name:     result.Step.Name,
+			Passed:   result.Success,
+			Warnings: result.Warnings,
+			Errors:   result.Errors,
+		})
+	}
+
+	if p.opts.Bump == "" || p.opts.Bump == "auto" {
+		suggested, err := p.changelogManager.SuggestBump(currentVersion)
+		if err != nil {
+			return nil, err
+		}
+		if suggested == "" {
+			return preview, nil
+		}
+		p.opts.Bump = suggested
+		preview.Suggested = true
+		preview.ClassifiedBy = p.changelogManager.BumpClassifiedBy()
+	}
+	newVersion, err := p.selectVersion()
+	if err != nil {
+		return nil, err
+	}
+	preview.Version = newVersion
+	preview.Bump = p.opts.Bump
+
+	preview.Tags = []string{"v" + newVersion}
+	if p.settings.Monorepo.ChangedOnly {
+		preview.Tags = append(preview.Tags, p.versionManager.PackageTags(newVersion)...)
+	}
+	if p.settings.Release.Workflow != config.WorkflowPullRequest {
+		aliases, err := release.AliasTags(newVersion, p.settings.Release.AliasTags)
+		if err != nil {
+			return nil, err
+		}
+		preview.Tags = append(preview.Tags, aliases...)
+	}
+
+	if p.settings.Release.TagOnly {
+		return preview, nil
+	}
+
+	changes, err := p.changelogManager.GenerateChanges(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	if p.settings.Changelog.Lint {
+		changes, _ = changelog.Lint(changes)
+	}
+	preview.Changelog = changes
+	preview.Generator = p.changelogManager.LastGenerator()
+
+	seen := make(map[string]bool)
+	for _, path := range p.releaseManager.ReleaseFiles(newVersion) {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		_, err := os.Stat(path)
+		preview.Files = append(preview.Files, PreviewFile{Path: path, Created: os.IsNotExist(err)})
+	}
+	return preview, nil
+}
+
+// Markdown renders the preview as a pull request comment
+func (r ReleasePreview) Markdown() string {
+	var b strings.Builder
+	b.WriteString(PreviewMarker + "\n")
+
+	if r.Version == "" {
+		fmt.Fprintf(&b, "## Release preview\n\nNo release is needed: every commit since v%s is marked `[skip changelog]` or `[skip release]`.\n", r.CurrentVersion)
+	} else {
+		fmt.Fprintf(&b, "## Release preview: v%s → v%s\n\n", r.CurrentVersion, r.Version)
+		reason := ""
+		if r.Suggested {
+			reason = ", as suggested by the conventional commits"
+		}
+		if r.ClassifiedBy != "" {
+			reason = fmt.Sprintf(", as suggested by the commits, classified by %s where they aren't conventional", r.ClassifiedBy)
+		}
+		if r.Workflow == config.WorkflowPullRequest {
+			fmt.Fprintf(&b, "The next release is **v%s**, a %s release%s. It opens a release pull request; the tags are created once that is merged.\n", r.Version, r.Bump, reason)
+		} else {
+			fmt.Fprintf(&b, "The next release is **v%s**, a %s release%s.\n", r.Version, r.Bump, reason)
+		}
+
+		if r.Changelog != "" {
+			fmt.Fprintf(&b, "\n### Changelog\n\n%s\n\n_Generated by %s_\n", nestHeadings(strings.TrimSpace(r.Changelog)), r.Generator)
+		}
+
+		b.WriteString("\n### Tags\n\n")
+		for _, tag := range r.Tags {
+			fmt.Fprintf(&b, "- `%s`\n", tag)
+		}
+
+		if len(r.Files) > 0 {
+			b.WriteString("\n### Files to be changed\n\n")
+			for _, file := range r.Files {
+				if file.Created {
+					fmt.Fprintf(&b, "- `%s` (new)\n", file.Path)
+				} else {
+					fmt.Fprintf(&b, "- `%s`\n", file.Path)
+				}
+			}
+		}
+	}
+
+	b.WriteString("\n### Checks\n\n| Check | Status |\n|---|---|\n")
+	for _, check := range r.Checks {
+		status := "✅ Passed"
+		switch {
+		case !check.Passed:
+			status = "❌ Failed: " + tableCell(strings.Join(check.Errors, "; "))
+		case len(check.Warnings) > 0:
+			status = "⚠️ Passed with warnings: " + tableCell(strings.Join(check.Warnings, "; "))
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", check.Name, status)
+	}
+	return b.String()
+}
+
+// nestHeadings moves the changelog's "## Category" headings below the report's own
+func nestHeadings(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = "##" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableCell keeps text from breaking out of a markdown table cell
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,666 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/plugin"
+	"bump-tui/internal/registry"
+	"bump-tui/internal/release"
+	"bump-tui/internal/version"
+)
+
+// ErrNoInput is returned when a prompt needs an answer but stdin is closed
+var ErrNoInput = errors.New("no input available to answer prompts; pass -bump and -yes to run headless")
+
+// Options configure the prompt-based CLI
+type Options struct {
+	// Since and Until override the commit range used for changelog generation
+	Since string
+	Until string
+	// Bump is "major", "minor", "patch" or "auto"; empty asks for a choice
+	Bump string
+	// Yes answers every confirmation with yes
+	Yes bool
+	// ForceWithLease lets the pull-request workflow replace an existing remote release branch
+	ForceWithLease bool
+	// NoVerify bypasses git hooks for the release commit and pushes
+	NoVerify bool
+	// TagOnly only tags and pushes HEAD, without version files, changelog or commit
+	TagOnly bool
+	// ChangelogOnly is an already-tagged version whose changelog entry is written
+	// without touching version files, commits or tags
+	ChangelogOnly string
+	// NotesOut overrides changelog.notes_out, the path the entry is also written to
+	NotesOut string
+	// Rehearse runs the release in a temporary clone and reports what it would create
+	Rehearse bool
+	// Quick skips the validation checks that need the network, e.g. in a pre-push hook
+	Quick bool
+}
+
+// Prompter runs the release workflow with plain line-based prompts instead of the
+// TUI, for terminals without TTY support, pipes and CI
+type Prompter struct {
+	in   *bufio.Reader
+	out  io.Writer
+	opts Options
+
+	versionManager   *version.Manager
+	gitManager       *git.Manager
+	changelogManager *changelog.Manager
+	releaseManager   *release.Manager
+	registryManager  *registry.Manager
+	settings         *config.Settings
+	timings          []release.StepTiming
+}
+
+func NewPrompter(opts Options, in io.Reader, out io.Writer) *Prompter {
+	versionManager := version.NewManager()
+	changelogManager := changelog.NewManager()
+	changelogManager.SetCommitRange(opts.Since, opts.Until)
+	releaseManager := release.NewManager(versionManager, changelogManager)
+	releaseManager.SetForceWithLease(opts.ForceWithLease)
+
+	return &Prompter{
+		in:               bufio.NewReader(in),
+		out:              out,
+		opts:             opts,
+		versionManager:   versionManager,
+		gitManager:       git.NewManager(),
+		changelogManager: changelogManager,
+		releaseManager:   releaseManager,
+		registryManager:  registry.NewManager(),
+		settings:         config.DefaultSettings(),
+	}
+}
+
+// Run performs one release: validation, bump selection, changelog and the release pipeline
+func (p *Prompter) Run() error {
+	if err := p.initProject(); err != nil {
+		return err
+	}
+
+	if p.opts.ChangelogOnly != "" {
+		return p.runChangelogOnly()
+	}
+
+	return p.release()
+}
+
+// release validates the repository, selects the version and runs the release pipeline
+func (p *Prompter) release() error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	currentVersion := p.versionManager.CurrentVersion.String()
+	if pending, previous := p.releaseManager.PendingRelease(currentVersion); pending != "" {
+		if p.opts.Rehearse {
+			return p.rehearse(release.Plan{PreviousVersion: previous, Version: pending})
+		}
+		return p.resumeRelease(pending, previous)
+	}
+
+	newVersion, err := p.selectVersion()
+	if err != nil {
+		return err
+	}
+	if newVersion == "" {
+		return nil
+	}
+
+	plan := release.Plan{
+		PreviousVersion: currentVersion,
+		Version:         newVersion,
+	}
+
+	if p.settings.Release.TagOnly {
+		p.printf("\nTag-only release: v%s will be created at HEAD and pushed; no files are changed\n", newVersion)
+	} else {
+		p.printf("\nGenerating changelog for %s → %s...\n", currentVersion, newVersion)
+		start := time.Now()
+		changes, err := p.changelogManager.GenerateChanges(currentVersion)
+		if err != nil {
+			return err
+		}
+		p.timings = release.SetTiming(p.timings, "changelog generation", time.Since(start))
+		if p.settings.Changelog.Lint {
+			changes, _ = changelog.Lint(changes)
+		}
+		p.printf("Generated by %s\n\n%s\n\n", p.changelogManager.LastGenerator(), changes)
+		for _, issue := range p.changelogManager.LastQualityIssues() {
+			p.printf("⚠ Self-check: %s\n", issue)
+		}
+		plan.Changes = changes
+		plan.Generator = p.changelogManager.LastGenerator()
+	}
+
+	if !p.settings.Release.TagOnly && p.changelogManager.HasEntry(newVersion) && !p.changelogManager.EntryMatches(newVersion, plan.Changes) {
+		p.printf("%s already contains an entry for %s (possibly from an aborted run)\n",
+			p.changelogManager.ChangelogPath(), newVersion)
+		replace, err := p.confirm("Replace the existing entry?")
+		if err != nil {
+			return err
+		}
+		if !replace {
+			return fmt.Errorf("aborted: changelog entry for %s already exists", newVersion)
+		}
+		plan.ReplaceChangelogEntry = true
+	}
+
+	if p.settings.Release.Workflow != config.WorkflowPullRequest {
+		aliases, err := release.AliasTags(newVersion, p.settings.Release.AliasTags)
+		if err != nil {
+			return err
+		}
+		if len(aliases) > 0 {
+			p.printf("Warning: alias tags %s will be force-pushed to point at v%s\n", strings.Join(aliases, ", "), newVersion)
+		}
+	}
+	if p.settings.Git.NoVerify {
+		p.printf("Warning: git hooks are bypassed (--no-verify); pre-commit and pre-push checks will not run\n")
+	}
+	if p.opts.Rehearse {
+		return p.rehearse(plan)
+	}
+	proceed, err := p.confirm(fmt.Sprintf("Release v%s?", newVersion))
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		p.printf("Aborted, nothing was changed\n")
+		return nil
+	}
+
+	if p.settings.Release.StrictConfirm && !p.opts.Yes {
+		typed, err := p.ask(fmt.Sprintf("Type %s to confirm: ", newVersion))
+		if err != nil {
+			return err
+		}
+		if strings.TrimPrefix(typed, "v") != newVersion {
+			return fmt.Errorf("aborted: version does not match")
+		}
+	}
+
+	p.printf("Releasing v%s...\n", newVersion)
+	outcome, err := p.releaseManager.Execute(plan)
+	if p.releaseManager.CanRetryPush(plan, err) {
+		p.printf("%v\n", err)
+		retry, confirmErr := p.confirm("Rebase onto the remote and retry the push?")
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !retry {
+			return fmt.Errorf("aborted: release commit and tag v%s were not pushed", newVersion)
+		}
+		p.timings = append(p.timings, outcome.Timings...)
+		outcome, err = p.releaseManager.RetryPush(plan)
+	}
+	if err != nil {
+		return err
+	}
+	p.timings = append(p.timings, outcome.Timings...)
+	defer func() {
+		p.printf("Timings: %s\n", release.FormatTimings(p.timings))
+	}()
+
+	if outcome.PullRequestURL != "" {
+		p.printf("Opened release pull request: %s\n", outcome.PullRequestURL)
+		p.printf("Merge the pull request, then tag the merge commit with v%s to publish the release\n", newVersion)
+		return nil
+	}
+
+	p.printf("Released v%s\n", newVersion)
+	p.postRelease(currentVersion, newVersion)
+	return nil
+}
+
+// resumeRelease finishes a release an earlier run committed at HEAD but didn't tag or
+// push, instead of bumping past it
+func (p *Prompter) resumeRelease(version, previousVersion string) error {
+	p.printf("\nHEAD is the release commit of %s from an earlier run that didn't finish\n", version)
+	proceed, err := p.confirm(fmt.Sprintf("Tag and push v%s?", version))
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		p.printf("Aborted, nothing was changed\n")
+		return nil
+	}
+
+	p.printf("Resuming v%s...\n", version)
+	outcome, err := p.releaseManager.Execute(release.Plan{PreviousVersion: previousVersion, Version: version})
+	if err != nil {
+		return err
+	}
+	p.timings = append(p.timings, outcome.Timings...)
+	p.printf("Released v%s\nTimings: %s\n", version, release.FormatTimings(p.timings))
+	p.postRelease(previousVersion, version)
+	return nil
+}
+
+// initProject mirrors the TUI's startup checks
+func (p *Prompter) initProject() error {
+	if err := p.gitManager.IsGitRepository(); err != nil {
+		return err
+	}
+
+	for _, ref := range []string{p.opts.Since, p.opts.Until} {
+		if ref == "" {
+			continue
+		}
+		if _, err := p.gitManager.ResolveRef(ref); err != nil {
+			return fmt.Errorf("invalid commit range: %v", err)
+		}
+	}
+
+	settings, err := config.LoadSettings(".")
+	if err != nil {
+		return err
+	}
+	if p.opts.NoVerify {
+		settings.Git.NoVerify = true
+	}
+	if p.opts.NotesOut != "" {
+		settings.Changelog.NotesOut = p.opts.NotesOut
+		if err := settings.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.opts.TagOnly {
+		settings.Release.TagOnly = true
+		if err := settings.Validate(); err != nil {
+			return err
+		}
+	}
+	p.settings = settings
+	p.changelogManager.SetSettings(settings)
+	p.releaseManager.SetSettings(settings)
+
+	plugins, err := plugin.Load(settings.Plugins.Enabled)
+	if err != nil {
+		return err
+	}
+	wasmPlugins, err := plugin.LoadWASM(settings.Plugins.WASM)
+	if err != nil {
+		return err
+	}
+	plugins = append(plugins, wasmPlugins...)
+	plugin.RegisterHandlers(plugins)
+	p.changelogManager.SetPlugins(plugins)
+	p.releaseManager.SetPlugins(plugins)
+
+	// Changelog-only mode documents an existing tag, so version files aren't needed
+	if p.opts.ChangelogOnly != "" {
+		return nil
+	}
+
+	p.versionManager.SetSettings(settings)
+	if settings.Release.TagOnly {
+		if err := p.versionManager.DetectVersionFromTags(); err != nil {
+			return err
+		}
+		p.printf("Current version: %s (from tags)\n", p.versionManager.CurrentVersion.String())
+		return nil
+	}
+
+	if err := p.versionManager.DetectVersionFiles("."); err != nil {
+		return err
+	}
+
+	p.printf("Current version: %s\n", p.versionManager.CurrentVersion.String())
+	for _, file := range p.versionManager.ProjectFiles {
+		p.printf("  • %s (%s)\n", file.Path, file.Type)
+	}
+	p.printLastRelease()
+
+	if settings.Monorepo.ChangedOnly {
+		return p.selectChangedPackages()
+	}
+	return nil
+}
+
+// printLastRelease describes how the current version was released, from the metadata
+// in its tag annotation, and warns when its changelog entry was edited since
+func (p *Prompter) printLastRelease() {
+	currentVersion := p.versionManager.CurrentVersion.String()
+	if p.releaseManager.ChangelogChanged(currentVersion) {
+		p.printf("Warning: the changelog entry of v%s changed since its release commit (%s trailer)\n", currentVersion, release.TrailerChangelogHash)
+	}
+	metadata, err := p.releaseManager.ReleaseMetadata(currentVersion)
+	if err != nil || metadata.BumpType == "" {
+		return
+	}
+
+	description := fmt.Sprintf("v%s was a %s release from %s", currentVersion, metadata.BumpType, metadata.PreviousVersion)
+	if metadata.Generator != "" {
+		description += fmt.Sprintf(", changelog by %s", metadata.Generator)
+	}
+	p.printf("%s\n", description)
+	if metadata.ConfigHash != "" && metadata.ConfigHash != release.ConfigHash(p.settings) {
+		p.printf("Settings changed since v%s\n", currentVersion)
+	}
+}
+
+// selectChangedPackages prints the affected packages summary and limits the release
+// to the packages with commits since their last tag
+func (p *Prompter) selectChangedPackages() error {
+	changes, err := p.versionManager.DetectChangedPackages()
+	if err != nil {
+		return err
+	}
+
+	p.printf("\nAffected packages:\n")
+	for _, change := range changes {
+		since := "never tagged"
+		if change.LastTag != "" {
+			since = "since " + change.LastTag
+		}
+		status := "unchanged"
+		if change.Changed() {
+			status = "bump"
+		}
+		p.printf("  [%s] %s — %d commits %s\n", status, change.File.Path, change.Commits, since)
+	}
+
+	if err := p.versionManager.SelectChangedPackages(changes); err != nil {
+		return err
+	}
+	p.printf("Releasing from %s; each package is bumped from its own version\n", p.versionManager.CurrentVersion.String())
+	return nil
+}
+
+// runChangelogOnly writes the changelog entry for an existing tag, e.g. one created
+// by hand, from the commits since the tag before it. Nothing is committed or tagged.
+func (p *Prompter) runChangelogOnly() error {
+	version := strings.TrimPrefix(p.opts.ChangelogOnly, "v")
+	tag := "v" + version
+	if _, err := p.gitManager.ResolveRef(tag); err != nil {
+		return fmt.Errorf("tag %s does not exist; changelog-only mode documents an existing tag: %v", tag, err)
+	}
+
+	since := p.opts.Since
+	if since == "" {
+		previous, err := p.gitManager.GetPreviousTag(tag)
+		if err != nil {
+			return err
+		}
+		since = previous
+	}
+	until := p.opts.Until
+	if until == "" {
+		until = tag
+	}
+	p.changelogManager.SetCommitRange(since, until)
+
+	date, err := p.gitManager.GetTagDate(tag)
+	if err != nil {
+		return err
+	}
+
+	p.printf("\nGenerating changelog for %s (%s)...\n", tag, p.changelogManager.DescribeRange(""))
+	changes, err := p.changelogManager.GenerateChanges(strings.TrimPrefix(since, "v"))
+	if err != nil {
+		return err
+	}
+	if p.settings.Changelog.Lint {
+		changes, _ = changelog.Lint(changes)
+	}
+	p.printf("\n%s\n\n", changes)
+
+	replace := false
+	if p.changelogManager.HasEntry(version) {
+		p.printf("%s already contains an entry for %s\n", p.changelogManager.ChangelogPath(), version)
+		if replace, err = p.confirm("Regenerate the existing entry?"); err != nil {
+			return err
+		}
+		if !replace {
+			p.printf("Aborted, nothing was changed\n")
+			return nil
+		}
+	} else {
+		proceed, err := p.confirm(fmt.Sprintf("Add this entry for %s to %s?", tag, p.changelogManager.ChangelogPath()))
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			p.printf("Aborted, nothing was changed\n")
+			return nil
+		}
+	}
+
+	if err := p.changelogManager.BackfillEntry(version, changes, date, replace); err != nil {
+		return err
+	}
+	if path, err := p.changelogManager.WriteNotes(version, changes); err != nil {
+		return err
+	} else if path != "" {
+		p.printf("Wrote release notes to %s\n", path)
+	}
+
+	p.printf("Updated %s for %s; review and commit it yourself\n", p.changelogManager.ChangelogPath(), tag)
+	return nil
+}
+
+// validate runs the repository validation and prints every warning and error
+func (p *Prompter) validate() error {
+	p.printf("\nValidating repository...\n")
+	start := time.Now()
+	summary, err := p.validationSummary()
+	if err != nil {
+		return err
+	}
+	p.timings = release.SetTiming(p.timings, "validation", time.Since(start))
+
+	for _, result := range summary.Results {
+		status := "ok"
+		if !result.Success {
+			status = "failed"
+		}
+		p.printf("  [%s] %s\n", status, result.Step.Name)
+		for _, finding := range result.Findings() {
+			p.printf("      %s: %s\n", finding.Severity, finding.Message)
+			if finding.Fix != "" {
+				p.printf("        fix: %s\n", finding.Fix)
+			}
+		}
+	}
+
+	if !summary.CanProceed {
+		return ErrValidationFailed
+	}
+	return nil
+}
+
+// validationSummary runs the repository checks and the project-specific checks
+func (p *Prompter) validationSummary() (*git.ValidationSummary, error) {
+	validate := p.gitManager.ValidateRepositoryStatus
+	if p.opts.Quick {
+		validate = p.gitManager.ValidateLocalStatus
+	}
+	summary, err := validate(nil)
+	if err != nil {
+		return nil, err
+	}
+	// Changed-only monorepo packages are versioned independently
+	if !p.settings.Release.TagOnly && !p.settings.Monorepo.ChangedOnly {
+		summary.Add(p.versionManager.ValidateVersionSync())
+	}
+	if result := p.versionManager.ValidateRustSources(); result != nil {
+		summary.Add(*result)
+	}
+	if result := p.releaseManager.CheckGoreleaser(); result != nil {
+		summary.Add(*result)
+	}
+	// Quick validation leaves out the checks asking GitHub and the package registries
+	if !p.opts.Quick {
+		if result := p.releaseManager.CheckLatestRelease(p.versionManager.CurrentVersion.String()); result != nil {
+			summary.Add(*result)
+		}
+		if p.settings.Registry.Check {
+			summary.Add(p.registryManager.ValidatePublished(registry.FindPackages(p.versionManager.ProjectFiles)))
+		}
+	}
+	if p.settings.CommitLint.Enabled {
+		summary.Add(p.changelogManager.ValidateCommitMessages(p.versionManager.CurrentVersion.String()))
+	}
+	return summary, nil
+}
+
+// selectVersion returns the new version from -bump or a numbered choice. An empty
+// version means no release is needed.
+func (p *Prompter) selectVersion() (string, error) {
+	currentVersion := p.versionManager.CurrentVersion.String()
+
+	bump := p.opts.Bump
+	if bump == "auto" {
+		suggested, err := p.changelogManager.SuggestBump(currentVersion)
+		if err != nil {
+			return "", err
+		}
+		if suggested == "" {
+			p.printf("No release needed: every commit since %s is marked [skip changelog] or [skip release]\n", currentVersion)
+			return "", nil
+		}
+		if generator := p.changelogManager.BumpClassifiedBy(); generator != "" {
+			p.printf("Commits classified by %s suggest a %s release\n", generator, suggested)
+		} else {
+			p.printf("Conventional commits suggest a %s release\n", suggested)
+		}
+		bump = suggested
+	}
+
+	if bump == "" {
+		choices := []string{changelog.BumpMajor, changelog.BumpMinor, changelog.BumpPatch}
+		p.printf("\nSelect version bump:\n")
+		p.printf("  1) Major (%s)\n", p.versionManager.BumpMajor())
+		p.printf("  2) Minor (%s)\n", p.versionManager.BumpMinor())
+		p.printf("  3) Patch (%s)\n", p.versionManager.BumpPatch())
+		for {
+			answer, err := p.ask("Choice [1-3]: ")
+			if err != nil {
+				return "", err
+			}
+			if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+				bump = choices[n-1]
+				break
+			}
+			p.printf("Please enter 1, 2 or 3\n")
+		}
+	}
+
+	switch bump {
+	case changelog.BumpMajor:
+		return p.versionManager.BumpMajor().String(), nil
+	case changelog.BumpMinor:
+		return p.versionManager.BumpMinor().String(), nil
+	case changelog.BumpPatch:
+		return p.versionManager.BumpPatch().String(), nil
+	default:
+		return "", fmt.Errorf("unknown bump type %q (use major, minor, patch or auto)", bump)
+	}
+}
+
+// postRelease runs milestone and pull request housekeeping and lists the checklist
+func (p *Prompter) postRelease(previousVersion, newVersion string) {
+	notes, err := p.releaseManager.UpdateMilestones(p.settings.GitHub.Milestones, newVersion)
+	for _, note := range notes {
+		p.printf("%s\n", note)
+	}
+	if err != nil {
+		p.printf("Warning: milestones not updated: %v\n", err)
+	}
+
+	if p.releaseManager.ShouldRunGoreleaser() {
+		p.printf("Running goreleaser release --clean...\n")
+		if err := p.releaseManager.RunGoreleaser(); err != nil {
+			p.printf("Warning: %v\n", err)
+		} else {
+			p.printf("goreleaser release finished\n")
+		}
+	}
+
+	if p.releaseManager.ShouldPublishFirmware() {
+		p.printf("Building firmware...\n")
+		firmwareNotes, err := p.releaseManager.PublishFirmware(newVersion)
+		for _, note := range firmwareNotes {
+			p.printf("%s\n", note)
+		}
+		if err != nil {
+			p.printf("Warning: %v\n", err)
+		}
+	}
+
+	if names := p.releaseManager.PostReleasePlugins(); len(names) > 0 {
+		p.printf("Running plugins %s...\n", strings.Join(names, ", "))
+		pluginNotes, err := p.releaseManager.RunPostReleasePlugins(previousVersion, newVersion)
+		for _, note := range pluginNotes {
+			p.printf("%s\n", note)
+		}
+		if err != nil {
+			p.printf("Warning: %v\n", err)
+		}
+	}
+
+	containerNotes, err := p.releaseManager.SyncContainerImages(newVersion)
+	for _, note := range containerNotes {
+		p.printf("%s\n", note)
+	}
+	if err != nil {
+		p.printf("Warning: container images not fully retagged: %v\n", err)
+	}
+
+	count, err := p.releaseManager.NotifyReleasedPullRequests(p.settings.GitHub.ReleasedPRs, previousVersion, newVersion)
+	if count > 0 {
+		p.printf("Notified %d pull requests released in v%s\n", count, newVersion)
+	}
+	if err != nil {
+		p.printf("Warning: released pull requests not fully updated: %v\n", err)
+	}
+
+	if len(p.settings.Release.Checklist) > 0 {
+		p.printf("\nNext steps:\n")
+		for _, item := range p.settings.Release.Checklist {
+			p.printf("  - %s\n", item.Title)
+		}
+	}
+}
+
+// confirm asks a yes/no question, defaulting to no; -yes answers yes
+func (p *Prompter) confirm(question string) (bool, error) {
+	if p.opts.Yes {
+		p.printf("%s [y/N]: y\n", question)
+		return true, nil
+	}
+
+	answer, err := p.ask(question + " [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// ask prints a prompt and reads one line of input
+func (p *Prompter) ask(prompt string) (string, error) {
+	p.printf("%s", prompt)
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		p.printf("\n")
+		return "", ErrNoInput
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (p *Prompter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.out, format, args...)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/release"
+)
+
+// rehearse runs the release pipeline in a temporary clone whose origin is a local copy
+// of the remote, prints the commits, tags and pushes it made, then discards the clone.
+// Steps after the push, such as GitHub releases and plugins, are not rehearsed.
+func (p *Prompter) rehearse(plan release.Plan) error {
+	if p.settings.Release.Workflow == config.WorkflowPullRequest {
+		return fmt.Errorf("rehearsals need the direct workflow; the pull-request workflow opens the pull request on GitHub")
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	clone, err := git.NewRehearsalClone()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := clone.Remove(); err != nil {
+			p.printf("Warning: unable to remove the rehearsal clone: %v\n", err)
+		}
+	}()
+
+	if err := os.Chdir(clone.Dir); err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Chdir(workDir); err != nil {
+			p.printf("Warning: unable to return to %s: %v\n", workDir, err)
+		}
+	}()
+
+	p.printf("Rehearsing v%s in a temporary clone; the repository and its remote are not touched...\n", plan.Version)
+	outcome, err := p.releaseManager.Execute(plan)
+	if err != nil {
+		return fmt.Errorf("the rehearsal of v%s failed: %v", plan.Version, err)
+	}
+	report, err := clone.Report()
+	if err != nil {
+		return err
+	}
+
+	p.printf("\nCommits:\n")
+	p.printLines(report.Commits)
+	if report.Stat != "" {
+		p.printf("%s\n", indent(report.Stat, "    "))
+	}
+	p.printf("Tags:\n")
+	p.printLines(report.Tags)
+	p.printf("Pushes:\n")
+	p.printLines(report.Pushed)
+	p.printf("Timings: %s\n", release.FormatTimings(append(p.timings, outcome.Timings...)))
+	p.printf("\nRehearsal of v%s succeeded and was discarded; nothing was changed\n", plan.Version)
+	return nil
+}
+
+// printLines prints one indented line per item, or "none"
+func (p *Prompter) printLines(lines []string) {
+	if len(lines) == 0 {
+		p.printf("  none\n")
+	}
+	for _, line := range lines {
+		p.printf("  %s\n", line)
+	}
+}
+
+// indent prefixes every line of text
+func indent(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bump-tui/internal/git"
+	"bump-tui/internal/gitfixture"
+)
+
+// newRustProject creates a released Rust crate at 1.0.0 with two conventional commits
+// since, pushed to its origin. The changelog uses the regex generator so no AI runs.
+func newRustProject(t *testing.T) *gitfixture.Repo {
+	repo := gitfixture.New(t)
+	repo.WriteFile("Cargo.toml", "[package]\nname = \"app\"\nversion = \"1.0.0\"\nedition = \"2021\"\n")
+	repo.WriteFile(".bump.toml", "[ai]\ngenerators = [\"regex\"]\n")
+	repo.Commit("chore: initial commit")
+	repo.Tag("1.0.0")
+	repo.WriteFile("src/export.rs", "pub fn export() {}\n")
+	repo.Commit("feat: add export")
+	repo.WriteFile("src/input.rs", "pub fn parse() {}\n")
+	repo.Commit("fix: handle empty input")
+	repo.Push()
+	repo.Chdir()
+	return repo
+}
+
+func TestReleaseEndToEnd(t *testing.T) {
+	repo := newRustProject(t)
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	if err := prompter.Run(); err != nil {
+		t.Fatalf("Run failed: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(repo.ReadFile("Cargo.toml"), `version = "1.1.0"`) {
+		t.Errorf("Expected Cargo.toml at 1.1.0, got:\n%s", repo.ReadFile("Cargo.toml"))
+	}
+	changes := repo.ReadFile("docs/CHANGELOG.md")
+	for _, expected := range []string{"1.1.0", "add export", "handle empty input"} {
+		if !strings.Contains(changes, expected) {
+			t.Errorf("Expected the changelog to contain %q, got:\n%s", expected, changes)
+		}
+	}
+	if subject := repo.Git("log", "-1", "--format=%s"); subject != "chore(release): bump version to 1.1.0" {
+		t.Errorf("Expected the release commit at HEAD, got %q", subject)
+	}
+	if head, tagged := repo.Git("rev-parse", "HEAD"), repo.Git("rev-parse", "v1.1.0^{commit}"); head != tagged {
+		t.Errorf("Expected v1.1.0 to tag HEAD %s, got %s", head, tagged)
+	}
+	if pushed := repo.OriginGit("rev-parse", "main"); pushed != repo.Git("rev-parse", "HEAD") {
+		t.Errorf("Expected origin main at the release commit, got %s", pushed)
+	}
+	if tags := repo.OriginGit("tag", "--list"); tags != "v1.0.0\nv1.1.0" {
+		t.Errorf("Expected v1.1.0 to be pushed, got %q", tags)
+	}
+}
+
+func TestReleaseScriptedPrompts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		// An invalid choice is asked again; 3 picks patch
+		{"patch after retry", "9\n3\ny\n", "v1.0.1"},
+		{"minor", "2\ny\n", "v1.1.0"},
+		{"declined", "1\nn\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRustProject(t)
+
+			var out bytes.Buffer
+			prompter := NewPrompter(Options{}, strings.NewReader(tt.input), &out)
+			if err := prompter.Run(); err != nil {
+				t.Fatalf("Run failed: %v\n%s", err, out.String())
+			}
+
+			tags := repo.Git("tag", "--list")
+			if tt.expected == "" {
+				if tags != "v1.0.0" {
+					t.Errorf("Expected no release after declining, got tags %q", tags)
+				}
+				return
+			}
+			if tags != "v1.0.0\n"+tt.expected {
+				t.Errorf("Expected %s to be released, got tags %q\n%s", tt.expected, tags, out.String())
+			}
+		})
+	}
+
+	// Without answers the prompts fail instead of hanging
+	newRustProject(t)
+	prompter := NewPrompter(Options{}, strings.NewReader(""), &bytes.Buffer{})
+	if err := prompter.Run(); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Expected ErrNoInput with closed stdin, got %v", err)
+	}
+}
+
+func TestReleaseBlockedByUncommittedChanges(t *testing.T) {
+	repo := newRustProject(t)
+	head := repo.Git("rev-parse", "HEAD")
+	repo.WriteFile("src/export.rs", "pub fn export() { todo!() }\n")
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	if err := prompter.Run(); err == nil || !strings.Contains(err.Error(), "validation failed") {
+		t.Fatalf("Expected validation to fail, got %v\n%s", err, out.String())
+	}
+
+	if repo.Git("rev-parse", "HEAD") != head || repo.Git("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected no release commit or tag after failed validation")
+	}
+}
+
+func TestReleaseRestoredAfterFailedCommit(t *testing.T) {
+	repo := newRustProject(t)
+	head := repo.Git("rev-parse", "HEAD")
+	hook := filepath.Join(repo.Dir, ".git", "hooks", "pre-commit")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\necho 'lint failed' >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatalf("Failed to write the pre-commit hook: %v", err)
+	}
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	err := prompter.Run()
+	if err == nil || !strings.Contains(err.Error(), "restored to its state before the release") {
+		t.Fatalf("Expected the failed commit to restore the repository, got %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(repo.ReadFile("Cargo.toml"), `version = "1.0.0"`) {
+		t.Errorf("Expected Cargo.toml back at 1.0.0, got:\n%s", repo.ReadFile("Cargo.toml"))
+	}
+	if _, err := os.Stat(filepath.Join(repo.Dir, "docs", "CHANGELOG.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected the changelog created by the release to be removed, got %v", err)
+	}
+	if status := repo.Git("status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean working tree, got %q", status)
+	}
+	if repo.Git("rev-parse", "HEAD") != head || repo.Git("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected no release commit or tag after the rollback")
+	}
+	if repo.OriginGit("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected nothing to be pushed")
+	}
+}
+
+func TestReleaseRetryPush(t *testing.T) {
+	repo := newRustProject(t)
+	// Someone pushes after validation read the remote, so the first push is rejected
+	repo.PushFromElsewhere("docs: fix a typo")
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	if err := prompter.Run(); err != nil {
+		t.Fatalf("Run failed: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(out.String(), "Rebase onto the remote and retry the push?") {
+		t.Errorf("Expected the retry to be offered, got:\n%s", out.String())
+	}
+	head := repo.Git("rev-parse", "HEAD")
+	if repo.Git("log", "-1", "--format=%s", "HEAD~1") != "docs: fix a typo" {
+		t.Errorf("Expected the release commit rebased onto the remote commit")
+	}
+	if repo.Git("rev-parse", "v1.1.0^{commit}") != head || repo.OriginGit("rev-parse", "main") != head {
+		t.Errorf("Expected the moved tag and the rebased release commit to be pushed")
+	}
+}
+
+func TestReleaseRetryPushConflict(t *testing.T) {
+	repo := newRustProject(t)
+	repo.PushFileFromElsewhere("Cargo.toml", "[package]\nname = \"app\"\nversion = \"1.0.1\"\nedition = \"2021\"\n",
+		"chore(release): bump version to 1.0.1")
+	remote := repo.OriginGit("rev-parse", "main")
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	err := prompter.Run()
+	if err == nil || !strings.Contains(err.Error(), "still local and unpushed") {
+		t.Fatalf("Expected the conflicting rebase to fail, got %v\n%s", err, out.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(repo.Dir, ".git", "rebase-merge")); !os.IsNotExist(err) {
+		t.Errorf("Expected the rebase to be aborted, got %v", err)
+	}
+	if status := repo.Git("status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean working tree, got %q", status)
+	}
+	if subject := repo.Git("log", "-1", "--format=%s"); subject != "chore(release): bump version to 1.1.0" {
+		t.Errorf("Expected the release commit still at HEAD, got %q", subject)
+	}
+	if repo.Git("rev-parse", "v1.1.0^{commit}") != repo.Git("rev-parse", "HEAD") {
+		t.Errorf("Expected v1.1.0 to still tag the release commit")
+	}
+	if repo.OriginGit("rev-parse", "main") != remote || repo.OriginGit("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected nothing to be pushed")
+	}
+}
+
+func TestPullRequestPushRejected(t *testing.T) {
+	repo := newRustProject(t)
+	repo.WriteFile(".bump.toml", "[ai]\ngenerators = [\"regex\"]\n\n[release]\nworkflow = \"pull-request\"\n")
+	repo.Commit("chore: release through pull requests")
+	repo.Push()
+	head := repo.Git("rev-parse", "HEAD")
+	// A stale release branch with other commits is already on the remote
+	repo.Git("checkout", "-b", "stale")
+	repo.WriteFile("NOTES.md", "stale\n")
+	repo.Commit("docs: stale release notes")
+	repo.Git("push", "origin", "stale:release/v1.1.0")
+	repo.Git("checkout", "main")
+	repo.Git("branch", "-D", "stale")
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{Bump: "minor", Yes: true}, strings.NewReader(""), &out)
+	err := prompter.Run()
+	if err == nil || !strings.Contains(err.Error(), "already exists on the remote") {
+		t.Fatalf("Expected the rejected release branch to fail the release, got %v\n%s", err, out.String())
+	}
+
+	if strings.Contains(out.String(), "retry the push") {
+		t.Errorf("Expected no rebase-and-retry for a pull request release, got:\n%s", out.String())
+	}
+	if repo.Git("rev-parse", "HEAD") != head || repo.Git("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected the repository restored without a release tag")
+	}
+	if repo.OriginGit("rev-parse", "main") != head || repo.OriginGit("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected nothing to be pushed to main and no tag on the remote")
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	repo := newRustProject(t)
+	repo.WriteFile("src/export.rs", "pub fn export() { todo!() }\n")
+
+	var progress, out bytes.Buffer
+	prompter := NewPrompter(Options{}, strings.NewReader(""), &progress)
+	if err := prompter.RunValidate(true, &out); !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("Expected validation to fail, got %v\n%s", err, out.String())
+	}
+
+	var report ValidationReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Expected a JSON report, got %v:\n%s", err, out.String())
+	}
+	if report.CanProceed {
+		t.Errorf("Expected the report to block the release")
+	}
+	var found *git.Finding
+	for _, check := range report.Checks {
+		for i, finding := range check.Findings {
+			if finding.Code == "uncommitted_changes" {
+				found = &check.Findings[i]
+			}
+		}
+	}
+	if found == nil || found.Severity != git.SeverityError || found.Fix == "" {
+		t.Errorf("Expected an uncommitted_changes error with a fix, got %+v", report.Checks)
+	}
+}
+
+func TestChangelogOnly(t *testing.T) {
+	repo := newRustProject(t)
+	// 1.1.0 was tagged by hand, without a changelog, and work went on after it
+	repo.Tag("1.1.0")
+	repo.WriteFile("src/import.rs", "pub fn import() {}\n")
+	repo.Commit("feat: add import")
+	head := repo.Git("rev-parse", "HEAD")
+	date := repo.Git("log", "-1", "--format=%cs", "v1.1.0")
+
+	run := func(input string) string {
+		t.Helper()
+		var out bytes.Buffer
+		prompter := NewPrompter(Options{ChangelogOnly: "v1.1.0"}, strings.NewReader(input), &out)
+		if err := prompter.Run(); err != nil {
+			t.Fatalf("Run failed: %v\n%s", err, out.String())
+		}
+		return out.String()
+	}
+
+	run("y\n")
+	changes := repo.ReadFile("docs/CHANGELOG.md")
+	for _, expected := range []string{"1.1.0", date, "add export", "handle empty input"} {
+		if !strings.Contains(changes, expected) {
+			t.Errorf("Expected the entry to contain %q, got:\n%s", expected, changes)
+		}
+	}
+	if strings.Contains(changes, "add import") {
+		t.Errorf("Expected commits after the tag to be left out, got:\n%s", changes)
+	}
+
+	// Nothing is committed, tagged, pushed or bumped
+	if repo.Git("rev-parse", "HEAD") != head || repo.Git("tag", "--list") != "v1.0.0\nv1.1.0" {
+		t.Errorf("Expected no commit or tag")
+	}
+	if repo.OriginGit("tag", "--list") != "v1.0.0" {
+		t.Errorf("Expected nothing to be pushed")
+	}
+	if !strings.Contains(repo.ReadFile("Cargo.toml"), `version = "1.0.0"`) {
+		t.Errorf("Expected Cargo.toml to be left alone")
+	}
+
+	// An existing entry is only regenerated when confirmed, and never duplicated
+	if out := run("n\n"); !strings.Contains(out, "already contains an entry for 1.1.0") || repo.ReadFile("docs/CHANGELOG.md") != changes {
+		t.Errorf("Expected declining to leave the entry alone:\n%s", out)
+	}
+	repo.WriteFile("docs/CHANGELOG.md", strings.Replace(changes, "add export", "add exports", 1))
+	run("y\n")
+	if regenerated := repo.ReadFile("docs/CHANGELOG.md"); regenerated != changes {
+		t.Errorf("Expected the entry to be regenerated in place, got:\n%s", regenerated)
+	}
+
+	var out bytes.Buffer
+	prompter := NewPrompter(Options{ChangelogOnly: "2.0.0"}, strings.NewReader("y\n"), &out)
+	if err := prompter.Run(); err == nil || !strings.Contains(err.Error(), "tag v2.0.0 does not exist") {
+		t.Errorf("Expected an error for a missing tag, got %v", err)
+	}
+}
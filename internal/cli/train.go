@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/release"
+)
+
+// RunTrain reports whether a release is due on the release train cadence and what it
+// would include. With auto set, a due release is run headlessly like -auto.
+func (p *Prompter) RunTrain(auto bool) error {
+	if err := p.initProject(); err != nil {
+		return err
+	}
+
+	currentVersion := p.versionManager.CurrentVersion.String()
+	tag := "v" + currentVersion
+	var lastRelease time.Time
+	if _, err := p.gitManager.ResolveRef(tag); err == nil {
+		date, err := p.gitManager.GetTagDate(tag)
+		if err != nil {
+			return err
+		}
+		lastRelease = date
+	}
+
+	status, err := release.TrainSchedule(p.settings.Train, lastRelease, time.Now())
+	if err != nil {
+		return err
+	}
+
+	p.printf("\nRelease train: every %d days from %s\n", p.settings.Train.IntervalDays, p.settings.Train.Start)
+	if lastRelease.IsZero() {
+		p.printf("Last release: none (no %s tag)\n", tag)
+	} else {
+		p.printf("Last release: %s on %s\n", tag, lastRelease.Format(config.TrainDateLayout))
+	}
+	p.printf("Next train: %s\n", status.Next.Format(config.TrainDateLayout))
+
+	commits, err := p.changelogManager.PendingCommits(currentVersion)
+	if err != nil {
+		return err
+	}
+	p.printf("\n%d commits since %s:\n", len(commits), tag)
+	for _, commit := range commits {
+		p.printf("  • %s %s\n", commit.Hash, commit.Message)
+	}
+
+	suggested, err := p.changelogManager.SuggestBump(currentVersion)
+	if err != nil {
+		return err
+	}
+
+	if status.Train.IsZero() {
+		p.printf("\nNo release due: the first train is on %s\n", status.Next.Format(config.TrainDateLayout))
+		return nil
+	}
+	if !status.Due {
+		p.printf("\nNo release due: %s was released after the last train\n", tag)
+		return nil
+	}
+	if suggested == "" {
+		p.printf("\nThe train of %s is due, but no release is needed: every commit is marked [skip changelog] or [skip release]\n",
+			status.Train.Format(config.TrainDateLayout))
+		return nil
+	}
+	if len(commits) == 0 {
+		p.printf("\nThe train of %s is due, but there are no commits to release\n", status.Train.Format(config.TrainDateLayout))
+		return nil
+	}
+
+	p.printf("\nRelease due: the train of %s has no release yet (suggested %s bump)\n",
+		status.Train.Format(config.TrainDateLayout), suggested)
+	if !auto {
+		p.printf("Run `bump-tui train -auto` to release it\n")
+		return nil
+	}
+
+	p.opts.Bump = "auto"
+	p.opts.Yes = true
+	return p.release()
+}
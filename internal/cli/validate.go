@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"bump-tui/internal/git"
+)
+
+// ErrValidationFailed is returned by RunValidate when a check blocks the release
+var ErrValidationFailed = errors.New("repository validation failed")
+
+// ValidationReport is the result of `bump-tui validate -json`
+type ValidationReport struct {
+	CanProceed bool              `json:"can_proceed"`
+	Checks     []ValidationCheck `json:"checks"`
+}
+
+// ValidationCheck is a validation step and what it found
+type ValidationCheck struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Passed      bool          `json:"passed"`
+	Findings    []git.Finding `json:"findings,omitempty"`
+}
+
+// NewValidationReport converts a validation summary into its report
+func NewValidationReport(summary *git.ValidationSummary) ValidationReport {
+	report := ValidationReport{CanProceed: summary.CanProceed, Checks: []ValidationCheck{}}
+	for _, result := range summary.Results {
+		report.Checks = append(report.Checks, ValidationCheck{
+			Name:        result.Step.Name,
+			Description: result.Step.Description,
+			Passed:      result.Success,
+			Findings:    result.Findings(),
+		})
+	}
+	return report
+}
+
+// RunValidate runs the repository validation without releasing. With jsonOutput the
+// report is written to report as JSON, e.g. for CI or an editor; otherwise the
+// checks are printed like before a release. It returns ErrValidationFailed when a
+// check blocks the release.
+func (p *Prompter) RunValidate(jsonOutput bool, report io.Writer) error {
+	if err := p.initProject(); err != nil {
+		return err
+	}
+	if !jsonOutput {
+		return p.validate()
+	}
+
+	summary, err := p.validationSummary()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(NewValidationReport(summary), "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(report, "%s\n", content); err != nil {
+		return err
+	}
+	if !summary.CanProceed {
+		return ErrValidationFailed
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// command is a platform clipboard tool and the arguments that make it read stdin
+type command struct {
+	name string
+	args []string
+}
+
+// platformCommands lists the clipboard tools to try on this system, in order
+func platformCommands() []command {
+	switch runtime.GOOS {
+	case "darwin":
+		return []command{{"pbcopy", nil}}
+	case "windows":
+		return []command{{"clip.exe", nil}}
+	}
+
+	var commands []command
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, command{"wl-copy", nil})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		commands = append(commands,
+			command{"xclip", []string{"-selection", "clipboard"}},
+			command{"xsel", []string{"--clipboard", "--input"}})
+	}
+	// WSL shares the Windows clipboard
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		commands = append(commands, command{"clip.exe", nil})
+	}
+	return commands
+}
+
+// Copy puts text on the system clipboard with the first platform tool that works and
+// also sends it to the terminal as an OSC 52 sequence, which reaches the local
+// clipboard over SSH in terminals that support it. It returns where the text went.
+func Copy(text string) (string, error) {
+	copied := ""
+	for _, cmd := range platformCommands() {
+		if _, err := exec.LookPath(cmd.name); err != nil {
+			continue
+		}
+		c := exec.Command(cmd.name, cmd.args...)
+		c.Stdin = strings.NewReader(text)
+		if c.Run() == nil {
+			copied = "clipboard (" + cmd.name + ")"
+			break
+		}
+	}
+
+	oscErr := writeOSC52(text)
+	switch {
+	case copied != "":
+		return copied, nil
+	case oscErr == nil:
+		return "terminal clipboard (OSC 52)", nil
+	}
+	return "", fmt.Errorf("no clipboard available: install pbcopy, wl-copy, xclip or xsel, or use a terminal with OSC 52: %v", oscErr)
+}
+
+// writeOSC52 writes the OSC 52 sequence for text to the controlling terminal
+func writeOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tty.Close()
+	}()
+
+	_, err = tty.WriteString(OSC52(text, os.Getenv("TMUX") != "", strings.HasPrefix(os.Getenv("TERM"), "screen")))
+	return err
+}
+
+// OSC52 returns the escape sequence that sets the clipboard to text, wrapped in a
+// passthrough sequence for tmux or GNU screen, which don't forward it otherwise
+func OSC52(text string, tmux, screen bool) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	switch {
+	case tmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case screen:
+		return "\x1bP" + sequence + "\x1b\\"
+	}
+	return sequence
+}
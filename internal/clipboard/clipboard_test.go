@@ -0,0 +1,24 @@
+package clipboard
+
+import "testing"
+
+func TestOSC52(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmux     bool
+		screen   bool
+		expected string
+	}{
+		{"plain", false, false, "\x1b]52;c;aGk=\x07"},
+		{"tmux", true, false, "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\"},
+		{"screen", false, true, "\x1bP\x1b]52;c;aGk=\x07\x1b\\"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OSC52("hi", tt.tmux, tt.screen); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -18,6 +19,8 @@ type BumpConfig struct {
 type VersionFile struct {
 	// Path to the file relative to the repository root
 	Path string
+	// Pattern is the glob the file was matched by, empty for files listed explicitly
+	Pattern string
 }
 
 // LoadBumpConfig loads the .bump configuration file from the project root
@@ -51,7 +54,21 @@ func LoadBumpConfig(projectRoot string) (*BumpConfig, error) {
 			continue
 		}
 
-		config.Files = append(config.Files, VersionFile{Path: line})
+		path, err := cleanPath(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid .bump config: %v", err)
+		}
+
+		if !isGlobPattern(path) {
+			config.Files = append(config.Files, VersionFile{Path: path})
+			continue
+		}
+
+		matches, err := expandPattern(projectRoot, path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid .bump config: %v", err)
+		}
+		config.Files = append(config.Files, matches...)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -66,6 +83,48 @@ func LoadBumpConfig(projectRoot string) (*BumpConfig, error) {
 	return &config, nil
 }
 
+// cleanPath normalizes a .bump entry, so ./Cargo.toml and Cargo.toml are the same
+// file, and rejects entries outside the project root
+func cleanPath(line string) (string, error) {
+	path := filepath.ToSlash(filepath.Clean(filepath.FromSlash(line)))
+	if filepath.IsAbs(line) || strings.HasPrefix(line, "/") || path == ".." || strings.HasPrefix(path, "../") {
+		return "", fmt.Errorf("%s is outside the project", line)
+	}
+	return path, nil
+}
+
+// isGlobPattern reports whether a .bump line is a glob such as packages/*/Cargo.toml
+func isGlobPattern(line string) bool {
+	return strings.ContainsAny(line, "*?[")
+}
+
+// expandPattern returns the files matching a glob relative to the project root,
+// sorted so the file order is stable between runs
+func expandPattern(projectRoot, pattern string) ([]VersionFile, error) {
+	matches, err := filepath.Glob(filepath.Join(projectRoot, pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %v", pattern, err)
+	}
+	sort.Strings(matches)
+
+	var files []VersionFile
+	for _, match := range matches {
+		if info, err := os.Stat(match); err != nil || info.IsDir() {
+			continue
+		}
+		relative, err := filepath.Rel(projectRoot, match)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, VersionFile{Path: filepath.ToSlash(relative), Pattern: pattern})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("pattern %s matches no files", pattern)
+	}
+	return files, nil
+}
+
 // Validate checks if the configuration is valid
 func (c *BumpConfig) Validate(projectRoot string) error {
 	if len(c.Files) == 0 {
@@ -80,20 +139,44 @@ func (c *BumpConfig) Validate(projectRoot string) error {
 
 		// Check for duplicate paths
 		if seenPaths[file.Path] {
+			if file.Pattern != "" {
+				return fmt.Errorf("duplicate file path: %s (also matched by %s)", file.Path, file.Pattern)
+			}
 			return fmt.Errorf("duplicate file path: %s", file.Path)
 		}
 		seenPaths[file.Path] = true
 
 		// Validate file exists
 		fullPath := filepath.Join(projectRoot, file.Path)
-		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		info, err := os.Stat(fullPath)
+		if os.IsNotExist(err) {
 			return fmt.Errorf("file does not exist: %s", file.Path)
 		}
+		if err != nil {
+			return fmt.Errorf("cannot read %q: %v", file.Path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory, not a version file", file.Path)
+		}
 	}
 
 	return nil
 }
 
+// SaveBumpConfig writes a .bump file listing the given paths relative to the project root
+func SaveBumpConfig(projectRoot string, paths []string) error {
+	var content strings.Builder
+	content.WriteString("# Version files to manage\n")
+	for _, path := range paths {
+		content.WriteString(filepath.ToSlash(path) + "\n")
+	}
+
+	if err := os.WriteFile(filepath.Join(projectRoot, ".bump"), []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write .bump config: %v", err)
+	}
+	return nil
+}
+
 // GetAbsolutePaths returns the absolute paths of all configured files
 func (c *BumpConfig) GetAbsolutePaths(projectRoot string) []string {
 	paths := make([]string, len(c.Files))
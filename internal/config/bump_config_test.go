@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadBumpConfigGlobs(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		expected  []string
+		expectErr bool
+	}{
+		{"explicit file", "Cargo.toml\n", []string{"Cargo.toml"}, false},
+		{"glob sorted", "packages/*/Cargo.toml\n", []string{"packages/a/Cargo.toml", "packages/b/Cargo.toml"}, false},
+		{"glob and explicit", "Cargo.toml\npackages/*/Cargo.toml\n", []string{"Cargo.toml", "packages/a/Cargo.toml", "packages/b/Cargo.toml"}, false},
+		{"glob skips directories", "packages/*\n", nil, true},
+		{"no matches", "crates/*/Cargo.toml\n", nil, true},
+		{"overlapping entries", "packages/a/Cargo.toml\npackages/*/Cargo.toml\n", nil, true},
+		{"same file spelled twice", "Cargo.toml\n./Cargo.toml\n", nil, true},
+		{"cleaned path", "./packages/a/../b/Cargo.toml\n", []string{"packages/b/Cargo.toml"}, false},
+		{"outside the project", "../Cargo.toml\n", nil, true},
+		{"directory", "packages/a\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, path := range []string{"Cargo.toml", "packages/b/Cargo.toml", "packages/a/Cargo.toml"} {
+				fullPath := filepath.Join(root, path)
+				if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(fullPath, []byte("[package]\nversion = \"1.0.0\"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(root, ".bump"), []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadBumpConfig(root)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var paths []string
+			for _, file := range config.Files {
+				paths = append(paths, file.Path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, paths)
+			}
+		})
+	}
+}
+
+func TestSaveBumpConfig(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"Cargo.toml", "crates/cli/Cargo.toml"} {
+		fullPath := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte("[package]\nversion = \"1.0.0\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := SaveBumpConfig(root, []string{"Cargo.toml", "crates/cli/Cargo.toml"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	config, err := LoadBumpConfig(root)
+	if err != nil {
+		t.Fatalf("Expected saved config to load, got %v", err)
+	}
+	if len(config.Files) != 2 || config.Files[1].Path != "crates/cli/Cargo.toml" {
+		t.Errorf("Expected both files to round-trip, got %+v", config.Files)
+	}
+}
+
+func FuzzLoadBumpConfig(f *testing.F) {
+	for _, seed := range []string{
+		"Cargo.toml\n",
+		"# Version files to manage\nCargo.toml\npackages/*/Cargo.toml\n",
+		"  Cargo.toml  \r\n\n#packages/a/Cargo.toml\n",
+		"./Cargo.toml\npackages/a/../b/Cargo.toml\n",
+		"../Cargo.toml\n/etc/passwd\npackages/[a\n",
+	} {
+		f.Add(seed)
+	}
+
+	root := f.TempDir()
+	for _, path := range []string{"Cargo.toml", "packages/a/Cargo.toml", "packages/b/Cargo.toml"} {
+		fullPath := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			f.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte("[package]\nversion = \"1.0.0\"\n"), 0644); err != nil {
+			f.Fatal(err)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, content string) {
+		if err := os.WriteFile(filepath.Join(root, ".bump"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := LoadBumpConfig(root)
+		if err != nil {
+			return
+		}
+
+		seen := make(map[string]bool)
+		for _, file := range config.Files {
+			if file.Path != filepath.ToSlash(filepath.Clean(file.Path)) || strings.HasPrefix(file.Path, "../") {
+				t.Errorf("Expected a clean path inside the project, got %q", file.Path)
+			}
+			if seen[file.Path] {
+				t.Errorf("Expected %q to be listed once", file.Path)
+			}
+			seen[file.Path] = true
+			if info, err := os.Stat(filepath.Join(root, file.Path)); err != nil || info.IsDir() {
+				t.Errorf("Expected %q to be an existing file", file.Path)
+			}
+		}
+	})
+}
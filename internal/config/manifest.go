@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ManifestFileName is the default multi-repo manifest, listing repositories released together
+const ManifestFileName = "bump-repos.toml"
+
+// RepoManifest lists sibling repositories that must be released together,
+// e.g. firmware and its host library
+type RepoManifest struct {
+	Repos []ManifestRepo `toml:"repos"`
+}
+
+// ManifestRepo is one repository in a multi-repo release
+type ManifestRepo struct {
+	// Path is the repository root, relative to the manifest
+	Path string `toml:"path"`
+	// Name labels the repository in progress output; defaults to the directory name
+	Name string `toml:"name"`
+}
+
+// LoadRepoManifest reads a multi-repo manifest. Repository paths are resolved
+// relative to the manifest and returned as absolute paths.
+func LoadRepoManifest(path string) (*RepoManifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+
+	var manifest RepoManifest
+	if err := toml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(manifest.Repos) == 0 {
+		return nil, fmt.Errorf("%s lists no repositories; add [[repos]] entries with a path", path)
+	}
+
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for i, repo := range manifest.Repos {
+		if repo.Path == "" {
+			return nil, fmt.Errorf("repos[%d]: path is required", i)
+		}
+
+		root := repo.Path
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(base, root)
+		}
+		root = filepath.Clean(root)
+
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("repos[%d]: %s is not a directory", i, repo.Path)
+		}
+		if seen[root] {
+			return nil, fmt.Errorf("repos[%d]: %s is listed more than once", i, repo.Path)
+		}
+		seen[root] = true
+
+		manifest.Repos[i].Path = root
+		if repo.Name == "" {
+			manifest.Repos[i].Name = filepath.Base(root)
+		}
+	}
+
+	return &manifest, nil
+}
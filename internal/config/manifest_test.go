@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRepoManifest(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"firmware", "host-lib"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		manifest  string
+		expected  []string
+		expectErr bool
+	}{
+		{"relative paths", "[[repos]]\npath = \"firmware\"\n[[repos]]\npath = \"host-lib\"\nname = \"host\"\n", []string{"firmware", "host"}, false},
+		{"no repositories", "", nil, true},
+		{"missing directory", "[[repos]]\npath = \"missing\"\n", nil, true},
+		{"duplicate path", "[[repos]]\npath = \"firmware\"\n[[repos]]\npath = \"./firmware\"\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(root, ManifestFileName)
+			if err := os.WriteFile(path, []byte(tt.manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			manifest, err := LoadRepoManifest(path)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(manifest.Repos) != len(tt.expected) {
+				t.Fatalf("Expected %d repos, got %d", len(tt.expected), len(manifest.Repos))
+			}
+			for i, name := range tt.expected {
+				if manifest.Repos[i].Name != name {
+					t.Errorf("Expected name %s, got %s", name, manifest.Repos[i].Name)
+				}
+				if !filepath.IsAbs(manifest.Repos[i].Path) {
+					t.Errorf("Expected absolute path, got %s", manifest.Repos[i].Path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultLanguage is the language of the texts bump writes when messages.language is unset
+const DefaultLanguage = "en"
+
+// MessageSettings sets the language of the texts bump writes to the git history and
+// changelogs. The TUI itself stays in English.
+type MessageSettings struct {
+	// Language selects built-in translations, e.g. "de"
+	Language string `toml:"language"`
+	// Commit replaces the release commit subject; {{.Version}} and {{.Tag}} are expanded
+	Commit string `toml:"commit"`
+	// Tag replaces the annotation of release tags; {{.Version}} and {{.Tag}} are expanded
+	Tag string `toml:"tag"`
+}
+
+// Language holds the texts bump writes in one language
+type Language struct {
+	// Name is the English name of the language, used to ask AI generators to write in it
+	Name string
+	// Commit and Tag are the templates of the release commit subject and tag annotation.
+	// The commit keeps its conventional chore(release) prefix so tools still recognize it.
+	Commit string
+	Tag    string
+	// Headings of the changelog sections bump generates
+	BreakingChanges string
+	Security        string
+	Deprecations    string
+	Dependencies    string
+	// MinorUpdates stands in for releases without any listed commits
+	MinorUpdates string
+}
+
+// Languages are the built-in translations for messages.language
+var Languages = map[string]Language{
+	"en": {
+		Name:            "English",
+		Commit:          "chore(release): bump version to {{.Version}}",
+		Tag:             "Release version {{.Version}}",
+		BreakingChanges: "⚠ Breaking Changes",
+		Security:        "🔒 Security",
+		Deprecations:    "🗑️ Deprecations",
+		Dependencies:    "📦 Dependencies",
+		MinorUpdates:    "Minor updates and improvements",
+	},
+	"de": {
+		Name:            "German",
+		Commit:          "chore(release): Version auf {{.Version}} erhöht",
+		Tag:             "Veröffentlichung von Version {{.Version}}",
+		BreakingChanges: "⚠ Inkompatible Änderungen",
+		Security:        "🔒 Sicherheit",
+		Deprecations:    "🗑️ Veraltete Funktionen",
+		Dependencies:    "📦 Abhängigkeiten",
+		MinorUpdates:    "Kleinere Aktualisierungen und Verbesserungen",
+	},
+	"es": {
+		Name:            "Spanish",
+		Commit:          "chore(release): actualizar la versión a {{.Version}}",
+		Tag:             "Publicación de la versión {{.Version}}",
+		BreakingChanges: "⚠ Cambios incompatibles",
+		Security:        "🔒 Seguridad",
+		Deprecations:    "🗑️ Funciones obsoletas",
+		Dependencies:    "📦 Dependencias",
+		MinorUpdates:    "Actualizaciones y mejoras menores",
+	},
+	"fr": {
+		Name:            "French",
+		Commit:          "chore(release): passage à la version {{.Version}}",
+		Tag:             "Publication de la version {{.Version}}",
+		BreakingChanges: "⚠ Changements incompatibles",
+		Security:        "🔒 Sécurité",
+		Deprecations:    "🗑️ Dépréciations",
+		Dependencies:    "📦 Dépendances",
+		MinorUpdates:    "Mises à jour et améliorations mineures",
+	},
+	"it": {
+		Name:            "Italian",
+		Commit:          "chore(release): aggiornamento alla versione {{.Version}}",
+		Tag:             "Rilascio della versione {{.Version}}",
+		BreakingChanges: "⚠ Modifiche incompatibili",
+		Security:        "🔒 Sicurezza",
+		Deprecations:    "🗑️ Deprecazioni",
+		Dependencies:    "📦 Dipendenze",
+		MinorUpdates:    "Aggiornamenti e miglioramenti minori",
+	},
+	"nl": {
+		Name:            "Dutch",
+		Commit:          "chore(release): versie verhoogd naar {{.Version}}",
+		Tag:             "Release van versie {{.Version}}",
+		BreakingChanges: "⚠ Incompatibele wijzigingen",
+		Security:        "🔒 Beveiliging",
+		Deprecations:    "🗑️ Verouderde functies",
+		Dependencies:    "📦 Afhankelijkheden",
+		MinorUpdates:    "Kleine updates en verbeteringen",
+	},
+	"pt": {
+		Name:            "Portuguese",
+		Commit:          "chore(release): atualizar a versão para {{.Version}}",
+		Tag:             "Lançamento da versão {{.Version}}",
+		BreakingChanges: "⚠ Alterações incompatíveis",
+		Security:        "🔒 Segurança",
+		Deprecations:    "🗑️ Descontinuações",
+		Dependencies:    "📦 Dependências",
+		MinorUpdates:    "Pequenas atualizações e melhorias",
+	},
+}
+
+// Texts returns the texts of the configured language, with the commit and tag
+// overrides applied
+func (m MessageSettings) Texts() Language {
+	language, ok := Languages[m.Language]
+	if !ok {
+		language = Languages[DefaultLanguage]
+	}
+	if m.Commit != "" {
+		language.Commit = m.Commit
+	}
+	if m.Tag != "" {
+		language.Tag = m.Tag
+	}
+	return language
+}
+
+// validate checks the language and that the message overrides are single-line templates
+func (m MessageSettings) validate() error {
+	if _, ok := Languages[m.Language]; !ok {
+		var codes []string
+		for code := range Languages {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		return fmt.Errorf("messages.language: unsupported language %q (use %s)", m.Language, strings.Join(codes, ", "))
+	}
+	for key, text := range map[string]string{"commit": m.Commit, "tag": m.Tag} {
+		if strings.Contains(text, "\n") {
+			return fmt.Errorf("messages.%s must be a single line", key)
+		}
+		if _, err := template.New(key).Parse(text); err != nil {
+			return fmt.Errorf("messages.%s: %v", key, err)
+		}
+	}
+	return nil
+}
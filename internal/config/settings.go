@@ -0,0 +1,796 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"bump-tui/internal/git"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// SettingsFileName is the name of the optional settings file in the project root
+const SettingsFileName = ".bump.toml"
+
+// Squash merge ingestion modes for the changelog.squash_prs setting
+const (
+	SquashPRsAuto   = "auto"
+	SquashPRsAlways = "always"
+	SquashPRsNever  = "never"
+)
+
+// Bot commit handling modes for the changelog.bot_commits setting
+const (
+	BotCommitsExclude   = "exclude"
+	BotCommitsAggregate = "aggregate"
+	BotCommitsInclude   = "include"
+)
+
+// AI response formats for the ai.output setting
+const (
+	// AIOutputJSON asks for changelog entries as JSON matching a schema, rendered locally
+	AIOutputJSON = "json"
+	// AIOutputMarkdown asks for a markdown changelog, parsed into entries
+	AIOutputMarkdown = "markdown"
+)
+
+// Changelog generators for the ai.generators chain
+const (
+	// GeneratorClaudeCLI runs the Claude CLI
+	GeneratorClaudeCLI = "claude-cli"
+	// GeneratorOpenAI calls the OpenAI chat completions API with OPENAI_API_KEY
+	GeneratorOpenAI = "openai"
+	// GeneratorRegex lists the commits without AI and never fails
+	GeneratorRegex = "regex"
+	// GeneratorPluginPrefix names a plugin generator, e.g. "plugin:notes" for bump-plugin-notes
+	GeneratorPluginPrefix = "plugin:"
+)
+
+// PluginGenerator returns the plugin name of a "plugin:<name>" generator
+func PluginGenerator(generator string) (string, bool) {
+	if !strings.HasPrefix(generator, GeneratorPluginPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(generator, GeneratorPluginPrefix), true
+}
+
+// Release workflows for the release.workflow setting
+const (
+	// WorkflowDirect commits, tags and pushes to the current branch
+	WorkflowDirect = "direct"
+	// WorkflowPullRequest commits to a release branch and opens a pull request; tagging happens after merge
+	WorkflowPullRequest = "pull-request"
+)
+
+// Push modes for the release.push_mode setting
+const (
+	// PushSeparate pushes the commit and then the tag, so each triggers its own workflows
+	PushSeparate = "separate"
+	// PushAtomic pushes the commit and tag in one atomic push so both land or neither does
+	PushAtomic = "atomic"
+)
+
+// Latest release check modes for the github.latest_release setting
+const (
+	// LatestReleaseOff skips comparing the current version with GitHub
+	LatestReleaseOff = "off"
+	// LatestReleaseWarn reports a mismatch with GitHub's latest release as a warning
+	LatestReleaseWarn = "warn"
+	// LatestReleaseBlock fails validation on a mismatch with GitHub's latest release
+	LatestReleaseBlock = "block"
+)
+
+// CMake version header modes for the cmake.mode setting
+const (
+	// CMakeHeaderPlain rewrites a header with the version macros at each release
+	CMakeHeaderPlain = "plain"
+	// CMakeHeaderConfigure creates a version.h.in template once and a configure_file()
+	// call, so CMake fills in the project() version at configure time
+	CMakeHeaderConfigure = "configure"
+)
+
+// Settings represents the optional .bump.toml settings file
+type Settings struct {
+	Changelog  ChangelogSettings  `toml:"changelog"`
+	AI         AISettings         `toml:"ai"`
+	Git        GitSettings        `toml:"git"`
+	Release    ReleaseSettings    `toml:"release"`
+	GitHub     GitHubSettings     `toml:"github"`
+	Updates    UpdateSettings     `toml:"updates"`
+	Goreleaser GoreleaserSettings `toml:"goreleaser"`
+	Detection  DetectionSettings  `toml:"detection"`
+	CommitLint CommitLintSettings `toml:"commit_lint"`
+	Train      TrainSettings      `toml:"train"`
+	Monorepo   MonorepoSettings   `toml:"monorepo"`
+	Nix        NixSettings        `toml:"nix"`
+	Debian     DebianSettings     `toml:"debian"`
+	Registry   RegistrySettings   `toml:"registry"`
+	Firmware   FirmwareSettings   `toml:"firmware"`
+	CMake      CMakeSettings      `toml:"cmake"`
+	Plugins    PluginSettings     `toml:"plugins"`
+	Messages   MessageSettings    `toml:"messages"`
+	CI         CISettings         `toml:"ci"`
+}
+
+// CISettings configures runs in CI, detected from the CI and GITHUB_ACTIONS variables
+type CISettings struct {
+	// Headless replaces the TUI with plain prompts in CI, answering yes and inferring
+	// the bump from conventional commits unless -bump is given
+	Headless bool `toml:"headless"`
+	// BotName and BotEmail author and commit the release commit and tags in CI, unless
+	// GIT_AUTHOR_NAME or GIT_COMMITTER_NAME is set; empty uses the git config
+	BotName  string `toml:"bot_name"`
+	BotEmail string `toml:"bot_email"`
+	// TokenEnv names the variable holding the GitHub token, passed to gh as GH_TOKEN
+	// when that is unset
+	TokenEnv string `toml:"token_env"`
+}
+
+// PluginSettings configures external bump-plugin-<name> executables
+type PluginSettings struct {
+	// Enabled lists the plugins to load by name; "helm" runs bump-plugin-helm from PATH
+	Enabled []string `toml:"enabled"`
+	// WASM lists sandboxed WebAssembly version file handlers, relative to the project root
+	WASM []string `toml:"wasm"`
+}
+
+// CMakeSettings configures a C/C++ version header for CMake projects
+type CMakeSettings struct {
+	// Header is the header, or the template in configure mode, relative to the
+	// repository root; empty disables it
+	Header string `toml:"header"`
+	// Mode is "plain" or "configure"
+	Mode string `toml:"mode"`
+	// Prefix names the macros, e.g. MYLIB for MYLIB_VERSION_MAJOR; defaults to the
+	// project() name
+	Prefix string `toml:"prefix"`
+}
+
+// ChangelogSettings configures changelog generation
+type ChangelogSettings struct {
+	// Lint fixes markdown, whitespace and common misspellings before the preview
+	Lint bool `toml:"lint"`
+	// HighlightSecurity adds a highlighted note above the security section for the GitHub Release
+	HighlightSecurity bool `toml:"highlight_security"`
+	// SquashPRs controls reading pull request titles for squash merges: "auto", "always" or "never"
+	SquashPRs string `toml:"squash_prs"`
+	// BotAuthors are matched against commit author names and emails to detect automated commits
+	BotAuthors []string `toml:"bot_authors"`
+	// BotCommits controls commits by bot authors: "exclude", "aggregate" into one bullet, or "include"
+	BotCommits string `toml:"bot_commits"`
+	// Reword offers to describe commits that aren't conventional commits before the changelog
+	// is generated; descriptions are saved by commit hash and only used in the changelog
+	Reword bool `toml:"reword"`
+	// NotesOut also writes each entry on its own to this path, e.g.
+	// "release-notes/{{.Tag}}.md"; {{.Version}} and {{.Tag}} are expanded
+	NotesOut string `toml:"notes_out"`
+	// Stats starts each entry with the number of commits, files and lines changed and contributors
+	Stats bool `toml:"stats"`
+	// Groups route commits to custom sections or leave them out; the first matching rule wins
+	Groups []GroupRule `toml:"groups"`
+}
+
+// GroupRule matches commits by type, scope and changed paths. Every criterion that is
+// set must match; paths match when all of a commit's files are under one of the prefixes.
+type GroupRule struct {
+	Types  []string `toml:"types"`
+	Scopes []string `toml:"scopes"`
+	Paths  []string `toml:"paths"`
+	// Section is the heading matching commits are listed under, e.g. "API Changes"
+	Section string `toml:"section"`
+	// Skip leaves matching commits out of the changelog
+	Skip bool `toml:"skip"`
+}
+
+// AISettings configures the prompt sent to the AI changelog generator
+type AISettings struct {
+	// PromptTemplate replaces the built-in prompt; {{.Commits}} expands to the commit list
+	PromptTemplate string `toml:"prompt_template"`
+	// Instructions are appended to the prompt, one requirement per entry
+	Instructions []string `toml:"instructions"`
+	// Prices in USD per million tokens, used for pre-invocation cost estimates
+	InputCostPerMTok  float64 `toml:"input_cost_per_mtok"`
+	OutputCostPerMTok float64 `toml:"output_cost_per_mtok"`
+	// MonthlyBudgetUSD warns once the month's recorded AI spend exceeds it; 0 disables the budget
+	MonthlyBudgetUSD float64 `toml:"monthly_budget_usd"`
+	// Output is the response format requested from the AI: "json" or "markdown"
+	Output string `toml:"output"`
+	// Generators are tried in order until one produces a changelog; the regex
+	// generator is the last resort even when it isn't listed
+	Generators []string `toml:"generators"`
+	// Timeouts limit each generator, in seconds by generator name
+	Timeouts map[string]int `toml:"timeouts"`
+	// OpenAIModel is the model used by the openai generator
+	OpenAIModel string `toml:"openai_model"`
+	// ClassifyBumps asks the AI generators to classify commits that aren't conventional
+	// as breaking, feature or fix when suggesting the bump type. Off by default, since it
+	// sends commit messages and diff stats to the generators.
+	ClassifyBumps bool `toml:"classify_bumps"`
+}
+
+// GitSettings configures git operations
+type GitSettings struct {
+	// CommitStrategy selects the commits used for changelogs:
+	// "no-merges", "first-parent", "include-merges" or "pull-requests"
+	CommitStrategy git.CommitStrategy `toml:"commit_strategy"`
+	// NoVerify skips git hooks (pre-commit, commit-msg, pre-push) for the release commit and pushes
+	NoVerify bool `toml:"no_verify"`
+	// Trailers are appended to the release commit message, e.g. "Signed-off-by: {{.UserName}} <{{.UserEmail}}>";
+	// {{.Version}}, {{.Tag}}, {{.UserName}} and {{.UserEmail}} are expanded
+	Trailers []string `toml:"trailers"`
+	// Notes attaches each changelog entry to its release commit as a git note in
+	// refs/notes/releases and pushes it with the release
+	Notes bool `toml:"notes"`
+}
+
+// ReleaseSettings configures the release confirmation and pipeline
+type ReleaseSettings struct {
+	// Workflow is "direct" or "pull-request"
+	Workflow string `toml:"workflow"`
+	// StrictConfirm requires typing the new version before the release runs
+	StrictConfirm bool `toml:"strict_confirm"`
+	// CountdownSeconds is the abort window shown after confirming, before any git mutation; 0 disables it
+	CountdownSeconds int `toml:"countdown_seconds"`
+	// PushMode is "separate" (push the commit, then the tag) or "atomic"
+	PushMode string `toml:"push_mode"`
+	// AliasTags lists floating tags moved to each release: "major" (v1) and/or "minor" (v1.3)
+	AliasTags []string `toml:"alias_tags"`
+	// Containers lists container images retagged after a release
+	Containers []ContainerImage `toml:"containers"`
+	// TagOnly skips version files, the changelog and the release commit, and only tags and pushes HEAD
+	TagOnly bool `toml:"tag_only"`
+	// Checklist lists follow-up actions shown in the results view after a release
+	Checklist []ChecklistItem `toml:"checklist"`
+}
+
+// ContainerImage is a registry image whose release tags are pointed at the image
+// built from the release commit
+type ContainerImage struct {
+	// Image is the repository without a tag, e.g. "ghcr.io/org/app"
+	Image string `toml:"image"`
+	// Source is the existing tag built from the release commit; defaults to "sha-{{.ShortCommit}}"
+	Source string `toml:"source"`
+	// Tags are added to the source image; defaults to "{{.Version}}", "{{.Major}}.{{.Minor}}" and "latest"
+	Tags []string `toml:"tags"`
+	// Tool is "buildx" (docker buildx imagetools create, default) or "crane"
+	Tool string `toml:"tool"`
+}
+
+// ChecklistItem is a post-release follow-up action. Items without a webhook or
+// command are manual reminders that are ticked off with their key.
+type ChecklistItem struct {
+	Title string `toml:"title"`
+	// Key is the single key that triggers (or ticks off) the item in the results view
+	Key string `toml:"key"`
+	// Webhook receives a JSON POST with a "text" field, e.g. a Slack incoming webhook
+	Webhook string `toml:"webhook"`
+	// Message is the webhook text; {{.Version}} and {{.Tag}} are expanded
+	Message string `toml:"message"`
+	// Command is run with sh -c; {{.Version}} and {{.Tag}} are expanded
+	Command string `toml:"command"`
+}
+
+// GitHubSettings configures GitHub housekeeping performed through the gh CLI
+type GitHubSettings struct {
+	Milestones  MilestoneSettings   `toml:"milestones"`
+	ReleasedPRs ReleasedPRSettings  `toml:"released_prs"`
+	PullRequest PullRequestSettings `toml:"pull_request"`
+	// LatestRelease compares the current version with the latest GitHub release and
+	// tag during validation: "off", "warn" or "block"
+	LatestRelease string `toml:"latest_release"`
+}
+
+// PullRequestSettings configures the version bump pull request of the pull-request workflow
+type PullRequestSettings struct {
+	// BranchPrefix is prepended to the version to name the release branch
+	BranchPrefix string `toml:"branch_prefix"`
+	// BodyTemplate replaces the built-in pull request body. Available fields: {{.Version}},
+	// {{.Tag}}, {{.PreviousTag}}, {{.Changelog}}, {{.CompareURL}} and {{.Checklist}}
+	BodyTemplate string `toml:"body_template"`
+}
+
+// ReleasedPRSettings configures notifying pull requests that shipped in a release
+type ReleasedPRSettings struct {
+	// Label adds a label to every pull request merged since the previous release
+	Label bool `toml:"label"`
+	// Comment posts a comment on every pull request merged since the previous release
+	Comment bool `toml:"comment"`
+	// LabelName is the label text; {{.Version}} and {{.Tag}} are expanded
+	LabelName string `toml:"label_name"`
+	// CommentBody is the comment text; {{.Version}} and {{.Tag}} are expanded
+	CommentBody string `toml:"comment_body"`
+}
+
+// MilestoneSettings configures milestone handling after a successful release
+type MilestoneSettings struct {
+	// Close closes the milestone matching the released version
+	Close bool `toml:"close"`
+	// CreateNext creates the milestone for the next version
+	CreateNext bool `toml:"create_next"`
+	// NextBump selects the next milestone version: "major", "minor" or "patch"
+	NextBump string `toml:"next_bump"`
+	// TitlePrefix is prepended to versions to form milestone titles
+	TitlePrefix string `toml:"title_prefix"`
+}
+
+// UpdateSettings configures the startup check for newer bump-tui releases
+type UpdateSettings struct {
+	// Check looks for a newer release at most once a day and shows a banner when one exists
+	Check bool `toml:"check"`
+}
+
+// DetectionSettings configures automatic version file detection when no .bump file exists
+type DetectionSettings struct {
+	// Recursive also scans subdirectories for version files, skipping files ignored by git
+	Recursive bool `toml:"recursive"`
+	// MaxDepth bounds the recursive scan; 1 means direct subdirectories of the root
+	MaxDepth int `toml:"max_depth"`
+}
+
+// CommitLintSettings configures linting commit messages since the last tag during validation
+type CommitLintSettings struct {
+	// Enabled adds the commit lint step; offending commits are listed as warnings
+	Enabled bool `toml:"enabled"`
+	// Types are the allowed conventional commit types
+	Types []string `toml:"types"`
+	// Scopes restricts scopes to this list; empty allows any scope
+	Scopes []string `toml:"scopes"`
+	// RequireScope flags commits without a scope
+	RequireScope bool `toml:"require_scope"`
+	// MaxSubjectLength flags longer subject lines; 0 disables the check
+	MaxSubjectLength int `toml:"max_subject_length"`
+}
+
+// MonorepoSettings configures releases of repositories with several packages
+type MonorepoSettings struct {
+	// ChangedOnly only bumps packages (directories of version files) with commits since
+	// their last package tag, and tags each bumped package as "<dir>/v<version>"
+	ChangedOnly bool `toml:"changed_only"`
+}
+
+// FirmwareSettings configures versioned firmware artifacts for PlatformIO projects
+type FirmwareSettings struct {
+	Enabled bool `toml:"enabled"`
+	// Header is a C header generated with the version macros and committed with the
+	// release, e.g. "include/version.h"; empty skips it
+	Header string `toml:"header"`
+	// BuildCommand builds the firmware of the release commit after the tag is pushed
+	BuildCommand string `toml:"build_command"`
+	// Artifacts are globs of the built binaries to publish
+	Artifacts []string `toml:"artifacts"`
+	// Name is the template of the versioned file name. Available fields: {{.Version}},
+	// {{.Env}} (the PlatformIO environment directory), {{.Name}} and {{.Ext}}
+	Name string `toml:"name"`
+	// OutputDir receives the renamed copies
+	OutputDir string `toml:"output_dir"`
+	// Attach uploads the renamed binaries to the GitHub release of the tag (requires gh)
+	Attach bool `toml:"attach"`
+}
+
+// RegistrySettings configures the published version check against crates.io and npm
+type RegistrySettings struct {
+	// Check warns during validation when a package's local version differs from the
+	// latest version published to its registry
+	Check bool `toml:"check"`
+}
+
+// NixSettings configures releases of Nix-packaged projects
+type NixSettings struct {
+	// UpdateHashes recomputes vendorHash and cargoHash in managed Nix files after the
+	// version bump by building the package; requires nix
+	UpdateHashes bool `toml:"update_hashes"`
+}
+
+// DebianSettings configures the optional debian/changelog entry written with each release
+type DebianSettings struct {
+	Enabled bool `toml:"enabled"`
+	// Package is the source package name; defaults to the name in the latest debian/changelog entry
+	Package string `toml:"package"`
+	// Distribution is the target suite, e.g. "unstable" or "jammy"
+	Distribution string `toml:"distribution"`
+	// Urgency is one of low, medium, high, emergency or critical
+	Urgency string `toml:"urgency"`
+	// Revision is the Debian revision appended to the version, e.g. "1" for 1.2.0-1
+	Revision string `toml:"revision"`
+	// Maintainer is "Name <email>"; defaults to git config user.name and user.email
+	Maintainer string `toml:"maintainer"`
+}
+
+// TrainSettings configures the release train cadence checked by `bump-tui train`
+type TrainSettings struct {
+	// Start is the date of the first train, e.g. "2024-01-02"; empty disables the train
+	Start string `toml:"start"`
+	// IntervalDays is the number of days between trains, e.g. 14 for every other week
+	IntervalDays int `toml:"interval_days"`
+}
+
+// TrainDateLayout is the date format of train.start
+const TrainDateLayout = "2006-01-02"
+
+// GoreleaserSettings configures the GoReleaser integration, used when a .goreleaser.yaml exists
+type GoreleaserSettings struct {
+	// Check runs `goreleaser check` during repository validation
+	Check bool `toml:"check"`
+	// Release runs `goreleaser release --clean` locally after the tag is pushed
+	Release bool `toml:"release"`
+}
+
+// DefaultSettings returns the settings used when no .bump.toml file exists
+func DefaultSettings() *Settings {
+	return &Settings{
+		Changelog: ChangelogSettings{
+			SquashPRs:  SquashPRsAuto,
+			BotAuthors: []string{"dependabot", "renovate", "github-actions"},
+			BotCommits: BotCommitsExclude,
+			Reword:     true,
+		},
+		AI: AISettings{
+			InputCostPerMTok:  3.0,
+			OutputCostPerMTok: 15.0,
+			Output:            AIOutputJSON,
+			Generators:        []string{GeneratorClaudeCLI, GeneratorRegex},
+			OpenAIModel:       "gpt-4o-mini",
+		},
+		Git: GitSettings{
+			CommitStrategy: git.StrategyNoMerges,
+		},
+		Release: ReleaseSettings{
+			Workflow: WorkflowDirect,
+			PushMode: PushSeparate,
+		},
+		GitHub: GitHubSettings{
+			Milestones: MilestoneSettings{
+				NextBump:    "minor",
+				TitlePrefix: "v",
+			},
+			ReleasedPRs: ReleasedPRSettings{
+				LabelName:   "released in {{.Tag}}",
+				CommentBody: "🚀 This change was released in {{.Tag}}.",
+			},
+			PullRequest: PullRequestSettings{
+				BranchPrefix: "release/v",
+			},
+			LatestRelease: LatestReleaseOff,
+		},
+		Updates: UpdateSettings{
+			Check: true,
+		},
+		Goreleaser: GoreleaserSettings{
+			Check: true,
+		},
+		Detection: DetectionSettings{
+			MaxDepth: 3,
+		},
+		Train: TrainSettings{
+			IntervalDays: 14,
+		},
+		CMake: CMakeSettings{
+			Mode: CMakeHeaderPlain,
+		},
+		Firmware: FirmwareSettings{
+			Header:       "include/version.h",
+			BuildCommand: "pio run",
+			Artifacts:    []string{".pio/build/*/firmware.bin"},
+			Name:         "{{.Name}}-v{{.Version}}{{.Ext}}",
+			OutputDir:    ".pio/release",
+			Attach:       true,
+		},
+		Debian: DebianSettings{
+			Distribution: "unstable",
+			Urgency:      "medium",
+			Revision:     "1",
+		},
+		CommitLint: CommitLintSettings{
+			Types:            []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"},
+			MaxSubjectLength: 72,
+		},
+		Messages: MessageSettings{
+			Language: DefaultLanguage,
+		},
+		CI: CISettings{
+			Headless: true,
+			BotName:  "github-actions[bot]",
+			BotEmail: "41898282+github-actions[bot]@users.noreply.github.com",
+			TokenEnv: "GITHUB_TOKEN",
+		},
+	}
+}
+
+// LoadSettings loads the .bump.toml settings file from the project root
+func LoadSettings(projectRoot string) (*Settings, error) {
+	settings := DefaultSettings()
+	settingsPath := filepath.Join(projectRoot, SettingsFileName)
+
+	file, err := os.Open(settingsPath)
+	if os.IsNotExist(err) {
+		return settings, nil // No settings file, use defaults
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", SettingsFileName, err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Warning: failed to close settings file: %v\n", err)
+		}
+	}()
+
+	// Reject unknown keys so typos don't silently fall back to defaults
+	if err := toml.NewDecoder(file).DisallowUnknownFields().Decode(settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SettingsFileName, err)
+	}
+
+	if err := settings.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", SettingsFileName, err)
+	}
+
+	return settings, nil
+}
+
+// Validate checks that the settings are usable
+func (s *Settings) Validate() error {
+	if s.AI.InputCostPerMTok < 0 || s.AI.OutputCostPerMTok < 0 || s.AI.MonthlyBudgetUSD < 0 {
+		return fmt.Errorf("ai: costs and budget cannot be negative")
+	}
+
+	if s.Detection.MaxDepth < 0 {
+		return fmt.Errorf("detection.max_depth cannot be negative")
+	}
+
+	if s.Train.Start != "" {
+		if _, err := time.Parse(TrainDateLayout, s.Train.Start); err != nil {
+			return fmt.Errorf("train.start must be a date like \"2024-01-02\", got %q", s.Train.Start)
+		}
+	}
+	if s.Train.IntervalDays < 1 {
+		return fmt.Errorf("train.interval_days must be at least 1")
+	}
+
+	if s.CommitLint.MaxSubjectLength < 0 {
+		return fmt.Errorf("commit_lint.max_subject_length cannot be negative")
+	}
+	if s.CommitLint.Enabled && len(s.CommitLint.Types) == 0 {
+		return fmt.Errorf("commit_lint.types cannot be empty")
+	}
+
+	if s.Firmware.Enabled {
+		if len(s.Firmware.Artifacts) == 0 || s.Firmware.OutputDir == "" {
+			return fmt.Errorf("firmware.artifacts and firmware.output_dir cannot be empty")
+		}
+		if _, err := template.New("firmware").Parse(s.Firmware.Name); err != nil {
+			return fmt.Errorf("firmware.name: %v", err)
+		}
+	}
+
+	switch s.Debian.Urgency {
+	case "low", "medium", "high", "emergency", "critical":
+	default:
+		return fmt.Errorf("debian.urgency must be \"low\", \"medium\", \"high\", \"emergency\" or \"critical\", got %q", s.Debian.Urgency)
+	}
+	if s.Debian.Distribution == "" || s.Debian.Revision == "" {
+		return fmt.Errorf("debian.distribution and debian.revision cannot be empty")
+	}
+
+	if s.Release.CountdownSeconds < 0 {
+		return fmt.Errorf("release.countdown_seconds cannot be negative")
+	}
+
+	if err := validateChecklist(s.Release.Checklist); err != nil {
+		return err
+	}
+
+	switch s.GitHub.Milestones.NextBump {
+	case "major", "minor", "patch":
+	default:
+		return fmt.Errorf("github.milestones.next_bump must be \"major\", \"minor\" or \"patch\", got %q", s.GitHub.Milestones.NextBump)
+	}
+
+	switch s.Release.Workflow {
+	case WorkflowDirect, WorkflowPullRequest:
+	default:
+		return fmt.Errorf("release.workflow must be \"direct\" or \"pull-request\", got %q", s.Release.Workflow)
+	}
+	switch s.GitHub.LatestRelease {
+	case LatestReleaseOff, LatestReleaseWarn, LatestReleaseBlock:
+	default:
+		return fmt.Errorf("github.latest_release must be \"off\", \"warn\" or \"block\", got %q", s.GitHub.LatestRelease)
+	}
+	switch s.CMake.Mode {
+	case CMakeHeaderPlain:
+	case CMakeHeaderConfigure:
+		if s.CMake.Header != "" && !strings.HasSuffix(s.CMake.Header, ".in") {
+			return fmt.Errorf("cmake.header must be a .in template in configure mode, got %q", s.CMake.Header)
+		}
+	default:
+		return fmt.Errorf("cmake.mode must be \"plain\" or \"configure\", got %q", s.CMake.Mode)
+	}
+	switch s.Release.PushMode {
+	case PushSeparate, PushAtomic:
+	default:
+		return fmt.Errorf("release.push_mode must be \"separate\" or \"atomic\", got %q", s.Release.PushMode)
+	}
+	for _, alias := range s.Release.AliasTags {
+		if alias != "major" && alias != "minor" {
+			return fmt.Errorf("release.alias_tags entries must be \"major\" or \"minor\", got %q", alias)
+		}
+	}
+	if err := validateContainers(s.Release.Containers); err != nil {
+		return err
+	}
+	if s.Release.Workflow == WorkflowPullRequest && s.Release.TagOnly {
+		return fmt.Errorf("release.tag_only cannot be used with the pull-request workflow")
+	}
+	if s.Release.Workflow == WorkflowPullRequest && s.Git.Notes {
+		return fmt.Errorf("git.notes cannot be used with the pull-request workflow; the release commit is only final once merged")
+	}
+	if s.Release.Workflow == WorkflowPullRequest && s.GitHub.PullRequest.BranchPrefix == "" {
+		return fmt.Errorf("github.pull_request.branch_prefix cannot be empty")
+	}
+
+	for _, text := range []string{s.GitHub.ReleasedPRs.LabelName, s.GitHub.ReleasedPRs.CommentBody, s.GitHub.PullRequest.BodyTemplate} {
+		if _, err := template.New("released_prs").Parse(text); err != nil {
+			return fmt.Errorf("github.released_prs: %v", err)
+		}
+	}
+
+	if _, err := template.New("notes_out").Parse(s.Changelog.NotesOut); err != nil {
+		return fmt.Errorf("changelog.notes_out: %v", err)
+	}
+	if err := validateGroups(s.Changelog.Groups); err != nil {
+		return err
+	}
+	switch s.Changelog.SquashPRs {
+	case SquashPRsAuto, SquashPRsAlways, SquashPRsNever:
+	default:
+		return fmt.Errorf("changelog.squash_prs must be \"auto\", \"always\" or \"never\", got %q", s.Changelog.SquashPRs)
+	}
+
+	switch s.Changelog.BotCommits {
+	case BotCommitsExclude, BotCommitsAggregate, BotCommitsInclude:
+	default:
+		return fmt.Errorf("changelog.bot_commits must be \"exclude\", \"aggregate\" or \"include\", got %q", s.Changelog.BotCommits)
+	}
+
+	validStrategy := false
+	for _, strategy := range git.CommitStrategies {
+		if s.Git.CommitStrategy == strategy {
+			validStrategy = true
+		}
+	}
+	if !validStrategy {
+		return fmt.Errorf("git.commit_strategy %q is not supported (use %v)", s.Git.CommitStrategy, git.CommitStrategies)
+	}
+
+	for i, trailer := range s.Git.Trailers {
+		if _, err := template.New("trailer").Parse(trailer); err != nil {
+			return fmt.Errorf("git.trailers[%d]: %v", i, err)
+		}
+	}
+
+	if (s.CI.BotName == "") != (s.CI.BotEmail == "") {
+		return fmt.Errorf("ci.bot_name and ci.bot_email must be set together")
+	}
+
+	switch s.AI.Output {
+	case AIOutputJSON, AIOutputMarkdown:
+	default:
+		return fmt.Errorf("ai.output must be \"json\" or \"markdown\", got %q", s.AI.Output)
+	}
+
+	for _, name := range s.Plugins.Enabled {
+		if name == "" || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("plugins.enabled: invalid plugin name %q", name)
+		}
+	}
+	for _, path := range s.Plugins.WASM {
+		if filepath.Ext(path) != ".wasm" {
+			return fmt.Errorf("plugins.wasm: %q is not a .wasm module", path)
+		}
+	}
+
+	if err := validateGenerators(s.AI, s.Plugins); err != nil {
+		return err
+	}
+
+	if err := s.Messages.validate(); err != nil {
+		return err
+	}
+
+	if s.AI.PromptTemplate != "" {
+		if _, err := template.New("prompt").Parse(s.AI.PromptTemplate); err != nil {
+			return fmt.Errorf("ai.prompt_template: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateGroups checks that grouping rules match something and either name a section or skip
+func validateGroups(rules []GroupRule) error {
+	for i, rule := range rules {
+		if len(rule.Types) == 0 && len(rule.Scopes) == 0 && len(rule.Paths) == 0 {
+			return fmt.Errorf("changelog.groups[%d]: set types, scopes or paths", i)
+		}
+		if (rule.Section == "") == !rule.Skip {
+			return fmt.Errorf("changelog.groups[%d]: set either section or skip = true", i)
+		}
+	}
+	return nil
+}
+
+// validateGenerators checks that the generator chain and its timeouts name known generators
+func validateGenerators(settings AISettings, plugins PluginSettings) error {
+	known := map[string]bool{GeneratorClaudeCLI: true, GeneratorOpenAI: true, GeneratorRegex: true}
+	for _, name := range plugins.Enabled {
+		known[GeneratorPluginPrefix+name] = true
+	}
+	if len(settings.Generators) == 0 {
+		return fmt.Errorf("ai.generators must list at least one generator")
+	}
+	for _, generator := range settings.Generators {
+		if !known[generator] {
+			return fmt.Errorf("ai.generators: unknown generator %q (use %q, %q, %q or \"plugin:<name>\" for an enabled plugin)",
+				generator, GeneratorClaudeCLI, GeneratorOpenAI, GeneratorRegex)
+		}
+	}
+	for generator, seconds := range settings.Timeouts {
+		if !known[generator] {
+			return fmt.Errorf("ai.timeouts: unknown generator %q", generator)
+		}
+		if seconds <= 0 {
+			return fmt.Errorf("ai.timeouts.%s must be a positive number of seconds, got %d", generator, seconds)
+		}
+	}
+	return nil
+}
+
+// validateContainers checks that container images name a repository, a known tool and valid templates
+func validateContainers(images []ContainerImage) error {
+	for i, image := range images {
+		if image.Image == "" || strings.Contains(image.Image, "@") {
+			return fmt.Errorf("release.containers[%d]: image must be a repository like \"ghcr.io/org/app\", got %q", i, image.Image)
+		}
+		if image.Tool != "" && image.Tool != "buildx" && image.Tool != "crane" {
+			return fmt.Errorf("release.containers[%d]: tool must be \"buildx\" or \"crane\", got %q", i, image.Tool)
+		}
+		for _, text := range append([]string{image.Source}, image.Tags...) {
+			if _, err := template.New("container").Parse(text); err != nil {
+				return fmt.Errorf("release.containers[%d]: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// reservedChecklistKeys are keys already bound in the results view
+var reservedChecklistKeys = map[string]bool{"q": true, "?": true, "enter": true, "esc": true}
+
+// validateChecklist checks that checklist items have a title and a unique, unreserved key
+func validateChecklist(items []ChecklistItem) error {
+	seen := make(map[string]bool)
+	for i, item := range items {
+		if item.Title == "" {
+			return fmt.Errorf("release.checklist[%d]: title is required", i)
+		}
+		if len([]rune(item.Key)) != 1 || reservedChecklistKeys[item.Key] {
+			return fmt.Errorf("release.checklist[%d]: key must be a single character other than q or ?, got %q", i, item.Key)
+		}
+		if seen[item.Key] {
+			return fmt.Errorf("release.checklist[%d]: key %q is used more than once", i, item.Key)
+		}
+		seen[item.Key] = true
+
+		if item.Webhook != "" && item.Command != "" {
+			return fmt.Errorf("release.checklist[%d]: set either webhook or command, not both", i)
+		}
+		for _, text := range []string{item.Message, item.Command} {
+			if _, err := template.New("checklist").Parse(text); err != nil {
+				return fmt.Errorf("release.checklist[%d]: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
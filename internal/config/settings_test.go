@@ -0,0 +1,119 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestValidateChecklist(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []ChecklistItem
+		expectErr bool
+	}{
+		{"empty", nil, false},
+		{"manual and automated", []ChecklistItem{
+			{Title: "Update docs site", Key: "d"},
+			{Title: "Announce in Slack", Key: "s", Webhook: "https://hooks.slack.com/services/x"},
+		}, false},
+		{"missing title", []ChecklistItem{{Key: "d"}}, true},
+		{"reserved key", []ChecklistItem{{Title: "Quit", Key: "q"}}, true},
+		{"multi-character key", []ChecklistItem{{Title: "Docs", Key: "dd"}}, true},
+		{"duplicate key", []ChecklistItem{{Title: "A", Key: "a"}, {Title: "B", Key: "a"}}, true},
+		{"webhook and command", []ChecklistItem{{Title: "A", Key: "a", Webhook: "https://x", Command: "true"}}, true},
+		{"invalid template", []ChecklistItem{{Title: "A", Key: "a", Command: "echo {{.Version"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChecklist(tt.items)
+			if tt.expectErr && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRelease(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(s *Settings)
+		expectErr bool
+	}{
+		{"defaults", func(s *Settings) {}, false},
+		{"atomic push", func(s *Settings) { s.Release.PushMode = PushAtomic }, false},
+		{"unknown push mode", func(s *Settings) { s.Release.PushMode = "parallel" }, true},
+		{"tag only", func(s *Settings) { s.Release.TagOnly = true }, false},
+		{"tag only with pull request workflow", func(s *Settings) {
+			s.Release.TagOnly = true
+			s.Release.Workflow = WorkflowPullRequest
+		}, true},
+		{"release notes", func(s *Settings) { s.Git.Notes = true }, false},
+		{"release notes with pull request workflow", func(s *Settings) {
+			s.Git.Notes = true
+			s.Release.Workflow = WorkflowPullRequest
+		}, true},
+		{"invalid trailer template", func(s *Settings) { s.Git.Trailers = []string{"Signed-off-by: {{.UserName"} }, true},
+		{"blocking latest release check", func(s *Settings) { s.GitHub.LatestRelease = LatestReleaseBlock }, false},
+		{"unknown latest release mode", func(s *Settings) { s.GitHub.LatestRelease = "strict" }, true},
+		{"group rules", func(s *Settings) {
+			s.Changelog.Groups = []GroupRule{{Scopes: []string{"api"}, Section: "API Changes"}, {Paths: []string{"docs/"}, Skip: true}}
+		}, false},
+		{"group rule without criteria", func(s *Settings) { s.Changelog.Groups = []GroupRule{{Section: "API Changes"}} }, true},
+		{"group rule with section and skip", func(s *Settings) {
+			s.Changelog.Groups = []GroupRule{{Types: []string{"ci"}, Section: "CI", Skip: true}}
+		}, true},
+		{"generator chain", func(s *Settings) {
+			s.AI.Generators = []string{GeneratorClaudeCLI, GeneratorOpenAI, GeneratorRegex}
+			s.AI.Timeouts = map[string]int{GeneratorOpenAI: 30}
+		}, false},
+		{"unknown generator", func(s *Settings) { s.AI.Generators = []string{"gemini"} }, true},
+		{"empty generator chain", func(s *Settings) { s.AI.Generators = nil }, true},
+		{"plugin generator", func(s *Settings) {
+			s.Plugins.Enabled = []string{"notes"}
+			s.AI.Generators = []string{"plugin:notes", GeneratorRegex}
+			s.AI.Timeouts = map[string]int{"plugin:notes": 10}
+		}, false},
+		{"generator of a plugin that isn't enabled", func(s *Settings) { s.AI.Generators = []string{"plugin:notes"} }, true},
+		{"plugin name with a path", func(s *Settings) { s.Plugins.Enabled = []string{"../notes"} }, true},
+		{"wasm handler", func(s *Settings) { s.Plugins.WASM = []string{"plugins/helm.wasm"} }, false},
+		{"wasm handler without .wasm", func(s *Settings) { s.Plugins.WASM = []string{"plugins/helm"} }, true},
+		{"zero timeout", func(s *Settings) { s.AI.Timeouts = map[string]int{GeneratorClaudeCLI: 0} }, true},
+		{"german messages", func(s *Settings) {
+			s.Messages.Language = "de"
+			s.Messages.Tag = "Version {{.Version}} ({{.Tag}})"
+		}, false},
+		{"unsupported language", func(s *Settings) { s.Messages.Language = "klingon" }, true},
+		{"multi-line commit message", func(s *Settings) { s.Messages.Commit = "release {{.Version}}\n\nbody" }, true},
+		{"invalid tag message template", func(s *Settings) { s.Messages.Tag = "Release {{.Version" }, true},
+		{"cmake configure template", func(s *Settings) {
+			s.CMake.Mode = CMakeHeaderConfigure
+			s.CMake.Header = "include/version.h.in"
+		}, false},
+		{"cmake configure without template", func(s *Settings) {
+			s.CMake.Mode = CMakeHeaderConfigure
+			s.CMake.Header = "include/version.h"
+		}, true},
+		{"ci bot identity from git config", func(s *Settings) {
+			s.CI.BotName = ""
+			s.CI.BotEmail = ""
+		}, false},
+		{"ci bot name without email", func(s *Settings) { s.CI.BotEmail = "" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			tt.modify(settings)
+			err := settings.Validate()
+			if tt.expectErr && err == nil {
+				t.Errorf("Expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
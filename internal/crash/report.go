@@ -0,0 +1,92 @@
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bump-tui/internal/git"
+	"bump-tui/internal/release"
+)
+
+// Report describes a panic of the TUI and the release it interrupted
+type Report struct {
+	Time time.Time
+	// Value is the value passed to panic and Stack the goroutine stack where it happened
+	Value any
+	Stack []byte
+	// State describes the pipeline when it crashed, one "name: value" per line
+	State []string
+	// Recovery tells how to bring the repository back to a known state
+	Recovery []string
+}
+
+// NewReport describes a panic, with the recovery steps for the release of version
+// (empty when no version was chosen yet)
+func NewReport(value any, stack []byte, state []string, gitManager *git.Manager, version string) *Report {
+	return &Report{
+		Time:     time.Now(),
+		Value:    value,
+		Stack:    stack,
+		State:    state,
+		Recovery: Recovery(gitManager, version),
+	}
+}
+
+// Recovery inspects the repository after a crash during the release of version and
+// tells how to bring it back to a known state
+func Recovery(gitManager *git.Manager, version string) []string {
+	if gitManager.HasSnapshot() {
+		steps := []string{
+			fmt.Sprintf("The release stopped before it was committed and tagged. The state before it is kept in %s;", git.SnapshotRef),
+			"to restore it, on the branch you released from run:",
+			fmt.Sprintf("  git reset --hard %s^1", git.SnapshotRef),
+			fmt.Sprintf("  git stash apply %s", git.SnapshotRef),
+		}
+		if version != "" {
+			steps = append(steps, fmt.Sprintf("  git tag -d v%s   (if it was created)", version))
+		}
+		return append(steps,
+			fmt.Sprintf("  git update-ref -d %s", git.SnapshotRef),
+			fmt.Sprintf("Copies of the files the release wrote are kept in .git/%s.", release.BackupDir),
+		)
+	}
+	if version != "" && gitManager.IsReleaseCommit(version) {
+		return []string{
+			fmt.Sprintf("The release commit of v%s is at HEAD. If it wasn't pushed yet, run bump-tui again to finish the release.", version),
+		}
+	}
+	return []string{"The release didn't change the repository."}
+}
+
+// Write saves the report as bump-crash-<time>.log in dir and returns its path
+func Write(dir string, report *Report) (string, error) {
+	path := filepath.Join(dir, fmt.Sprintf("bump-crash-%s.log", report.Time.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(report.String()), 0644); err != nil {
+		return "", fmt.Errorf("unable to write the crash report: %v", err)
+	}
+	return path, nil
+}
+
+// String renders the report as written to the crash log
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bump-tui crash report\n")
+	fmt.Fprintf(&b, "time: %s\n", r.Time.Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n", r.Value)
+
+	b.WriteString("\nstate:\n")
+	for _, line := range r.State {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+
+	b.WriteString("\nrecovery:\n")
+	for _, line := range r.Recovery {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+
+	fmt.Fprintf(&b, "\nstack:\n%s", r.Stack)
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package crash
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	report := &Report{
+		Time:     time.Date(2024, 3, 1, 14, 5, 9, 0, time.UTC),
+		Value:    "index out of range",
+		Stack:    []byte("goroutine 1 [running]:\nmain.main()\n"),
+		State:    []string{"view: progress", "version: 1.2.0 → 1.3.0"},
+		Recovery: []string{"The release didn't change the repository."},
+	}
+
+	path, err := Write(t.TempDir(), report)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !strings.HasSuffix(path, "bump-crash-20240301-140509.log") {
+		t.Errorf("Expected the report to be named after its time, got %s", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the report: %v", err)
+	}
+	for _, expected := range []string{
+		"panic: index out of range",
+		"state:\n  view: progress\n  version: 1.2.0 → 1.3.0\n",
+		"recovery:\n  The release didn't change the repository.\n",
+		"stack:\ngoroutine 1 [running]:",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package git
+
+// Severity is how much a validation finding matters: errors block the release,
+// warnings don't
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a validation error or warning in a form tools can act on
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Code identifies the kind of finding, e.g. "uncommitted_changes"
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Fix suggests how to resolve it, "" when there is no obvious fix
+	Fix string `json:"fix,omitempty"`
+	// Action resolves it from the validation view; nil when it has to be fixed by hand
+	Action *FixAction `json:"-"`
+}
+
+// AddError fails the result with a coded error
+func (r *ValidationResult) AddError(code, message, fix string) {
+	r.Success = false
+	r.Errors = append(r.Errors, message)
+	r.details = append(r.details, Finding{Severity: SeverityError, Code: code, Message: message, Fix: fix})
+}
+
+// AddWarning adds a coded warning to the result
+func (r *ValidationResult) AddWarning(code, message, fix string) {
+	r.Warnings = append(r.Warnings, message)
+	r.details = append(r.details, Finding{Severity: SeverityWarning, Code: code, Message: message, Fix: fix})
+}
+
+// SetAction attaches a fix action to the findings with code
+func (r *ValidationResult) SetAction(code string, action *FixAction) {
+	for i := range r.details {
+		if r.details[i].Code == code {
+			r.details[i].Action = action
+		}
+	}
+}
+
+// Findings returns the result's errors and then its warnings. Messages appended to
+// Errors or Warnings directly, rather than with AddError or AddWarning, get the
+// step's name as their code.
+func (r ValidationResult) Findings() []Finding {
+	var findings []Finding
+	for _, message := range r.Errors {
+		findings = append(findings, r.finding(SeverityError, message))
+	}
+	for _, message := range r.Warnings {
+		findings = append(findings, r.finding(SeverityWarning, message))
+	}
+	return findings
+}
+
+// finding returns the coded finding recorded for message, or an uncoded one
+func (r ValidationResult) finding(severity Severity, message string) Finding {
+	for _, detail := range r.details {
+		if detail.Severity == severity && detail.Message == message {
+			return detail
+		}
+	}
+	return Finding{Severity: severity, Code: r.Step.Name, Message: message}
+}
@@ -0,0 +1,28 @@
+package git
+
+import "testing"
+
+func TestFindings(t *testing.T) {
+	result := ValidationResult{Step: ValidationStep{Name: "branch"}, Success: true}
+	result.AddWarning("detached_head", "In detached HEAD state", "git switch <branch>")
+	result.Warnings = append(result.Warnings, "Branch status: no branch specified")
+	result.AddError("branch_failed", "Failed to get current branch", "")
+
+	if result.Success {
+		t.Errorf("Expected AddError to fail the result")
+	}
+	expected := []Finding{
+		{Severity: SeverityError, Code: "branch_failed", Message: "Failed to get current branch"},
+		{Severity: SeverityWarning, Code: "detached_head", Message: "In detached HEAD state", Fix: "git switch <branch>"},
+		{Severity: SeverityWarning, Code: "branch", Message: "Branch status: no branch specified"},
+	}
+	findings := result.Findings()
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %+v", len(expected), findings)
+	}
+	for i := range expected {
+		if findings[i] != expected[i] {
+			t.Errorf("Finding %d: expected %+v, got %+v", i, expected[i], findings[i])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FixAction resolves a validation finding when run, e.g. by pulling from the remote
+type FixAction struct {
+	// Label describes what running it does, e.g. "Pull from origin"
+	Label string
+	Run   func() error
+}
+
+// ExcludeUntrackedFiles adds every untracked file to .git/info/exclude, ignoring
+// them in this clone without changing .gitignore and dirtying the working tree
+func (g *Manager) ExcludeUntrackedFiles() error {
+	untracked, err := g.getUntrackedFiles()
+	if err != nil {
+		return err
+	}
+	if len(untracked) == 0 {
+		return nil
+	}
+
+	path, err := g.gitOutput(nil, "rev-parse", "--git-path", "info/exclude")
+	if err != nil {
+		return fmt.Errorf("unable to locate .git/info/exclude: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	// Anchor each path at the root so only that file is excluded
+	var lines []string
+	for _, name := range untracked {
+		lines = append(lines, "/"+name)
+	}
+	if _, err := fmt.Fprintf(file, "# Excluded by bump-tui\n%s\n", strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("unable to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// PullFastForward fast-forwards the current branch to its upstream
+func (g *Manager) PullFastForward() error {
+	if err := g.runGitCommand("pull", "--ff-only"); err != nil {
+		return fmt.Errorf("unable to fast-forward to the remote branch: %v", err)
+	}
+	return nil
+}
+
+// FetchTags fetches the tags from origin, e.g. a release made from another clone
+func (g *Manager) FetchTags() error {
+	if err := g.runGitCommand("fetch", "--tags", "origin"); err != nil {
+		return fmt.Errorf("unable to fetch tags from origin: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"testing"
+
+	"bump-tui/internal/gitfixture"
+)
+
+func TestExcludeUntrackedFiles(t *testing.T) {
+	repo := gitfixture.New(t)
+	repo.WriteFile("notes.txt", "draft\n")
+	repo.WriteFile("tmp/out.log", "log\n")
+	repo.Chdir()
+
+	manager := NewManager()
+	if err := manager.ExcludeUntrackedFiles(); err != nil {
+		t.Fatalf("ExcludeUntrackedFiles failed: %v", err)
+	}
+	if untracked, err := manager.getUntrackedFiles(); err != nil || len(untracked) != 0 {
+		t.Errorf("Expected no untracked files left, got %v (%v)", untracked, err)
+	}
+	if status := repo.Git("status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean working tree, got %q", status)
+	}
+}
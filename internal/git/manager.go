@@ -1,11 +1,11 @@
 package git
 
 import (
-	"bytes"
-	"context"
+	"errors"
 	"fmt"
-	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,10 +22,80 @@ const (
 	ValidationStepCount = 6
 )
 
-type Manager struct{}
+// CommitStrategy controls which commits are collected for changelog generation
+type CommitStrategy string
+
+const (
+	// StrategyNoMerges collects every non-merge commit (default)
+	StrategyNoMerges CommitStrategy = "no-merges"
+	// StrategyFirstParent follows only the first parent, so merged branches appear as their merge commit
+	StrategyFirstParent CommitStrategy = "first-parent"
+	// StrategyIncludeMerges collects all commits, including merge commits
+	StrategyIncludeMerges CommitStrategy = "include-merges"
+	// StrategyPullRequests collects only pull request merge commits on the first-parent history
+	StrategyPullRequests CommitStrategy = "pull-requests"
+)
+
+// CommitStrategies lists all supported commit strategies
+var CommitStrategies = []CommitStrategy{StrategyNoMerges, StrategyFirstParent, StrategyIncludeMerges, StrategyPullRequests}
+
+type Manager struct {
+	commitStrategy CommitStrategy
+	// noVerify skips pre-commit, commit-msg and pre-push hooks for release commits and pushes
+	noVerify bool
+	// messages are the release commit subject and tag annotation templates
+	messages ReleaseMessages
+	// runner runs every git command
+	runner GitRunner
+}
 
 func NewManager() *Manager {
-	return &Manager{}
+	return &Manager{
+		commitStrategy: StrategyNoMerges,
+		messages:       DefaultReleaseMessages,
+		runner:         ExecRunner{},
+	}
+}
+
+// SetRunner replaces how git commands are run, e.g. with a stub in tests
+func (g *Manager) SetRunner(runner GitRunner) {
+	g.runner = runner
+}
+
+// SetCommitStrategy changes how GetCommitsSince traverses history
+func (g *Manager) SetCommitStrategy(strategy CommitStrategy) {
+	if strategy == "" {
+		strategy = StrategyNoMerges
+	}
+	g.commitStrategy = strategy
+}
+
+// SetNoVerify passes --no-verify to the release commit and pushes, bypassing git hooks
+func (g *Manager) SetNoVerify(enabled bool) {
+	g.noVerify = enabled
+}
+
+// hookArgs inserts --no-verify after the git subcommand when hooks are bypassed
+func (g *Manager) hookArgs(subcommand string, args ...string) []string {
+	result := []string{subcommand}
+	if g.noVerify {
+		result = append(result, "--no-verify")
+	}
+	return append(result, args...)
+}
+
+// commitLogArgs returns the git log arguments for the configured commit strategy
+func (g *Manager) commitLogArgs() []string {
+	switch g.commitStrategy {
+	case StrategyFirstParent:
+		return []string{"log", commitLogFormat, "--name-only", "--first-parent"}
+	case StrategyIncludeMerges:
+		return []string{"log", commitLogFormat, "--name-only"}
+	case StrategyPullRequests:
+		return []string{"log", commitLogFormat, "--name-only", "--first-parent", "--merges"}
+	default:
+		return []string{"log", commitLogFormat, "--name-only", "--no-merges"}
+	}
 }
 
 // validateSubmodulePath validates that a submodule path is safe and within repository bounds
@@ -37,7 +107,7 @@ func (g *Manager) validateSubmodulePath(path string) error {
 
 	// Clean the path to normalize it and resolve any path traversal attempts
 	cleanPath := filepath.Clean(path)
-	
+
 	// Reject absolute paths (check both original and cleaned paths)
 	if filepath.IsAbs(path) || filepath.IsAbs(cleanPath) {
 		return fmt.Errorf("submodule path cannot be absolute: %s", path)
@@ -84,36 +154,53 @@ func (g *Manager) validateSubmodulePath(path string) error {
 	return nil
 }
 
-
 func (g *Manager) IsGitRepository() error {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--git-dir")
-	if err := cmd.Run(); err != nil {
+	if _, _, err := g.runner.Run(nil, "rev-parse", "--git-dir"); err != nil {
 		return fmt.Errorf("not a git repository")
 	}
 	return nil
 }
 
-func (g *Manager) CommitVersionBump(version string) error {
+// CommitVersionBump commits all changes as the release commit, appending any
+// trailers (e.g. "Signed-off-by: Name <email>") after a blank line
+func (g *Manager) CommitVersionBump(version string, trailers ...string) error {
 	// Add all changes
 	if err := g.runGitCommand("add", "."); err != nil {
 		return fmt.Errorf("unable to stage changes for commit. Ensure you have write permissions: %v", err)
 	}
 
 	// Create commit
-	message := fmt.Sprintf("chore(release): bump version to %s", version)
-	if err := g.runGitCommand("commit", "-m", message); err != nil {
+	message := WithTrailers(g.ReleaseCommitSubject(version), trailers)
+	if err := g.runGitCommand(g.hookArgs("commit", "-m", message)...); err != nil {
 		return fmt.Errorf("unable to create version bump commit. Check git configuration: %v", err)
 	}
 
 	return nil
 }
 
-func (g *Manager) CreateTag(version string) error {
+// HasStagedChanges reports whether the index differs from HEAD
+func (g *Manager) HasStagedChanges() (bool, error) {
+	stdout, _, err := g.runner.Run(nil, "diff", "--cached", "--name-only")
+	if err != nil {
+		return false, fmt.Errorf("unable to read the staged changes: %v", err)
+	}
+	return strings.TrimSpace(stdout) != "", nil
+}
+
+// Commit commits the staged changes with message. The repository's hooks run, so a
+// commit-msg hook can still reject it.
+func (g *Manager) Commit(message string) error {
+	if err := g.runGitCommand("commit", "-m", message); err != nil {
+		return fmt.Errorf("unable to commit: %v", err)
+	}
+	return nil
+}
+
+// CreateTag creates the annotated version tag at HEAD, appending any trailers to its
+// annotation after a blank line
+func (g *Manager) CreateTag(version string, trailers ...string) error {
 	tagName := fmt.Sprintf("v%s", version)
-	message := fmt.Sprintf("Release version %s", version)
+	message := WithTrailers(g.TagMessage(tagName, version), trailers)
 
 	if err := g.runGitCommand("tag", "-a", tagName, "-m", message); err != nil {
 		return fmt.Errorf("unable to create git tag %s. Tag may already exist: %v", tagName, err)
@@ -124,128 +211,472 @@ func (g *Manager) CreateTag(version string) error {
 
 func (g *Manager) PushChanges() error {
 	// Push commits first
-	if err := g.runGitCommand("push", "origin", "HEAD"); err != nil {
+	if err := g.runGitCommand(g.hookArgs("push", "origin", "HEAD")...); err != nil {
 		return fmt.Errorf("unable to push commits to remote. Check network and permissions: %v", err)
 	}
 	return nil
 }
 
+// MoveAliasTag points a floating alias tag such as v1 or v1.3 at the commit of the version tag
+func (g *Manager) MoveAliasTag(alias, version string) error {
+	if err := g.runGitCommand("tag", "-f", alias, fmt.Sprintf("v%s^{commit}", version)); err != nil {
+		return fmt.Errorf("unable to move alias tag %s: %v", alias, err)
+	}
+	return nil
+}
+
+// PushAliasTag force-pushes a single alias tag; other refs are never forced
+func (g *Manager) PushAliasTag(alias string) error {
+	refspec := fmt.Sprintf("+refs/tags/%s:refs/tags/%s", alias, alias)
+	if err := g.runGitCommand(g.hookArgs("push", "origin", refspec)...); err != nil {
+		return fmt.Errorf("unable to push alias tag %s to remote. Check network and permissions: %v", alias, err)
+	}
+	return nil
+}
+
+// PushAtomic pushes HEAD and the version tag in a single atomic push, so the
+// remote either receives both or neither
+func (g *Manager) PushAtomic(version string) error {
+	tagName := fmt.Sprintf("v%s", version)
+	if err := g.runGitCommand(g.hookArgs("push", "--atomic", "origin", "HEAD", "refs/tags/"+tagName)...); err != nil {
+		return fmt.Errorf("unable to push commits and tag %s to remote. Check network and permissions: %v", tagName, err)
+	}
+	return nil
+}
+
+// CreateNamedTag creates an annotated tag with an arbitrary name at HEAD, e.g. a
+// per-package tag such as packages/api/v1.2.0
+func (g *Manager) CreateNamedTag(name, message string) error {
+	if err := g.runGitCommand("tag", "-a", name, "-m", message); err != nil {
+		return fmt.Errorf("unable to create git tag %s. Tag may already exist: %v", name, err)
+	}
+	return nil
+}
+
+// PushTags pushes the named tags to origin
+func (g *Manager) PushTags(names ...string) error {
+	args := []string{"origin"}
+	for _, name := range names {
+		args = append(args, "refs/tags/"+name)
+	}
+	if err := g.runGitCommand(g.hookArgs("push", args...)...); err != nil {
+		return fmt.Errorf("unable to push tags %s to remote. Check network and permissions: %v", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+// PushDryRun checks that HEAD and the version tag would be accepted by the remote
+// without pushing anything
+func (g *Manager) PushDryRun(version string) error {
+	tagName := fmt.Sprintf("v%s", version)
+	if err := g.runGitCommand("push", "--dry-run", "--atomic", "origin", "HEAD", "refs/tags/"+tagName); err != nil {
+		return fmt.Errorf("remote would reject commits and tag %s: %v", tagName, err)
+	}
+	return nil
+}
+
+// DeleteTag removes a local version tag
+func (g *Manager) DeleteTag(version string) error {
+	return g.DeleteNamedTag(fmt.Sprintf("v%s", version))
+}
+
+// DeleteNamedTag deletes a local tag, e.g. a package tag of a rolled back release
+func (g *Manager) DeleteNamedTag(name string) error {
+	if err := g.runGitCommand("tag", "-d", name); err != nil {
+		return fmt.Errorf("unable to delete git tag %s: %v", name, err)
+	}
+	return nil
+}
+
+// ResetHard moves the current branch and working tree back to ref, discarding
+// tracked changes; used to roll back a release that was not pushed
+func (g *Manager) ResetHard(ref string) error {
+	if err := g.runGitCommand("reset", "--hard", ref); err != nil {
+		return fmt.Errorf("unable to reset to %s: %v", ref, err)
+	}
+	return nil
+}
+
+// PushBranchWithLease pushes a branch with --force-with-lease, replacing the remote
+// branch only if it still points where this clone last saw it. Plain --force is never used.
+func (g *Manager) PushBranchWithLease(name string) error {
+	if err := g.runGitCommand(g.hookArgs("push", "--force-with-lease", "-u", "origin", name)...); err != nil {
+		return fmt.Errorf("unable to push branch %s to remote. The remote branch changed since it was last fetched: %v", name, err)
+	}
+	return nil
+}
+
+// RebaseOnRemote fetches origin and rebases the current branch onto its remote
+// counterpart, aborting the rebase if it stops on a conflict
+func (g *Manager) RebaseOnRemote() error {
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	if err := g.runGitCommand("fetch", "origin", branch); err != nil {
+		return fmt.Errorf("unable to fetch origin/%s: %v", branch, err)
+	}
+
+	if err := g.runGitCommand("rebase", "origin/"+branch); err != nil {
+		if abortErr := g.runGitCommand("rebase", "--abort"); abortErr != nil {
+			return fmt.Errorf("unable to rebase onto origin/%s and unable to abort the rebase; run git rebase --abort: %v", branch, abortErr)
+		}
+		return fmt.Errorf("unable to rebase onto origin/%s. Rebase manually and resolve the conflicts: %v", branch, err)
+	}
+
+	return nil
+}
+
+// MoveTag points an existing local annotated version tag at HEAD, e.g. after a rebase
+func (g *Manager) MoveTag(version string, trailers ...string) error {
+	tagName := fmt.Sprintf("v%s", version)
+	return g.MoveNamedTag(tagName, WithTrailers(g.TagMessage(tagName, version), trailers))
+}
+
+// MoveNamedTag points an existing local annotated tag at HEAD. It never creates the
+// tag, so a tag that was rolled back isn't recreated at the wrong commit.
+func (g *Manager) MoveNamedTag(name, message string) error {
+	if _, err := g.ResolveRef("refs/tags/" + name); err != nil {
+		return fmt.Errorf("unable to move git tag %s: the tag doesn't exist", name)
+	}
+	if err := g.runGitCommand("tag", "-f", "-a", name, "-m", message); err != nil {
+		return fmt.Errorf("unable to move git tag %s: %v", name, err)
+	}
+	return nil
+}
+
+// IsNonFastForward reports whether err is a push rejected because the remote
+// branch has commits that are not in the local branch
+func IsNonFastForward(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	for _, marker := range []string{"non-fast-forward", "fetch first", "stale info"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateBranch creates a branch at HEAD and switches to it, keeping working tree changes
+func (g *Manager) CreateBranch(name string) error {
+	if err := g.runGitCommand("checkout", "-b", name); err != nil {
+		return fmt.Errorf("unable to create branch %s. Branch may already exist: %v", name, err)
+	}
+	return nil
+}
+
+// PushBranch pushes a branch to origin and sets it as the upstream
+func (g *Manager) PushBranch(name string) error {
+	if err := g.runGitCommand(g.hookArgs("push", "-u", "origin", name)...); err != nil {
+		return fmt.Errorf("unable to push branch %s to remote. Check network and permissions: %v", name, err)
+	}
+	return nil
+}
+
 func (g *Manager) PushTag(version string) error {
 	tagName := fmt.Sprintf("v%s", version)
 	// Push tag separately to ensure workflow triggers
-	if err := g.runGitCommand("push", "origin", tagName); err != nil {
+	if err := g.runGitCommand(g.hookArgs("push", "origin", tagName)...); err != nil {
 		return fmt.Errorf("unable to push tag %s to remote. Check network and permissions: %v", tagName, err)
 	}
 	return nil
 }
 
+// commitLogFormat starts each commit with a record separator and separates hash, author,
+// date, subject and body with unit separators. The final separator is followed by the
+// changed paths printed by --name-only.
+const commitLogFormat = "--format=%x1e%h%x1f%an%x1f%ae%x1f%aI%x1f%s%x1f%b%x1f"
+
 func (g *Manager) GetCommitsSince(fromVersion string) ([]Commit, error) {
-	var args []string
 	if fromVersion != "" {
 		tagName := fmt.Sprintf("v%s", fromVersion)
 		// First check if the tag exists
-		ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-		checkCmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", tagName)
-		if err := checkCmd.Run(); err != nil {
-			// Tag doesn't exist, get all commits instead
-			args = []string{"log", "--oneline", "--no-merges", fmt.Sprintf("-%d", MaxCommitsToAnalyze)} // Limit to last N commits
-		} else {
-			args = []string{"log", "--oneline", "--no-merges", fmt.Sprintf("%s..HEAD", tagName)}
+		if _, err := g.ResolveRef(tagName); err == nil {
+			return g.GetCommitsInRange(tagName, "HEAD")
 		}
-		cancel()
-	} else {
-		args = []string{"log", "--oneline", "--no-merges", fmt.Sprintf("-%d", MaxCommitsToAnalyze)} // Limit to last N commits
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
+	// Tag doesn't exist, get the most recent commits instead
+	return g.GetCommitsInRange("", "HEAD")
+}
 
-	cmd := exec.CommandContext(ctx, "git", args...)
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+// GetCommitsInRange returns the commits reachable from until but not from since.
+// An empty since returns the last MaxCommitsToAnalyze commits; an empty until means HEAD.
+func (g *Manager) GetCommitsInRange(since, until string) ([]Commit, error) {
+	if until == "" {
+		until = "HEAD"
+	}
 
-	if err := cmd.Run(); err != nil {
-		// If git log fails, return empty commits instead of error
-		return []Commit{}, nil
+	args := g.commitLogArgs()
+	if since != "" {
+		args = append(args, fmt.Sprintf("%s..%s", since, until))
+	} else {
+		args = append(args, fmt.Sprintf("-%d", MaxCommitsToAnalyze), until) // Limit to last N commits
 	}
 
-	var commits []Commit
-	output := strings.TrimSpace(stdout.String())
-	if output == "" {
+	stdout, _, err := g.runner.Run(nil, args...)
+	if err != nil {
+		// If git log fails, return empty commits instead of error
 		return []Commit{}, nil
 	}
 
-	lines := strings.Split(output, "\n")
+	return parseCommitLog(stdout), nil
+}
 
-	for _, line := range lines {
-		if line == "" {
+// parseCommitLog parses git log output produced with commitLogFormat
+func parseCommitLog(output string) []Commit {
+	commits := []Commit{}
+
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\r\n")
+		if strings.TrimSpace(record) == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) < 2 {
+		parts := strings.SplitN(record, "\x1f", 7)
+		if len(parts) < 5 || parts[4] == "" {
 			continue
 		}
 
-		commits = append(commits, Commit{
-			Hash:    parts[0],
-			Message: parts[1],
-		})
+		commit := Commit{
+			Hash:        parts[0],
+			AuthorName:  parts[1],
+			AuthorEmail: parts[2],
+			Message:     parts[4],
+		}
+		if date, err := time.Parse(time.RFC3339, parts[3]); err == nil {
+			commit.Date = date
+		}
+		if len(parts) >= 6 {
+			commit.Body = strings.TrimSpace(parts[5])
+		}
+		if len(parts) == 7 {
+			for _, line := range strings.Split(parts[6], "\n") {
+				if path := strings.TrimSpace(line); path != "" {
+					commit.Files = append(commit.Files, path)
+				}
+			}
+		}
+
+		commits = append(commits, normalizeMergeCommit(commit))
 	}
 
-	return commits, nil
+	return commits
 }
 
-func (g *Manager) GetCurrentBranch() (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
+// pullRequestMergeRe matches GitHub's default merge commit subject
+var pullRequestMergeRe = regexp.MustCompile(`^Merge pull request #(\d+) from \S+$`)
 
-	cmd := exec.CommandContext(ctx, "git", "branch", "--show-current")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
+// normalizeMergeCommit replaces GitHub's "Merge pull request #N from branch" subject with
+// the pull request title GitHub places in the body, so merge commits read like changes
+func normalizeMergeCommit(commit Commit) Commit {
+	matches := pullRequestMergeRe.FindStringSubmatch(commit.Message)
+	if matches == nil || commit.Body == "" {
+		return commit
+	}
 
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("unable to determine current git branch: %v", err)
+	lines := strings.SplitN(commit.Body, "\n", 2)
+	commit.Message = fmt.Sprintf("%s (#%s)", strings.TrimSpace(lines[0]), matches[1])
+	commit.Body = ""
+	if len(lines) == 2 {
+		commit.Body = strings.TrimSpace(lines[1])
+	}
+	return commit
+}
+
+// GetTagDate returns the commit date of the commit a tag points to
+func (g *Manager) GetTagDate(tagName string) (time.Time, error) {
+	stdout, _, err := g.runner.Run(nil, "log", "-1", "--format=%cI", tagName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to read date of %s: %v", tagName, err)
 	}
 
-	return strings.TrimSpace(stdout.String()), nil
+	return time.Parse(time.RFC3339, strings.TrimSpace(stdout))
 }
 
-func (g *Manager) HasUncommittedChanges() (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
+// GetPreviousTag returns the most recent tag before ref, or "" if ref has no earlier tag
+func (g *Manager) GetPreviousTag(ref string) (string, error) {
+	if _, err := g.ResolveRef(ref); err != nil {
+		return "", err
+	}
 
-	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
+	stdout, _, err := g.runner.Run(nil, "describe", "--tags", "--abbrev=0", "--match", "*.*.*", ref+"^")
+	if err != nil {
+		// No tag before ref (or ref is the root commit)
+		return "", nil
+	}
 
-	if err := cmd.Run(); err != nil {
-		return false, fmt.Errorf("unable to check repository status: %v", err)
+	return strings.TrimSpace(stdout), nil
+}
+
+// LatestTag returns the most recent tag reachable from HEAD that matches a glob such
+// as "packages/api/v*.*.*", or "" if there is none
+func (g *Manager) LatestTag(pattern string) (string, error) {
+	stdout, stderr, err := g.runner.Run(nil, "describe", "--tags", "--abbrev=0", "--match", pattern, "HEAD")
+	if err != nil {
+		if strings.Contains(stderr, "No names found") || strings.Contains(stderr, "No tags can describe") {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to find the latest tag matching %s: %v: %s", pattern, err, strings.TrimSpace(stderr))
 	}
 
-	return len(strings.TrimSpace(stdout.String())) > 0, nil
+	return strings.TrimSpace(stdout), nil
 }
 
-func (g *Manager) runGitCommand(args ...string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
+// CountCommits counts the commits after since (every commit of HEAD when since is
+// empty) that touch any of pathspecs, e.g. "." and ":(exclude)packages/api"
+func (g *Manager) CountCommits(since string, pathspecs ...string) (int, error) {
+	revision := "HEAD"
+	if since != "" {
+		revision = since + "..HEAD"
+	}
 
-	cmd := exec.CommandContext(ctx, "git", args...)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	args := append([]string{"rev-list", "--count", revision, "--"}, pathspecs...)
+	stdout, _, err := g.runner.Run(nil, args...)
+	if err != nil {
+		return 0, fmt.Errorf("unable to count commits touching %s: %v", strings.Join(pathspecs, " "), err)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("git %s failed: %v\nError: %s", strings.Join(args, " "), err, stderr.String())
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected commit count for %s: %v", strings.Join(pathspecs, " "), err)
 	}
+	return count, nil
+}
 
-	return nil
+// DiffStat is the size of the changes between two refs
+type DiffStat struct {
+	Files      int
+	Insertions int
+	Deletions  int
+}
+
+// shortStatRe matches one count of `git diff --shortstat`, e.g. "12 insertions(+)"
+var shortStatRe = regexp.MustCompile(`(\d+) (file|insertion|deletion)`)
+
+// DiffShortStat returns the files changed and lines inserted and deleted from one ref to another
+func (g *Manager) DiffShortStat(from, to string) (DiffStat, error) {
+	stdout, _, err := g.runner.Run(nil, "diff", "--shortstat", from, to)
+	if err != nil {
+		return DiffStat{}, fmt.Errorf("unable to diff %s..%s: %v", from, to, err)
+	}
+	return parseShortStat(stdout), nil
+}
+
+// CommitShortStat returns the files changed and lines inserted and deleted by one commit
+func (g *Manager) CommitShortStat(hash string) (DiffStat, error) {
+	stdout, _, err := g.runner.Run(nil, "show", "--shortstat", "--format=", hash)
+	if err != nil {
+		return DiffStat{}, fmt.Errorf("unable to read the changes of %s: %v", hash, err)
+	}
+	return parseShortStat(stdout), nil
+}
+
+// parseShortStat parses e.g. " 3 files changed, 10 insertions(+), 2 deletions(-)".
+// Counts that are zero are left out by git.
+func parseShortStat(output string) DiffStat {
+	var stat DiffStat
+	for _, match := range shortStatRe.FindAllStringSubmatch(output, -1) {
+		count, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "file":
+			stat.Files = count
+		case "insertion":
+			stat.Insertions = count
+		case "deletion":
+			stat.Deletions = count
+		}
+	}
+	return stat
+}
+
+// GetGitDir returns the absolute path of the repository's .git directory
+func (g *Manager) GetGitDir() (string, error) {
+	stdout, _, err := g.runner.Run(nil, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("unable to locate git directory: %v", err)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath
+func (g *Manager) HooksDir() (string, error) {
+	stdout, _, err := g.runner.Run(nil, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("unable to locate the hooks directory: %v", err)
+	}
+
+	return filepath.Abs(strings.TrimSpace(stdout))
+}
+
+// ResolveRef returns the full commit hash a ref points to
+func (g *Manager) ResolveRef(ref string) (string, error) {
+	stdout, _, err := g.runner.Run(nil, "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s: %v", ref, err)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+func (g *Manager) GetCurrentBranch() (string, error) {
+	stdout, _, err := g.runner.Run(nil, "branch", "--show-current")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine current git branch: %v", err)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// UserIdentity returns user.name and user.email from git config
+func (g *Manager) UserIdentity() (string, string, error) {
+	values := make([]string, 2)
+	for i, key := range []string{"user.name", "user.email"} {
+		stdout, _, err := g.runner.Run(nil, "config", "--get", key)
+		if err != nil {
+			return "", "", fmt.Errorf("git config %s is not set. Configure it to use commit trailers: %v", key, err)
+		}
+		values[i] = strings.TrimSpace(stdout)
+	}
+	return values[0], values[1], nil
+}
+
+func (g *Manager) HasUncommittedChanges() (bool, error) {
+	stdout, _, err := g.runner.Run(nil, "status", "--porcelain")
+	if err != nil {
+		return false, fmt.Errorf("unable to check repository status: %v", err)
+	}
+
+	return len(strings.TrimSpace(stdout)) > 0, nil
+}
+
+func (g *Manager) runGitCommand(args ...string) error {
+	_, err := g.gitOutput(nil, args...)
+	return err
+}
+
+// gitOutput runs git with extra environment variables and returns its trimmed output
+func (g *Manager) gitOutput(env []string, args ...string) (string, error) {
+	return runGit(g.runner, env, args...)
 }
 
 type Commit struct {
-	Hash    string `json:"hash"`
+	Hash        string `json:"hash"`
+	AuthorName  string `json:"author_name,omitempty"`
+	AuthorEmail string `json:"author_email,omitempty"`
+	// Date is the author date of the commit
+	Date time.Time `json:"date,omitempty"`
+	// Message is the subject line of the commit
 	Message string `json:"message"`
+	// Body is the rest of the commit message, including any trailers
+	Body string `json:"body,omitempty"`
+	// Files lists the paths changed by the commit, empty for merge commits
+	Files []string `json:"files,omitempty"`
 }
 
 // ValidationStep represents a step in the git validation process
@@ -262,6 +693,8 @@ type ValidationResult struct {
 	Success  bool
 	Warnings []string
 	Errors   []string
+	// details holds the codes and fixes of messages added with AddError and AddWarning
+	details []Finding
 }
 
 // ValidationSummary contains the overall validation results
@@ -272,8 +705,78 @@ type ValidationSummary struct {
 	CanProceed  bool
 }
 
-// ValidateRepositoryStatus performs comprehensive git repository validation
-func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
+// Add appends the result of an extra validation step, e.g. a project-specific check
+func (s *ValidationSummary) Add(result ValidationResult) {
+	s.Results = append(s.Results, result)
+	if !result.Success {
+		s.HasErrors = true
+		s.CanProceed = false
+	}
+	if len(result.Warnings) > 0 {
+		s.HasWarnings = true
+	}
+}
+
+// ValidationProgress is told when a validation step starts, with a nil result, and
+// again with its result when it finishes. Independent steps run in parallel, so it
+// must be safe to call from several goroutines.
+type ValidationProgress func(step ValidationStep, result *ValidationResult)
+
+// Run reports step around running check; a nil ValidationProgress just runs it
+func (p ValidationProgress) Run(step ValidationStep, check func(ValidationStep) ValidationResult) ValidationResult {
+	if p != nil {
+		p(step, nil)
+	}
+	result := check(step)
+	p.Finish(result)
+	return result
+}
+
+// Finish reports a result, e.g. of a step that was skipped without starting
+func (p ValidationProgress) Finish(result ValidationResult) {
+	if p != nil {
+		p(result.Step, &result)
+	}
+}
+
+// ValidateRepositoryStatus performs comprehensive git repository validation, reporting
+// each step to progress, which may be nil
+func (g *Manager) ValidateRepositoryStatus(progress ValidationProgress) (*ValidationSummary, error) {
+	return g.validateRepository(true, progress)
+}
+
+// ValidateLocalStatus performs the repository validation without contacting the
+// remote; ValidateRemoteStatus reports the branch's remote status separately so a
+// slow network doesn't hold up the local results
+func (g *Manager) ValidateLocalStatus(progress ValidationProgress) (*ValidationSummary, error) {
+	return g.validateRepository(false, progress)
+}
+
+// ValidateRemoteStatus fetches from origin and reports whether the current branch is
+// behind or ahead of it. Remote problems are warnings, never errors.
+func (g *Manager) ValidateRemoteStatus() ValidationResult {
+	result := ValidationResult{
+		Step:     ValidationStep{Name: "remote", Description: "Comparing with the remote branch..."},
+		Success:  true,
+		Warnings: []string{},
+		Errors:   []string{},
+	}
+
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		g.addRemoteStatusWarning(&result, err)
+		return result
+	}
+	if err := g.checkRemoteStatus(branch); err != nil {
+		g.addRemoteStatusWarning(&result, err)
+	}
+
+	return result
+}
+
+// validateRepository runs the validation steps; remote adds the fetch and
+// ahead/behind comparison to the branch step
+func (g *Manager) validateRepository(remote bool, progress ValidationProgress) (*ValidationSummary, error) {
 	steps := []ValidationStep{
 		{Name: "repository", Description: "Checking repository status...", Index: 1, Total: ValidationStepCount},
 		{Name: "working_dir", Description: "Validating working directory...", Index: 2, Total: ValidationStepCount},
@@ -297,7 +800,7 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result := g.validateRepositoryStatus(steps[0])
+		result := progress.Run(steps[0], g.validateRepositoryStatus)
 		mu.Lock()
 		results[0] = result
 		if !result.Success {
@@ -313,7 +816,7 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result := g.validateWorkingDirectory(steps[1])
+		result := progress.Run(steps[1], g.validateWorkingDirectory)
 		mu.Lock()
 		results[1] = result
 		if !result.Success {
@@ -329,7 +832,9 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		result := g.validateBranchStatus(steps[2])
+		result := progress.Run(steps[2], func(step ValidationStep) ValidationResult {
+			return g.validateBranchStatus(step, remote)
+		})
 		mu.Lock()
 		results[2] = result
 		if !result.Success {
@@ -344,7 +849,7 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 	// Wait for parallel operations to complete
 	wg.Wait()
 	close(errChan)
-	
+
 	// Check if any errors occurred in goroutines
 	for err := range errChan {
 		if err != nil {
@@ -353,7 +858,12 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 	}
 
 	// Step 4: Scan for submodules (sequential - others depend on it)
-	submodules, result := g.scanSubmodules(steps[3])
+	var submodules []Submodule
+	result := progress.Run(steps[3], func(step ValidationStep) ValidationResult {
+		var result ValidationResult
+		submodules, result = g.scanSubmodules(step)
+		return result
+	})
 	results[3] = result
 	if !result.Success {
 		hasErrors = true
@@ -364,7 +874,9 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 
 	// Step 5: Validate submodules (sequential - depends on step 4)
 	if len(submodules) > 0 {
-		result = g.validateSubmodules(steps[4], submodules)
+		result = progress.Run(steps[4], func(step ValidationStep) ValidationResult {
+			return g.validateSubmodules(step, submodules)
+		})
 		results[4] = result
 		if !result.Success {
 			hasErrors = true
@@ -380,10 +892,11 @@ func (g *Manager) ValidateRepositoryStatus() (*ValidationSummary, error) {
 			Warnings: nil,
 			Errors:   nil,
 		}
+		progress.Finish(results[4])
 	}
 
 	// Step 6: Final validation (can run independently but do it last for logical flow)
-	result = g.performFinalValidation(steps[5])
+	result = progress.Run(steps[5], g.performFinalValidation)
 	results[5] = result
 	if !result.Success {
 		hasErrors = true
@@ -411,8 +924,7 @@ func (g *Manager) validateRepositoryStatus(step ValidationStep) ValidationResult
 
 	// Check if we're in a git repository
 	if err := g.IsGitRepository(); err != nil {
-		result.Success = false
-		result.Errors = append(result.Errors, "Current directory is not a git repository. Run 'git init' or navigate to a git repository.")
+		result.AddError("not_a_repository", "Current directory is not a git repository. Run 'git init' or navigate to a git repository.", "git init")
 		return result
 	}
 
@@ -431,29 +943,28 @@ func (g *Manager) validateWorkingDirectory(step ValidationStep) ValidationResult
 	// Check for uncommitted changes
 	hasChanges, err := g.HasUncommittedChanges()
 	if err != nil {
-		result.Success = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Failed to check working directory status: %v", err))
+		result.AddError("status_failed", fmt.Sprintf("Failed to check working directory status: %v", err), "")
 		return result
 	}
 
 	if hasChanges {
-		result.Success = false
-		result.Errors = append(result.Errors, "Working directory has uncommitted changes. Commit or stash changes before proceeding.")
+		result.AddError("uncommitted_changes", "Working directory has uncommitted changes. Commit or stash changes before proceeding.", "git stash --include-untracked")
 	}
 
 	// Check for untracked files
 	untracked, err := g.getUntrackedFiles()
 	if err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check for untracked files: %v", err))
+		result.AddWarning("untracked_check_failed", fmt.Sprintf("Could not check for untracked files: %v", err), "")
 	} else if len(untracked) > 0 {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Found %d untracked files", len(untracked)))
+		result.AddWarning("untracked_files", fmt.Sprintf("Found %d untracked files", len(untracked)), "Add them to .gitignore or commit them")
+		result.SetAction("untracked_files", &FixAction{Label: "Ignore them in .git/info/exclude", Run: g.ExcludeUntrackedFiles})
 	}
 
 	return result
 }
 
-// validateBranchStatus checks the current branch status
-func (g *Manager) validateBranchStatus(step ValidationStep) ValidationResult {
+// validateBranchStatus checks the current branch status, and its remote status when remote is set
+func (g *Manager) validateBranchStatus(step ValidationStep, remote bool) ValidationResult {
 	result := ValidationResult{
 		Step:     step,
 		Success:  true,
@@ -464,18 +975,20 @@ func (g *Manager) validateBranchStatus(step ValidationStep) ValidationResult {
 	// Get current branch
 	branch, err := g.GetCurrentBranch()
 	if err != nil {
-		result.Success = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Failed to get current branch: %v", err))
+		result.AddError("branch_failed", fmt.Sprintf("Failed to get current branch: %v", err), "")
 		return result
 	}
 
 	if branch == "" {
-		result.Warnings = append(result.Warnings, "In detached HEAD state")
+		result.AddWarning("detached_head", "In detached HEAD state", "git switch <branch>")
 	}
 
 	// Check if branch is up to date with remote
+	if !remote {
+		return result
+	}
 	if err := g.checkRemoteStatus(branch); err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Branch status: %v", err))
+		g.addRemoteStatusWarning(&result, err)
 	}
 
 	return result
@@ -492,8 +1005,7 @@ func (g *Manager) scanSubmodules(step ValidationStep) ([]Submodule, ValidationRe
 
 	submodules, err := g.getSubmodules()
 	if err != nil {
-		result.Success = false
-		result.Errors = append(result.Errors, fmt.Sprintf("Failed to scan submodules: %v", err))
+		result.AddError("submodule_scan_failed", fmt.Sprintf("Failed to scan submodules: %v", err), "")
 		return nil, result
 	}
 
@@ -516,22 +1028,21 @@ func (g *Manager) validateSubmodules(step ValidationStep, submodules []Submodule
 	for _, submodule := range submodules {
 		// Validate submodule path for security
 		if err := g.validateSubmodulePath(submodule.Path); err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Insecure submodule path %s: %v", submodule.Name, err))
-			result.Success = false
+			result.AddError("insecure_submodule_path", fmt.Sprintf("Insecure submodule path %s: %v", submodule.Name, err), "")
 			continue
 		}
 
 		// Check if submodule points to a tag
 		isTag, _, err := g.isSubmodulePointingToTag(submodule.Path)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to check submodule %s: %v", submodule.Name, err))
-			result.Success = false
+			result.AddError("submodule_check_failed", fmt.Sprintf("Failed to check submodule %s: %v", submodule.Name, err), "")
 			continue
 		}
 
 		if !isTag {
 			// Only warn when submodule is NOT pointing to a tag
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Submodule '%s' is not pointing to a release tag", submodule.Name))
+			result.AddWarning("submodule_not_at_tag", fmt.Sprintf("Submodule '%s' is not pointing to a release tag", submodule.Name),
+				fmt.Sprintf("git -C %s checkout <tag>", submodule.Path))
 		} else {
 			// Success case - submodule points to a tag (no warning needed)
 			tagsFound++
@@ -539,10 +1050,10 @@ func (g *Manager) validateSubmodules(step ValidationStep, submodules []Submodule
 
 		// Check if submodule has uncommitted changes
 		if hasChanges, err := g.submoduleHasChanges(submodule.Path); err != nil {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check submodule %s status: %v", submodule.Name, err))
+			result.AddWarning("submodule_status_failed", fmt.Sprintf("Could not check submodule %s status: %v", submodule.Name, err), "")
 		} else if hasChanges {
-			result.Errors = append(result.Errors, fmt.Sprintf("Submodule '%s' has uncommitted changes", submodule.Name))
-			result.Success = false
+			result.AddError("submodule_uncommitted_changes", fmt.Sprintf("Submodule '%s' has uncommitted changes", submodule.Name),
+				fmt.Sprintf("git -C %s stash", submodule.Path))
 		}
 	}
 
@@ -563,7 +1074,7 @@ func (g *Manager) performFinalValidation(step ValidationStep) ValidationResult {
 
 	// Check git connectivity
 	if err := g.checkGitConnectivity(); err != nil {
-		result.Warnings = append(result.Warnings, fmt.Sprintf("Git connectivity check: %v", err))
+		result.AddWarning("no_remotes", fmt.Sprintf("Git connectivity check: %v", err), "git remote add origin <url>")
 	}
 
 	return result
@@ -581,18 +1092,12 @@ type Submodule struct {
 
 // getUntrackedFiles returns a list of untracked files
 func (g *Manager) getUntrackedFiles() ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "git", "ls-files", "--others", "--exclude-standard")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
+	stdout, _, err := g.runner.Run(nil, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
 		return nil, fmt.Errorf("failed to get untracked files: %v", err)
 	}
 
-	output := strings.TrimSpace(stdout.String())
+	output := strings.TrimSpace(stdout)
 	if output == "" {
 		return []string{}, nil
 	}
@@ -600,6 +1105,36 @@ func (g *Manager) getUntrackedFiles() ([]string, error) {
 	return strings.Split(output, "\n"), nil
 }
 
+// remoteStatusError is a problem found comparing the branch with its remote, with
+// the code and fix of its finding
+type remoteStatusError struct {
+	code    string
+	fix     string
+	message string
+}
+
+func (e *remoteStatusError) Error() string {
+	return e.message
+}
+
+// addRemoteStatusWarning adds a branch status problem as a warning, with a fix
+// action for a branch behind or diverged from its remote
+func (g *Manager) addRemoteStatusWarning(result *ValidationResult, err error) {
+	code, fix := "remote_status", ""
+	var statusErr *remoteStatusError
+	if errors.As(err, &statusErr) {
+		code, fix = statusErr.code, statusErr.fix
+	}
+	result.AddWarning(code, fmt.Sprintf("Branch status: %v", err), fix)
+
+	switch code {
+	case "behind_remote":
+		result.SetAction(code, &FixAction{Label: "Fast-forward to origin", Run: g.PullFastForward})
+	case "diverged_from_remote":
+		result.SetAction(code, &FixAction{Label: "Rebase onto origin", Run: g.RebaseOnRemote})
+	}
+}
+
 // checkRemoteStatus checks if the current branch is up to date with remote
 func (g *Manager) checkRemoteStatus(branch string) error {
 	if branch == "" {
@@ -607,61 +1142,47 @@ func (g *Manager) checkRemoteStatus(branch string) error {
 	}
 
 	// Check if remote exists
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
-	if err := cmd.Run(); err != nil {
-		cancel()
-		return fmt.Errorf("no remote origin configured")
+	if _, _, err := g.runner.Run(nil, "remote", "get-url", "origin"); err != nil {
+		return &remoteStatusError{code: "no_remote", fix: "git remote add origin <url>", message: "no remote origin configured"}
 	}
-	cancel()
 
 	// Fetch to get latest remote refs (but don't show output)
-	ctx, cancel = context.WithTimeout(context.Background(), GitCommandTimeout)
-	cmd = exec.CommandContext(ctx, "git", "fetch", "--dry-run")
-	var fetchErr bytes.Buffer
-	cmd.Stderr = &fetchErr
-	fetchResult := cmd.Run()
-	cancel()
+	_, fetchErr, fetchResult := g.runner.Run(nil, "fetch", "--dry-run")
 
 	// Analyze fetch errors for specific issues
 	if fetchResult != nil {
-		fetchErrMsg := strings.TrimSpace(fetchErr.String())
-		
+		fetchErrMsg := strings.TrimSpace(fetchErr)
+
 		// Classify error type based on error message patterns
 		if fetchErrMsg != "" {
 			errLower := strings.ToLower(fetchErrMsg)
 			switch {
-			case strings.Contains(errLower, "authentication failed") || 
-				 strings.Contains(errLower, "permission denied") ||
-				 strings.Contains(errLower, "access denied"):
-				return fmt.Errorf("authentication failed - check your credentials: %v", fetchErrMsg)
-			case strings.Contains(errLower, "network") || 
-				 strings.Contains(errLower, "connection") ||
-				 strings.Contains(errLower, "timeout") ||
-				 strings.Contains(errLower, "unreachable"):
-				return fmt.Errorf("network connectivity issue - check internet connection: %v", fetchErrMsg)
+			case strings.Contains(errLower, "authentication failed") ||
+				strings.Contains(errLower, "permission denied") ||
+				strings.Contains(errLower, "access denied"):
+				return &remoteStatusError{code: "remote_auth_failed", message: fmt.Sprintf("authentication failed - check your credentials: %v", fetchErrMsg)}
+			case strings.Contains(errLower, "network") ||
+				strings.Contains(errLower, "connection") ||
+				strings.Contains(errLower, "timeout") ||
+				strings.Contains(errLower, "unreachable"):
+				return &remoteStatusError{code: "remote_unreachable", message: fmt.Sprintf("network connectivity issue - check internet connection: %v", fetchErrMsg)}
 			case strings.Contains(errLower, "repository not found") ||
-				 strings.Contains(errLower, "does not exist"):
-				return fmt.Errorf("remote repository not found - check remote URL: %v", fetchErrMsg)
+				strings.Contains(errLower, "does not exist"):
+				return &remoteStatusError{code: "remote_not_found", fix: "git remote set-url origin <url>", message: fmt.Sprintf("remote repository not found - check remote URL: %v", fetchErrMsg)}
 			default:
-				return fmt.Errorf("remote connectivity issue: %v", fetchErrMsg)
+				return &remoteStatusError{code: "remote_unreachable", message: fmt.Sprintf("remote connectivity issue: %v", fetchErrMsg)}
 			}
 		}
-		return fmt.Errorf("unable to fetch from remote - check network connection and credentials")
+		return &remoteStatusError{code: "remote_unreachable", message: "unable to fetch from remote - check network connection and credentials"}
 	}
 
 	// Check ahead/behind status
-	ctx, cancel = context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-	cmd = exec.CommandContext(ctx, "git", "rev-list", "--count", "--left-right", fmt.Sprintf("origin/%s...HEAD", branch))
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("cannot compare with remote branch")
+	stdout, _, err := g.runner.Run(nil, "rev-list", "--count", "--left-right", fmt.Sprintf("origin/%s...HEAD", branch))
+	if err != nil {
+		return &remoteStatusError{code: "no_upstream", fix: fmt.Sprintf("git push -u origin %s", branch), message: "cannot compare with remote branch"}
 	}
 
-	output := strings.TrimSpace(stdout.String())
+	output := strings.TrimSpace(stdout)
 	parts := strings.Fields(output)
 	if len(parts) != 2 {
 		return nil
@@ -669,11 +1190,14 @@ func (g *Manager) checkRemoteStatus(branch string) error {
 
 	behind, ahead := parts[0], parts[1]
 	if behind != "0" && ahead != "0" {
-		return fmt.Errorf("branch is %s commits behind and %s commits ahead of origin", behind, ahead)
+		return &remoteStatusError{code: "diverged_from_remote", fix: "git pull --rebase",
+			message: fmt.Sprintf("branch is %s commits behind and %s commits ahead of origin", behind, ahead)}
 	} else if behind != "0" {
-		return fmt.Errorf("branch is %s commits behind origin", behind)
+		return &remoteStatusError{code: "behind_remote", fix: "git pull --ff-only",
+			message: fmt.Sprintf("branch is %s commits behind origin", behind)}
 	} else if ahead != "0" {
-		return fmt.Errorf("branch is %s commits ahead of origin", ahead)
+		return &remoteStatusError{code: "ahead_of_remote", fix: "git push",
+			message: fmt.Sprintf("branch is %s commits ahead of origin", ahead)}
 	}
 
 	return nil
@@ -682,30 +1206,18 @@ func (g *Manager) checkRemoteStatus(branch string) error {
 // getSubmodules returns a list of git submodules
 func (g *Manager) getSubmodules() ([]Submodule, error) {
 	// First check if .gitmodules exists
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	cmd := exec.CommandContext(ctx, "git", "ls-files", ".gitmodules")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil || strings.TrimSpace(stdout.String()) == "" {
-		cancel()
+	if stdout, _, err := g.runner.Run(nil, "ls-files", ".gitmodules"); err != nil || strings.TrimSpace(stdout) == "" {
 		// No .gitmodules file, so no submodules
 		return []Submodule{}, nil
 	}
-	cancel()
 
 	// Get submodule status
-	ctx, cancel = context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-	cmd = exec.CommandContext(ctx, "git", "submodule", "status")
-	stdout.Reset()
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
+	stdout, _, err := g.runner.Run(nil, "submodule", "status")
+	if err != nil {
 		return []Submodule{}, fmt.Errorf("failed to get submodule status: %v", err)
 	}
 
-	output := strings.TrimSpace(stdout.String())
+	output := strings.TrimSpace(stdout)
 	if output == "" {
 		return []Submodule{}, nil
 	}
@@ -744,26 +1256,21 @@ func (g *Manager) parseSubmoduleStatusLine(line string) (Submodule, error) {
 		return Submodule{}, fmt.Errorf("line too short: %s", line)
 	}
 
-	var statusChar byte
-	var commit, path string
-
-	// Check if line starts with a commit hash (no status character prefix)
-	if len(line) >= CommitHashLength && isHexString(line[:CommitHashLength]) {
-		// No status character - this is the commit hash directly
-		statusChar = ' ' // Assume current status
-		parts := strings.Fields(line)
-		if len(parts) >= 2 {
-			commit = parts[0]
-			path = parts[1]
-		}
-	} else {
-		// Has status character prefix
+	// Lines start with a status character unless the output was trimmed
+	statusChar := byte(' ')
+	rest := line
+	if !isHexString(line[:CommitHashLength]) {
 		statusChar = line[0]
-		rest := line[1:]
-		parts := strings.Fields(rest)
-		if len(parts) >= 2 {
-			commit = parts[0]
-			path = parts[1]
+		rest = line[1:]
+	}
+
+	// Paths may contain spaces, so the path is everything after the commit
+	commit, path, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	path = strings.TrimSpace(path)
+	// Initialized submodules end with "(describe)", e.g. "(v1.0.0)"
+	if statusChar != '-' && strings.HasSuffix(path, ")") {
+		if idx := strings.LastIndex(path, " ("); idx >= 0 {
+			path = strings.TrimSpace(path[:idx])
 		}
 	}
 
@@ -782,9 +1289,6 @@ func (g *Manager) parseSubmoduleStatusLine(line string) (Submodule, error) {
 		name = path[idx+1:]
 	}
 
-	// Note: statusChar can be used for additional validation if needed
-	_ = statusChar
-
 	return Submodule{
 		Name:   name,
 		Path:   path,
@@ -797,46 +1301,27 @@ func (g *Manager) parseSubmoduleStatusLine(line string) (Submodule, error) {
 func (g *Manager) isSubmodulePointingToTag(submodulePath string) (bool, string, error) {
 	// Check if the submodule directory exists and is initialized
 	// Modern git uses .git files that point to the actual git directory
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	cmd := exec.CommandContext(ctx, "git", "-C", submodulePath, "rev-parse", "--git-dir")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		cancel()
-		return false, "", fmt.Errorf("submodule %s is not initialized: %v (stderr: %s)", submodulePath, err, stderr.String())
+	if _, stderr, err := g.runner.Run(nil, "-C", submodulePath, "rev-parse", "--git-dir"); err != nil {
+		return false, "", fmt.Errorf("submodule %s is not initialized: %v (stderr: %s)", submodulePath, err, stderr)
 	}
-	cancel()
 
 	// Get the commit hash that the submodule is currently pointing to
 	// Use git rev-parse HEAD in the submodule directory
-	ctx, cancel = context.WithTimeout(context.Background(), GitCommandTimeout)
-	cmd = exec.CommandContext(ctx, "git", "-C", submodulePath, "rev-parse", "HEAD")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-	stderr.Reset()
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		cancel()
-		return false, "", fmt.Errorf("failed to get submodule HEAD commit for %s: %v (stderr: %s)", submodulePath, err, stderr.String())
+	stdout, stderr, err := g.runner.Run(nil, "-C", submodulePath, "rev-parse", "HEAD")
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get submodule HEAD commit for %s: %v (stderr: %s)", submodulePath, err, stderr)
 	}
-	cancel()
 
-	currentCommit := strings.TrimSpace(stdout.String())
+	currentCommit := strings.TrimSpace(stdout)
 
 	// Check if this commit corresponds to any tags in the submodule
-	ctx, cancel = context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-	cmd = exec.CommandContext(ctx, "git", "-C", submodulePath, "tag", "--points-at", currentCommit)
-	stdout.Reset()
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
+	stdout, _, err = g.runner.Run(nil, "-C", submodulePath, "tag", "--points-at", currentCommit)
+	if err != nil {
 		// If tag command fails, assume no tags point to this commit
 		return false, "", nil
 	}
 
-	tagOutput := strings.TrimSpace(stdout.String())
+	tagOutput := strings.TrimSpace(stdout)
 
 	if tagOutput == "" {
 		return false, "", nil
@@ -849,27 +1334,17 @@ func (g *Manager) isSubmodulePointingToTag(submodulePath string) (bool, string,
 
 // submoduleHasChanges checks if a submodule has uncommitted changes
 func (g *Manager) submoduleHasChanges(submodulePath string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "git", "-C", submodulePath, "status", "--porcelain")
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
+	stdout, _, err := g.runner.Run(nil, "-C", submodulePath, "status", "--porcelain")
+	if err != nil {
 		return false, fmt.Errorf("failed to check submodule status: %v", err)
 	}
 
-	return len(strings.TrimSpace(stdout.String())) > 0, nil
+	return len(strings.TrimSpace(stdout)) > 0, nil
 }
 
 // checkGitConnectivity checks basic git connectivity
 func (g *Manager) checkGitConnectivity() error {
-	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "git", "remote", "-v")
-	if err := cmd.Run(); err != nil {
+	if _, _, err := g.runner.Run(nil, "remote", "-v"); err != nil {
 		return fmt.Errorf("no git remotes configured")
 	}
 	return nil
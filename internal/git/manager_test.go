@@ -1,11 +1,15 @@
 package git
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"bump-tui/internal/gitfixture"
 )
 
 func TestValidateRepositoryStatus(t *testing.T) {
@@ -119,7 +123,7 @@ func TestValidateRepositoryStatus(t *testing.T) {
 
 			// Run validation
 			manager := NewManager()
-			summary, err := manager.ValidateRepositoryStatus()
+			summary, err := manager.ValidateRepositoryStatus(nil)
 
 			// Check basic expectations
 			if tt.expectError && err != nil {
@@ -167,82 +171,100 @@ func TestValidateRepositoryStatus(t *testing.T) {
 
 func TestGetSubmodules(t *testing.T) {
 	tests := []struct {
-		name            string
-		submoduleOutput string
-		expectedCount   int
-		expectedNames   []string
+		name          string
+		submodules    []string
+		expectedNames []string
 	}{
 		{
-			name:            "no submodules",
-			submoduleOutput: "",
-			expectedCount:   0,
-			expectedNames:   []string{},
+			name:          "no submodules",
+			expectedNames: []string{},
 		},
 		{
-			name:            "single submodule with status char",
-			submoduleOutput: " 1234567890abcdef1234567890abcdef12345678 path/to/submodule (v1.0.0)",
-			expectedCount:   1,
-			expectedNames:   []string{"submodule"},
+			name:          "single submodule",
+			submodules:    []string{"path/to/submodule"},
+			expectedNames: []string{"submodule"},
 		},
 		{
-			name:            "single submodule without status char",
-			submoduleOutput: "1234567890abcdef1234567890abcdef12345678 path/to/submodule",
-			expectedCount:   1,
-			expectedNames:   []string{"submodule"},
-		},
-		{
-			name: "multiple submodules mixed format",
-			submoduleOutput: ` 1234567890abcdef1234567890abcdef12345678 libs/first-lib (v1.0.0)
-+abcdef1234567890abcdef1234567890abcdef12 libs/second-lib (v2.0.0-1-gabcdef1)
- fedcba0987654321fedcba0987654321fedcba09 third-lib`,
-			expectedCount: 3,
+			name:          "multiple submodules",
+			submodules:    []string{"libs/first-lib", "libs/second-lib", "third-lib"},
 			expectedNames: []string{"first-lib", "second-lib", "third-lib"},
 		},
-		{
-			name:            "malformed line - too short",
-			submoduleOutput: "short",
-			expectedCount:   0,
-			expectedNames:   []string{},
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a temporary directory with fake git setup
-			repoDir := createTempDir(t)
-			defer func() {
-				if err := os.RemoveAll(repoDir); err != nil {
-					t.Logf("Warning: failed to remove temp dir: %v", err)
-				}
-			}()
+			repo := gitfixture.New(t)
+			repo.WriteFile("README.md", "# app\n")
+			repo.Commit("initial commit")
 
-			originalDir, err := os.Getwd()
+			lib := gitfixture.New(t)
+			lib.WriteFile("lib.go", "package lib\n")
+			lib.Commit("initial commit")
+			lib.Tag("1.0.0")
+			for _, path := range tt.submodules {
+				repo.AddSubmodule(path, lib)
+			}
+			repo.Chdir()
+
+			submodules, err := NewManager().getSubmodules()
 			if err != nil {
-				t.Fatalf("Failed to get current directory: %v", err)
+				t.Fatalf("getSubmodules failed: %v", err)
 			}
-			defer func() {
-				if err := os.Chdir(originalDir); err != nil {
-					t.Logf("Warning: failed to change back to original dir: %v", err)
+			if len(submodules) != len(tt.expectedNames) {
+				t.Fatalf("Expected %d submodules, got %+v", len(tt.expectedNames), submodules)
+			}
+			head := lib.Git("rev-parse", "HEAD")
+			for i, submodule := range submodules {
+				if submodule.Name != tt.expectedNames[i] {
+					t.Errorf("Expected submodule %q, got %q", tt.expectedNames[i], submodule.Name)
+				}
+				if submodule.Path != tt.submodules[i] {
+					t.Errorf("Expected path %q, got %q", tt.submodules[i], submodule.Path)
+				}
+				if submodule.Commit != head {
+					t.Errorf("Expected commit %s, got %s", head, submodule.Commit)
 				}
-			}()
-
-			if err := os.Chdir(repoDir); err != nil {
-				t.Fatalf("Failed to change to repo directory: %v", err)
 			}
+		})
+	}
+}
 
-			// Initialize git repo
-			runGitCommand(t, repoDir, "init")
+func TestValidateSubmodules(t *testing.T) {
+	lib := gitfixture.New(t)
+	lib.WriteFile("lib.go", "package lib\n")
+	lib.Commit("initial commit")
+	lib.Tag("1.0.0")
 
-			// Create .gitmodules file if we expect submodules
-			if tt.expectedCount > 0 {
-				writeFile(t, filepath.Join(repoDir, ".gitmodules"), "[submodule \"test\"]\n")
-				runGitCommand(t, repoDir, "add", ".gitmodules")
-			}
+	repo := gitfixture.New(t)
+	repo.WriteFile("README.md", "# app\n")
+	repo.Commit("initial commit")
+	repo.AddSubmodule("libs/lib", lib)
+	repo.Chdir()
 
-			// We can't easily mock git submodule status, so we'll test the parsing logic directly
-			// This test would need to be enhanced with a proper git command mocker
-			t.Skip("Skipping integration test - would need git command mocking")
-		})
+	manager := NewManager()
+	step := ValidationStep{Name: "Submodule Validation"}
+	submodules, err := manager.getSubmodules()
+	if err != nil {
+		t.Fatalf("getSubmodules failed: %v", err)
+	}
+
+	result := manager.validateSubmodules(step, submodules)
+	if !result.Success || len(result.Warnings) != 0 {
+		t.Errorf("Expected a clean submodule at a tag to pass, got %+v", result)
+	}
+
+	// A commit past the tag is a warning, uncommitted changes block the release
+	submodule := filepath.Join(repo.Dir, "libs", "lib")
+	runGitCommand(t, submodule, "-c", "user.email=test@example.com", "-c", "user.name=Test User",
+		"commit", "--allow-empty", "-m", "wip")
+	writeFile(t, filepath.Join(submodule, "lib.go"), "package lib // changed\n")
+
+	result = manager.validateSubmodules(step, submodules)
+	if result.Success {
+		t.Errorf("Expected uncommitted submodule changes to fail validation, got %+v", result)
+	}
+	if len(result.Warnings) == 0 {
+		t.Errorf("Expected a warning for a submodule not at a tag, got %+v", result)
 	}
 }
 
@@ -273,6 +295,158 @@ func TestIsHexString(t *testing.T) {
 	}
 }
 
+func TestIsNonFastForward(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"fetch first", errors.New("! [rejected]        HEAD -> main (fetch first)"), true},
+		{"non-fast-forward", errors.New("! [rejected]        HEAD -> main (non-fast-forward)"), true},
+		{"stale lease", errors.New("! [rejected]        release/v1.2.0 (stale info)"), true},
+		{"tag exists", errors.New("! [rejected]        v1.2.0 -> v1.2.0 (already exists)"), false},
+		{"auth", errors.New("fatal: Authentication failed"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsNonFastForward(tt.err); result != tt.expected {
+				t.Errorf("IsNonFastForward(%v) = %v, want %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMoveTag(t *testing.T) {
+	repo := gitfixture.New(t)
+	repo.WriteFile("README.md", "# app\n")
+	repo.Commit("initial commit")
+	repo.Tag("1.0.0")
+	repo.Commit("chore(release): bump version to 1.1.0")
+	repo.Chdir()
+	manager := NewManager()
+
+	if err := manager.MoveTag("1.1.0"); err == nil {
+		t.Errorf("Expected moving a missing tag to fail")
+	}
+	if tags := repo.Git("tag", "--list"); tags != "v1.0.0" {
+		t.Errorf("Expected the missing tag not to be created, got %q", tags)
+	}
+
+	if err := manager.MoveTag("1.0.0"); err != nil {
+		t.Fatalf("MoveTag failed: %v", err)
+	}
+	if repo.Git("rev-parse", "v1.0.0^{commit}") != repo.Git("rev-parse", "HEAD") {
+		t.Errorf("Expected v1.0.0 to be moved to HEAD")
+	}
+}
+
+func TestValidationSummaryAdd(t *testing.T) {
+	summary := &ValidationSummary{CanProceed: true}
+
+	summary.Add(ValidationResult{Success: true, Warnings: []string{"tool not installed"}})
+	if !summary.CanProceed || !summary.HasWarnings {
+		t.Errorf("Expected warnings without blocking, got %+v", summary)
+	}
+
+	summary.Add(ValidationResult{Success: false, Errors: []string{"invalid config"}})
+	if summary.CanProceed || !summary.HasErrors {
+		t.Errorf("Expected a failed step to block, got %+v", summary)
+	}
+	if len(summary.Results) != 2 {
+		t.Errorf("Expected 2 results, got %d", len(summary.Results))
+	}
+}
+
+func TestHookArgs(t *testing.T) {
+	g := NewManager()
+	if got := strings.Join(g.hookArgs("push", "origin", "HEAD"), " "); got != "push origin HEAD" {
+		t.Errorf("Expected hooks to run by default, got %q", got)
+	}
+
+	g.SetNoVerify(true)
+	if got := strings.Join(g.hookArgs("commit", "-m", "msg"), " "); got != "commit --no-verify -m msg" {
+		t.Errorf("Expected --no-verify after the subcommand, got %q", got)
+	}
+}
+
+func TestReleaseMessages(t *testing.T) {
+	g := NewManager()
+	if got := g.ReleaseCommitSubject("1.2.0"); got != "chore(release): bump version to 1.2.0" {
+		t.Errorf("Expected the default commit subject, got %q", got)
+	}
+	if got := g.TagMessage("v1.2.0", "1.2.0"); got != "Release version 1.2.0" {
+		t.Errorf("Expected the default tag message, got %q", got)
+	}
+
+	g.SetReleaseMessages(ReleaseMessages{Tag: "Version {{.Version}} ({{.Tag}})"})
+	if got := g.ReleaseCommitSubject("1.2.0"); got != "chore(release): bump version to 1.2.0" {
+		t.Errorf("Expected an empty commit template to keep the default, got %q", got)
+	}
+	if got := g.TagMessage("packages/api/v1.2.0", "1.2.0"); got != "Version 1.2.0 (packages/api/v1.2.0)" {
+		t.Errorf("Expected the configured tag message, got %q", got)
+	}
+
+	g.SetReleaseMessages(ReleaseMessages{Commit: "{{.Missing}}"})
+	if got := g.ReleaseCommitSubject("1.2.0"); got != "chore(release): bump version to 1.2.0" {
+		t.Errorf("Expected a template that can't be rendered to fall back to the default, got %q", got)
+	}
+}
+
+func TestTagTrailers(t *testing.T) {
+	repoDir := createTempDir(t)
+	defer func() {
+		if err := os.RemoveAll(repoDir); err != nil {
+			t.Logf("Warning: failed to remove temp dir: %v", err)
+		}
+	}()
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo directory: %v", err)
+	}
+
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "initial commit", "-m", "Signed-off-by: Test User <test@example.com>")
+	runGitCommand(t, repoDir, "tag", "v0.9.0")
+
+	g := NewManager()
+	if err := g.CreateTag("1.0.0", "Bump-Type: major", "Bump-Previous-Version: 0.9.0"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+
+	trailers, err := g.TagTrailers("v1.0.0")
+	if err != nil {
+		t.Fatalf("TagTrailers failed: %v", err)
+	}
+	if len(trailers) != 2 || trailers["Bump-Type"] != "major" || trailers["Bump-Previous-Version"] != "0.9.0" {
+		t.Errorf("Expected the annotation trailers, got %v", trailers)
+	}
+
+	if trailers, err := g.TagTrailers("v0.9.0"); err != nil || len(trailers) != 0 {
+		t.Errorf("Expected no trailers for a lightweight tag, got %v (%v)", trailers, err)
+	}
+	if _, err := g.TagTrailers("v2.0.0"); err == nil {
+		t.Errorf("Expected an error for a missing tag")
+	}
+
+	// Commit trailers are read through the tag, which has its own
+	if trailers, err := g.CommitTrailers("v1.0.0"); err != nil || len(trailers) != 1 || trailers["Signed-off-by"] != "Test User <test@example.com>" {
+		t.Errorf("Expected the commit's trailers, got %v (%v)", trailers, err)
+	}
+}
+
 func TestHasUncommittedChanges(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -468,6 +642,22 @@ func TestParseSubmoduleStatusLine(t *testing.T) {
 			expectedCommit: "fedcba0987654321fedcba0987654321fedcba09",
 			expectError:    false,
 		},
+		{
+			name:           "path with spaces",
+			line:           " fedcba0987654321fedcba0987654321fedcba09 vendor/my lib (v1.0.0)",
+			expectedName:   "my lib",
+			expectedPath:   "vendor/my lib",
+			expectedCommit: "fedcba0987654321fedcba0987654321fedcba09",
+			expectError:    false,
+		},
+		{
+			name:           "uninitialized path with parentheses",
+			line:           "-fedcba0987654321fedcba0987654321fedcba09 lib (old)",
+			expectedName:   "lib (old)",
+			expectedPath:   "lib (old)",
+			expectedCommit: "fedcba0987654321fedcba0987654321fedcba09",
+			expectError:    false,
+		},
 		{
 			name:        "line too short",
 			line:        "short",
@@ -522,6 +712,144 @@ func TestParseSubmoduleStatusLine(t *testing.T) {
 	}
 }
 
+func FuzzParseSubmoduleStatusLine(f *testing.F) {
+	for _, seed := range []string{
+		" 1234567890abcdef1234567890abcdef12345678 path/to/submodule (v1.0.0)",
+		"1234567890abcdef1234567890abcdef12345678 path/to/submodule",
+		"+abcdef1234567890abcdef1234567890abcdef12 libs/second-lib (v2.0.0-1-gabcdef1)",
+		"-fedcba0987654321fedcba0987654321fedcba09 vendor/my lib",
+		"U1234567890abcdef1234567890abcdef12345678  ( )",
+	} {
+		f.Add(seed)
+	}
+
+	const commit = "1234567890abcdef1234567890abcdef12345678"
+	manager := NewManager()
+	f.Fuzz(func(t *testing.T, line string) {
+		submodule, err := manager.parseSubmoduleStatusLine(line)
+		if err == nil {
+			if len(submodule.Commit) != CommitHashLength || !isHexString(submodule.Commit) {
+				t.Errorf("Expected a full commit hash, got %q", submodule.Commit)
+			}
+			if submodule.Path == "" || !strings.Contains(line, submodule.Path) {
+				t.Errorf("Expected a path from %q, got %q", line, submodule.Path)
+			}
+		}
+
+		// Any trimmed path survives a round trip through the status format
+		path := line
+		if path == "" || path != strings.TrimSpace(path) {
+			return
+		}
+		for _, status := range []string{" ", "+", "-"} {
+			formatted := status + commit + " " + path
+			if status != "-" {
+				formatted += " (v1.0.0)"
+			}
+			submodule, err := manager.parseSubmoduleStatusLine(formatted)
+			if err != nil {
+				t.Fatalf("Expected %q to parse, got %v", formatted, err)
+			}
+			if submodule.Path != path || submodule.Commit != commit {
+				t.Errorf("Expected %q at %s, got %q at %s", path, commit, submodule.Path, submodule.Commit)
+			}
+		}
+	})
+}
+
+func TestParseCommitLog(t *testing.T) {
+	output := "\x1eabc1234\x1fJane Doe\x1fjane@example.com\x1f2024-03-01T10:00:00+01:00\x1ffeat(api)!: remove v1 endpoints\x1fBREAKING CHANGE: use /v2 instead\n\x1f\n\napi/v1.go\napi/v2.go\n" +
+		"\x1edef5678\x1fdependabot[bot]\x1f49699333+dependabot[bot]@users.noreply.github.com\x1f2024-03-02T09:30:00Z\x1ffix: handle empty input\x1f\x1f\n" +
+		"\x1e\n"
+
+	commits := parseCommitLog(output)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+
+	if commits[0].Hash != "abc1234" || commits[0].Message != "feat(api)!: remove v1 endpoints" {
+		t.Errorf("Unexpected first commit: %+v", commits[0])
+	}
+	if commits[0].Body != "BREAKING CHANGE: use /v2 instead" {
+		t.Errorf("Expected body to be parsed, got %q", commits[0].Body)
+	}
+
+	if commits[0].AuthorName != "Jane Doe" || commits[0].AuthorEmail != "jane@example.com" {
+		t.Errorf("Expected author to be parsed, got %q <%q>", commits[0].AuthorName, commits[0].AuthorEmail)
+	}
+
+	if len(commits[0].Files) != 2 || commits[0].Files[0] != "api/v1.go" || commits[0].Files[1] != "api/v2.go" {
+		t.Errorf("Expected changed files to be parsed, got %v", commits[0].Files)
+	}
+	if !commits[0].Date.Equal(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected author date to be parsed, got %v", commits[0].Date)
+	}
+
+	if commits[1].Message != "fix: handle empty input" || commits[1].Body != "" || len(commits[1].Files) != 0 {
+		t.Errorf("Unexpected second commit: %+v", commits[1])
+	}
+	if commits[1].AuthorName != "dependabot[bot]" {
+		t.Errorf("Expected bot author, got %q", commits[1].AuthorName)
+	}
+}
+
+func TestParseShortStat(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected DiffStat
+	}{
+		{" 3 files changed, 10 insertions(+), 2 deletions(-)\n", DiffStat{Files: 3, Insertions: 10, Deletions: 2}},
+		{" 1 file changed, 1 insertion(+)\n", DiffStat{Files: 1, Insertions: 1}},
+		{" 2 files changed, 5 deletions(-)\n", DiffStat{Files: 2, Deletions: 5}},
+		{"", DiffStat{}},
+	}
+
+	for _, tt := range tests {
+		if got := parseShortStat(tt.output); got != tt.expected {
+			t.Errorf("Expected %+v for %q, got %+v", tt.expected, tt.output, got)
+		}
+	}
+}
+
+func TestNormalizeMergeCommit(t *testing.T) {
+	tests := []struct {
+		name            string
+		commit          Commit
+		expectedMessage string
+		expectedBody    string
+	}{
+		{
+			name:            "pull request merge uses title from body",
+			commit:          Commit{Message: "Merge pull request #42 from user/feature", Body: "feat: add export\n\nDetails here"},
+			expectedMessage: "feat: add export (#42)",
+			expectedBody:    "Details here",
+		},
+		{
+			name:            "pull request merge without body is unchanged",
+			commit:          Commit{Message: "Merge pull request #42 from user/feature"},
+			expectedMessage: "Merge pull request #42 from user/feature",
+		},
+		{
+			name:            "regular commit is unchanged",
+			commit:          Commit{Message: "fix: handle nil", Body: "body"},
+			expectedMessage: "fix: handle nil",
+			expectedBody:    "body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizeMergeCommit(tt.commit)
+			if result.Message != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, result.Message)
+			}
+			if result.Body != tt.expectedBody {
+				t.Errorf("Expected body %q, got %q", tt.expectedBody, result.Body)
+			}
+		})
+	}
+}
+
 func TestValidateSubmodulePath(t *testing.T) {
 	tests := []struct {
 		name        string
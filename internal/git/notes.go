@@ -0,0 +1,58 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReleaseNotesRef holds the release notes attached to release commits as git notes.
+// bump owns this ref: it is replaced by origin's copy before a note is added.
+const ReleaseNotesRef = "refs/notes/releases"
+
+// FetchReleaseNotes replaces the local release notes with origin's, so a new note
+// builds on the notes pushed by other clones. A remote without release notes is
+// not an error.
+func (g *Manager) FetchReleaseNotes() error {
+	refspec := fmt.Sprintf("+%s:%s", ReleaseNotesRef, ReleaseNotesRef)
+	if err := g.runGitCommand("fetch", "origin", refspec); err != nil {
+		if strings.Contains(err.Error(), "couldn't find remote ref") {
+			return nil
+		}
+		return fmt.Errorf("unable to fetch the release notes from origin: %v", err)
+	}
+	return nil
+}
+
+// AddReleaseNote attaches message to a commit as its release note, replacing any
+// note it already has
+func (g *Manager) AddReleaseNote(commit, message string) error {
+	if err := g.runGitCommand("notes", "--ref="+ReleaseNotesRef, "add", "-f", "-m", message, commit); err != nil {
+		return fmt.Errorf("unable to add the release note to %s: %v", commit, err)
+	}
+	return nil
+}
+
+// RemoveReleaseNote removes the release note of a commit, if it has one
+func (g *Manager) RemoveReleaseNote(commit string) error {
+	if err := g.runGitCommand("notes", "--ref="+ReleaseNotesRef, "remove", "--ignore-missing", commit); err != nil {
+		return fmt.Errorf("unable to remove the release note of %s: %v", commit, err)
+	}
+	return nil
+}
+
+// ReleaseNote returns the release note of a commit, or "" if it has none
+func (g *Manager) ReleaseNote(commit string) string {
+	note, err := g.gitOutput(nil, "notes", "--ref="+ReleaseNotesRef, "show", commit)
+	if err != nil {
+		return ""
+	}
+	return note
+}
+
+// PushReleaseNotes pushes the release notes to origin; nothing is forced
+func (g *Manager) PushReleaseNotes() error {
+	if err := g.runGitCommand(g.hookArgs("push", "origin", ReleaseNotesRef)...); err != nil {
+		return fmt.Errorf("unable to push %s to remote. Check network and permissions: %v", ReleaseNotesRef, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReleaseNotes(t *testing.T) {
+	baseDir := createTempDir(t)
+	defer func() {
+		if err := os.RemoveAll(baseDir); err != nil {
+			t.Logf("Warning: failed to remove temp dir: %v", err)
+		}
+	}()
+	originDir := filepath.Join(baseDir, "origin.git")
+	repoDir := filepath.Join(baseDir, "repo")
+	otherDir := filepath.Join(baseDir, "other")
+
+	runGitCommand(t, baseDir, "init", "--bare", "-b", "main", originDir)
+	runGitCommand(t, baseDir, "init", repoDir)
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "remote", "add", "origin", originDir)
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "chore(release): bump version to 1.0.0")
+	runGitCommand(t, repoDir, "push", "origin", "HEAD:refs/heads/main")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo directory: %v", err)
+	}
+
+	manager := NewManager()
+	// origin has no release notes yet
+	if err := manager.FetchReleaseNotes(); err != nil {
+		t.Fatalf("Expected fetching missing release notes to succeed, got %v", err)
+	}
+	if note := manager.ReleaseNote("HEAD"); note != "" {
+		t.Errorf("Expected no release note, got %q", note)
+	}
+
+	note := "Release version 1.0.0\n\n## Features\n\n- Add release notes"
+	if err := manager.AddReleaseNote("HEAD", note); err != nil {
+		t.Fatalf("AddReleaseNote failed: %v", err)
+	}
+	if got := manager.ReleaseNote("HEAD"); got != note {
+		t.Errorf("Expected the note to keep its markdown headings, got %q", got)
+	}
+	if err := manager.PushReleaseNotes(); err != nil {
+		t.Fatalf("PushReleaseNotes failed: %v", err)
+	}
+
+	// Another clone fetches the pushed notes
+	runGitCommand(t, baseDir, "clone", originDir, otherDir)
+	runGitCommand(t, otherDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, otherDir, "config", "user.name", "Test User")
+	if err := os.Chdir(otherDir); err != nil {
+		t.Fatalf("Failed to change to clone directory: %v", err)
+	}
+	if err := manager.FetchReleaseNotes(); err != nil {
+		t.Fatalf("FetchReleaseNotes failed: %v", err)
+	}
+	if got := manager.ReleaseNote("HEAD"); got != note {
+		t.Errorf("Expected the fetched note, got %q", got)
+	}
+
+	if err := manager.RemoveReleaseNote("HEAD"); err != nil {
+		t.Fatalf("RemoveReleaseNote failed: %v", err)
+	}
+	if got := manager.ReleaseNote("HEAD"); got != "" {
+		t.Errorf("Expected the note to be removed, got %q", got)
+	}
+	if err := manager.RemoveReleaseNote("HEAD"); err != nil {
+		t.Errorf("Expected removing a missing note to succeed, got %v", err)
+	}
+}
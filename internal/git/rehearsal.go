@@ -0,0 +1,231 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// rehearsalConfig are the settings copied from the repository to a rehearsal clone, so
+// the release commit and tags are authored and signed the same way
+var rehearsalConfig = []string{"user.name", "user.email", "user.signingkey", "gpg.format", "commit.gpgsign", "tag.gpgsign"}
+
+// RehearsalClone is a temporary local clone of the repository to rehearse a release
+// in. Its origin is a temporary bare repository holding origin's branches and the
+// tags as last fetched, so even pushes never leave this machine.
+type RehearsalClone struct {
+	// Dir is the directory of the clone matching the current directory
+	Dir string
+
+	root   string
+	repo   string
+	remote string
+	// head, tags and remoteRefs are the state before the rehearsal
+	head       string
+	tags       map[string]string
+	remoteRefs map[string]string
+}
+
+// RehearsalReport lists what a rehearsed release created
+type RehearsalReport struct {
+	// Commits are the new commits on HEAD, oldest first, as "abc1234 subject"
+	Commits []string
+	// Stat is the diffstat of the new commits
+	Stat string
+	// Tags are the created or moved tags, e.g. "v1.2.0 → abc1234 (Release version 1.2.0)"
+	Tags []string
+	// Pushed are the refs the remote would receive, e.g. "refs/tags/v1.2.0"
+	Pushed []string
+}
+
+// NewRehearsalClone clones the repository of the current directory into a temporary
+// directory. Uncommitted changes are not part of the clone. Remove deletes it.
+func NewRehearsalClone() (*RehearsalClone, error) {
+	top, err := gitOutput(nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := gitOutput(nil, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.MkdirTemp("", "bump-rehearsal-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the rehearsal directory: %v", err)
+	}
+	c := &RehearsalClone{
+		root:   root,
+		repo:   filepath.Join(root, "repo"),
+		remote: filepath.Join(root, "origin.git"),
+	}
+	c.Dir = filepath.Join(c.repo, prefix)
+	if err := c.setup(top); err != nil {
+		_ = c.Remove()
+		return nil, fmt.Errorf("unable to set up the rehearsal clone: %v", err)
+	}
+	return c, nil
+}
+
+// setup creates the rehearsal remote and the clone and records their refs
+func (c *RehearsalClone) setup(top string) error {
+	if _, err := gitOutput(nil, "init", "--quiet", "--bare", c.remote); err != nil {
+		return err
+	}
+	// The rehearsal remote gets origin's branches and every tag, pushed locally
+	// without hooks; the repository's own remote is never contacted
+	refspecs, err := mirrorRefspecs()
+	if err != nil {
+		return err
+	}
+	if len(refspecs) > 0 {
+		args := append([]string{"push", "--quiet", "--no-verify", c.remote}, refspecs...)
+		if _, err := gitOutput(nil, args...); err != nil {
+			return err
+		}
+	}
+
+	if _, err := gitOutput(nil, "clone", "--quiet", top, c.repo); err != nil {
+		return err
+	}
+	if _, err := gitOutput(nil, "-C", c.repo, "remote", "set-url", "origin", c.remote); err != nil {
+		return err
+	}
+	if _, err := gitOutput(nil, "-C", c.repo, "fetch", "--quiet", "--prune", "origin"); err != nil {
+		return err
+	}
+
+	for _, key := range rehearsalConfig {
+		if value, err := gitOutput(nil, "config", "--get", key); err == nil {
+			if _, err := gitOutput(nil, "-C", c.repo, "config", key, value); err != nil {
+				return err
+			}
+		}
+	}
+	// Hooks run as they would in the repository
+	hooks, err := gitOutput(nil, "config", "--get", "core.hooksPath")
+	if err != nil {
+		commonDir, err := gitOutput(nil, "rev-parse", "--git-common-dir")
+		if err != nil {
+			return err
+		}
+		if hooks, err = filepath.Abs(filepath.Join(commonDir, "hooks")); err != nil {
+			return err
+		}
+	}
+	if _, err := gitOutput(nil, "-C", c.repo, "config", "core.hooksPath", hooks); err != nil {
+		return err
+	}
+
+	if c.head, err = gitOutput(nil, "-C", c.repo, "rev-parse", "HEAD"); err != nil {
+		return err
+	}
+	if c.tags, err = listRefs(c.repo, "refs/tags"); err != nil {
+		return err
+	}
+	c.remoteRefs, err = listRefs(c.remote, "refs")
+	return err
+}
+
+// mirrorRefspecs returns the refspecs pushing origin's remote-tracking branches and
+// all tags to the rehearsal remote
+func mirrorRefspecs() ([]string, error) {
+	output, err := gitOutput(nil, "for-each-ref", "--format=%(refname)", "refs/remotes/origin", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	var refspecs []string
+	for _, ref := range strings.Fields(output) {
+		if branch, ok := strings.CutPrefix(ref, "refs/remotes/origin/"); ok {
+			if branch != "HEAD" {
+				refspecs = append(refspecs, ref+":refs/heads/"+branch)
+			}
+			continue
+		}
+		refspecs = append(refspecs, ref+":"+ref)
+	}
+	return refspecs, nil
+}
+
+// listRefs maps the refs under prefix in a repository to the objects they point at
+func listRefs(dir, prefix string) (map[string]string, error) {
+	output, err := gitOutput(nil, "-C", dir, "for-each-ref", "--format=%(refname) %(objectname)", prefix)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if name, object, ok := strings.Cut(line, " "); ok {
+			refs[name] = object
+		}
+	}
+	return refs, nil
+}
+
+// changedRefs returns the refs that were created or moved, sorted
+func changedRefs(before, after map[string]string) []string {
+	var changed []string
+	for name, object := range after {
+		if before[name] != object {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Report lists the commits and tags created in the clone and the refs pushed to the
+// rehearsal remote since it was set up
+func (c *RehearsalClone) Report() (*RehearsalReport, error) {
+	report := &RehearsalReport{}
+
+	commits, err := gitOutput(nil, "-C", c.repo, "log", "--reverse", "--format=%h %s", c.head+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if commits != "" {
+		report.Commits = strings.Split(commits, "\n")
+		if report.Stat, err = gitOutput(nil, "-C", c.repo, "diff", "--stat", c.head, "HEAD"); err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := listRefs(c.repo, "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range changedRefs(c.tags, tags) {
+		name := strings.TrimPrefix(ref, "refs/tags/")
+		target, err := gitOutput(nil, "-C", c.repo, "rev-parse", "--short", ref+"^{commit}")
+		if err != nil {
+			return nil, err
+		}
+		kind, err := gitOutput(nil, "-C", c.repo, "for-each-ref", "--format=%(objecttype)", ref)
+		if err != nil {
+			return nil, err
+		}
+		description := fmt.Sprintf("%s → %s", name, target)
+		if kind == "tag" {
+			message, err := gitOutput(nil, "-C", c.repo, "for-each-ref", "--format=%(contents:subject)", ref)
+			if err != nil {
+				return nil, err
+			}
+			description += fmt.Sprintf(" (%s)", message)
+		}
+		report.Tags = append(report.Tags, description)
+	}
+
+	remoteRefs, err := listRefs(c.remote, "refs")
+	if err != nil {
+		return nil, err
+	}
+	report.Pushed = changedRefs(c.remoteRefs, remoteRefs)
+	return report, nil
+}
+
+// Remove deletes the clone and the rehearsal remote
+func (c *RehearsalClone) Remove() error {
+	return os.RemoveAll(c.root)
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRehearsalClone(t *testing.T) {
+	baseDir := createTempDir(t)
+	defer func() {
+		if err := os.RemoveAll(baseDir); err != nil {
+			t.Logf("Warning: failed to remove temp dir: %v", err)
+		}
+	}()
+	originDir := filepath.Join(baseDir, "origin.git")
+	repoDir := filepath.Join(baseDir, "repo")
+
+	runGitCommand(t, baseDir, "init", "--bare", originDir)
+	runGitCommand(t, baseDir, "init", "-b", "main", repoDir)
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "remote", "add", "origin", originDir)
+	if err := os.Mkdir(filepath.Join(repoDir, "app"), 0755); err != nil {
+		t.Fatalf("Failed to create app directory: %v", err)
+	}
+	writeFile(t, filepath.Join(repoDir, "app", "VERSION"), "1.0.0\n")
+	runGitCommand(t, repoDir, "add", ".")
+	runGitCommand(t, repoDir, "commit", "-m", "initial commit")
+	runGitCommand(t, repoDir, "tag", "-a", "v1.0.0", "-m", "Release version 1.0.0")
+	runGitCommand(t, repoDir, "push", "origin", "main", "v1.0.0")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(filepath.Join(repoDir, "app")); err != nil {
+		t.Fatalf("Failed to change to repo directory: %v", err)
+	}
+
+	clone, err := NewRehearsalClone()
+	if err != nil {
+		t.Fatalf("NewRehearsalClone failed: %v", err)
+	}
+	if filepath.Base(clone.Dir) != "app" {
+		t.Errorf("Expected the clone directory to match the current directory, got %s", clone.Dir)
+	}
+
+	// Release in the clone the way the pipeline does
+	writeFile(t, filepath.Join(clone.Dir, "VERSION"), "1.1.0\n")
+	runGitCommand(t, clone.Dir, "commit", "-am", "chore(release): bump version to 1.1.0")
+	runGitCommand(t, clone.Dir, "tag", "-a", "v1.1.0", "-m", "Release version 1.1.0")
+	runGitCommand(t, clone.Dir, "tag", "v1")
+	runGitCommand(t, clone.Dir, "push", "origin", "HEAD", "refs/tags/v1.1.0")
+
+	report, err := clone.Report()
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if len(report.Commits) != 1 || !strings.HasSuffix(report.Commits[0], " chore(release): bump version to 1.1.0") {
+		t.Errorf("Expected the release commit, got %v", report.Commits)
+	}
+	if !strings.Contains(report.Stat, "app/VERSION") {
+		t.Errorf("Expected the diffstat to list app/VERSION, got %q", report.Stat)
+	}
+	if len(report.Tags) != 2 || !strings.HasPrefix(report.Tags[0], "v1 → ") || !strings.HasSuffix(report.Tags[1], "(Release version 1.1.0)") {
+		t.Errorf("Expected the alias and version tags, got %v", report.Tags)
+	}
+	if strings.Join(report.Pushed, ",") != "refs/heads/main,refs/tags/v1.1.0" {
+		t.Errorf("Expected main and v1.1.0 to be pushed, got %v", report.Pushed)
+	}
+
+	// Neither the repository nor its remote saw the rehearsal
+	for _, dir := range []string{repoDir, originDir} {
+		if output, _ := exec.Command("git", "-C", dir, "tag", "-l", "v1.1.0").Output(); len(output) > 0 {
+			t.Errorf("Expected no v1.1.0 tag in %s", dir)
+		}
+	}
+
+	if err := clone.Remove(); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(clone.Dir); !os.IsNotExist(err) {
+		t.Errorf("Expected the clone to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// ReleaseMessages are the templates of the release commit subject and the annotation
+// of release tags; {{.Version}} and {{.Tag}} are expanded
+type ReleaseMessages struct {
+	Commit string
+	Tag    string
+}
+
+// DefaultReleaseMessages are the English release messages
+var DefaultReleaseMessages = ReleaseMessages{
+	Commit: "chore(release): bump version to {{.Version}}",
+	Tag:    "Release version {{.Version}}",
+}
+
+// SetReleaseMessages changes the release commit subject and tag annotation, e.g. to
+// keep a non-English history
+func (g *Manager) SetReleaseMessages(messages ReleaseMessages) {
+	if messages.Commit == "" {
+		messages.Commit = DefaultReleaseMessages.Commit
+	}
+	if messages.Tag == "" {
+		messages.Tag = DefaultReleaseMessages.Tag
+	}
+	g.messages = messages
+}
+
+// ReleaseCommitSubject is the subject of the release commit for a version
+func (g *Manager) ReleaseCommitSubject(version string) string {
+	return renderReleaseMessage(g.messages.Commit, DefaultReleaseMessages.Commit, "v"+version, version)
+}
+
+// TagMessage is the annotation of a release tag, such as v1.2.0 or a package tag
+func (g *Manager) TagMessage(tag, version string) string {
+	return renderReleaseMessage(g.messages.Tag, DefaultReleaseMessages.Tag, tag, version)
+}
+
+// renderReleaseMessage expands a release message template, falling back to the English
+// message if it can't be rendered. Settings validation parses templates beforehand.
+func renderReleaseMessage(text, fallback, tag, version string) string {
+	data := struct{ Version, Tag string }{Version: version, Tag: tag}
+	var buf bytes.Buffer
+	tmpl, err := template.New("message").Parse(text)
+	if err == nil {
+		err = tmpl.Execute(&buf, data)
+	}
+	if err != nil || strings.TrimSpace(buf.String()) == "" {
+		log.Printf("Failed to render release message %q: %v", text, err)
+		buf.Reset()
+		_ = template.Must(template.New("message").Parse(fallback)).Execute(&buf, data)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// WithTrailers appends "Token: value" trailers to a commit or tag message after a blank line
+func WithTrailers(message string, trailers []string) string {
+	if len(trailers) == 0 {
+		return message
+	}
+	return message + "\n\n" + strings.Join(trailers, "\n")
+}
+
+// TagTrailers returns the trailers of a tag's annotation by token, e.g.
+// {"Bump-Type": "minor"}; a repeated token keeps its last value
+func (g *Manager) TagTrailers(name string) (map[string]string, error) {
+	output, err := g.gitOutput(nil, "for-each-ref", "--format=%(objecttype)%00%(contents:trailers:unfold,only)", "refs/tags/"+name)
+	if err != nil {
+		return nil, err
+	}
+	kind, trailers, _ := strings.Cut(output, "\x00")
+	if kind == "" {
+		return nil, fmt.Errorf("tag %s not found", name)
+	}
+
+	// Lightweight tags have no annotation; their commit's trailers don't describe the tag
+	if kind != "tag" {
+		return make(map[string]string), nil
+	}
+	return parseTrailers(trailers), nil
+}
+
+// CommitTrailers returns the trailers of a commit's message by token, e.g.
+// {"Changelog-Hash": "sha256:..."}; ref may also name a tag of the commit
+func (g *Manager) CommitTrailers(ref string) (map[string]string, error) {
+	output, err := g.gitOutput(nil, "log", "-1", "--format=%(trailers:unfold,only)", ref+"^{commit}", "--")
+	if err != nil {
+		return nil, err
+	}
+	return parseTrailers(output), nil
+}
+
+// parseTrailers maps "Token: value" lines by token; a repeated token keeps its last value
+func parseTrailers(text string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		if token, value, ok := strings.Cut(line, ": "); ok {
+			result[token] = strings.TrimSpace(value)
+		}
+	}
+	return result
+}
+
+// IsReleaseCommit reports whether HEAD is the release commit of version, e.g. one
+// left by a run that stopped before tagging or pushing
+func (g *Manager) IsReleaseCommit(version string) bool {
+	subject, err := g.gitOutput(nil, "log", "-1", "--format=%s", "HEAD")
+	return err == nil && subject == g.ReleaseCommitSubject(version)
+}
+
+// TagStatus reports whether a tag exists and whether it points at HEAD
+func (g *Manager) TagStatus(name string) (exists, atHead bool) {
+	tagged, err := g.ResolveRef("refs/tags/" + name)
+	if err != nil {
+		return false, false
+	}
+	head, err := g.ResolveRef("HEAD")
+	return true, err == nil && head == tagged
+}
+
+// IsPushed reports whether a remote-tracking branch contains HEAD, as of the last fetch
+func (g *Manager) IsPushed() bool {
+	branches, err := g.gitOutput(nil, "branch", "--remotes", "--contains", "HEAD")
+	return err == nil && branches != ""
+}
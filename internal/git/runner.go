@@ -0,0 +1,35 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+)
+
+// GitRunner runs git commands for the Manager. Tests inject a stub with SetRunner to
+// script git's output (submodule status, rev-list counts, fetch errors) without a repository.
+type GitRunner interface {
+	// Run runs git with args in the current directory, with env added to the process
+	// environment, and returns what it wrote to stdout and stderr
+	Run(env []string, args ...string) (stdout, stderr string, err error)
+}
+
+// ExecRunner runs the git executable, stopping it after GitCommandTimeout
+type ExecRunner struct{}
+
+func (ExecRunner) Run(env []string, args ...string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,323 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stubResult is what a stubbed git command prints and returns
+type stubResult struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+// stubRunner answers git commands from a table keyed by their space-joined arguments
+type stubRunner struct {
+	results map[string]stubResult
+}
+
+func (s *stubRunner) Run(env []string, args ...string) (string, string, error) {
+	result, ok := s.results[strings.Join(args, " ")]
+	if !ok {
+		return "", "", fmt.Errorf("unexpected git %s", strings.Join(args, " "))
+	}
+	return result.stdout, result.stderr, result.err
+}
+
+func TestCheckRemoteStatus(t *testing.T) {
+	failed := errors.New("exit status 128")
+	tests := []struct {
+		name     string
+		getURL   stubResult
+		fetch    stubResult
+		revList  stubResult
+		expected string
+	}{
+		{
+			name:     "no remote",
+			getURL:   stubResult{err: failed},
+			expected: "no remote origin configured",
+		},
+		{
+			name:     "authentication",
+			fetch:    stubResult{stderr: "fatal: Authentication failed for 'https://github.com/acme/app.git/'\n", err: failed},
+			expected: "authentication failed - check your credentials",
+		},
+		{
+			name:     "permission denied",
+			fetch:    stubResult{stderr: "git@github.com: Permission denied (publickey).\n", err: failed},
+			expected: "authentication failed - check your credentials",
+		},
+		{
+			name:     "network",
+			fetch:    stubResult{stderr: "ssh: connect to host github.com port 22: Connection refused\n", err: failed},
+			expected: "network connectivity issue",
+		},
+		{
+			name:     "repository not found",
+			fetch:    stubResult{stderr: "ERROR: Repository not found.\n", err: failed},
+			expected: "remote repository not found",
+		},
+		{
+			name:     "other fetch error",
+			fetch:    stubResult{stderr: "fatal: protocol error: bad pack header\n", err: failed},
+			expected: "remote connectivity issue: fatal: protocol error",
+		},
+		{
+			name:     "fetch error without output",
+			fetch:    stubResult{err: failed},
+			expected: "unable to fetch from remote",
+		},
+		{
+			name:     "unknown upstream",
+			revList:  stubResult{err: failed},
+			expected: "cannot compare with remote branch",
+		},
+		{
+			name:     "behind",
+			revList:  stubResult{stdout: "2\t0\n"},
+			expected: "branch is 2 commits behind origin",
+		},
+		{
+			name:     "ahead",
+			revList:  stubResult{stdout: "0\t3\n"},
+			expected: "branch is 3 commits ahead of origin",
+		},
+		{
+			name:     "diverged",
+			revList:  stubResult{stdout: "1\t4\n"},
+			expected: "branch is 1 commits behind and 4 commits ahead of origin",
+		},
+		{
+			name:    "up to date",
+			revList: stubResult{stdout: "0\t0\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager()
+			manager.SetRunner(&stubRunner{results: map[string]stubResult{
+				"remote get-url origin": tt.getURL,
+				"fetch --dry-run":       tt.fetch,
+				"rev-list --count --left-right origin/main...HEAD": tt.revList,
+			}})
+
+			err := manager.checkRemoteStatus("main")
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetSubmodulesFromStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		gitmodules    string
+		status        stubResult
+		expectedNames []string
+		expectErr     bool
+	}{
+		{
+			name:          "no .gitmodules",
+			expectedNames: []string{},
+		},
+		{
+			name:          "single submodule with status char",
+			gitmodules:    ".gitmodules\n",
+			status:        stubResult{stdout: " 1234567890abcdef1234567890abcdef12345678 path/to/submodule (v1.0.0)\n"},
+			expectedNames: []string{"submodule"},
+		},
+		{
+			name:       "multiple submodules mixed format",
+			gitmodules: ".gitmodules\n",
+			status: stubResult{stdout: ` 1234567890abcdef1234567890abcdef12345678 libs/first-lib (v1.0.0)
++abcdef1234567890abcdef1234567890abcdef12 libs/second-lib (v2.0.0-1-gabcdef1)
+-fedcba0987654321fedcba0987654321fedcba09 third-lib
+`},
+			expectedNames: []string{"first-lib", "second-lib", "third-lib"},
+		},
+		{
+			name:       "malformed and unsafe lines are skipped",
+			gitmodules: ".gitmodules\n",
+			status: stubResult{stdout: `short
+ 1234567890abcdef1234567890abcdef12345678 ../outside (v1.0.0)
+ abcdef1234567890abcdef1234567890abcdef12 libs/kept
+`},
+			expectedNames: []string{"kept"},
+		},
+		{
+			name:       "submodule status fails",
+			gitmodules: ".gitmodules\n",
+			status:     stubResult{stderr: "fatal: no submodule mapping found\n", err: errors.New("exit status 128")},
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager()
+			manager.SetRunner(&stubRunner{results: map[string]stubResult{
+				"ls-files .gitmodules": {stdout: tt.gitmodules},
+				"submodule status":     tt.status,
+			}})
+
+			submodules, err := manager.getSubmodules()
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", submodules)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(submodules) != len(tt.expectedNames) {
+				t.Fatalf("Expected %d submodules, got %+v", len(tt.expectedNames), submodules)
+			}
+			for i, submodule := range submodules {
+				if submodule.Name != tt.expectedNames[i] {
+					t.Errorf("Expected submodule %q, got %q", tt.expectedNames[i], submodule.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestRunGitError(t *testing.T) {
+	runner := &stubRunner{results: map[string]stubResult{
+		"tag -a v1.2.0":  {stderr: "fatal: tag 'v1.2.0' already exists\n", err: errors.New("exit status 128")},
+		"rev-parse HEAD": {stdout: "abc123\n"},
+	}}
+
+	if _, err := runGit(runner, nil, "tag", "-a", "v1.2.0"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the error to include git's stderr, got %v", err)
+	}
+	if output, err := runGit(runner, nil, "rev-parse", "HEAD"); err != nil || output != "abc123" {
+		t.Errorf("Expected trimmed output, got %q (%v)", output, err)
+	}
+}
+
+// recordingRunner records every git command it is asked to run; validation steps
+// run in parallel
+type recordingRunner struct {
+	stubRunner
+	mu       sync.Mutex
+	commands []string
+}
+
+func (r *recordingRunner) Run(env []string, args ...string) (string, string, error) {
+	r.mu.Lock()
+	r.commands = append(r.commands, strings.Join(args, " "))
+	r.mu.Unlock()
+	return r.stubRunner.Run(env, args...)
+}
+
+func TestValidateLocalStatusSkipsRemote(t *testing.T) {
+	runner := &recordingRunner{stubRunner: stubRunner{results: map[string]stubResult{
+		"branch --show-current":                            {stdout: "main\n"},
+		"remote get-url origin":                            {stdout: "git@example.com:app.git\n"},
+		"fetch --dry-run":                                  {},
+		"rev-list --count --left-right origin/main...HEAD": {stdout: "2\t0\n"},
+	}}}
+	manager := NewManager()
+	manager.SetRunner(runner)
+
+	if _, err := manager.ValidateLocalStatus(nil); err != nil {
+		t.Fatalf("ValidateLocalStatus failed: %v", err)
+	}
+	for _, command := range runner.commands {
+		if strings.HasPrefix(command, "fetch") || strings.HasPrefix(command, "rev-list") {
+			t.Errorf("Expected no remote commands, ran git %s", command)
+		}
+	}
+
+	result := manager.ValidateRemoteStatus()
+	if !result.Success || len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "2 commits behind") {
+		t.Errorf("Expected a behind warning from the remote check, got %+v", result)
+	}
+}
+
+func TestValidationProgress(t *testing.T) {
+	manager := NewManager()
+	manager.SetRunner(&stubRunner{results: map[string]stubResult{
+		"branch --show-current": {stdout: "main\n"},
+	}})
+
+	var mu sync.Mutex
+	started := map[string]bool{}
+	finished := map[string]bool{}
+	progress := ValidationProgress(func(step ValidationStep, result *ValidationResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if result == nil {
+			started[step.Name] = true
+			return
+		}
+		if result.Step.Name != step.Name {
+			t.Errorf("Expected the result of %s, got %s", step.Name, result.Step.Name)
+		}
+		finished[step.Name] = true
+	})
+
+	summary, err := manager.ValidateLocalStatus(progress)
+	if err != nil {
+		t.Fatalf("ValidateLocalStatus failed: %v", err)
+	}
+	for _, result := range summary.Results {
+		if !finished[result.Step.Name] {
+			t.Errorf("Expected step %s to be reported", result.Step.Name)
+		}
+	}
+	// Without submodules the submodule states aren't checked, only reported
+	if started["submodules_status"] || len(started) != ValidationStepCount-1 {
+		t.Errorf("Expected every step but the skipped one to be reported starting, got %v", started)
+	}
+}
+
+func TestLatestTag(t *testing.T) {
+	failed := errors.New("exit status 128")
+	tests := []struct {
+		name      string
+		describe  stubResult
+		expected  string
+		expectErr bool
+	}{
+		{"tagged", stubResult{stdout: "packages/api/v1.2.0\n"}, "packages/api/v1.2.0", false},
+		{"no tags", stubResult{stderr: "fatal: No names found, cannot describe anything.", err: failed}, "", false},
+		{"no matching tag", stubResult{stderr: "fatal: No tags can describe 'a1b2c3d'.", err: failed}, "", false},
+		{"not a repository", stubResult{stderr: "fatal: not a git repository", err: failed}, "", true},
+		{"timeout", stubResult{err: errors.New("signal: killed")}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewManager()
+			manager.SetRunner(&stubRunner{results: map[string]stubResult{
+				"describe --tags --abbrev=0 --match packages/api/v*.*.* HEAD": tt.describe,
+			}})
+
+			tag, err := manager.LatestTag("packages/api/v*.*.*")
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %q", tag)
+				}
+				return
+			}
+			if err != nil || tag != tt.expected {
+				t.Errorf("Expected %q, got %q (%v)", tt.expected, tag, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SnapshotRef keeps the pre-release state while a release runs, so it survives a
+// crash. It is shaped like a stash: `git stash apply refs/bump/snapshot` restores it by hand.
+const SnapshotRef = "refs/bump/snapshot"
+
+// Snapshot is the state of the repository before a release changed anything
+type Snapshot struct {
+	// Branch is the checked out branch, empty for a detached HEAD
+	Branch string
+	// Head is the commit HEAD pointed to
+	Head string
+	// Index is the tree of the staging area
+	Index string
+	// Commit records the working tree, including untracked files that aren't ignored,
+	// with Head and the staged changes as parents
+	Commit string
+	// Tags lists the tags that existed, so tags created by the release can be removed
+	Tags map[string]bool
+}
+
+// CreateSnapshot records HEAD, the staging area and the working tree under SnapshotRef
+func (g *Manager) CreateSnapshot() (*Snapshot, error) {
+	snapshot := &Snapshot{Tags: make(map[string]bool)}
+
+	var err error
+	if snapshot.Head, err = g.ResolveRef("HEAD"); err != nil {
+		return nil, err
+	}
+	if snapshot.Branch, err = g.GetCurrentBranch(); err != nil {
+		return nil, err
+	}
+	if snapshot.Index, err = g.gitOutput(nil, "write-tree"); err != nil {
+		return nil, fmt.Errorf("unable to record the staged changes: %v", err)
+	}
+
+	worktree, err := g.worktreeTree()
+	if err != nil {
+		return nil, fmt.Errorf("unable to record the working tree: %v", err)
+	}
+
+	indexCommit, err := g.gitOutput(nil, "commit-tree", snapshot.Index, "-p", snapshot.Head, "-m", "index on bump snapshot")
+	if err != nil {
+		return nil, fmt.Errorf("unable to record the staged changes: %v", err)
+	}
+	snapshot.Commit, err = g.gitOutput(nil, "commit-tree", worktree, "-p", snapshot.Head, "-p", indexCommit, "-m", "bump: pre-release snapshot")
+	if err != nil {
+		return nil, fmt.Errorf("unable to record the working tree: %v", err)
+	}
+	if err := g.runGitCommand("update-ref", SnapshotRef, snapshot.Commit); err != nil {
+		return nil, err
+	}
+
+	tags, err := g.gitOutput(nil, "tag", "--list")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags: %v", err)
+	}
+	for _, tag := range strings.Fields(tags) {
+		snapshot.Tags[tag] = true
+	}
+
+	return snapshot, nil
+}
+
+// worktreeTree writes a tree of the working tree through a temporary copy of the
+// index, leaving the real staging area alone
+func (g *Manager) worktreeTree() (string, error) {
+	gitDir, err := g.gitOutput(nil, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+
+	index, err := os.CreateTemp(gitDir, "bump-snapshot-index-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.Remove(index.Name())
+	}()
+	if content, err := os.ReadFile(filepath.Join(gitDir, "index")); err == nil {
+		if _, err := index.Write(content); err != nil {
+			_ = index.Close()
+			return "", err
+		}
+	}
+	if err := index.Close(); err != nil {
+		return "", err
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + index.Name()}
+	if _, err := g.gitOutput(env, "add", "--all"); err != nil {
+		return "", err
+	}
+	return g.gitOutput(env, "write-tree")
+}
+
+// RestoreSnapshot puts the repository back in the recorded state: the branch and
+// HEAD, the working tree and staging area, without the tags created since. Files
+// the release created are removed; ignored files are left alone.
+func (g *Manager) RestoreSnapshot(snapshot *Snapshot) error {
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+	if branch != snapshot.Branch {
+		target := snapshot.Branch
+		if target == "" {
+			target = "--detach"
+		}
+		if err := g.runGitCommand("checkout", "--force", target); err != nil {
+			return fmt.Errorf("unable to switch back to %s: %v", snapshot.Branch, err)
+		}
+	}
+
+	if err := g.ResetHard(snapshot.Head); err != nil {
+		return err
+	}
+	if err := g.runGitCommand("clean", "--force", "-d"); err != nil {
+		return fmt.Errorf("unable to remove the files created by the release: %v", err)
+	}
+	if err := g.runGitCommand("read-tree", "--reset", "-u", snapshot.Commit); err != nil {
+		return fmt.Errorf("unable to restore the working tree: %v", err)
+	}
+	if err := g.runGitCommand("read-tree", snapshot.Index); err != nil {
+		return fmt.Errorf("unable to restore the staged changes: %v", err)
+	}
+
+	tags, err := g.gitOutput(nil, "tag", "--list")
+	if err != nil {
+		return fmt.Errorf("unable to list tags: %v", err)
+	}
+	for _, tag := range strings.Fields(tags) {
+		if snapshot.Tags[tag] {
+			continue
+		}
+		if err := g.runGitCommand("tag", "-d", tag); err != nil {
+			return fmt.Errorf("unable to delete git tag %s: %v", tag, err)
+		}
+	}
+
+	return g.DropSnapshot()
+}
+
+// HasSnapshot reports whether SnapshotRef exists, i.e. a release is running or was
+// interrupted before it finished locally
+func (g *Manager) HasSnapshot() bool {
+	_, err := g.ResolveRef(SnapshotRef)
+	return err == nil
+}
+
+// DropSnapshot deletes SnapshotRef once the release no longer needs it
+func (g *Manager) DropSnapshot() error {
+	if err := g.runGitCommand("update-ref", "-d", SnapshotRef); err != nil {
+		return fmt.Errorf("unable to delete %s: %v", SnapshotRef, err)
+	}
+	return nil
+}
+
+// gitOutput runs git with extra environment variables and returns its trimmed output,
+// for code that runs git outside a Manager
+func gitOutput(env []string, args ...string) (string, error) {
+	return runGit(ExecRunner{}, env, args...)
+}
+
+// runGit runs git through runner and returns its trimmed output, or an error with git's stderr
+func runGit(runner GitRunner, env []string, args ...string) (string, error) {
+	stdout, stderr, err := runner.Run(env, args...)
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %v\nError: %s", strings.Join(args, " "), err, stderr)
+	}
+	return strings.TrimSpace(stdout), nil
+}
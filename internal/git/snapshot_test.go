@@ -0,0 +1,122 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	repoDir := createTempDir(t)
+	defer func() {
+		if err := os.RemoveAll(repoDir); err != nil {
+			t.Logf("Warning: failed to remove temp dir: %v", err)
+		}
+	}()
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo directory: %v", err)
+	}
+
+	runGitCommand(t, repoDir, "init")
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	writeFile(t, filepath.Join(repoDir, ".gitignore"), "build/\n")
+	writeFile(t, filepath.Join(repoDir, "version.txt"), "1.0.0")
+	writeFile(t, filepath.Join(repoDir, "notes.txt"), "notes")
+	runGitCommand(t, repoDir, "add", ".")
+	runGitCommand(t, repoDir, "commit", "-m", "initial commit")
+	runGitCommand(t, repoDir, "tag", "v1.0.0")
+
+	// Dirty state: a staged change, an unstaged change, an untracked and an ignored file
+	writeFile(t, filepath.Join(repoDir, "notes.txt"), "staged notes")
+	runGitCommand(t, repoDir, "add", "notes.txt")
+	writeFile(t, filepath.Join(repoDir, "version.txt"), "1.0.0-dev")
+	writeFile(t, filepath.Join(repoDir, "draft.txt"), "draft")
+	if err := os.Mkdir(filepath.Join(repoDir, "build"), 0755); err != nil {
+		t.Fatalf("Failed to create build directory: %v", err)
+	}
+	writeFile(t, filepath.Join(repoDir, "build", "out.bin"), "binary")
+	before := gitStatus(t)
+
+	manager := NewManager()
+	snapshot, err := manager.CreateSnapshot()
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+	if gitStatus(t) != before {
+		t.Fatalf("Expected CreateSnapshot to leave the status alone, got %q", gitStatus(t))
+	}
+	if !manager.HasSnapshot() {
+		t.Errorf("Expected HasSnapshot after CreateSnapshot")
+	}
+
+	// A release that fails after committing and tagging
+	writeFile(t, filepath.Join(repoDir, "version.txt"), "1.1.0")
+	writeFile(t, filepath.Join(repoDir, "CHANGELOG.md"), "## 1.1.0")
+	runGitCommand(t, repoDir, "add", ".")
+	runGitCommand(t, repoDir, "commit", "-m", "chore(release): bump version to 1.1.0")
+	runGitCommand(t, repoDir, "tag", "v1.1.0")
+	writeFile(t, filepath.Join(repoDir, "debian-changelog"), "leftover")
+
+	if err := manager.RestoreSnapshot(snapshot); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+	if manager.HasSnapshot() {
+		t.Errorf("Expected RestoreSnapshot to drop the snapshot")
+	}
+
+	if status := gitStatus(t); status != before {
+		t.Errorf("Expected status %q, got %q", before, status)
+	}
+	for file, expected := range map[string]string{
+		"version.txt":      "1.0.0-dev",
+		"notes.txt":        "staged notes",
+		"draft.txt":        "draft",
+		"build/out.bin":    "binary",
+		"CHANGELOG.md":     "",
+		"debian-changelog": "",
+	} {
+		content, err := os.ReadFile(filepath.Join(repoDir, file))
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Expected %s to be removed", file)
+			}
+			continue
+		}
+		if string(content) != expected {
+			t.Errorf("Expected %s to contain %q, got %q", file, expected, string(content))
+		}
+	}
+	if head, _ := manager.ResolveRef("HEAD"); head != snapshot.Head {
+		t.Errorf("Expected HEAD %s, got %s", snapshot.Head, head)
+	}
+	if _, err := manager.ResolveRef("v1.1.0"); err == nil {
+		t.Error("Expected the release tag to be deleted")
+	}
+	if _, err := manager.ResolveRef("v1.0.0"); err != nil {
+		t.Errorf("Expected the existing tag to be kept: %v", err)
+	}
+	if _, err := manager.ResolveRef(SnapshotRef); err == nil {
+		t.Errorf("Expected %s to be deleted", SnapshotRef)
+	}
+}
+
+func gitStatus(t *testing.T) string {
+	output, err := exec.Command("git", "status", "--porcelain").Output()
+	if err != nil {
+		t.Fatalf("git status failed: %v", err)
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RepositoryRoot returns the top-level directory of the repository
+func (g *Manager) RepositoryRoot() (string, error) {
+	return g.gitOutput(nil, "rev-parse", "--show-toplevel")
+}
+
+// UpstreamStatus returns the remote-tracking branch of the current branch and how many
+// commits HEAD is ahead of and behind it, as of the last fetch. upstream is "" when
+// the branch tracks none.
+func (g *Manager) UpstreamStatus() (upstream string, ahead, behind int, err error) {
+	upstream, err = g.gitOutput(nil, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return "", 0, 0, nil
+	}
+
+	counts, err := g.gitOutput(nil, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return upstream, 0, 0, err
+	}
+	fields := strings.Fields(counts)
+	if len(fields) != 2 {
+		return upstream, 0, 0, fmt.Errorf("unexpected ahead/behind counts %q", counts)
+	}
+	if ahead, err = strconv.Atoi(fields[0]); err != nil {
+		return upstream, 0, 0, err
+	}
+	if behind, err = strconv.Atoi(fields[1]); err != nil {
+		return upstream, 0, 0, err
+	}
+	return upstream, ahead, behind, nil
+}
+
+// Submodules lists the submodules of the repository
+func (g *Manager) Submodules() ([]Submodule, error) {
+	return g.getSubmodules()
+}
+
+// SubmoduleTag returns the tag a submodule is checked out at, or "" if it isn't at one
+func (g *Manager) SubmoduleTag(path string) string {
+	if err := g.validateSubmodulePath(path); err != nil {
+		return ""
+	}
+	onTag, tag, err := g.isSubmodulePointingToTag(path)
+	if err != nil || !onTag {
+		return ""
+	}
+	return tag
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpstreamStatus(t *testing.T) {
+	baseDir := createTempDir(t)
+	defer func() {
+		if err := os.RemoveAll(baseDir); err != nil {
+			t.Logf("Warning: failed to remove temp dir: %v", err)
+		}
+	}()
+	originDir := filepath.Join(baseDir, "origin.git")
+	repoDir := filepath.Join(baseDir, "repo")
+	otherDir := filepath.Join(baseDir, "other")
+
+	runGitCommand(t, baseDir, "init", "--bare", "-b", "main", originDir)
+	runGitCommand(t, baseDir, "init", "-b", "main", repoDir)
+	runGitCommand(t, repoDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, repoDir, "config", "user.name", "Test User")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "initial commit")
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Logf("Warning: failed to change back to original dir: %v", err)
+		}
+	}()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repo directory: %v", err)
+	}
+
+	g := NewManager()
+	if upstream, _, _, err := g.UpstreamStatus(); err != nil || upstream != "" {
+		t.Errorf("Expected no upstream before the first push, got %q (%v)", upstream, err)
+	}
+
+	runGitCommand(t, repoDir, "remote", "add", "origin", originDir)
+	runGitCommand(t, repoDir, "push", "-u", "origin", "main")
+	runGitCommand(t, baseDir, "clone", originDir, otherDir)
+	runGitCommand(t, otherDir, "config", "user.email", "test@example.com")
+	runGitCommand(t, otherDir, "config", "user.name", "Test User")
+	runGitCommand(t, otherDir, "commit", "--allow-empty", "-m", "remote commit")
+	runGitCommand(t, otherDir, "push", "origin", "main")
+
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "local commit 1")
+	runGitCommand(t, repoDir, "commit", "--allow-empty", "-m", "local commit 2")
+	runGitCommand(t, repoDir, "fetch", "origin")
+
+	upstream, ahead, behind, err := g.UpstreamStatus()
+	if err != nil {
+		t.Fatalf("UpstreamStatus failed: %v", err)
+	}
+	if upstream != "origin/main" || ahead != 2 || behind != 1 {
+		t.Errorf("Expected origin/main 2 ahead 1 behind, got %s %d ahead %d behind", upstream, ahead, behind)
+	}
+}
@@ -0,0 +1,161 @@
+// Package gitfixture builds disposable git repositories for tests: commits, tags,
+// submodules and a local bare repository as the origin remote. The managers run git
+// in the current directory, so tests Chdir into the fixture before using them.
+package gitfixture
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Repo is a repository on branch main whose origin is a bare repository next to it.
+// Both are removed when the test ends.
+type Repo struct {
+	t testing.TB
+	// Dir is the working tree and Origin the bare repository it pushes to
+	Dir    string
+	Origin string
+}
+
+// New creates an empty repository with a configured committer and an origin remote
+func New(t testing.TB) *Repo {
+	t.Helper()
+	base := t.TempDir()
+	r := &Repo{
+		t:      t,
+		Dir:    filepath.Join(base, "repo"),
+		Origin: filepath.Join(base, "origin.git"),
+	}
+
+	run(t, base, "init", "--bare", "-b", "main", r.Origin)
+	run(t, base, "init", "-b", "main", r.Dir)
+	r.Git("config", "user.email", "test@example.com")
+	r.Git("config", "user.name", "Test User")
+	// Keep the user's signing setup from prompting or failing in tests
+	r.Git("config", "commit.gpgsign", "false")
+	r.Git("config", "tag.gpgsign", "false")
+	r.Git("remote", "add", "origin", r.Origin)
+	return r
+}
+
+// Git runs git in the working tree and returns its trimmed output, failing the test on error
+func (r *Repo) Git(args ...string) string {
+	r.t.Helper()
+	return run(r.t, r.Dir, args...)
+}
+
+// OriginGit runs git in the origin repository, e.g. to check what was pushed
+func (r *Repo) OriginGit(args ...string) string {
+	r.t.Helper()
+	return run(r.t, r.Origin, args...)
+}
+
+// WriteFile writes a file relative to the working tree, creating its directories
+func (r *Repo) WriteFile(path, content string) {
+	r.t.Helper()
+	full := filepath.Join(r.Dir, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		r.t.Fatalf("Failed to create the directory of %s: %v", path, err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		r.t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// ReadFile returns the content of a file relative to the working tree
+func (r *Repo) ReadFile(path string) string {
+	r.t.Helper()
+	content, err := os.ReadFile(filepath.Join(r.Dir, path))
+	if err != nil {
+		r.t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
+
+// Commit stages every change and commits it with message
+func (r *Repo) Commit(message string) {
+	r.t.Helper()
+	r.Git("add", "--all")
+	r.Git("commit", "--allow-empty", "-m", message)
+}
+
+// Tag creates the annotated release tag v<version> at HEAD
+func (r *Repo) Tag(version string) {
+	r.t.Helper()
+	r.Git("tag", "-a", "v"+version, "-m", "Release version "+version)
+}
+
+// Push pushes main and every tag to origin and sets main's upstream
+func (r *Repo) Push() {
+	r.t.Helper()
+	r.Git("push", "--set-upstream", "origin", "main")
+	r.Git("push", "origin", "--tags")
+}
+
+// PushFromElsewhere commits to origin's main from another clone, as when someone
+// pushes while a release runs
+func (r *Repo) PushFromElsewhere(message string) {
+	r.t.Helper()
+	clone := filepath.Join(r.t.TempDir(), "elsewhere")
+	run(r.t, filepath.Dir(clone), "clone", r.Origin, clone)
+	run(r.t, clone, "-c", "user.email=other@example.com", "-c", "user.name=Other User",
+		"commit", "--allow-empty", "-m", message)
+	run(r.t, clone, "push", "origin", "main")
+}
+
+// PushFileFromElsewhere commits a file to origin's main from another clone, e.g. to
+// make a release commit conflict with what was pushed in the meantime
+func (r *Repo) PushFileFromElsewhere(path, content, message string) {
+	r.t.Helper()
+	clone := filepath.Join(r.t.TempDir(), "elsewhere")
+	run(r.t, filepath.Dir(clone), "clone", r.Origin, clone)
+	if err := os.WriteFile(filepath.Join(clone, path), []byte(content), 0644); err != nil {
+		r.t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	run(r.t, clone, "add", path)
+	run(r.t, clone, "-c", "user.email=other@example.com", "-c", "user.name=Other User",
+		"commit", "-m", message)
+	run(r.t, clone, "push", "origin", "main")
+}
+
+// AddSubmodule adds sub as a submodule at path and commits it. sub is checked out
+// at its current HEAD.
+func (r *Repo) AddSubmodule(path string, sub *Repo) {
+	r.t.Helper()
+	r.Git("-c", "protocol.file.allow=always", "submodule", "add", sub.Dir, path)
+	r.Commit("chore: add submodule " + path)
+}
+
+// Chdir makes the working tree the current directory until the test ends
+func (r *Repo) Chdir() {
+	r.t.Helper()
+	original, err := os.Getwd()
+	if err != nil {
+		r.t.Fatalf("Failed to get the current directory: %v", err)
+	}
+	if err := os.Chdir(r.Dir); err != nil {
+		r.t.Fatalf("Failed to change to %s: %v", r.Dir, err)
+	}
+	r.t.Cleanup(func() {
+		if err := os.Chdir(original); err != nil {
+			r.t.Logf("Warning: failed to change back to %s: %v", original, err)
+		}
+	})
+}
+
+func run(t testing.TB, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return strings.TrimSpace(string(output))
+}
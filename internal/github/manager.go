@@ -0,0 +1,285 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// GhCommandTimeout is the default timeout for gh CLI operations
+	GhCommandTimeout = 30 * time.Second
+	// ReleaseUploadTimeout bounds uploading release assets
+	ReleaseUploadTimeout = 5 * time.Minute
+	// MaxPullRequestsToFetch is the maximum number of merged pull requests requested in one listing
+	MaxPullRequestsToFetch = 200
+	// NotifyBatchSize is the most pull requests labeled or commented on in one GraphQL mutation
+	NotifyBatchSize = 20
+)
+
+// PullRequest is the subset of pull request fields used for changelog generation
+type PullRequest struct {
+	// ID is the GraphQL node ID, used to update pull requests in batches
+	ID       string    `json:"id"`
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body"`
+	MergedAt time.Time `json:"mergedAt"`
+}
+
+// Manager wraps the gh CLI for GitHub operations
+type Manager struct {
+	// runner runs every gh command
+	runner GhRunner
+}
+
+func NewManager() *Manager {
+	return &Manager{runner: ExecRunner{}}
+}
+
+// SetRunner replaces the runner used for gh commands
+func (m *Manager) SetRunner(runner GhRunner) {
+	m.runner = runner
+}
+
+// IsAvailable reports whether the gh CLI is installed
+func (m *Manager) IsAvailable() bool {
+	_, _, err := m.runner.Run(GhCommandTimeout, "--version")
+	return err == nil
+}
+
+// ListMergedPullRequests returns merged pull requests matching a GitHub search query,
+// e.g. "merged:>=2025-01-31"
+func (m *Manager) ListMergedPullRequests(search string) ([]PullRequest, error) {
+	args := []string{"pr", "list", "--state", "merged",
+		"--json", "id,number,title,body,mergedAt",
+		"--limit", strconv.Itoa(MaxPullRequestsToFetch)}
+	if search != "" {
+		args = append(args, "--search", search)
+	}
+
+	output, err := m.runGhCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequests []PullRequest
+	if err := json.Unmarshal([]byte(output), &pullRequests); err != nil {
+		return nil, fmt.Errorf("unable to parse gh pr list output: %v", err)
+	}
+
+	return pullRequests, nil
+}
+
+// Milestone is the subset of milestone fields used for release housekeeping
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// FindMilestone returns the milestone with the given title, or nil if none exists
+func (m *Manager) FindMilestone(title string) (*Milestone, error) {
+	output, err := m.runGhCommand("api", "--paginate", "repos/{owner}/{repo}/milestones?state=all&per_page=100")
+	if err != nil {
+		return nil, err
+	}
+
+	// --paginate concatenates the JSON arrays of every page
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for decoder.More() {
+		var page []Milestone
+		if err := decoder.Decode(&page); err != nil {
+			return nil, fmt.Errorf("unable to parse milestones: %v", err)
+		}
+		for _, milestone := range page {
+			if milestone.Title == title {
+				found := milestone
+				return &found, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// CloseMilestone closes an open milestone
+func (m *Manager) CloseMilestone(number int) error {
+	_, err := m.runGhCommand("api", "-X", "PATCH",
+		fmt.Sprintf("repos/{owner}/{repo}/milestones/%d", number), "-f", "state=closed")
+	return err
+}
+
+// CreateMilestone creates an open milestone with the given title
+func (m *Manager) CreateMilestone(title string) error {
+	_, err := m.runGhCommand("api", "-X", "POST", "repos/{owner}/{repo}/milestones", "-f", "title="+title)
+	return err
+}
+
+// RepoURL returns the web URL of the current repository
+func (m *Manager) RepoURL() (string, error) {
+	output, err := m.runGhCommand("repo", "view", "--json", "url", "--jq", ".url")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// CreatePullRequest opens a pull request from head into base and returns its URL
+func (m *Manager) CreatePullRequest(title, body, base, head string) (string, error) {
+	output, err := m.runGhCommand("pr", "create",
+		"--title", title, "--body", body, "--base", base, "--head", head)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// LatestReleaseTag returns the tag of the repository's latest GitHub release, or ""
+// if it has no releases
+func (m *Manager) LatestReleaseTag() (string, error) {
+	output, err := m.runGhCommand("api", "repos/{owner}/{repo}/releases/latest", "--jq", ".tag_name")
+	if err != nil {
+		if strings.Contains(err.Error(), "Not Found") || strings.Contains(err.Error(), "404") {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// UploadReleaseAssets attaches files to the GitHub release of tag, replacing assets
+// with the same name. The release is created with generated notes if the tag has none
+// yet, e.g. when no release workflow runs on tag pushes.
+func (m *Manager) UploadReleaseAssets(tag string, files []string) error {
+	if _, err := m.runGhCommand("release", "view", tag, "--json", "tagName"); err != nil {
+		if !strings.Contains(err.Error(), "release not found") {
+			return err
+		}
+		args := append([]string{"release", "create", tag, "--verify-tag", "--title", tag, "--generate-notes"}, files...)
+		_, err := m.runGhCommandWithTimeout(ReleaseUploadTimeout, args...)
+		return err
+	}
+
+	args := append([]string{"release", "upload", tag, "--clobber"}, files...)
+	_, err := m.runGhCommandWithTimeout(ReleaseUploadTimeout, args...)
+	return err
+}
+
+// ListTags returns the names of every tag in the remote repository
+func (m *Manager) ListTags() ([]string, error) {
+	output, err := m.runGhCommand("api", "--paginate", "repos/{owner}/{repo}/tags?per_page=100", "--jq", ".[].name")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// RateLimit is the state of the REST API rate limit for the authenticated user
+type RateLimit struct {
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"-"`
+}
+
+// GetRateLimit returns the remaining requests of a rate limit resource, "core" for the
+// REST API or "graphql", and when the limit resets
+func (m *Manager) GetRateLimit(resource string) (*RateLimit, error) {
+	output, err := m.runGhCommand("api", "rate_limit", "--jq", ".resources."+resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var limit struct {
+		Remaining int   `json:"remaining"`
+		Reset     int64 `json:"reset"`
+	}
+	if err := json.Unmarshal([]byte(output), &limit); err != nil {
+		return nil, fmt.Errorf("unable to parse rate limit: %v", err)
+	}
+
+	return &RateLimit{Remaining: limit.Remaining, Reset: time.Unix(limit.Reset, 0)}, nil
+}
+
+// EnsureLabel creates a label, updating its color and description if it already
+// exists, and returns its GraphQL node ID
+func (m *Manager) EnsureLabel(name, color, description string) (string, error) {
+	if _, err := m.runGhCommand("label", "create", name, "--color", color, "--description", description, "--force"); err != nil {
+		return "", err
+	}
+
+	output, err := m.runGhCommand("api", "graphql",
+		"-f", "query=query($owner: String!, $repo: String!, $name: String!) { repository(owner: $owner, name: $repo) { label(name: $name) { id } } }",
+		"-F", "owner={owner}", "-F", "repo={repo}", "-f", "name="+name,
+		"--jq", ".data.repository.label.id")
+	if err != nil {
+		return "", err
+	}
+	id := strings.TrimSpace(output)
+	if id == "" || id == "null" {
+		return "", fmt.Errorf("label %q not found after creating it", name)
+	}
+	return id, nil
+}
+
+// NotifyPullRequests adds a label and/or a comment to pull requests in a single
+// GraphQL mutation, one aliased field per update. An empty labelID or comment skips
+// that update. GitHub runs the fields in order and reports each failing one as an
+// error without undoing the others, so a failure may leave some pull requests updated.
+func (m *Manager) NotifyPullRequests(pullRequests []PullRequest, labelID, comment string) error {
+	if len(pullRequests) == 0 || (labelID == "" && comment == "") {
+		return nil
+	}
+
+	var variables, fields []string
+	args := []string{"api", "graphql"}
+	if labelID != "" {
+		variables = append(variables, "$label: ID!")
+		args = append(args, "-f", "label="+labelID)
+	}
+	if comment != "" {
+		variables = append(variables, "$body: String!")
+		args = append(args, "-f", "body="+comment)
+	}
+	for i, pr := range pullRequests {
+		if pr.ID == "" {
+			return fmt.Errorf("pull request #%d has no node ID", pr.Number)
+		}
+		variables = append(variables, fmt.Sprintf("$pr%d: ID!", i))
+		args = append(args, "-f", fmt.Sprintf("pr%d=%s", i, pr.ID))
+		if labelID != "" {
+			fields = append(fields, fmt.Sprintf(
+				"label%d: addLabelsToLabelable(input: {labelableId: $pr%d, labelIds: [$label]}) { clientMutationId }", i, i))
+		}
+		if comment != "" {
+			fields = append(fields, fmt.Sprintf(
+				"comment%d: addComment(input: {subjectId: $pr%d, body: $body}) { clientMutationId }", i, i))
+		}
+	}
+
+	query := fmt.Sprintf("mutation(%s) { %s }", strings.Join(variables, ", "), strings.Join(fields, " "))
+	_, err := m.runGhCommand(append(args, "-f", "query="+query)...)
+	return err
+}
+
+// IsRateLimitError reports whether a gh failure was caused by the primary or secondary rate limit
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "rate limit")
+}
+
+func (m *Manager) runGhCommand(args ...string) (string, error) {
+	return m.runGhCommandWithTimeout(GhCommandTimeout, args...)
+}
+
+func (m *Manager) runGhCommandWithTimeout(timeout time.Duration, args ...string) (string, error) {
+	stdout, stderr, err := m.runner.Run(timeout, args...)
+	if err != nil {
+		return "", fmt.Errorf("gh %s failed: %v\nError: %s", strings.Join(args, " "), err, stderr)
+	}
+	return stdout, nil
+}
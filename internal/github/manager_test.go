@@ -0,0 +1,190 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubRunner answers gh commands with a function and records them
+type stubRunner struct {
+	respond func(args []string) (string, string, error)
+	calls   [][]string
+}
+
+func (s *stubRunner) Run(timeout time.Duration, args ...string) (string, string, error) {
+	s.calls = append(s.calls, args)
+	return s.respond(args)
+}
+
+// flag returns the value of a -f/-F field passed to gh api, or ""
+func flag(args []string, name string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if (args[i] == "-f" || args[i] == "-F") && strings.HasPrefix(args[i+1], name+"=") {
+			return strings.TrimPrefix(args[i+1], name+"=")
+		}
+	}
+	return ""
+}
+
+func TestListMergedPullRequests(t *testing.T) {
+	stub := &stubRunner{respond: func(args []string) (string, string, error) {
+		return `[{"id":"PR_1","number":12,"title":"Add export","body":"","mergedAt":"2026-01-02T03:04:05Z"}]`, "", nil
+	}}
+	manager := NewManager()
+	manager.SetRunner(stub)
+
+	pullRequests, err := manager.ListMergedPullRequests("merged:>=2026-01-01")
+	if err != nil {
+		t.Fatalf("ListMergedPullRequests failed: %v", err)
+	}
+	if len(pullRequests) != 1 || pullRequests[0].ID != "PR_1" || pullRequests[0].Number != 12 {
+		t.Fatalf("Unexpected pull requests %+v", pullRequests)
+	}
+	args := strings.Join(stub.calls[0], " ")
+	if !strings.Contains(args, "--json id,number,title,body,mergedAt") || !strings.HasSuffix(args, "--search merged:>=2026-01-01") {
+		t.Errorf("Unexpected gh arguments %q", args)
+	}
+}
+
+func TestGetRateLimit(t *testing.T) {
+	stub := &stubRunner{respond: func(args []string) (string, string, error) {
+		if strings.Join(args, " ") != "api rate_limit --jq .resources.graphql" {
+			return "", "", fmt.Errorf("unexpected gh %s", strings.Join(args, " "))
+		}
+		return `{"limit":5000,"remaining":42,"reset":1767225600}`, "", nil
+	}}
+	manager := NewManager()
+	manager.SetRunner(stub)
+
+	limit, err := manager.GetRateLimit("graphql")
+	if err != nil {
+		t.Fatalf("GetRateLimit failed: %v", err)
+	}
+	if limit.Remaining != 42 || !limit.Reset.Equal(time.Unix(1767225600, 0)) {
+		t.Errorf("Unexpected rate limit %+v", limit)
+	}
+}
+
+func TestEnsureLabel(t *testing.T) {
+	stub := &stubRunner{respond: func(args []string) (string, string, error) {
+		if args[0] == "label" {
+			return "", "", nil
+		}
+		if flag(args, "name") != "released in v1.2.0" || flag(args, "owner") != "{owner}" {
+			return "", "", fmt.Errorf("unexpected gh %s", strings.Join(args, " "))
+		}
+		return "LA_1\n", "", nil
+	}}
+	manager := NewManager()
+	manager.SetRunner(stub)
+
+	id, err := manager.EnsureLabel("released in v1.2.0", "0E8A16", "Shipped in v1.2.0")
+	if err != nil || id != "LA_1" {
+		t.Fatalf("Expected label ID LA_1, got %q (%v)", id, err)
+	}
+	if got := strings.Join(stub.calls[0], " "); got != "label create released in v1.2.0 --color 0E8A16 --description Shipped in v1.2.0 --force" {
+		t.Errorf("Unexpected label create arguments %q", got)
+	}
+}
+
+func TestNotifyPullRequests(t *testing.T) {
+	pullRequests := []PullRequest{{ID: "PR_1", Number: 1}, {ID: "PR_2", Number: 2}}
+	tests := []struct {
+		name      string
+		labelID   string
+		comment   string
+		fields    []string
+		variables []string
+		calls     int
+	}{
+		{
+			name:    "label and comment",
+			labelID: "LA_1",
+			comment: "Released",
+			fields: []string{
+				"label0: addLabelsToLabelable(input: {labelableId: $pr0, labelIds: [$label]})",
+				"comment0: addComment(input: {subjectId: $pr0, body: $body})",
+				"label1: addLabelsToLabelable(input: {labelableId: $pr1, labelIds: [$label]})",
+				"comment1: addComment(input: {subjectId: $pr1, body: $body})",
+			},
+			variables: []string{"mutation($label: ID!, $body: String!, $pr0: ID!, $pr1: ID!)"},
+			calls:     1,
+		},
+		{
+			name:      "label only",
+			labelID:   "LA_1",
+			fields:    []string{"label1: addLabelsToLabelable"},
+			variables: []string{"mutation($label: ID!, $pr0: ID!, $pr1: ID!)"},
+			calls:     1,
+		},
+		{
+			name:      "comment only",
+			comment:   "Released",
+			fields:    []string{"comment1: addComment"},
+			variables: []string{"mutation($body: String!, $pr0: ID!, $pr1: ID!)"},
+			calls:     1,
+		},
+		{
+			name: "nothing to do",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubRunner{respond: func(args []string) (string, string, error) { return "{}", "", nil }}
+			manager := NewManager()
+			manager.SetRunner(stub)
+
+			if err := manager.NotifyPullRequests(pullRequests, tt.labelID, tt.comment); err != nil {
+				t.Fatalf("NotifyPullRequests failed: %v", err)
+			}
+			if len(stub.calls) != tt.calls {
+				t.Fatalf("Expected %d gh calls, got %d", tt.calls, len(stub.calls))
+			}
+			if tt.calls == 0 {
+				return
+			}
+
+			args := stub.calls[0]
+			query := flag(args, "query")
+			for _, expected := range append(tt.fields, tt.variables...) {
+				if !strings.Contains(query, expected) {
+					t.Errorf("Expected the mutation to contain %q, got %q", expected, query)
+				}
+			}
+			if tt.labelID == "" && strings.Contains(query, "addLabelsToLabelable") || tt.comment == "" && strings.Contains(query, "addComment") {
+				t.Errorf("Unexpected update in %q", query)
+			}
+			if flag(args, "pr0") != "PR_1" || flag(args, "pr1") != "PR_2" || flag(args, "label") != tt.labelID || flag(args, "body") != tt.comment {
+				t.Errorf("Unexpected variables %v", args)
+			}
+		})
+	}
+
+	manager := NewManager()
+	manager.SetRunner(&stubRunner{respond: func(args []string) (string, string, error) { return "", "", nil }})
+	if err := manager.NotifyPullRequests([]PullRequest{{Number: 3}}, "LA_1", ""); err == nil {
+		t.Error("Expected an error for a pull request without a node ID")
+	}
+}
+
+func TestRunGhCommandError(t *testing.T) {
+	manager := NewManager()
+	manager.SetRunner(&stubRunner{respond: func(args []string) (string, string, error) {
+		return "", "API rate limit exceeded for user\n", errors.New("exit status 1")
+	}})
+
+	_, err := manager.runGhCommand("api", "rate_limit")
+	if err == nil || !strings.Contains(err.Error(), "gh api rate_limit failed") {
+		t.Fatalf("Expected the command in the error, got %v", err)
+	}
+	if !IsRateLimitError(err) {
+		t.Errorf("Expected a rate limit error from stderr, got %v", err)
+	}
+	if IsRateLimitError(errors.New("HTTP 404: Not Found")) || IsRateLimitError(nil) {
+		t.Error("Expected other errors not to be rate limit errors")
+	}
+}
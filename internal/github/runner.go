@@ -0,0 +1,33 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"time"
+)
+
+// GhRunner runs gh commands for the Manager. Tests inject a stub with SetRunner to
+// script gh's output (pull request listings, rate limits, API errors) without a
+// GitHub repository.
+type GhRunner interface {
+	// Run runs gh with args in the current directory, stopping it after timeout, and
+	// returns what it wrote to stdout and stderr
+	Run(timeout time.Duration, args ...string) (stdout, stderr string, err error)
+}
+
+// ExecRunner runs the gh executable
+type ExecRunner struct{}
+
+func (ExecRunner) Run(timeout time.Duration, args ...string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/changelog"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// startEntryEditing enters structured editing of the previewed changelog
+func (m MainModel) startEntryEditing() (tea.Model, tea.Cmd) {
+	m.entryEditing = true
+	m.bulletCursor = 0
+	return m.refreshEntryView(), nil
+}
+
+// updateEntryEditing handles the structured editing keys of the changelog preview
+func (m MainModel) updateEntryEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry := changelog.ParseEntry(m.generatedChanges)
+	bullets := entry.Bullets()
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.bulletCursor > 0 {
+			m.bulletCursor--
+		}
+		return m.refreshEntryView(), nil
+	case key.Matches(msg, m.keys.Down):
+		if m.bulletCursor < len(bullets)-1 {
+			m.bulletCursor++
+		}
+		return m.refreshEntryView(), nil
+	case msg.String() == "home" || msg.String() == "g":
+		m.bulletCursor = 0
+		return m.refreshEntryView(), nil
+	case (msg.String() == "end" || msg.String() == "G") && len(bullets) > 0:
+		m.bulletCursor = len(bullets) - 1
+		return m.refreshEntryView(), nil
+	case msg.String() == "a":
+		m.addingBullet = true
+		m.addCategory = m.defaultCategory(entry, bullets)
+		m.bulletInput.SetValue("")
+		return m, m.bulletInput.Focus()
+	case msg.String() == "d" && len(bullets) > 0:
+		entry.RemoveBullet(bullets[m.bulletCursor])
+		if m.bulletCursor >= len(bullets)-1 && m.bulletCursor > 0 {
+			m.bulletCursor--
+		}
+		return m.applyEntry(entry), nil
+	case (msg.String() == "m" || msg.String() == "M") && len(bullets) > 0:
+		ref := bullets[m.bulletCursor]
+		step := 1
+		if msg.String() == "M" {
+			step = -1
+		}
+		target := nextCategory(entry, ref.Category, step)
+		moved := entry.MoveBullet(ref, target)
+		// Follow the bullet to its new position
+		for i, bullet := range entry.Bullets() {
+			if bullet == moved {
+				m.bulletCursor = i
+			}
+		}
+		return m.applyEntry(entry), nil
+	case msg.String() == "e" || msg.Type == tea.KeyEsc:
+		m.entryEditing = false
+		return m.setChangelogContent(m.generatedChanges), nil
+	case key.Matches(msg, m.keys.Enter):
+		m.entryEditing = false
+		m = m.setChangelogContent(m.generatedChanges)
+		return m.updateChangelogPreview(msg)
+	}
+
+	return m, nil
+}
+
+// updateAddBullet handles typing a new bullet; tab picks the category
+func (m MainModel) updateAddBullet(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry := changelog.ParseEntry(m.generatedChanges)
+
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.addingBullet = false
+		m.bulletInput.Blur()
+		return m, nil
+	case tea.KeyTab:
+		m.addCategory = nextCategory(entry, m.addCategory, 1)
+		return m, nil
+	case tea.KeyEnter:
+		m.addingBullet = false
+		m.bulletInput.Blur()
+		text := strings.TrimSpace(m.bulletInput.Value())
+		if text == "" {
+			return m, nil
+		}
+		added := entry.AddBullet(m.addCategory, strings.TrimPrefix(text, "- "))
+		for i, bullet := range entry.Bullets() {
+			if bullet == added {
+				m.bulletCursor = i
+			}
+		}
+		return m.applyEntry(entry), nil
+	}
+
+	var cmd tea.Cmd
+	m.bulletInput, cmd = m.bulletInput.Update(msg)
+	return m, cmd
+}
+
+// applyEntry stores an edited entry as the generated changes and redraws the preview
+func (m MainModel) applyEntry(entry *changelog.Entry) MainModel {
+	return m.recordRevision(entry.String(), "edited").refreshEntryView()
+}
+
+// refreshEntryView renders the changelog with the selected bullet highlighted and
+// scrolls it into view
+func (m MainModel) refreshEntryView() MainModel {
+	entry := changelog.ParseEntry(m.generatedChanges)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Bold(true)
+
+	width := m.changelogView.Width
+	var lines []string
+	selectedLine := 0
+	bullet := 0
+	for _, category := range entry.Categories {
+		if category.Heading != "" {
+			lines = append(lines, wrapLine(category.Heading, width)...)
+		}
+		for _, item := range category.Items {
+			if !item.Bullet {
+				lines = append(lines, strings.Split(wrapChangelog(item.Text, width), "\n")...)
+				continue
+			}
+
+			if bullet != m.bulletCursor {
+				lines = append(lines, strings.Split(wrapChangelog("  - "+item.Text, width), "\n")...)
+				bullet++
+				continue
+			}
+			// Wrap before styling so the highlight covers every wrapped line
+			selectedLine = len(lines)
+			for _, line := range strings.Split(wrapChangelog("› - "+item.Text, width), "\n") {
+				lines = append(lines, selectedStyle.Render(line))
+			}
+			bullet++
+		}
+	}
+
+	m.changelogView.SetContent(strings.Join(lines, "\n"))
+	if selectedLine < m.changelogView.YOffset || selectedLine >= m.changelogView.YOffset+m.changelogView.Height {
+		m.changelogView.SetYOffset(selectedLine)
+	}
+	return m
+}
+
+// defaultCategory is where new bullets go: the selected bullet's category, or the first heading
+func (m MainModel) defaultCategory(entry *changelog.Entry, bullets []changelog.BulletRef) int {
+	if m.bulletCursor < len(bullets) {
+		return bullets[m.bulletCursor].Category
+	}
+	return nextCategory(entry, 0, 1)
+}
+
+// nextCategory steps through the categories bullets can be placed in: every heading,
+// plus the unheaded start of the entry when it already holds bullets or there is no heading
+func nextCategory(entry *changelog.Entry, from, step int) int {
+	var choices []int
+	for c, category := range entry.Categories {
+		if category.Heading != "" || c == 0 && (len(entry.Categories) == 1 || hasBullets(category)) {
+			choices = append(choices, c)
+		}
+	}
+
+	for i, c := range choices {
+		if c == from {
+			return choices[(i+step+len(choices))%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+func hasBullets(category changelog.Category) bool {
+	for _, item := range category.Items {
+		if item.Bullet {
+			return true
+		}
+	}
+	return false
+}
+
+// entryEditStatus renders the add-bullet prompt shown below the preview
+func (m MainModel) entryEditStatus() string {
+	if !m.addingBullet {
+		return ""
+	}
+	entry := changelog.ParseEntry(m.generatedChanges)
+	label := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Bold(true).
+		Render(fmt.Sprintf("Add to %s:", entry.CategoryName(m.addCategory)))
+	return label + " " + m.bulletInput.View()
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"bump-tui/internal/crash"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// InterruptMsg is sent when the process receives SIGINT, SIGTERM or SIGHUP. A running
+// release is canceled and rolled back before the TUI exits; otherwise it quits.
+type InterruptMsg struct{}
+
+// crashMsg carries a panic of a command's goroutine to Update, where it is reported
+// with the pipeline state
+type crashMsg struct {
+	value any
+	stack []byte
+}
+
+var stateNames = map[sessionState]string{
+	welcomeView:             "welcome",
+	detectedFilesView:       "detected files",
+	validationView:          "validation",
+	affectedPackagesView:    "affected packages",
+	versionSelectView:       "version select",
+	rewordView:              "reword",
+	changelogGeneratingView: "changelog generating",
+	changelogPreviewView:    "changelog preview",
+	confirmationView:        "confirmation",
+	countdownView:           "countdown",
+	progressView:            "progress",
+	resultsView:             "results",
+}
+
+// Update handles a message. A panic, in Update or in a command it returned, is
+// re-raised as a *crash.Report so the program's caller can restore the terminal and
+// report it with the state of the release.
+func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer m.recoverCrash()
+	if msg, ok := msg.(crashMsg); ok {
+		panic(m.crashReport(msg.value, msg.stack))
+	}
+	model, cmd := m.update(msg)
+	return model, guardCmd(cmd)
+}
+
+// recoverCrash turns a panic into a *crash.Report of the model; deferred by Update and View
+func (m MainModel) recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if _, ok := r.(*crash.Report); ok {
+		panic(r)
+	}
+	panic(m.crashReport(r, debug.Stack()))
+}
+
+func (m MainModel) crashReport(value any, stack []byte) *crash.Report {
+	return crash.NewReport(value, stack, m.crashState(), m.gitManager, m.newVersion)
+}
+
+// crashState describes the release for a crash report
+func (m MainModel) crashState() []string {
+	state := []string{fmt.Sprintf("view: %s", stateNames[m.state])}
+	if m.versionManager.CurrentVersion != nil {
+		state = append(state, fmt.Sprintf("current version: %s", m.versionManager.CurrentVersion))
+	}
+	if m.newVersion != "" {
+		state = append(state, fmt.Sprintf("new version: %s", m.newVersion))
+	}
+	if m.state == progressView {
+		state = append(state, "release pipeline: running")
+	}
+	if m.resuming {
+		state = append(state, fmt.Sprintf("resuming the release commit of %s", m.pendingRelease))
+	}
+	if m.quitting {
+		state = append(state, "release canceled by the user, rolling back")
+	}
+	if m.pushRejected {
+		state = append(state, "push rejected, release commit and tag are local")
+	}
+	if m.err != nil {
+		state = append(state, fmt.Sprintf("error: %v", m.err))
+	}
+	return state
+}
+
+// guardCmd runs cmd, and the commands of a batch it returns, so that a panic becomes
+// a crashMsg instead of killing the process with the terminal still in raw mode
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = crashMsg{value: r, stack: debug.Stack()}
+			}
+		}()
+
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			guarded := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				guarded[i] = guardCmd(c)
+			}
+			return guarded
+		}
+		return msg
+	}
+}
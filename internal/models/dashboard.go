@@ -0,0 +1,320 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/version"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// workspaceStatus is the state of the repository summarized on the welcome dashboard
+type workspaceStatus struct {
+	// repo is the name of the repository's top-level directory
+	repo   string
+	branch string
+	// upstream is the remote-tracking branch, "" when the branch tracks none
+	upstream string
+	ahead    int
+	behind   int
+	dirty    bool
+	// lastTag is the latest version tag reachable from HEAD, "" before the first release
+	lastTag      string
+	lastTagDate  time.Time
+	commitsSince int
+	submodules   []submoduleStatus
+}
+
+// submoduleStatus is a submodule and the tag it is checked out at, if any
+type submoduleStatus struct {
+	path string
+	tag  string
+}
+
+// manifestVersion is the version a managed file currently holds
+type manifestVersion struct {
+	file version.ProjectFile
+	// version is "" when the file has none or it can't be read
+	version string
+}
+
+// workspaceStatusMsg carries the repository state read for the dashboard
+type workspaceStatusMsg struct {
+	status workspaceStatus
+	err    error
+}
+
+// generatorsCheckedMsg reports which changelog generators can run on this machine
+type generatorsCheckedMsg struct {
+	generators []changelog.GeneratorStatus
+}
+
+// loadWorkspaceStatus reads the branch, remote, tag and submodule state for the
+// dashboard. It only needs git, so it runs alongside the project detection.
+func (m MainModel) loadWorkspaceStatus() tea.Msg {
+	var status workspaceStatus
+	root, err := m.gitManager.RepositoryRoot()
+	if err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+	status.repo = filepath.Base(root)
+	if status.branch, err = m.gitManager.GetCurrentBranch(); err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+
+	if status.upstream, status.ahead, status.behind, err = m.gitManager.UpstreamStatus(); err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+	if status.dirty, err = m.gitManager.HasUncommittedChanges(); err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+
+	if status.lastTag, err = m.gitManager.LatestTag("v[0-9]*.*.*"); err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+	if status.lastTag != "" {
+		if status.lastTagDate, err = m.gitManager.GetTagDate(status.lastTag); err != nil {
+			return workspaceStatusMsg{err: err}
+		}
+	}
+	if status.commitsSince, err = m.gitManager.CountCommits(status.lastTag, ":/"); err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+
+	submodules, err := m.gitManager.Submodules()
+	if err != nil {
+		return workspaceStatusMsg{err: err}
+	}
+	for _, submodule := range submodules {
+		status.submodules = append(status.submodules, submoduleStatus{
+			path: submodule.Path,
+			tag:  m.gitManager.SubmoduleTag(submodule.Path),
+		})
+	}
+	return workspaceStatusMsg{status: status}
+}
+
+// checkGenerators looks for the changelog generators of the chain once the settings
+// are loaded, since finding the Claude CLI takes a moment
+func (m MainModel) checkGenerators() tea.Msg {
+	return generatorsCheckedMsg{generators: m.changelogManager.Generators()}
+}
+
+// manifestVersions reads the current version of every managed file
+func (m MainModel) manifestVersions() []manifestVersion {
+	var manifests []manifestVersion
+	for _, file := range m.versionManager.ProjectFiles {
+		manifest := manifestVersion{file: file}
+		if v, err := m.versionManager.FileVersion(file); err == nil && v != nil {
+			manifest.version = v.String()
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests
+}
+
+// dashboardReady reports whether the project is loaded and the generators were
+// checked, so the release can start
+func (m MainModel) dashboardReady() bool {
+	return m.projectLoaded && m.generatorsChecked
+}
+
+// updateWelcome starts the release from the dashboard once everything it needs is
+// loaded; "p" starts a quick patch release
+func (m MainModel) updateWelcome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.dashboardReady() {
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.Enter):
+	case msg.String() == "p":
+		m.quickPatch = true
+	default:
+		return m, nil
+	}
+
+	// Let the user choose which auto-detected files to manage when there is a choice
+	if m.needsFileSelection() {
+		m.fileSelected = make([]bool, len(m.versionManager.ProjectFiles))
+		for i := range m.fileSelected {
+			m.fileSelected[i] = true
+		}
+		m.state = detectedFilesView
+		return m, nil
+	}
+	return m.startValidation()
+}
+
+func (m MainModel) welcomeView() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Bold(true).
+		Render("🚀 Bump - Version Manager")
+
+	subtitle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6e738d")).
+		Render("Interactive semantic version management tool")
+
+	footer := "Detecting project files... • q: quit"
+	if m.dashboardReady() {
+		footer = fmt.Sprintf("enter: start a release • p: patch release v%s • q: quit", m.versionManager.BumpPatch())
+	} else if m.projectLoaded {
+		footer = "Checking changelog generators... • q: quit"
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		subtitle,
+		"",
+		m.dashboardView(),
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(footer),
+		"",
+		m.updateBannerView(),
+	)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// dashboardView lists the workspace state; rows still loading show an ellipsis
+func (m MainModel) dashboardView() string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Width(12)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#f5a97f"))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#a6da95"))
+	loading := mutedStyle.Render("…")
+
+	var rows []string
+	row := func(label string, values ...string) {
+		for i, value := range values {
+			if i > 0 {
+				label = ""
+			}
+			rows = append(rows, labelStyle.Render(label)+value)
+		}
+	}
+
+	// Versions come from the project detection
+	switch {
+	case !m.projectLoaded:
+		row("Version", loading)
+	case m.settings.Release.TagOnly:
+		row("Version", m.versionManager.CurrentVersion.String()+mutedStyle.Render(" (from tags)"))
+	default:
+		row("Version", m.versionManager.CurrentVersion.String())
+		var manifests []string
+		for _, manifest := range m.manifests {
+			line := fmt.Sprintf("%s %s", manifest.file.Path, mutedStyle.Render("("+string(manifest.file.Type)+")"))
+			if manifest.version != "" && manifest.version != m.versionManager.CurrentVersion.String() {
+				line += " " + warnStyle.Render(manifest.version)
+			} else if manifest.version != "" {
+				line += " " + manifest.version
+			}
+			manifests = append(manifests, line)
+		}
+		if len(manifests) > 0 {
+			row("Manifests", manifests...)
+		}
+	}
+
+	// Repository state comes from git alone
+	switch {
+	case m.workspaceErr != nil:
+		row("Repository", warnStyle.Render(fmt.Sprintf("unavailable: %v", firstLine(m.workspaceErr.Error()))))
+	case m.workspace == nil:
+		row("Last tag", loading)
+		row("Branch", loading)
+	default:
+		ws := m.workspace
+		if ws.lastTag == "" {
+			row("Last tag", mutedStyle.Render("none yet"))
+		} else {
+			row("Last tag", fmt.Sprintf("%s %s", ws.lastTag, mutedStyle.Render("("+formatAge(time.Since(ws.lastTagDate))+")")))
+		}
+		since := fmt.Sprintf("%d commits since the last release", ws.commitsSince)
+		if ws.commitsSince == 1 {
+			since = "1 commit since the last release"
+		}
+		row("Unreleased", since)
+
+		branch := ws.branch
+		switch {
+		case ws.upstream == "":
+			branch += mutedStyle.Render(" (no upstream)")
+		case ws.ahead == 0 && ws.behind == 0:
+			branch += " → " + ws.upstream + " " + okStyle.Render("up to date")
+		default:
+			branch += " → " + ws.upstream + " " + warnStyle.Render(fmt.Sprintf("%d ahead, %d behind", ws.ahead, ws.behind))
+		}
+		if ws.dirty {
+			branch += " " + warnStyle.Render("• uncommitted changes")
+		}
+		row("Branch", branch)
+
+		if len(ws.submodules) > 0 {
+			var untagged []string
+			for _, submodule := range ws.submodules {
+				if submodule.tag == "" {
+					untagged = append(untagged, submodule.path)
+				}
+			}
+			summary := fmt.Sprintf("%d, all at tags", len(ws.submodules))
+			if len(untagged) > 0 {
+				summary = fmt.Sprintf("%d, %s", len(ws.submodules), warnStyle.Render("not at a tag: "+strings.Join(untagged, ", ")))
+			}
+			row("Submodules", summary)
+		}
+	}
+
+	// Generators are checked once the settings name the chain
+	if !m.generatorsChecked {
+		row("Changelog", loading)
+	} else {
+		var generators []string
+		for _, generator := range m.generators {
+			if generator.Available {
+				generators = append(generators, okStyle.Render("✓ ")+generator.Name)
+			} else {
+				generators = append(generators, warnStyle.Render("✗ ")+mutedStyle.Render(generator.Name))
+			}
+		}
+		row("Changelog", strings.Join(generators, "  "))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// formatAge describes how long ago something happened, e.g. "3 days ago"
+func formatAge(age time.Duration) string {
+	days := int(age.Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days < 60:
+		return fmt.Sprintf("%d days ago", days)
+	case days < 730:
+		return fmt.Sprintf("%d months ago", days/30)
+	default:
+		return fmt.Sprintf("%d years ago", days/365)
+	}
+}
+
+// firstLine returns the first line of a possibly multi-line message
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+
+	"bump-tui/internal/changelog"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxChangelogRevisions bounds the changelog drafts kept for undo
+const maxChangelogRevisions = 20
+
+// changelogRevision is a draft of the previewed changelog and how it came about,
+// e.g. "generated", "edited" or "refined"
+type changelogRevision struct {
+	changes string
+	source  string
+}
+
+// recordRevision makes changes the current draft. Drafts undone before it are
+// dropped, like the redo history of an editor.
+func (m MainModel) recordRevision(changes, source string) MainModel {
+	m.generatedChanges = changes
+	if len(m.changelogRevisions) > 0 && m.changelogRevisions[m.revisionIndex].changes == changes {
+		return m
+	}
+
+	revisions := append([]changelogRevision{}, m.changelogRevisions...)
+	if len(revisions) > 0 {
+		revisions = revisions[:m.revisionIndex+1]
+	}
+	revisions = append(revisions, changelogRevision{changes: changes, source: source})
+	if len(revisions) > maxChangelogRevisions {
+		revisions = revisions[len(revisions)-maxChangelogRevisions:]
+	}
+	m.changelogRevisions = revisions
+	m.revisionIndex = len(revisions) - 1
+	return m
+}
+
+// canUndo reports whether an earlier draft exists
+func (m MainModel) canUndo() bool {
+	return m.revisionIndex > 0
+}
+
+// canRedo reports whether a draft was undone that can be restored
+func (m MainModel) canRedo() bool {
+	return m.revisionIndex < len(m.changelogRevisions)-1
+}
+
+// showRevision moves step drafts back (-1) or forward (1) through the history
+func (m MainModel) showRevision(step int) MainModel {
+	m.revisionIndex += step
+	m.generatedChanges = m.changelogRevisions[m.revisionIndex].changes
+	m.refineErr = nil
+	if m.entryEditing {
+		if bullets := len(changelog.ParseEntry(m.generatedChanges).Bullets()); m.bulletCursor >= bullets && bullets > 0 {
+			m.bulletCursor = bullets - 1
+		}
+		return m.refreshEntryView()
+	}
+	return m.setChangelogContent(m.generatedChanges)
+}
+
+// revisionStatus shows the position in the draft history once there is more than one draft
+func (m MainModel) revisionStatus() string {
+	if len(m.changelogRevisions) < 2 {
+		return ""
+	}
+	status := fmt.Sprintf("Draft %d of %d (%s)", m.revisionIndex+1, len(m.changelogRevisions), m.changelogRevisions[m.revisionIndex].source)
+	if m.canUndo() {
+		status += " • u: undo"
+	}
+	if m.canRedo() {
+		status += " • ctrl+r: redo"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(status)
+}
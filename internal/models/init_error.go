@@ -0,0 +1,187 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/version"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// initStage identifies the part of project initialization that failed
+type initStage int
+
+const (
+	initStageGit initStage = iota
+	initStageRange
+	initStageSettings
+	initStagePlugins
+	initStageTags
+	initStageFiles
+)
+
+// initFailure is a structured description of an initialization error
+type initFailure struct {
+	title string
+	// file is the file the problem was found in, if any
+	file string
+	// detail is the specific problem, e.g. the TOML parse error with its position
+	detail      string
+	suggestions []string
+}
+
+// describeInitError turns an initProject error into a failure with recovery suggestions
+func describeInitError(stage initStage, err error) initFailure {
+	switch stage {
+	case initStageGit:
+		return initFailure{
+			title:  "Not a git repository",
+			detail: err.Error(),
+			suggestions: []string{
+				"Run bump from the root of your project",
+				"Run `git init` and make a first commit to start tracking this project",
+			},
+		}
+
+	case initStageRange:
+		return initFailure{
+			title:  "Invalid commit range",
+			detail: err.Error(),
+			suggestions: []string{
+				"Check the -since and -until refs exist (`git rev-parse <ref>`)",
+				"Run `git fetch --tags` if the ref is a tag from the remote",
+			},
+		}
+
+	case initStageSettings:
+		failure := initFailure{
+			title:  "Could not load " + config.SettingsFileName,
+			file:   config.SettingsFileName,
+			detail: err.Error(),
+		}
+
+		var strictErr *toml.StrictMissingError
+		var decodeErr *toml.DecodeError
+		switch {
+		case errors.As(err, &strictErr):
+			failure.detail = "Unknown keys:\n" + strictErr.String()
+			failure.suggestions = []string{
+				"Remove or rename the unknown keys; typos are rejected rather than ignored",
+				"See the configuration section of the README for the supported keys",
+			}
+		case errors.As(err, &decodeErr):
+			row, column := decodeErr.Position()
+			failure.file = fmt.Sprintf("%s:%d:%d", config.SettingsFileName, row, column)
+			failure.detail = decodeErr.Error() + "\n" + decodeErr.String()
+			failure.suggestions = []string{
+				"Fix the TOML syntax at the position shown above",
+				"Strings must be quoted and tables written as [section]",
+			}
+		default:
+			failure.suggestions = []string{
+				"Fix the value reported above",
+				"Delete " + config.SettingsFileName + " to fall back to the defaults",
+			}
+		}
+		return failure
+
+	case initStagePlugins:
+		return initFailure{
+			title:  "Could not load plugins",
+			file:   config.SettingsFileName,
+			detail: err.Error(),
+			suggestions: []string{
+				"Install the plugin executable (bump-plugin-<name>) somewhere on PATH",
+				"Check that WASM modules in plugins.wasm exist and export memory, alloc and handle",
+				"Remove the plugin from " + config.SettingsFileName,
+			},
+		}
+
+	case initStageTags:
+		return initFailure{
+			title:  "Could not read the version from tags",
+			detail: err.Error(),
+			suggestions: []string{
+				"Create a first release tag, e.g. `git tag v0.1.0`",
+				"Run `git fetch --tags` if the tags only exist on the remote",
+			},
+		}
+	}
+
+	var fileErr *version.FileError
+	if errors.As(err, &fileErr) {
+		suggestions := []string{
+			fmt.Sprintf("Make sure %s contains a semantic version such as 1.2.3", fileErr.File.Path),
+		}
+		if fileErr.File.Pattern != "" {
+			suggestions = append(suggestions, fmt.Sprintf("Narrow the pattern %s in .bump so it only matches version files", fileErr.File.Pattern))
+		} else {
+			suggestions = append(suggestions, "Remove the file from .bump if bump shouldn't manage it")
+		}
+		return initFailure{
+			title:       "Could not read a version file",
+			file:        fileErr.File.Path,
+			detail:      fileErr.Err.Error(),
+			suggestions: suggestions,
+		}
+	}
+
+	if errors.Is(err, version.ErrBumpConfig) {
+		return initFailure{
+			title:  "Could not load .bump",
+			file:   ".bump",
+			detail: err.Error(),
+			suggestions: []string{
+				"List one version file path or glob per line; lines starting with # are comments",
+				"Fix or remove entries that point at missing files",
+				"Delete .bump to let bump detect version files automatically",
+			},
+		}
+	}
+
+	return initFailure{
+		title:  "Could not detect version files",
+		detail: err.Error(),
+		suggestions: []string{
+			"Create a .bump file listing the files that hold your version",
+		},
+	}
+}
+
+// initErrorView renders an initialization failure with the failing file and suggestions
+func (m MainModel) initErrorView() string {
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#ed8796")).
+		Bold(true)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+
+	sections := []string{
+		errorStyle.Render("❌ " + m.initFailure.title),
+		"",
+	}
+	if m.initFailure.file != "" {
+		sections = append(sections, labelStyle.Render("File: ")+m.initFailure.file, "")
+	}
+	sections = append(sections, m.initFailure.detail, "")
+
+	if len(m.initFailure.suggestions) > 0 {
+		sections = append(sections, labelStyle.Render("To fix this:"))
+		for _, suggestion := range m.initFailure.suggestions {
+			sections = append(sections, "  • "+suggestion)
+		}
+		sections = append(sections, "")
+	}
+	sections = append(sections, mutedStyle.Render("Press q to quit"))
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left, sections...),
+	)
+}
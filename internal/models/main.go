@@ -2,15 +2,23 @@ package models
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
 	"bump-tui/internal/git"
+	"bump-tui/internal/plugin"
+	"bump-tui/internal/registry"
+	"bump-tui/internal/release"
+	"bump-tui/internal/update"
 	"bump-tui/internal/version"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -20,11 +28,15 @@ type sessionState int
 
 const (
 	welcomeView sessionState = iota
+	detectedFilesView
 	validationView
+	affectedPackagesView
 	versionSelectView
+	rewordView
 	changelogGeneratingView
 	changelogPreviewView
 	confirmationView
+	countdownView
 	progressView
 	resultsView
 )
@@ -112,6 +124,21 @@ func (i versionItem) Title() string       { return i.title }
 func (i versionItem) Description() string { return i.desc }
 func (i versionItem) FilterValue() string { return i.title }
 
+// Options are command-line options that adjust the TUI workflow
+type Options struct {
+	// Version of the running binary, used for the update check
+	Version string
+	// Since and Until override the commit range used for changelog generation
+	Since string
+	Until string
+	// ForceWithLease lets the pull-request workflow replace an existing remote release branch
+	ForceWithLease bool
+	// NoVerify bypasses git hooks for the release commit and pushes
+	NoVerify bool
+	// TagOnly only tags and pushes HEAD, without version files, changelog or commit
+	TagOnly bool
+}
+
 type MainModel struct {
 	state  sessionState
 	keys   keyMap
@@ -123,26 +150,182 @@ type MainModel struct {
 	versionManager   *version.Manager
 	gitManager       *git.Manager
 	changelogManager *changelog.Manager
+	releaseManager   *release.Manager
+	updateManager    *update.Manager
+	registryManager  *registry.Manager
+
+	// Project settings from .bump.toml
+	settings *config.Settings
+	options  Options
 
 	// UI components
 	versionList   list.Model
 	changelogView viewport.Model
 	spinner       spinner.Model
+	// changelogContent is the unwrapped text shown in changelogView
+	changelogContent string
+	// Search within the changelog preview: the query, the wrapped lines matching it and the current match
+	searching     bool
+	searchInput   textinput.Model
+	searchQuery   string
+	searchMatches []int
+	searchCursor  int
+	// AI refinement of the changelog preview
+	refining      bool
+	refineRunning bool
+	refineInput   textinput.Model
+	refineErr     error
+	// Drafts of the previewed changelog for undo/redo, and the one shown
+	changelogRevisions []changelogRevision
+	revisionIndex      int
+	// copyStatus reports the last copy to the clipboard until the next key
+	copyStatus string
 
 	// State data
-	selectedBump          bumpType
-	generatedChanges      string
-	newVersion            string
-	showHelp              bool
-	claudeEnabled         bool
+	selectedBump     bumpType
+	generatedChanges string
+	lintFixes        []string
+	// qualityIssues are discrepancies between the AI changelog and its commits
+	qualityIssues    []string
+	showPrompt       bool
+	changesFromCache bool
+	// changesGenerator is the generator that produced the changelog, e.g. "claude-cli"
+	changesGenerator string
+	aiEstimate       *changelog.Usage
+	aiUsage          *changelog.Usage
+	aiMonthSpend     float64
+	aiMonthlyBudget  float64
+	// Commits since the last release are all marked [skip changelog]/[skip release]
+	noReleaseNeeded   bool
+	skippedCommits    int
+	newVersion        string
+	showHelp          bool
+	aiEnabled         bool
 	validationSummary *git.ValidationSummary
+	// validationID discards results from an earlier validation run; remotePending holds
+	// the description of each remote check still running, "" once it reported back
+	validationID  int
+	remotePending []string
+	// Steps of the running validation that started, and the results of those that
+	// finished, until the summary arrives
+	runningSteps []git.ValidationStep
+	stepResults  []git.ValidationResult
+	// Fix actions of the validation findings: the selected one, whether it is running,
+	// and the label or error of the last one run
+	fixCursor int
+	fixing    bool
+	fixNote   string
+	fixErr    error
+	// Release commit at HEAD left by an earlier run, the version before it, and whether it is being finished
+	pendingRelease  string
+	pendingPrevious string
+	resuming        bool
+	// Changelog already has an entry for newVersion, e.g. from an aborted run
+	changelogEntryExists  bool
+	replaceChangelogEntry bool
+	// entryExistsNotice is set when y was pressed while the entry exists
+	entryExistsNotice bool
+	// Structured editing of the changelog preview: the selected bullet and the new bullet input
+	entryEditing bool
+	bulletCursor int
+	addingBullet bool
+	addCategory  int
+	bulletInput  textinput.Model
+	// Unparseable commits offered for a changelog-only description, one at a time
+	rewordCommits []git.Commit
+	rewordIndex   int
+	rewordInput   textinput.Model
+	rewordErr     error
+	// Typed version confirmation (release.strict_confirm)
+	confirmInput    textinput.Model
+	confirmTyping   bool
+	confirmMismatch bool
+	// Seconds left in the abort window before the release starts
+	countdown   int
+	countdownID int
+	// Progress of the post-release checklist, indexed like settings.Release.Checklist
+	checklist []checklistEntry
+	// Milestone changes made after the release, or the error that stopped them
+	milestoneNotes []string
+	milestoneErr   error
+	// Container images retagged after the release, or the error that stopped it
+	containerNotes []string
+	containerErr   error
+	// Notes of the post-release plugins, or the error that stopped them
+	pluginsRunning bool
+	pluginNotes    []string
+	pluginErr      error
+	// Local `goreleaser release` run after the tag is pushed
+	goreleaserRunning bool
+	goreleaserDone    bool
+	goreleaserErr     error
+	// Firmware built, renamed and attached after the tag is pushed
+	firmwareRunning bool
+	firmwareNotes   []string
+	firmwareErr     error
+	// Pull requests labeled/commented as released, or the error that stopped it
+	releasedPRs    int
+	releasedPRsErr error
+	// URL of the version bump pull request opened by the pull-request workflow
+	pullRequestURL string
+	// Auto-detected version files chosen for this release, indexed like versionManager.ProjectFiles
+	fileSelected  []bool
+	fileCursor    int
+	fileSelectErr error
+	filesSaved    bool
+	// Packages with and without commits since their last tag (monorepo.changed_only)
+	packageChanges []version.PackageChange
+	// Newer bump-tui release found by the startup check
+	latestRelease *update.Release
+	// Duration of each step, shown in the results view
+	timings []release.StepTiming
+	// pushRejected is set when err is a non-fast-forward push that can be rebased and retried
+	pushRejected bool
+	// initFailed is set when err came from loading the project; initFailure describes it
+	initFailed  bool
+	initFailure initFailure
+	// Welcome dashboard: the project detection finished, the managed files' versions,
+	// the repository state and the changelog generators, each loaded on its own
+	projectLoaded     bool
+	manifests         []manifestVersion
+	workspace         *workspaceStatus
+	workspaceErr      error
+	generators        []changelog.GeneratorStatus
+	generatorsChecked bool
+	// quickPatch is set by "p" on the dashboard: a clean validation continues on its
+	// own and version selection is skipped for a patch bump
+	quickPatch bool
+	// startedAt is when the session began, for the elapsed time in the status bar
+	startedAt time.Time
+	// Quitting while the release runs: the confirmation is shown, the release was
+	// canceled and is rolling back, and what happened to it, printed after exiting
+	confirmQuit bool
+	quitting    bool
+	quitNote    string
+}
+
+type checklistStatus int
+
+const (
+	checklistPending checklistStatus = iota
+	checklistRunning
+	checklistDone
+	checklistFailed
+)
+
+type checklistEntry struct {
+	status checklistStatus
+	err    error
 }
 
-func NewMainModel() MainModel {
+func NewMainModel(opts Options) MainModel {
 	// Initialize managers
 	versionManager := version.NewManager()
 	gitManager := git.NewManager()
 	changelogManager := changelog.NewManager()
+	changelogManager.SetCommitRange(opts.Since, opts.Until)
+	releaseManager := release.NewManager(versionManager, changelogManager)
+	releaseManager.SetForceWithLease(opts.ForceWithLease)
 
 	// Create version selection items
 	items := []list.Item{
@@ -198,90 +381,378 @@ func NewMainModel() MainModel {
 
 	changelogView := viewport.New(0, 0)
 
-	// Initialize spinner for Claude processing
+	// Input for the typed version confirmation
+	confirmInput := textinput.New()
+	confirmInput.Prompt = "› "
+	confirmInput.CharLimit = 64
+
+	// Input for changelog descriptions of unparseable commits
+	rewordInput := textinput.New()
+	rewordInput.Prompt = "› "
+	rewordInput.CharLimit = 200
+
+	// Input for bullets added in the changelog preview
+	bulletInput := textinput.New()
+	bulletInput.Prompt = "› "
+	bulletInput.CharLimit = 200
+
+	// Input for searching the changelog preview
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	searchInput.CharLimit = 100
+
+	// Input for instructions refining the changelog with AI
+	refineInput := textinput.New()
+	refineInput.Prompt = "AI› "
+	refineInput.Placeholder = "e.g. make it terser"
+	refineInput.CharLimit = 300
+
+	// Initialize spinner for AI processing
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#8aadf4"))
 
 	// Progress bar removed - using spinner for validation since it's instantaneous
 
-	// Check if Claude is available
-	claudeAvailable := changelogManager.IsClaudeAvailable()
-
 	return MainModel{
 		state:            welcomeView,
 		keys:             keys,
 		versionManager:   versionManager,
 		gitManager:       gitManager,
 		changelogManager: changelogManager,
+		releaseManager:   releaseManager,
+		updateManager:    update.NewManager(opts.Version),
+		registryManager:  registry.NewManager(),
+		settings:         config.DefaultSettings(),
+		options:          opts,
 		versionList:      versionList,
 		changelogView:    changelogView,
 		spinner:          s,
-		claudeEnabled:    claudeAvailable,
+		confirmInput:     confirmInput,
+		rewordInput:      rewordInput,
+		bulletInput:      bulletInput,
+		searchInput:      searchInput,
+		refineInput:      refineInput,
+		startedAt:        time.Now(),
 	}
 }
 
 type initDoneMsg struct {
 	projectFiles   []version.ProjectFile
 	currentVersion string
-	err            error
+	settings       *config.Settings
+	packageChanges []version.PackageChange
+	manifests      []manifestVersion
+	// pendingRelease is the version of a release commit at HEAD that an earlier run didn't finish
+	pendingRelease  string
+	pendingPrevious string
+	err             error
+	stage           initStage
 }
 
 type changelogGeneratedMsg struct {
-	changes string
-	err     error
+	changes   string
+	lintFixes []string
+	usage     *changelog.Usage
+	cached    bool
+	generator string
+	quality   []string
+	duration  time.Duration
+	err       error
+}
+
+type aiEstimateMsg struct {
+	estimate *changelog.Usage
+	spent    float64
+	budget   float64
+}
+
+type releaseNeededMsg struct {
+	needed  bool
+	skipped int
+}
+
+// checklistActionMsg reports the outcome of an automated checklist action
+type checklistActionMsg struct {
+	index int
+	err   error
+}
+
+// milestonesUpdatedMsg reports the milestone housekeeping done after a release
+type milestonesUpdatedMsg struct {
+	notes []string
+	err   error
+}
+
+// containersSyncedMsg reports the container images retagged after a release
+type containersSyncedMsg struct {
+	notes []string
+	err   error
+}
+
+// pluginsDoneMsg reports the post-release actions of plugins
+type pluginsDoneMsg struct {
+	notes []string
+	err   error
 }
 
+// goreleaserDoneMsg is sent when the local `goreleaser release` finished
+type goreleaserDoneMsg struct {
+	err error
+}
+
+// firmwarePublishedMsg is sent when the firmware binaries were built and published
+type firmwarePublishedMsg struct {
+	notes []string
+	err   error
+}
+
+// copiedMsg is sent when text was copied to the clipboard
+type copiedMsg struct {
+	target string
+	err    error
+}
+
+// releaseCompleteMsg is sent when the release pipeline finished
+type releaseCompleteMsg struct {
+	outcome *release.Outcome
+}
+
+// pushRejectedMsg is sent when the remote moved between validation and push
+type pushRejectedMsg struct {
+	err error
+}
+
+// releasedPRsMsg reports how many pull requests were notified about the release
+type releasedPRsMsg struct {
+	count int
+	err   error
+}
+
+// countdownTickMsg ticks the abort window; id discards ticks from an aborted countdown
+type countdownTickMsg struct {
+	id int
+}
+
+// validationStepMsg reports a validation step starting, with a nil result, or
+// finishing; events delivers the rest of the run
+type validationStepMsg struct {
+	id     int
+	step   git.ValidationStep
+	result *git.ValidationResult
+	events <-chan tea.Msg
+}
 
 type validationCompleteMsg struct {
-	summary *git.ValidationSummary
-	err     error
+	id       int
+	summary  *git.ValidationSummary
+	duration time.Duration
+	err      error
+}
+
+// updateAvailableMsg carries a newer bump-tui release found on startup
+type updateAvailableMsg struct {
+	release *update.Release
 }
 
 func (m MainModel) Init() tea.Cmd {
-	return tea.Batch(
-		tea.EnterAltScreen,
+	// The alt screen is a program option, left off in CI
+	return guardCmd(tea.Batch(
 		m.initProject,
-	)
+		m.loadWorkspaceStatus,
+		m.statusTick(),
+	))
+}
+
+// checkForUpdate looks for a newer bump-tui release unless disabled in the settings
+// loaded by initProject. Failures are ignored; the check must never get in the way
+// of a release.
+func (m MainModel) checkForUpdate() tea.Msg {
+	if !m.settings.Updates.Check {
+		return nil
+	}
+
+	latest, err := m.updateManager.CheckForUpdate()
+	if err != nil || latest == nil {
+		return nil
+	}
+	return updateAvailableMsg{release: latest}
 }
 
 func (m MainModel) initProject() tea.Msg {
 	// Check if we're in a git repository
 	if err := m.gitManager.IsGitRepository(); err != nil {
-		return initDoneMsg{err: err}
+		return initDoneMsg{err: err, stage: initStageGit}
+	}
+
+	// Make sure commit range overrides point at real commits
+	for _, ref := range []string{m.options.Since, m.options.Until} {
+		if ref == "" {
+			continue
+		}
+		if _, err := m.gitManager.ResolveRef(ref); err != nil {
+			return initDoneMsg{err: fmt.Errorf("invalid commit range: %v", err), stage: initStageRange}
+		}
 	}
 
-	// Detect version files
-	if err := m.versionManager.DetectVersionFiles("."); err != nil {
-		return initDoneMsg{err: err}
+	// Load optional project settings
+	settings, err := config.LoadSettings(".")
+	if err != nil {
+		return initDoneMsg{err: err, stage: initStageSettings}
+	}
+	if m.options.NoVerify {
+		settings.Git.NoVerify = true
+	}
+	if m.options.TagOnly {
+		settings.Release.TagOnly = true
+		if err := settings.Validate(); err != nil {
+			return initDoneMsg{err: err, stage: initStageSettings}
+		}
 	}
 
+	// Plugins may add version file handlers, changelog generators and post-release actions
+	plugins, err := plugin.Load(settings.Plugins.Enabled)
+	if err != nil {
+		return initDoneMsg{err: err, stage: initStagePlugins}
+	}
+	wasmPlugins, err := plugin.LoadWASM(settings.Plugins.WASM)
+	if err != nil {
+		return initDoneMsg{err: err, stage: initStagePlugins}
+	}
+	plugins = append(plugins, wasmPlugins...)
+	plugin.RegisterHandlers(plugins)
+	m.changelogManager.SetPlugins(plugins)
+	m.releaseManager.SetPlugins(plugins)
+
+	m.changelogManager.SetSettings(settings)
+	m.releaseManager.SetSettings(settings)
+
+	// Detect version files, or read the version from tags when only tagging
+	m.versionManager.SetSettings(settings)
+	if settings.Release.TagOnly {
+		if err := m.versionManager.DetectVersionFromTags(); err != nil {
+			return initDoneMsg{err: err, stage: initStageTags}
+		}
+	} else if err := m.versionManager.DetectVersionFiles("."); err != nil {
+		return initDoneMsg{err: err, stage: initStageFiles}
+	}
+
+	// Find the packages with commits since their last tag
+	var packageChanges []version.PackageChange
+	if settings.Monorepo.ChangedOnly && !settings.Release.TagOnly {
+		packageChanges, err = m.versionManager.DetectChangedPackages()
+		if err != nil {
+			return initDoneMsg{err: err, stage: initStageFiles}
+		}
+	}
+
+	pending, previous := m.releaseManager.PendingRelease(m.versionManager.CurrentVersion.String())
 	return initDoneMsg{
-		projectFiles:   m.versionManager.ProjectFiles,
-		currentVersion: m.versionManager.CurrentVersion.String(),
+		projectFiles:    m.versionManager.ProjectFiles,
+		currentVersion:  m.versionManager.CurrentVersion.String(),
+		settings:        settings,
+		packageChanges:  packageChanges,
+		manifests:       m.manifestVersions(),
+		pendingRelease:  pending,
+		pendingPrevious: previous,
 	}
 }
 
 func (m MainModel) generateChangelog() tea.Msg {
+	start := time.Now()
 	changes, err := m.changelogManager.GenerateChanges(m.versionManager.CurrentVersion.String())
+	if err != nil {
+		return changelogGeneratedMsg{err: err}
+	}
+
+	changes, lintFixes := m.lintChanges(changes)
 	return changelogGeneratedMsg{
-		changes: changes,
-		err:     err,
+		changes:   changes,
+		lintFixes: lintFixes,
+		usage:     m.changelogManager.LastUsage(),
+		cached:    m.changelogManager.LastFromCache(),
+		generator: m.changelogManager.LastGenerator(),
+		quality:   m.changelogManager.LastQualityIssues(),
+		duration:  time.Since(start),
+	}
+}
+
+// estimateAIUsage estimates the cost of the AI generation before it is invoked
+func (m MainModel) estimateAIUsage() tea.Msg {
+	spent, budget := m.changelogManager.BudgetStatus()
+	msg := aiEstimateMsg{spent: spent, budget: budget}
+
+	if estimate, err := m.changelogManager.EstimateUsage(m.versionManager.CurrentVersion.String()); err == nil {
+		msg.estimate = &estimate
 	}
+	return msg
 }
 
+// checkReleaseNeeded checks whether the commits since the last release were all marked as skipped
+func (m MainModel) checkReleaseNeeded() tea.Msg {
+	needed, skipped, err := m.changelogManager.ReleaseNeeded(m.versionManager.CurrentVersion.String())
+	if err != nil {
+		return releaseNeededMsg{needed: true}
+	}
+	return releaseNeededMsg{needed: needed, skipped: skipped}
+}
+
+// lintChanges applies the optional changelog lint stage configured in .bump.toml
+func (m MainModel) lintChanges(changes string) (string, []string) {
+	if !m.settings.Changelog.Lint {
+		return changes, nil
+	}
+	return changelog.Lint(changes)
+}
+
+// validateRepository runs the local checks, streaming each step as a
+// validationStepMsg before the validationCompleteMsg; the network checks follow in
+// the background once they are shown (see remoteChecks)
 func (m MainModel) validateRepository() tea.Cmd {
+	id := m.validationID
 	return func() tea.Msg {
-		summary, err := m.gitManager.ValidateRepositoryStatus()
-		if err != nil {
-			return validationCompleteMsg{err: err}
-		}
+		events := make(chan tea.Msg, validationEventBuffer)
+		go func() {
+			events <- m.runValidation(id, events)
+		}()
+		return <-events
+	}
+}
+
+// runValidation runs the repository and project checks, sending their progress to events
+func (m MainModel) runValidation(id int, events chan tea.Msg) validationCompleteMsg {
+	start := time.Now()
+	progress := git.ValidationProgress(func(step git.ValidationStep, result *git.ValidationResult) {
+		events <- validationStepMsg{id: id, step: step, result: result, events: events}
+	})
 
-		return validationCompleteMsg{summary: summary}
+	summary, err := m.gitManager.ValidateLocalStatus(progress)
+	if err != nil {
+		return validationCompleteMsg{id: id, err: err}
 	}
+	add := func(result git.ValidationResult) {
+		summary.Add(result)
+		progress.Finish(result)
+	}
+	// Changed-only monorepo packages are versioned independently
+	if !m.settings.Release.TagOnly && !m.settings.Monorepo.ChangedOnly {
+		add(m.versionManager.ValidateVersionSync())
+	}
+	if result := m.versionManager.ValidateRustSources(); result != nil {
+		add(*result)
+	}
+	if result := m.releaseManager.CheckGoreleaser(); result != nil {
+		add(*result)
+	}
+	if m.settings.CommitLint.Enabled {
+		add(m.changelogManager.ValidateCommitMessages(m.versionManager.CurrentVersion.String()))
+	}
+
+	return validationCompleteMsg{id: id, summary: summary, duration: time.Since(start)}
 }
 
-func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// update handles a message; Update wraps it to report panics with the pipeline state
+func (m MainModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -289,36 +760,88 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update sub-components
 		m.versionList.SetWidth(msg.Width - 4)
-		m.versionList.SetHeight(msg.Height - 8)
+		m.versionList.SetHeight(msg.Height - 9)
 		m.changelogView.Width = msg.Width - 12   // Account for border + padding
-		m.changelogView.Height = msg.Height - 12 // Account for header, version info, footer, spacing, and borders
+		m.changelogView.Height = msg.Height - 13 // Account for header, version info, footer, status bar, spacing, and borders
 
-		return m, nil
+		return m.rewrapChangelog(), nil
 
 	case initDoneMsg:
 		if msg.err != nil {
 			m.err = msg.err
+			m.initFailed = true
+			m.initFailure = describeInitError(msg.stage, msg.err)
 			return m, nil
 		}
 
-		// Project initialized successfully, move to validation
-		m.state = validationView
-		return m, tea.Batch(
-			m.validateRepository(),
-			m.spinner.Tick,
-		)
+		m.settings = msg.settings
+		m.packageChanges = msg.packageChanges
+		m.manifests = msg.manifests
+		m.pendingRelease = msg.pendingRelease
+		m.pendingPrevious = msg.pendingPrevious
+		m.projectLoaded = true
+
+		// The dashboard stays up until the user starts the release
+		return m, tea.Batch(m.checkGenerators, m.checkForUpdate)
+
+	case workspaceStatusMsg:
+		if msg.err != nil {
+			m.workspaceErr = msg.err
+			return m, nil
+		}
+		m.workspace = &msg.status
+		return m, nil
+
+	case generatorsCheckedMsg:
+		m.generators = msg.generators
+		m.generatorsChecked = true
+		for _, generator := range msg.generators {
+			if generator.AI && generator.Available {
+				m.aiEnabled = true
+			}
+		}
+		return m, nil
+
+	case updateAvailableMsg:
+		m.latestRelease = msg.release
+		return m, nil
+
+	case statusTickMsg:
+		return m, m.statusTick()
 
 	case validationCompleteMsg:
+		if msg.id != m.validationID {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
 
 		m.validationSummary = msg.summary
+		m.timings = release.SetTiming(m.timings, "validation", msg.duration)
 
-		// Always stay on validation view to show results
-		// User must press enter to continue or see errors
-		return m, nil
+		// Stay on validation view to show results while the remote checks run; a quick
+		// patch moves on once they all finished with nothing to read
+		return m.scheduleRemoteChecks()
+
+	case validationStepMsg:
+		if msg.id != m.validationID {
+			return m, nil
+		}
+		return m.recordValidationStep(msg), waitForValidation(msg.events)
+
+	case remoteChecksDueMsg:
+		if msg.id != m.validationID {
+			return m, nil
+		}
+		return m, m.runRemoteChecks()
+
+	case remoteCheckMsg:
+		return m.handleRemoteCheck(msg)
+
+	case fixAppliedMsg:
+		return m.handleFixApplied(msg)
 
 	case changelogGeneratedMsg:
 		if msg.err != nil {
@@ -326,26 +849,197 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		m.generatedChanges = msg.changes
-		m.changelogView.SetContent(msg.changes)
+		m = m.recordRevision(msg.changes, "generated")
+		m.lintFixes = msg.lintFixes
+		m.timings = release.SetTiming(m.timings, "AI changelog", msg.duration)
+		m.showPrompt = false
+		m.changesFromCache = msg.cached
+		m.changesGenerator = msg.generator
+		m.qualityIssues = msg.quality
+		m.refineErr = nil
+		if msg.usage != nil {
+			m.aiUsage = msg.usage
+			m.aiMonthSpend += msg.usage.CostUSD
+		}
+		m = m.setChangelogContent(msg.changes)
 		m.state = changelogPreviewView
 		return m, nil
 
+	case releaseNeededMsg:
+		m.noReleaseNeeded = !msg.needed
+		m.skippedCommits = msg.skipped
+		return m, nil
+
+	case countdownTickMsg:
+		if m.state != countdownView || msg.id != m.countdownID {
+			return m, nil
+		}
+		m.countdown--
+		if m.countdown <= 0 {
+			return m.startVersionBump()
+		}
+		return m, m.countdownTick()
+
+	case aiEstimateMsg:
+		m.aiEstimate = msg.estimate
+		m.aiMonthSpend = msg.spent
+		m.aiMonthlyBudget = msg.budget
+		return m, nil
+
 	case spinner.TickMsg:
-		if m.state == validationView || m.state == changelogGeneratingView || m.state == progressView {
+		if m.state == validationView || m.state == changelogGeneratingView || m.state == progressView ||
+			(m.state == resultsView && (m.goreleaserRunning || m.firmwareRunning || m.pluginsRunning)) {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
 		}
 		return m, nil
 
-	case string:
-		if msg == "success" {
-			m.state = resultsView
+	case releaseCompleteMsg:
+		// The push had started when quitting was confirmed, so the release went through
+		if m.quitting {
+			m.quitNote = fmt.Sprintf("Released v%s: it was already being pushed when quitting, so it was finished. "+
+				"Post-release steps were skipped.", m.newVersion)
+			return m, tea.Quit
+		}
+		m.state = resultsView
+		m.checklist = make([]checklistEntry, len(m.settings.Release.Checklist))
+		m.timings = append(m.timings, msg.outcome.Timings...)
+
+		// The release isn't published until the pull request is merged and tagged,
+		// so post-release housekeeping doesn't run yet
+		if msg.outcome.PullRequestURL != "" {
+			m.pullRequestURL = msg.outcome.PullRequestURL
 			return m, nil
 		}
+		cmds := []tea.Cmd{m.updateMilestones, m.notifyReleasedPRs, m.syncContainers}
+		if m.releaseManager.ShouldRunGoreleaser() {
+			m.goreleaserRunning = true
+			cmds = append(cmds, m.runGoreleaser)
+		}
+		if m.releaseManager.ShouldPublishFirmware() {
+			m.firmwareRunning = true
+			cmds = append(cmds, m.publishFirmware)
+		}
+		if len(m.releaseManager.PostReleasePlugins()) > 0 {
+			m.pluginsRunning = true
+			cmds = append(cmds, m.runPlugins)
+		}
+		if m.goreleaserRunning || m.firmwareRunning || m.pluginsRunning {
+			cmds = append(cmds, m.spinner.Tick)
+		}
+		return m, tea.Batch(cmds...)
+
+	case InterruptMsg:
+		// A signal cancels a running release like a confirmed quit, so it's rolled back first
+		if m.state == progressView && m.err == nil {
+			if m.quitting {
+				return m, nil
+			}
+			return m.cancelRelease(), nil
+		}
+		return m, tea.Quit
+
+	case pushRejectedMsg:
+		m.err = msg.err
+		m.pushRejected = true
+		if m.quitting {
+			m.quitNote = msg.err.Error()
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case releasedPRsMsg:
+		m.releasedPRs = msg.count
+		m.releasedPRsErr = msg.err
+		return m, nil
+
+	case milestonesUpdatedMsg:
+		m.milestoneNotes = msg.notes
+		m.milestoneErr = msg.err
+		return m, nil
+
+	case goreleaserDoneMsg:
+		m.goreleaserRunning = false
+		m.goreleaserDone = true
+		m.goreleaserErr = msg.err
+		return m, nil
+
+	case firmwarePublishedMsg:
+		m.firmwareRunning = false
+		m.firmwareNotes = msg.notes
+		m.firmwareErr = msg.err
+		return m, nil
+
+	case containersSyncedMsg:
+		m.containerNotes = msg.notes
+		m.containerErr = msg.err
+		return m, nil
+
+	case pluginsDoneMsg:
+		m.pluginsRunning = false
+		m.pluginNotes = msg.notes
+		m.pluginErr = msg.err
+		return m, nil
+
+	case checklistActionMsg:
+		if msg.index < len(m.checklist) {
+			checklist := append([]checklistEntry(nil), m.checklist...)
+			checklist[msg.index] = checklistEntry{status: checklistDone}
+			if msg.err != nil {
+				checklist[msg.index] = checklistEntry{status: checklistFailed, err: msg.err}
+			}
+			m.checklist = checklist
+		}
+		return m, nil
+
+	case refinedMsg:
+		return m.applyRefined(msg), nil
+
+	case copiedMsg:
+		if msg.err != nil {
+			m.copyStatus = fmt.Sprintf("⚠️  %v", msg.err)
+		} else {
+			m.copyStatus = "📋 Copied to the " + msg.target
+		}
+		return m, nil
 
 	case tea.KeyMsg:
+		m.copyStatus = ""
+		// While typing the version to confirm, every key belongs to the input
+		if m.state == confirmationView && m.confirmTyping {
+			return m.updateConfirmInput(msg)
+		}
+		// Every key belongs to the description input while rewording
+		if m.state == rewordView {
+			return m.updateReword(msg)
+		}
+		if m.state == changelogPreviewView && m.addingBullet {
+			return m.updateAddBullet(msg)
+		}
+		if m.state == changelogPreviewView && m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.state == changelogPreviewView && m.refining {
+			return m.updateRefine(msg)
+		}
+		// esc clears the last search before it quits
+		if m.state == changelogPreviewView && m.searchQuery != "" && msg.Type == tea.KeyEsc {
+			m.searchQuery = ""
+			return m.renderChangelog(), nil
+		}
+		// esc aborts the countdown instead of quitting
+		if m.state == countdownView {
+			return m.updateCountdown(msg)
+		}
+		if m.pushRejected && msg.String() == "r" {
+			return m.retryPush()
+		}
+		// Quitting while the release runs asks first, then rolls back before exiting
+		if m.state == progressView && m.err == nil {
+			return m.updateProgress(msg)
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
@@ -356,8 +1050,14 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Handle state-specific key events
 		switch m.state {
+		case welcomeView:
+			return m.updateWelcome(msg)
+		case detectedFilesView:
+			return m.updateFileSelection(msg)
 		case validationView:
 			return m.updateValidation(msg)
+		case affectedPackagesView:
+			return m.updateAffectedPackages(msg)
 		case versionSelectView:
 			return m.updateVersionSelect(msg)
 		case changelogGeneratingView:
@@ -371,159 +1071,698 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case confirmationView:
 			return m.updateConfirmation(msg)
 		case resultsView:
-			return m, tea.Quit
+			return m.updateResults(msg)
 		}
 
 	case error:
 		m.err = msg
+		if m.quitting {
+			m.quitNote = msg.Error()
+			return m, tea.Quit
+		}
 		return m, nil
 	}
 
+	// Keep the confirmation input's cursor blinking
+	if m.confirmTyping {
+		var cmd tea.Cmd
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+		return m, cmd
+	}
+	if m.state == rewordView {
+		var cmd tea.Cmd
+		m.rewordInput, cmd = m.rewordInput.Update(msg)
+		return m, cmd
+	}
+	if m.addingBullet {
+		var cmd tea.Cmd
+		m.bulletInput, cmd = m.bulletInput.Update(msg)
+		return m, cmd
+	}
+	if m.searching {
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		return m, cmd
+	}
+	if m.refining {
+		var cmd tea.Cmd
+		m.refineInput, cmd = m.refineInput.Update(msg)
+		return m, cmd
+	}
+
 	return m, nil
 }
 
+// startValidation moves to the validation view and starts the repository checks
+func (m MainModel) startValidation() (tea.Model, tea.Cmd) {
+	m.state = validationView
+	m.validationID++
+	m.remotePending = nil
+	m.runningSteps = nil
+	m.stepResults = nil
+	return m, tea.Batch(
+		m.validateRepository(),
+		m.spinner.Tick,
+	)
+}
+
+// needsFileSelection reports whether automatic detection found several version files,
+// or files outside the project root, that the user should confirm before managing
+func (m MainModel) needsFileSelection() bool {
+	if m.versionManager.BumpConfig != nil || m.settings.Release.TagOnly || m.settings.Monorepo.ChangedOnly {
+		return false
+	}
+	if len(m.versionManager.ProjectFiles) > 1 {
+		return true
+	}
+	for _, file := range m.versionManager.ProjectFiles {
+		if filepath.Dir(file.Path) != "." {
+			return true
+		}
+	}
+	return false
+}
+
+// updateFileSelection handles the version file checklist
+func (m MainModel) updateFileSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.fileCursor > 0 {
+			m.fileCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.fileCursor < len(m.fileSelected)-1 {
+			m.fileCursor++
+		}
+	case msg.String() == " ":
+		selected := append([]bool(nil), m.fileSelected...)
+		selected[m.fileCursor] = !selected[m.fileCursor]
+		m.fileSelected = selected
+	case msg.String() == "a":
+		// Select all unless everything is already selected
+		all := true
+		for _, s := range m.fileSelected {
+			all = all && s
+		}
+		selected := make([]bool, len(m.fileSelected))
+		for i := range selected {
+			selected[i] = !all
+		}
+		m.fileSelected = selected
+	case msg.String() == "s":
+		// Saving dirties the working tree, so the release continues on the next run
+		var paths []string
+		for _, file := range m.selectedFiles() {
+			paths = append(paths, file.Path)
+		}
+		m.fileSelectErr = nil
+		m.filesSaved = false
+		if len(paths) == 0 {
+			m.fileSelectErr = fmt.Errorf("select at least one version file")
+		} else if err := config.SaveBumpConfig(".", paths); err != nil {
+			m.fileSelectErr = err
+		} else {
+			m.filesSaved = true
+		}
+	case key.Matches(msg, m.keys.Enter):
+		if err := m.versionManager.SelectProjectFiles(m.selectedFiles()); err != nil {
+			m.fileSelectErr = err
+			return m, nil
+		}
+		m.fileSelectErr = nil
+		return m.startValidation()
+	}
+	return m, nil
+}
+
+// selectedFiles returns the version files ticked in the checklist
+func (m MainModel) selectedFiles() []version.ProjectFile {
+	var files []version.ProjectFile
+	for i, file := range m.versionManager.ProjectFiles {
+		if m.fileSelected[i] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
 func (m MainModel) updateValidation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Enter):
 		// If validation completed and can proceed, move to version selection
-		if m.validationSummary != nil && m.validationSummary.CanProceed {
-			m.state = versionSelectView
-			return m, nil
+		if m.validationDone() && m.validationSummary.CanProceed {
+			return m.continueFromValidation()
 		}
-		// If validation failed, stay on validation view
+		// If validation failed or is still running, stay on validation view
 		return m, nil
+	case msg.String() == "r" && m.validationSummary != nil:
+		m.fixNote = ""
+		m.fixErr = nil
+		return m.rerunValidation()
+	case key.Matches(msg, m.keys.Up):
+		if m.fixCursor > 0 {
+			m.fixCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.fixCursor < len(m.fixableFindings())-1 {
+			m.fixCursor++
+		}
+	case msg.String() == "f":
+		return m.applyFix()
 	}
 	return m, nil
 }
 
+// continueFromValidation moves on to the affected packages or version selection
+func (m MainModel) continueFromValidation() (tea.Model, tea.Cmd) {
+	if m.settings.Monorepo.ChangedOnly && !m.settings.Release.TagOnly {
+		m.state = affectedPackagesView
+		return m, nil
+	}
+	return m.startVersionSelect()
+}
+
+// startVersionSelect moves to version selection and starts its background checks. A
+// quick patch skips it, unless there is an unfinished release to offer instead.
+func (m MainModel) startVersionSelect() (tea.Model, tea.Cmd) {
+	if m.quickPatch && m.pendingRelease == "" {
+		return m.selectBump(bumpPatch)
+	}
+	m.state = versionSelectView
+	if m.aiEnabled {
+		return m, tea.Batch(m.checkReleaseNeeded, m.estimateAIUsage)
+	}
+	return m, m.checkReleaseNeeded
+}
+
 func (m MainModel) updateVersionSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
+	case msg.String() == "f" && m.pendingRelease != "":
+		// Finish the earlier release: its commit is at HEAD, so only tagging and pushing remain
+		m.newVersion = m.pendingRelease
+		m.resuming = true
+		m.changelogEntryExists = false
+		m.state = confirmationView
+		return m, nil
 	case key.Matches(msg, m.keys.Enter):
 		if selectedItem, ok := m.versionList.SelectedItem().(versionItem); ok {
-			m.selectedBump = selectedItem.bump
-
-			// Calculate new version
-			switch m.selectedBump {
-			case bumpMajor:
-				m.newVersion = m.versionManager.BumpMajor().String()
-			case bumpMinor:
-				m.newVersion = m.versionManager.BumpMinor().String()
-			case bumpPatch:
-				m.newVersion = m.versionManager.BumpPatch().String()
-			}
+			return m.selectBump(selectedItem.bump)
+		}
+	}
 
-			// Show loading state if Claude is available, otherwise generate directly
-			if m.claudeEnabled {
-				m.state = changelogGeneratingView
-				return m, tea.Batch(
-					m.generateChangelog,
-					m.spinner.Tick,
-				)
-			} else {
-				// Generate changelog synchronously for non-Claude fallback
-				changes, err := m.changelogManager.GenerateChanges(m.versionManager.CurrentVersion.String())
-				if err != nil {
-					m.err = err
-					return m, nil
-				}
-				m.generatedChanges = changes
-				m.changelogView.SetContent(changes)
-
-				m.state = changelogPreviewView
+	var cmd tea.Cmd
+	m.versionList, cmd = m.versionList.Update(msg)
+	return m, cmd
+}
+
+// selectBump calculates the new version and moves on to the changelog, or straight
+// to the confirmation for tag-only releases
+func (m MainModel) selectBump(bump bumpType) (tea.Model, tea.Cmd) {
+	m.selectedBump = bump
+	m.resuming = false
+
+	// Calculate new version
+	switch m.selectedBump {
+	case bumpMajor:
+		m.newVersion = m.versionManager.BumpMajor().String()
+	case bumpMinor:
+		m.newVersion = m.versionManager.BumpMinor().String()
+	case bumpPatch:
+		m.newVersion = m.versionManager.BumpPatch().String()
+	}
+
+	// Tag-only releases have no changelog to generate
+	if m.settings.Release.TagOnly {
+		m.changelogEntryExists = false
+		m.state = confirmationView
+		return m, nil
+	}
+
+	// Offer to describe commits the changelog can't parse before generating it
+	if m.settings.Changelog.Reword {
+		commits, err := m.changelogManager.UnparseableCommits(m.versionManager.CurrentVersion.String())
+		if err == nil && len(commits) > 0 {
+			m.rewordCommits = commits
+			m.rewordIndex = 0
+			m.rewordErr = nil
+			m.rewordInput.SetValue("")
+			m.state = rewordView
+			return m, m.rewordInput.Focus()
+		}
+	}
+
+	return m.startChangelogGeneration()
+}
+
+// startChangelogGeneration generates the changelog, showing a spinner while an AI generator runs
+func (m MainModel) startChangelogGeneration() (tea.Model, tea.Cmd) {
+	if m.aiEnabled {
+		m.state = changelogGeneratingView
+		return m, tea.Batch(
+			m.generateChangelog,
+			m.spinner.Tick,
+		)
+	}
+
+	// Generate changelog synchronously with the regex generator
+	start := time.Now()
+	changes, err := m.changelogManager.GenerateChanges(m.versionManager.CurrentVersion.String())
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	m.timings = release.SetTiming(m.timings, "changelog generation", time.Since(start))
+	changes, m.lintFixes = m.lintChanges(changes)
+	m = m.recordRevision(changes, "generated")
+	m.showPrompt = false
+	m.changesFromCache = false
+	m.changesGenerator = m.changelogManager.LastGenerator()
+	m.qualityIssues = nil
+	m.refineErr = nil
+	m = m.setChangelogContent(m.generatedChanges)
+
+	m.state = changelogPreviewView
+	return m, nil
+}
+
+// updateReword handles the reword step: enter saves a description for the current
+// commit, tab skips it and esc skips the remaining commits
+func (m MainModel) updateReword(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.rewordInput.Blur()
+		return m.startChangelogGeneration()
+	case tea.KeyTab:
+		return m.nextReword()
+	case tea.KeyEnter:
+		if description := strings.TrimSpace(m.rewordInput.Value()); description != "" {
+			if err := m.changelogManager.SaveReword(m.rewordCommits[m.rewordIndex].Hash, description); err != nil {
+				m.rewordErr = err
 				return m, nil
 			}
 		}
+		return m.nextReword()
 	}
 
 	var cmd tea.Cmd
-	m.versionList, cmd = m.versionList.Update(msg)
+	m.rewordInput, cmd = m.rewordInput.Update(msg)
 	return m, cmd
 }
 
+// nextReword moves to the next unparseable commit, or generates the changelog after the last one
+func (m MainModel) nextReword() (tea.Model, tea.Cmd) {
+	m.rewordErr = nil
+	m.rewordIndex++
+	if m.rewordIndex >= len(m.rewordCommits) {
+		m.rewordInput.Blur()
+		return m.startChangelogGeneration()
+	}
+	m.rewordInput.SetValue("")
+	return m, nil
+}
+
 func (m MainModel) updateChangelogPreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Undo and redo step through the drafts, also while editing bullets
+	if !m.showPrompt && !m.refineRunning {
+		switch {
+		case msg.String() == "u" && m.canUndo():
+			return m.showRevision(-1), nil
+		case msg.String() == "ctrl+r" && m.canRedo():
+			return m.showRevision(1), nil
+		}
+	}
+	if m.entryEditing {
+		return m.updateEntryEditing(msg)
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Enter):
+		m.changelogEntryExists = m.changelogManager.HasEntry(m.newVersion) && !m.changelogManager.EntryMatches(m.newVersion, m.generatedChanges)
+		m.replaceChangelogEntry = false
+		m.entryExistsNotice = false
 		m.state = confirmationView
 		return m, nil
 	case key.Matches(msg, m.keys.Left):
 		m.state = versionSelectView
 		return m, nil
+	case msg.String() == "p":
+		// Debug view: show the exact prompt sent to the AI generator
+		m.showPrompt = !m.showPrompt
+		if !m.showPrompt {
+			return m.setChangelogContent(m.generatedChanges), nil
+		}
+		prompt, err := m.changelogManager.BuildPrompt(m.versionManager.CurrentVersion.String())
+		if err != nil {
+			prompt = fmt.Sprintf("Unable to build prompt: %v", err)
+		}
+		m = m.setChangelogContent(prompt)
+		m.changelogView.GotoTop()
+		return m, nil
+	case msg.String() == "e" && !m.showPrompt:
+		return m.startEntryEditing()
+	case msg.String() == "r" && m.aiEnabled && !m.showPrompt && !m.refineRunning:
+		return m.startRefine()
+	case msg.String() == "/":
+		return m.startSearch()
+	case msg.String() == "c":
+		return m, copyToClipboard(m.changelogContent)
+	case msg.String() == "n" && m.searchQuery != "":
+		return m.nextSearchMatch(1), nil
+	case msg.String() == "N" && m.searchQuery != "":
+		return m.nextSearchMatch(-1), nil
+	case msg.String() == "home" || msg.String() == "g":
+		m.changelogView.GotoTop()
+		return m, nil
+	case msg.String() == "end" || msg.String() == "G":
+		m.changelogView.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.changelogView, cmd = m.changelogView.Update(msg)
+	return m, cmd
+}
+
+func (m MainModel) updateConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		// Refuse to write a duplicate release section; replacing requires an explicit choice
+		if m.changelogEntryExists {
+			m.entryExistsNotice = true
+			return m, nil
+		}
+		return m.confirmVersionBump()
+	case "r", "R":
+		if !m.changelogEntryExists {
+			return m, nil
+		}
+		m.replaceChangelogEntry = true
+		return m.confirmVersionBump()
+	case "n", "N":
+		m.state = versionSelectView
+		return m, nil
+	case "left", "h":
+		m.state = changelogPreviewView
+		if m.settings.Release.TagOnly || m.resuming {
+			m.state = versionSelectView
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// confirmVersionBump starts the release, first asking for the typed version when
+// release.strict_confirm is enabled
+func (m MainModel) confirmVersionBump() (tea.Model, tea.Cmd) {
+	if m.settings.Release.StrictConfirm {
+		m.confirmTyping = true
+		m.confirmMismatch = false
+		m.confirmInput.SetValue("")
+		return m, m.confirmInput.Focus()
+	}
+
+	return m.startCountdown()
+}
+
+// startCountdown opens the abort window configured by release.countdown_seconds
+func (m MainModel) startCountdown() (tea.Model, tea.Cmd) {
+	if m.settings.Release.CountdownSeconds <= 0 {
+		return m.startVersionBump()
+	}
+
+	m.state = countdownView
+	m.countdown = m.settings.Release.CountdownSeconds
+	m.countdownID++
+	return m, m.countdownTick()
+}
+
+func (m MainModel) countdownTick() tea.Cmd {
+	id := m.countdownID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return countdownTickMsg{id: id}
+	})
+}
+
+// updateCountdown lets esc abort the release before any git mutation happens
+func (m MainModel) updateCountdown(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "n", "N":
+		m.state = confirmationView
+		m.replaceChangelogEntry = false
+		m.countdownID++
+		return m, nil
+	}
+	return m, nil
+}
+
+// startVersionBump runs the release pipeline
+func (m MainModel) startVersionBump() (tea.Model, tea.Cmd) {
+	m.state = progressView
+	return m, tea.Batch(
+		m.performVersionBump,
+		m.spinner.Tick,
+	)
+}
+
+// updateProgress asks before quitting while the release runs. Confirming cancels the
+// release; the pipeline restores the repository and its result then exits.
+func (m MainModel) updateProgress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.quitting:
+		// Keep rolling back; exiting now would leave the repository half released
+		return m, nil
+	case m.confirmQuit:
+		switch msg.String() {
+		case "y", "Y":
+			return m.cancelRelease(), nil
+		case "n", "N", "esc":
+			m.confirmQuit = false
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Quit):
+		m.confirmQuit = true
 	}
+	return m, nil
+}
+
+// cancelRelease stops the running release at its next step; the pipeline restores the
+// repository and the TUI exits once it returns
+func (m MainModel) cancelRelease() MainModel {
+	m.confirmQuit = false
+	m.quitting = true
+	m.releaseManager.Cancel()
+	return m
+}
+
+// QuitNote describes what happened to a release that was running when the user
+// quit, for printing once the TUI has exited
+func (m MainModel) QuitNote() string {
+	return m.quitNote
+}
+
+// updateConfirmInput handles the typed version confirmation
+func (m MainModel) updateConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.confirmTyping = false
+		m.confirmMismatch = false
+		m.replaceChangelogEntry = false
+		m.confirmInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		if strings.TrimPrefix(strings.TrimSpace(m.confirmInput.Value()), "v") != m.newVersion {
+			m.confirmMismatch = true
+			return m, nil
+		}
+		m.confirmTyping = false
+		m.confirmInput.Blur()
+		return m.startCountdown()
+	}
+
+	var cmd tea.Cmd
+	m.confirmMismatch = false
+	m.confirmInput, cmd = m.confirmInput.Update(msg)
+	return m, cmd
+}
+
+// updateResults handles checklist keys in the results view; without a checklist any key quits
+func (m MainModel) updateResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.settings.Release.Checklist
+	if msg.String() == "c" && !m.checklistUsesKey("c") {
+		return m, copyToClipboard(m.generatedChanges)
+	}
+	if len(items) == 0 || key.Matches(msg, m.keys.Enter) {
+		return m, tea.Quit
+	}
+
+	for i, item := range items {
+		if item.Key != msg.String() || i >= len(m.checklist) {
+			continue
+		}
+
+		// The checklist slice is shared with the previous model value; copy before changing it
+		checklist := append([]checklistEntry(nil), m.checklist...)
+		m.checklist = checklist
+
+		if !release.IsAutomated(item) {
+			if checklist[i].status == checklistDone {
+				checklist[i].status = checklistPending
+			} else {
+				checklist[i].status = checklistDone
+			}
+			return m, nil
+		}
+
+		if checklist[i].status == checklistRunning || checklist[i].status == checklistDone {
+			return m, nil
+		}
+		checklist[i] = checklistEntry{status: checklistRunning}
+		return m, m.runChecklistAction(i, item)
+	}
+
+	return m, nil
+}
+
+// updateMilestones closes and creates GitHub milestones as configured in [github.milestones]
+func (m MainModel) updateMilestones() tea.Msg {
+	notes, err := m.releaseManager.UpdateMilestones(m.settings.GitHub.Milestones, m.newVersion)
+	return milestonesUpdatedMsg{notes: notes, err: err}
+}
+
+// runGoreleaser runs `goreleaser release --clean` for the pushed tag
+func (m MainModel) runGoreleaser() tea.Msg {
+	return goreleaserDoneMsg{err: m.releaseManager.RunGoreleaser()}
+}
+
+// publishFirmware builds the firmware of the pushed tag and publishes versioned copies
+func (m MainModel) publishFirmware() tea.Msg {
+	notes, err := m.releaseManager.PublishFirmware(m.newVersion)
+	return firmwarePublishedMsg{notes: notes, err: err}
+}
 
-	var cmd tea.Cmd
-	m.changelogView, cmd = m.changelogView.Update(msg)
-	return m, cmd
+// syncContainers retags the container images configured in [[release.containers]]
+func (m MainModel) syncContainers() tea.Msg {
+	notes, err := m.releaseManager.SyncContainerImages(m.newVersion)
+	return containersSyncedMsg{notes: notes, err: err}
 }
 
-func (m MainModel) updateConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		m.state = progressView
-		return m, tea.Batch(
-			m.performVersionBump,
-			m.spinner.Tick,
-		)
-	case "n", "N":
-		m.state = versionSelectView
-		return m, nil
-	case "left", "h":
-		m.state = changelogPreviewView
-		return m, nil
-	}
+// runPlugins runs the post-release actions of plugins
+func (m MainModel) runPlugins() tea.Msg {
+	notes, err := m.releaseManager.RunPostReleasePlugins(m.releasePlan().PreviousVersion, m.newVersion)
+	return pluginsDoneMsg{notes: notes, err: err}
+}
 
-	return m, nil
+// notifyReleasedPRs labels and comments on the pull requests shipped in this release
+func (m MainModel) notifyReleasedPRs() tea.Msg {
+	count, err := m.releaseManager.NotifyReleasedPullRequests(
+		m.settings.GitHub.ReleasedPRs, m.versionManager.CurrentVersion.String(), m.newVersion)
+	return releasedPRsMsg{count: count, err: err}
 }
 
-func (m MainModel) performVersionBump() tea.Msg {
-	// Update all version files
-	if err := m.versionManager.UpdateAllVersions(m.newVersion); err != nil {
-		return err
+// runChecklistAction runs an automated checklist item in the background
+func (m MainModel) runChecklistAction(index int, item config.ChecklistItem) tea.Cmd {
+	data := release.ActionData{Version: m.newVersion, Tag: "v" + m.newVersion}
+	return func() tea.Msg {
+		return checklistActionMsg{index: index, err: m.releaseManager.RunChecklistAction(item, data)}
 	}
+}
 
-	// Update changelog
-	if err := m.changelogManager.UpdateChangelog(m.newVersion, m.generatedChanges); err != nil {
-		return err
+func (m MainModel) releasePlan() release.Plan {
+	previousVersion := m.versionManager.CurrentVersion.String()
+	if m.resuming {
+		previousVersion = m.pendingPrevious
 	}
-
-	// Git operations
-	if err := m.gitManager.CommitVersionBump(m.newVersion); err != nil {
-		return err
+	return release.Plan{
+		PreviousVersion:       previousVersion,
+		Version:               m.newVersion,
+		Changes:               m.generatedChanges,
+		ReplaceChangelogEntry: m.replaceChangelogEntry,
+		Generator:             m.changesGenerator,
 	}
+}
 
-	if err := m.gitManager.CreateTag(m.newVersion); err != nil {
-		return err
+func (m MainModel) performVersionBump() tea.Msg {
+	plan := m.releasePlan()
+	outcome, err := m.releaseManager.Execute(plan)
+	if m.releaseManager.CanRetryPush(plan, err) {
+		return pushRejectedMsg{err: err}
 	}
-
-	// Push changes and tag separately to GitHub (ensures workflow triggers)
-	if err := m.gitManager.PushChanges(); err != nil {
+	if err != nil {
 		return err
 	}
 
-	if err := m.gitManager.PushTag(m.newVersion); err != nil {
-		return err
-	}
+	return releaseCompleteMsg{outcome: outcome}
+}
 
-	return "success"
+// retryPush rebases the release commit onto the remote branch and pushes again
+func (m MainModel) retryPush() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.pushRejected = false
+	m.state = progressView
+	return m, tea.Batch(
+		func() tea.Msg {
+			plan := m.releasePlan()
+			outcome, err := m.releaseManager.RetryPush(plan)
+			if m.releaseManager.CanRetryPush(plan, err) {
+				return pushRejectedMsg{err: err}
+			}
+			if err != nil {
+				return err
+			}
+			return releaseCompleteMsg{outcome: outcome}
+		},
+		m.spinner.Tick,
+	)
 }
 
+// View renders the current view above the status bar
 func (m MainModel) View() string {
+	defer m.recoverCrash()
+	if m.width == 0 {
+		return m.stateView()
+	}
+	bar := m.statusBarView()
+	m.height -= lipgloss.Height(bar)
+	return m.stateView() + "\n" + bar
+}
+
+// stateView renders the view of the current state
+func (m MainModel) stateView() string {
 	if m.err != nil {
+		if m.initFailed {
+			return m.initErrorView()
+		}
 		return m.errorView()
 	}
 
 	switch m.state {
 	case welcomeView:
 		return m.welcomeView()
+	case detectedFilesView:
+		return m.detectedFilesView()
 	case validationView:
 		return m.validationView()
+	case affectedPackagesView:
+		return m.affectedPackagesView()
 	case versionSelectView:
 		return m.versionSelectView()
+	case rewordView:
+		return m.rewordView()
 	case changelogGeneratingView:
 		return m.changelogGeneratingView()
 	case changelogPreviewView:
 		return m.changelogPreviewView()
 	case confirmationView:
 		return m.confirmationView()
+	case countdownView:
+		return m.countdownView()
 	case progressView:
 		return m.progressView()
 	case resultsView:
@@ -538,13 +1777,62 @@ func (m MainModel) errorView() string {
 		Foreground(lipgloss.Color("#ed8796")).
 		Bold(true)
 
+	help := "Press q to quit"
+	if m.pushRejected {
+		help = "Press r to rebase onto the remote and retry the push, or q to quit"
+	}
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		errorStyle.Render("❌ Error"),
 		"",
 		m.err.Error(),
 		"",
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render("Press q to quit"),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(help),
+	)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+func (m MainModel) rewordView() string {
+	header := m.headerView("Reword Commits")
+
+	commit := m.rewordCommits[m.rewordIndex]
+	intro := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Render(fmt.Sprintf("Commit %d of %d isn't a conventional commit. Describe it for the changelog:", m.rewordIndex+1, len(m.rewordCommits)))
+
+	subject := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6e738d")).
+		Render(fmt.Sprintf("%s %s", commit.Hash, commit.Message))
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6e738d")).
+		Render("Only the changelog uses this; git history is unchanged. Start with e.g. \"fix: \" to set the change type.")
+
+	var status string
+	if m.rewordErr != nil {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("⚠️  %v", m.rewordErr))
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		intro,
+		"",
+		subject,
+		m.rewordInput.View(),
+		"",
+		hint,
+		status,
+		m.footerView("enter: save • tab: skip • esc: skip all • ctrl+c: quit"),
 	)
 
 	return lipgloss.Place(
@@ -571,8 +1859,8 @@ func (m MainModel) changelogGeneratingView() string {
 		Bold(true)
 
 	statusText := "Analyzing commits and generating changelog..."
-	if m.claudeEnabled {
-		statusText = "Using Claude to generate changelog..."
+	if m.aiEnabled {
+		statusText = "Using AI to generate changelog..."
 	}
 
 	spinner := spinnerStyle.Render(fmt.Sprintf("%s %s", m.spinner.View(), statusText))
@@ -611,7 +1899,11 @@ func (m MainModel) versionSelectView() string {
 
 	projectFiles := m.projectFilesView()
 
-	footer := m.footerView("↑/↓: navigate • enter: select • q: quit")
+	footerText := "↑/↓: navigate • enter: select • q: quit"
+	if m.pendingRelease != "" {
+		footerText = fmt.Sprintf("↑/↓: navigate • enter: select • f: finish v%s • q: quit", m.pendingRelease)
+	}
+	footer := m.footerView(footerText)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -621,6 +1913,9 @@ func (m MainModel) versionSelectView() string {
 		"",
 		projectFiles,
 		"",
+		m.pendingReleaseView(),
+		m.releaseNeededView(),
+		m.aiEstimateView(),
 		m.versionList.View(),
 		"",
 		footer,
@@ -631,14 +1926,28 @@ func (m MainModel) versionSelectView() string {
 
 func (m MainModel) changelogPreviewView() string {
 	header := m.headerView("Changelog Preview")
+	if m.showPrompt {
+		header = m.headerView("AI Prompt Preview")
+	}
 
 	versionInfoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#8aadf4")).
 		Bold(true)
 
-	versionInfo := versionInfoStyle.Render(
-		fmt.Sprintf("%s → %s", m.versionManager.CurrentVersion.String(), m.newVersion),
-	)
+	versionText := fmt.Sprintf("%s → %s", m.versionManager.CurrentVersion.String(), m.newVersion)
+	if m.changesGenerator != "" {
+		source := "generated by " + m.changesGenerator
+		if m.changesFromCache {
+			source += ", cached"
+		}
+		versionText += lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render("  (" + source + ")")
+	}
+	versionInfo := versionInfoStyle.Render(versionText)
+	if m.options.Since != "" || m.options.Until != "" {
+		versionInfo += "\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6e738d")).
+			Render("Commits: "+m.changelogManager.DescribeRange(m.versionManager.CurrentVersion.String()))
+	}
 
 	changelogStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -649,16 +1958,59 @@ func (m MainModel) changelogPreviewView() string {
 
 	changelog := changelogStyle.Render(m.changelogView.View())
 
-	footer := m.footerView("↑/↓: scroll • enter: continue • ←: back • q: quit")
+	var lintInfo string
+	if len(m.lintFixes) > 0 {
+		lintInfo = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6e738d")).
+			Render(fmt.Sprintf("🧹 Lint applied %d fixes: %s", len(m.lintFixes), strings.Join(m.lintFixes, " • ")))
+	}
+
+	var qualityInfo string
+	if len(m.qualityIssues) > 0 && !m.showPrompt {
+		noun := "issues"
+		if len(m.qualityIssues) == 1 {
+			noun = "issue"
+		}
+		qualityInfo = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Width(m.changelogView.Width + 4).
+			Render(fmt.Sprintf("⚠ Self-check found %d possible %s: %s", len(m.qualityIssues), noun, strings.Join(m.qualityIssues, " • ")))
+	}
+
+	footerText := "↑/↓ pgup/pgdn g/G: scroll • /: search • c: copy • enter: continue • ←: back • e: edit bullets • p: show prompt • q: quit"
+	if m.aiEnabled {
+		footerText = "↑/↓ pgup/pgdn g/G: scroll • /: search • c: copy • enter: continue • ←: back • e: edit bullets • r: refine with AI • p: show prompt • q: quit"
+	}
+	if m.showPrompt {
+		footerText = "↑/↓ pgup/pgdn g/G: scroll • /: search • c: copy • enter: continue • ←: back • p: show changelog • q: quit"
+	}
+	if m.searching {
+		footerText = "enter: search • esc: cancel"
+	}
+	if m.refining {
+		footerText = "enter: refine • esc: cancel"
+	}
+	if m.entryEditing {
+		footerText = "↑/↓: select • g/G: first/last • a: add • d: delete • m/M: move to next/previous category • u/ctrl+r: undo/redo • e/esc: done • enter: continue"
+	}
+	if m.addingBullet {
+		footerText = "enter: add • tab: change category • esc: cancel"
+	}
+	footer := m.footerView(footerText)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		header,
 		"",
 		versionInfo,
-		"",
+		lintInfo,
+		qualityInfo,
 		changelog,
-		"",
+		m.changelogStatus(),
+		m.refineStatus(),
+		m.revisionStatus(),
+		m.copyStatus,
+		m.entryEditStatus(),
 		footer,
 	)
 
@@ -678,12 +2030,35 @@ func (m MainModel) confirmationView() string {
 		Foreground(lipgloss.Color("#6e738d"))
 
 	var actions []string
-	actions = append(actions, fmt.Sprintf("• Update version to %s", m.newVersion))
-	actions = append(actions, "• Update changelog")
-	actions = append(actions, "• Create git commit")
-	actions = append(actions, fmt.Sprintf("• Create git tag v%s", m.newVersion))
-	actions = append(actions, "• Push changes to GitHub")
-	actions = append(actions, "• Push tag to trigger release workflow")
+	if m.settings.Release.TagOnly {
+		actions = append(actions, fmt.Sprintf("• Create annotated tag v%s at HEAD", m.newVersion))
+		actions = append(actions, "• Push tag to trigger release workflow")
+		actions = append(actions, "• Leave version files, changelog and commits untouched")
+	} else {
+		actions = m.releaseActions()
+	}
+
+	if m.releaseManager.ShouldRunGoreleaser() {
+		actions = append(actions, "• Run goreleaser release --clean locally after pushing the tag")
+	}
+	if names := m.releaseManager.PostReleasePlugins(); len(names) > 0 {
+		actions = append(actions, fmt.Sprintf("• Run post-release plugins %s after pushing the tag", strings.Join(names, ", ")))
+	}
+	if m.releaseManager.ShouldPublishFirmware() {
+		actions = append(actions, fmt.Sprintf("• Build firmware and copy versioned binaries to %s", m.settings.Firmware.OutputDir))
+		if m.settings.Firmware.Attach {
+			actions = append(actions, fmt.Sprintf("• Attach the binaries to the v%s GitHub release", m.newVersion))
+		}
+	}
+
+	// Alias tags are force-pushed, so call them out separately
+	var aliasWarning string
+	if aliases := m.aliasTags(); len(aliases) > 0 {
+		actions = append(actions, fmt.Sprintf("• Move alias tags %s to v%s", strings.Join(aliases, ", "), m.newVersion))
+		aliasWarning = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Render(fmt.Sprintf("⚠️  Alias tags %s will be force-pushed, replacing where they point on the remote", strings.Join(aliases, ", ")))
+	}
 
 	summary := summaryStyle.Render(
 		fmt.Sprintf("This will:\n%s", strings.Join(actions, "\n")),
@@ -697,7 +2072,41 @@ func (m MainModel) confirmationView() string {
 		"The GitHub Actions workflow will build binaries and update Homebrew tap",
 	)
 
-	footer := m.footerView("y: yes • n: no • ←: back • q: quit")
+	footerText := "y: yes • n: no • ←: back • q: quit"
+
+	var duplicateWarning string
+	if m.changelogEntryExists {
+		duplicateWarning = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("⚠️  %s already contains an entry for %s (possibly from an aborted run)",
+				m.changelogManager.ChangelogPath(), m.newVersion))
+		if m.entryExistsNotice {
+			duplicateWarning += "\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#f5a97f")).
+				Render("The entry exists: press r to replace it, or n to cancel")
+		}
+		footerText = "r: replace entry and proceed • n: no • ←: back • q: quit"
+	}
+
+	var hooksWarning string
+	if m.settings.Git.NoVerify {
+		hooksWarning = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render("⚠️  Git hooks are bypassed (--no-verify): pre-commit and pre-push checks will not run")
+	}
+
+	var typedConfirm string
+	if m.confirmTyping {
+		typedConfirm = fmt.Sprintf("Type %s to confirm the release:\n%s", m.newVersion, m.confirmInput.View())
+		if m.confirmMismatch {
+			typedConfirm += "\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#ed8796")).
+				Render("Version does not match")
+		}
+		footerText = "enter: confirm • esc: cancel • ctrl+c: quit"
+	}
+
+	footer := m.footerView(footerText)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -707,8 +2116,88 @@ func (m MainModel) confirmationView() string {
 		"",
 		summary,
 		"",
+		duplicateWarning,
+		hooksWarning,
+		aliasWarning,
 		workflowInfo,
 		"",
+		typedConfirm,
+		footer,
+	)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// aliasTags returns the alias tags the release will move; the pull-request workflow doesn't tag
+func (m MainModel) aliasTags() []string {
+	if m.settings.Release.Workflow == config.WorkflowPullRequest {
+		return nil
+	}
+	aliases, _ := release.AliasTags(m.newVersion, m.settings.Release.AliasTags)
+	return aliases
+}
+
+// releaseActions lists what the direct or pull-request workflow will do
+func (m MainModel) releaseActions() []string {
+	var actions []string
+	if m.resuming {
+		actions = append(actions, fmt.Sprintf("• Keep the release commit of %s at HEAD; files are not updated again", m.newVersion))
+		actions = append(actions, fmt.Sprintf("• Create git tag v%s if it is missing", m.newVersion))
+		actions = append(actions, "• Push changes and tag to GitHub")
+		return actions
+	}
+	actions = append(actions, fmt.Sprintf("• Update version to %s", m.newVersion))
+	if m.changelogEntryExists {
+		actions = append(actions, fmt.Sprintf("• Replace existing changelog entry for %s", m.newVersion))
+	} else {
+		actions = append(actions, "• Update changelog")
+	}
+	if m.settings.Release.Workflow == config.WorkflowPullRequest {
+		branch := m.settings.GitHub.PullRequest.BranchPrefix + m.newVersion
+		actions = append(actions, fmt.Sprintf("• Create branch %s and commit", branch))
+		actions = append(actions, "• Push the branch to GitHub")
+		actions = append(actions, "• Open a release pull request")
+	} else {
+		actions = append(actions, "• Create git commit")
+		actions = append(actions, fmt.Sprintf("• Create git tag v%s", m.newVersion))
+		if m.settings.Release.PushMode == config.PushAtomic {
+			actions = append(actions, "• Push changes and tag to GitHub in one atomic push")
+		} else {
+			actions = append(actions, "• Push changes to GitHub")
+			actions = append(actions, "• Push tag to trigger release workflow")
+		}
+	}
+	return actions
+}
+
+func (m MainModel) countdownView() string {
+	header := m.headerView("Starting Release")
+
+	countdownStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f5a97f")).
+		Bold(true)
+
+	countdown := countdownStyle.Render(
+		fmt.Sprintf("Releasing v%s in %d...", m.newVersion, m.countdown),
+	)
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6e738d")).
+		Render("Nothing has been changed yet")
+
+	footer := m.footerView("esc: abort • ctrl+c: quit")
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		countdown,
+		hint,
+		"",
 		footer,
 	)
 
@@ -725,7 +2214,11 @@ func (m MainModel) progressView() string {
 	spinnerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#8aadf4"))
 
-	spinner := spinnerStyle.Render(fmt.Sprintf("%s Updating version files...", m.spinner.View()))
+	status := "Updating version files..."
+	if m.quitting {
+		status = "Canceling the release and restoring the repository..."
+	}
+	spinner := spinnerStyle.Render(fmt.Sprintf("%s %s", m.spinner.View(), status))
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -734,6 +2227,28 @@ func (m MainModel) progressView() string {
 		spinner,
 	)
 
+	if m.confirmQuit {
+		warningStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Bold(true)
+		modal := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#f5a97f")).
+			Padding(0, 1).
+			Render(lipgloss.JoinVertical(
+				lipgloss.Left,
+				warningStyle.Render("⚠️  A release is in progress"),
+				"",
+				"Quit and roll back the changes made so far?",
+				"A push that already started is finished first.",
+				"",
+				lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#6e738d")).
+					Render("y: roll back and quit • n/esc: keep releasing"),
+			))
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", modal)
+	}
+
 	return lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
@@ -752,15 +2267,114 @@ func (m MainModel) resultsView() string {
 
 	// This was a version bump
 	results = append(results, fmt.Sprintf("Version bumped to %s", m.newVersion))
-	results = append(results, fmt.Sprintf("Created tag v%s", m.newVersion))
-	results = append(results, "Updated changelog")
-	results = append(results, "Pushed changes to GitHub")
-	results = append(results, "Pushed tag to trigger release workflow")
-	results = append(results, "")
-	results = append(results, "🚀 GitHub Actions will build binaries and update Homebrew tap")
+	if m.pullRequestURL != "" {
+		results = append(results, "Updated changelog")
+		results = append(results, fmt.Sprintf("Opened release pull request: %s", m.pullRequestURL))
+		results = append(results, "")
+		results = append(results, fmt.Sprintf("🔀 Merge the pull request, then tag the merge commit with v%s to publish the release", m.newVersion))
+	} else if m.settings.Release.TagOnly {
+		results = append(results, fmt.Sprintf("Created tag v%s at HEAD", m.newVersion))
+		results = append(results, "Pushed tag to trigger release workflow")
+	} else {
+		results = append(results, fmt.Sprintf("Created tag v%s", m.newVersion))
+		results = append(results, "Updated changelog")
+		results = append(results, "Pushed changes to GitHub")
+		results = append(results, "Pushed tag to trigger release workflow")
+		results = append(results, "")
+		results = append(results, "🚀 GitHub Actions will build binaries and update Homebrew tap")
+	}
+
+	for _, note := range m.milestoneNotes {
+		results = append(results, "🏁 "+note)
+	}
+	if m.releasedPRs > 0 {
+		noun := "pull requests"
+		if m.releasedPRs == 1 {
+			noun = "pull request"
+		}
+		results = append(results, fmt.Sprintf("🏷️ Notified %d %s released in v%s", m.releasedPRs, noun, m.newVersion))
+	}
+	if m.releasedPRsErr != nil {
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Render(fmt.Sprintf("⚠️  Released pull requests not fully updated: %v", m.releasedPRsErr)))
+	}
+	if m.milestoneErr != nil {
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Render(fmt.Sprintf("⚠️  Milestones not updated: %v", m.milestoneErr)))
+	}
+	switch {
+	case m.goreleaserRunning:
+		results = append(results, fmt.Sprintf("%s Running goreleaser release --clean...", m.spinner.View()))
+	case m.goreleaserErr != nil:
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("❌ %v", m.goreleaserErr)))
+	case m.goreleaserDone:
+		results = append(results, "📦 goreleaser release --clean finished")
+	}
+	if m.firmwareRunning {
+		results = append(results, fmt.Sprintf("%s Building firmware...", m.spinner.View()))
+	}
+	for _, note := range m.firmwareNotes {
+		results = append(results, "🔧 "+note)
+	}
+	if m.firmwareErr != nil {
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("❌ %v", m.firmwareErr)))
+	}
+	for _, note := range m.containerNotes {
+		results = append(results, "🐳 "+note)
+	}
+	if m.containerErr != nil {
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Render(fmt.Sprintf("⚠️  Container images not fully retagged: %v", m.containerErr)))
+	}
+	if m.pluginsRunning {
+		results = append(results, fmt.Sprintf("%s Running plugins...", m.spinner.View()))
+	}
+	for _, note := range m.pluginNotes {
+		results = append(results, "🔌 "+note)
+	}
+	if m.pluginErr != nil {
+		results = append(results, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("❌ %v", m.pluginErr)))
+	}
+
+	if m.aiUsage != nil {
+		usageLine := fmt.Sprintf("🤖 AI usage: %s", m.aiUsage)
+		if m.aiMonthlyBudget > 0 {
+			usageLine += fmt.Sprintf(" (month: $%.2f of $%.2f budget)", m.aiMonthSpend, m.aiMonthlyBudget)
+		}
+		results = append(results, "")
+		results = append(results, lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(usageLine))
+	}
+
+	if timings := release.FormatTimings(m.timings); timings != "" {
+		results = append(results, "")
+		results = append(results, lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render("⏱️  "+timings))
+	}
+
+	if checklist := m.checklistView(); checklist != "" {
+		results = append(results, "")
+		results = append(results, checklist)
+	}
+
+	if m.copyStatus != "" {
+		results = append(results, "")
+		results = append(results, m.copyStatus)
+	}
 
+	quitHint := "Press q to quit"
+	if m.generatedChanges != "" && !m.checklistUsesKey("c") {
+		quitHint = "Press c to copy the release notes • q to quit"
+	}
 	results = append(results, "")
-	results = append(results, lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render("Press q to quit"))
+	results = append(results, lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(quitHint))
 
 	content := lipgloss.JoinVertical(lipgloss.Left, results...)
 
@@ -771,6 +2385,96 @@ func (m MainModel) resultsView() string {
 	)
 }
 
+// checklistUsesKey reports whether a checklist item is bound to key, which then takes
+// precedence over the results view's own binding
+func (m MainModel) checklistUsesKey(key string) bool {
+	for _, item := range m.settings.Release.Checklist {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// checklistView renders the configured post-release checklist with the key of each item
+func (m MainModel) checklistView() string {
+	items := m.settings.Release.Checklist
+	if len(items) == 0 {
+		return ""
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+	lines := []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#8aadf4")).Bold(true).Render("Next steps")}
+	for i, item := range items {
+		entry := checklistEntry{}
+		if i < len(m.checklist) {
+			entry = m.checklist[i]
+		}
+
+		line := fmt.Sprintf("[%s] %s", item.Key, item.Title)
+		if release.IsAutomated(item) {
+			line += mutedStyle.Render(" (automated)")
+		}
+
+		switch entry.status {
+		case checklistRunning:
+			lines = append(lines, "… "+line+mutedStyle.Render(" running"))
+		case checklistDone:
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#a6da95")).Render("✓ ")+line)
+		case checklistFailed:
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#ed8796")).
+				Render(fmt.Sprintf("✗ %s: %v", line, entry.err)))
+		default:
+			lines = append(lines, "☐ "+line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// releaseNeededView warns when every commit since the last release was marked as skipped
+// pendingReleaseView points out a release commit at HEAD that an earlier run didn't tag or push
+func (m MainModel) pendingReleaseView() string {
+	if m.pendingRelease == "" {
+		return ""
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f5a97f")).
+		Render(fmt.Sprintf("⚠️  HEAD is the release commit of %s from an earlier run that didn't finish; press f to tag and push it instead of bumping again",
+			m.pendingRelease))
+}
+
+func (m MainModel) releaseNeededView() string {
+	if !m.noReleaseNeeded {
+		return ""
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#f5a97f")).
+		Render(fmt.Sprintf("ℹ️  No release needed: all %d commits since %s are marked [skip changelog] or [skip release]",
+			m.skippedCommits, m.versionManager.CurrentVersion.String()))
+}
+
+// aiEstimateView shows the estimated AI cost and the monthly budget before generation
+func (m MainModel) aiEstimateView() string {
+	if m.aiEstimate == nil {
+		return ""
+	}
+
+	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+	line := fmt.Sprintf("🤖 AI changelog estimate: %s", m.aiEstimate)
+	if m.aiMonthlyBudget > 0 {
+		line += fmt.Sprintf(" (month: $%.2f of $%.2f budget)", m.aiMonthSpend, m.aiMonthlyBudget)
+		if m.aiMonthSpend+m.aiEstimate.CostUSD > m.aiMonthlyBudget {
+			return lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#f5a97f")).
+				Render("⚠️  " + line + " - monthly AI budget exceeded")
+		}
+	}
+	return infoStyle.Render(line)
+}
+
 func (m MainModel) headerView(title string) string {
 	titleStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#8aadf4")).
@@ -814,8 +2518,18 @@ func (m MainModel) validationView() string {
 	var statusStyle lipgloss.Style
 
 	if m.validationSummary == nil {
-		// Still validating - show spinner
-		statusText = fmt.Sprintf("%s Validating repository status...", m.spinner.View())
+		// Still validating - show spinner with the latest step that started
+		current := "Validating repository status..."
+		if len(m.runningSteps) > 0 {
+			current = m.runningSteps[len(m.runningSteps)-1].Description
+		}
+		statusText = fmt.Sprintf("%s %s", m.spinner.View(), current)
+		statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8aadf4")).
+			Bold(true)
+	} else if m.validationSummary.CanProceed && m.remoteChecksRunning() {
+		// Local checks passed, network checks still running
+		statusText = fmt.Sprintf("%s Checking the remote...", m.spinner.View())
 		statusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#8aadf4")).
 			Bold(true)
@@ -841,9 +2555,14 @@ func (m MainModel) validationView() string {
 
 	status := statusStyle.Render(statusText)
 
-	// Results summary - ALWAYS show detailed results when available
-	var resultsContent []string
+	// Results summary - ALWAYS show detailed results when available, step by step
+	// while validation runs
+	results := m.stepResults
 	if m.validationSummary != nil {
+		results = m.validationSummary.Results
+	}
+	var resultsContent []string
+	if len(results) > 0 || len(m.runningSteps) > 0 {
 		resultsContent = append(resultsContent,
 			lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#8aadf4")).
@@ -851,7 +2570,7 @@ func (m MainModel) validationView() string {
 				Render("📋 Validation Results:"))
 		resultsContent = append(resultsContent, "")
 
-		for _, result := range m.validationSummary.Results {
+		for _, result := range results {
 			// Step name and status
 			stepIcon := "✅"
 			if !result.Success {
@@ -888,6 +2607,10 @@ func (m MainModel) validationView() string {
 			}
 		}
 
+		resultsContent = append(resultsContent, m.pendingChecksView()...)
+	}
+	resultsContent = append(resultsContent, m.fixesView()...)
+	if m.validationSummary != nil {
 		// Add summary stats
 		resultsContent = append(resultsContent, "")
 		summaryStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
@@ -895,6 +2618,9 @@ func (m MainModel) validationView() string {
 		if m.validationSummary.HasErrors {
 			resultsContent = append(resultsContent,
 				summaryStyle.Render("❌ Found blocking errors - cannot proceed with version bump"))
+		} else if m.remoteChecksRunning() {
+			resultsContent = append(resultsContent,
+				summaryStyle.Render("Local checks passed - waiting for the remote checks"))
 		} else if m.validationSummary.HasWarnings {
 			resultsContent = append(resultsContent,
 				summaryStyle.Render(fmt.Sprintf("⚠️  Found %d validation warnings - can proceed with caution",
@@ -905,16 +2631,22 @@ func (m MainModel) validationView() string {
 		}
 	}
 
-	results := strings.Join(resultsContent, "\n")
+	resultsText := strings.Join(resultsContent, "\n")
 
 	// Footer instructions
 	var footerText string
 	if m.validationSummary == nil {
 		footerText = "q: quit"
-	} else if m.validationSummary.CanProceed {
-		footerText = "enter: continue to version selection • q: quit"
+	} else if len(m.fixableFindings()) > 0 && m.validationSummary.CanProceed {
+		footerText = "enter: continue to version selection • ↑/↓ f: apply a fix • r: check again • q: quit"
+	} else if len(m.fixableFindings()) > 0 {
+		footerText = "↑/↓ f: apply a fix • r: check again • q: quit"
+	} else if !m.validationSummary.CanProceed {
+		footerText = "Fix errors and press r to check again • q: quit"
+	} else if m.remoteChecksRunning() {
+		footerText = "r: check again • q: quit"
 	} else {
-		footerText = "Fix errors and restart • q: quit"
+		footerText = "enter: continue to version selection • r: check again • q: quit"
 	}
 
 	footer := m.footerView(footerText)
@@ -926,10 +2658,12 @@ func (m MainModel) validationView() string {
 		status,
 		"",
 		"",
-		results,
+		resultsText,
 		"",
 		"",
 		footer,
+		"",
+		m.updateBannerView(),
 	)
 
 	return lipgloss.Place(
@@ -950,25 +2684,63 @@ func (m MainModel) countWarnings() int {
 	return count
 }
 
-func (m MainModel) welcomeView() string {
-	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#8aadf4")).
-		Bold(true).
-		Render("🚀 Bump - Version Manager")
+// updateBannerView renders a subtle note when a newer bump-tui release is available
+func (m MainModel) updateBannerView() string {
+	if m.latestRelease == nil {
+		return ""
+	}
 
-	subtitle := lipgloss.NewStyle().
+	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#6e738d")).
-		Render("Interactive semantic version management tool")
+		Render(fmt.Sprintf("✨ bump-tui %s is available: %s", m.latestRelease.Version, m.latestRelease.URL))
+}
+
+// detectedFilesView is a checklist of the auto-detected version files to manage in this release
+func (m MainModel) detectedFilesView() string {
+	header := m.headerView("Detected Version Files")
+
+	intro := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#8aadf4")).
+		Render(fmt.Sprintf("Found %d version files. Choose which ones this release updates:", len(m.versionManager.ProjectFiles)))
+
+	var files []string
+	for i, file := range m.versionManager.ProjectFiles {
+		check := "[ ]"
+		if m.fileSelected[i] {
+			check = "[x]"
+		}
+		line := fmt.Sprintf("%s %s", check, file.Path)
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+		if i == m.fileCursor {
+			line = "› " + line
+			style = style.Foreground(lipgloss.Color("#8aadf4")).Bold(true)
+		} else {
+			line = "  " + line
+		}
+		files = append(files, style.Render(line))
+	}
+
+	var status string
+	if m.fileSelectErr != nil {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("⚠️  %v", m.fileSelectErr))
+	} else if m.filesSaved {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#a6da95")).
+			Render("✅ Saved the selection to .bump. Commit it and run bump again to release with it")
+	}
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
-		title,
+		header,
 		"",
-		subtitle,
+		intro,
 		"",
-		"Detecting project files...",
+		strings.Join(files, "\n"),
 		"",
-		"Press q to quit",
+		status,
+		m.footerView("space: toggle • a: all/none • enter: continue • s: save to .bump • q: quit"),
 	)
 
 	return lipgloss.Place(
@@ -0,0 +1,451 @@
+package models
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bump-tui/internal/git"
+	"bump-tui/internal/gitfixture"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+)
+
+// packageDir is where testdata lives; the tests run inside fixture repositories
+var packageDir string
+
+func init() {
+	// Golden files hold plain text; styles would depend on the terminal running the tests
+	lipgloss.SetColorProfile(termenv.Ascii)
+	packageDir, _ = os.Getwd()
+}
+
+// newTestProject creates a released Rust crate with two unreleased commits. The
+// changelog uses the regex generator and the update check is off, so nothing in the
+// views depends on the machine running the tests.
+func newTestProject(t *testing.T) *gitfixture.Repo {
+	repo := gitfixture.New(t)
+	repo.WriteFile("Cargo.toml", "[package]\nname = \"app\"\nversion = \"1.0.0\"\nedition = \"2021\"\n")
+	repo.WriteFile(".bump.toml", "[ai]\ngenerators = [\"regex\"]\n\n[updates]\ncheck = false\n")
+	repo.Commit("chore: initial commit")
+	repo.Tag("1.0.0")
+	repo.WriteFile("src/export.rs", "pub fn export() {}\n")
+	repo.Commit("feat: add export")
+	repo.WriteFile("src/input.rs", "pub fn parse() {}\n")
+	repo.Commit("fix: handle empty input")
+	repo.Push()
+	repo.Chdir()
+	return repo
+}
+
+func startTestModel(t *testing.T) *teatest.TestModel {
+	return teatest.NewTestModel(t, NewMainModel(Options{}), teatest.WithInitialTermSize(100, 30))
+}
+
+// waitFor waits until the rendered output contains text
+func waitFor(t *testing.T, tm *teatest.TestModel, text string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		return bytes.Contains(out, []byte(text))
+	}, teatest.WithDuration(10*time.Second))
+}
+
+// sendKey sends a key by name, e.g. "enter" or "q"
+func sendKey(tm *teatest.TestModel, name string) {
+	switch name {
+	case "enter":
+		tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	case "down":
+		tm.Send(tea.KeyMsg{Type: tea.KeyDown})
+	default:
+		tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)})
+	}
+}
+
+// finalModel quits the program and returns its last model
+func finalModel(t *testing.T, tm *teatest.TestModel) MainModel {
+	t.Helper()
+	if err := tm.Quit(); err != nil {
+		t.Fatalf("Failed to quit: %v", err)
+	}
+	return tm.FinalModel(t, teatest.WithFinalTimeout(10*time.Second)).(MainModel)
+}
+
+// requireView compares the view with testdata/<test name>.golden; run the tests with
+// -update to rewrite the golden files
+func requireView(t *testing.T, m MainModel) {
+	t.Helper()
+	// The elapsed time in the status bar is the only part that changes between runs
+	m.startedAt = time.Now()
+	view := m.View()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get the working directory: %v", err)
+	}
+	if err := os.Chdir(packageDir); err != nil {
+		t.Fatalf("Failed to change to %s: %v", packageDir, err)
+	}
+	defer os.Chdir(dir)
+	teatest.RequireEqualOutput(t, []byte(view))
+}
+
+func TestWelcomeDashboard(t *testing.T) {
+	newTestProject(t)
+	tm := startTestModel(t)
+	waitFor(t, tm, "enter: start a release")
+
+	m := finalModel(t, tm)
+	if m.state != welcomeView {
+		t.Errorf("Expected the welcome view, got state %d", m.state)
+	}
+	requireView(t, m)
+}
+
+func TestReleaseFlowToConfirmation(t *testing.T) {
+	newTestProject(t)
+	tm := startTestModel(t)
+	waitFor(t, tm, "enter: start a release")
+
+	sendKey(tm, "enter")
+	waitFor(t, tm, "enter: continue to version selection")
+	sendKey(tm, "enter")
+	waitFor(t, tm, "Minor (0.x.0)")
+
+	// Minor is the second choice
+	sendKey(tm, "down")
+	sendKey(tm, "enter")
+	waitFor(t, tm, "enter: continue")
+	sendKey(tm, "enter")
+	waitFor(t, tm, "1.1.0")
+
+	m := finalModel(t, tm)
+	if m.state != confirmationView {
+		t.Fatalf("Expected the confirmation view, got state %d", m.state)
+	}
+	if m.newVersion != "1.1.0" {
+		t.Errorf("Expected a minor bump to 1.1.0, got %s", m.newVersion)
+	}
+	requireView(t, m)
+}
+
+func TestQuickPatchSkipsVersionSelection(t *testing.T) {
+	newTestProject(t)
+	tm := startTestModel(t)
+	waitFor(t, tm, "p: patch release v1.0.1")
+
+	sendKey(tm, "p")
+	waitFor(t, tm, "enter: continue")
+
+	m := finalModel(t, tm)
+	if m.state != changelogPreviewView || m.newVersion != "1.0.1" {
+		t.Errorf("Expected the changelog preview of 1.0.1, got state %d and version %q", m.state, m.newVersion)
+	}
+}
+
+func TestResize(t *testing.T) {
+	newTestProject(t)
+	tm := startTestModel(t)
+	waitFor(t, tm, "enter: start a release")
+	tm.Send(tea.WindowSizeMsg{Width: 70, Height: 24})
+
+	m := finalModel(t, tm)
+	if m.width != 70 || m.height != 24 {
+		t.Fatalf("Expected a 70x24 model, got %dx%d", m.width, m.height)
+	}
+	if m.versionList.Width() != 66 || m.changelogView.Width != 58 || m.changelogView.Height != 11 {
+		t.Errorf("Expected the list and changelog to follow the size, got list %d, changelog %dx%d",
+			m.versionList.Width(), m.changelogView.Width, m.changelogView.Height)
+	}
+	requireView(t, m)
+}
+
+func TestQuitDuringRelease(t *testing.T) {
+	m := NewMainModel(Options{})
+	m.state = progressView
+
+	quit := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	model, cmd := m.Update(quit)
+	m = model.(MainModel)
+	if !m.confirmQuit || cmd != nil {
+		t.Fatalf("Expected q to ask for confirmation instead of quitting")
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = model.(MainModel)
+	if m.confirmQuit || m.quitting {
+		t.Fatalf("Expected n to keep releasing")
+	}
+
+	model, _ = m.Update(quit)
+	model, cmd = model.(MainModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = model.(MainModel)
+	if !m.quitting || cmd != nil {
+		t.Fatalf("Expected y to cancel the release and wait for its rollback")
+	}
+
+	// The pipeline's result ends the program once the rollback finished
+	_, cmd = m.Update(errTest("the release was canceled"))
+	if cmd == nil {
+		t.Fatalf("Expected the release result to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("Expected a quit command")
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	m := NewMainModel(Options{})
+	if _, cmd := m.Update(InterruptMsg{}); cmd == nil {
+		t.Errorf("Expected an interrupt outside a release to quit")
+	}
+
+	m.state = progressView
+	model, cmd := m.Update(InterruptMsg{})
+	if !model.(MainModel).quitting || cmd != nil {
+		t.Errorf("Expected an interrupt during a release to cancel it before quitting")
+	}
+}
+
+func TestRemoteChecksStream(t *testing.T) {
+	m := NewMainModel(Options{})
+	m.state = validationView
+	m.validationID = 2
+	m.validationSummary = &git.ValidationSummary{CanProceed: true}
+	m.remotePending = []string{"Comparing with the remote branch...", "Checking the latest GitHub release..."}
+
+	behind := &git.ValidationResult{Success: true, Warnings: []string{"Branch status: branch is 2 commits behind origin"}}
+	model, _ := m.Update(remoteCheckMsg{id: 1, index: 0, result: behind})
+	if len(model.(MainModel).validationSummary.Results) != 0 {
+		t.Fatalf("Expected a result from an earlier run to be discarded")
+	}
+
+	model, _ = m.Update(remoteCheckMsg{id: 2, index: 0, result: behind})
+	m = model.(MainModel)
+	if len(m.validationSummary.Results) != 1 || !m.remoteChecksRunning() {
+		t.Fatalf("Expected the first result to show while the second check runs")
+	}
+	if model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}); model.(MainModel).state != validationView {
+		t.Errorf("Expected enter to wait for the remaining remote check")
+	}
+
+	model, _ = m.Update(remoteCheckMsg{id: 2, index: 1})
+	m = model.(MainModel)
+	if !m.validationDone() || !m.validationSummary.HasWarnings {
+		t.Errorf("Expected validation to finish with the behind warning")
+	}
+}
+
+func TestValidationStepsStream(t *testing.T) {
+	m := NewMainModel(Options{})
+	m.state = validationView
+	m.validationID = 1
+	events := make(chan tea.Msg, 1)
+
+	branch := git.ValidationStep{Name: "branch", Description: "Checking branch status..."}
+	model, cmd := m.Update(validationStepMsg{id: 1, step: branch, events: events})
+	m = model.(MainModel)
+	if len(m.runningSteps) != 1 || cmd == nil {
+		t.Fatalf("Expected the branch step to run and the next event to be awaited")
+	}
+	if view := m.validationView(); !strings.Contains(view, "Checking branch status...") {
+		t.Errorf("Expected the running step in the view, got:\n%s", view)
+	}
+
+	result := &git.ValidationResult{Step: branch, Success: true, Warnings: []string{"In detached HEAD state"}}
+	model, _ = m.Update(validationStepMsg{id: 1, step: branch, result: result, events: events})
+	m = model.(MainModel)
+	if len(m.runningSteps) != 0 || len(m.stepResults) != 1 {
+		t.Errorf("Expected the branch step to be finished, got %d running and %d results", len(m.runningSteps), len(m.stepResults))
+	}
+	if view := m.validationView(); !strings.Contains(view, "In detached HEAD state") {
+		t.Errorf("Expected the finished step's warning in the view, got:\n%s", view)
+	}
+}
+
+func TestApplyFix(t *testing.T) {
+	m := NewMainModel(Options{})
+	m.state = validationView
+	m.validationID = 1
+
+	fixed := false
+	result := git.ValidationResult{Step: git.ValidationStep{Name: "working_dir"}, Success: true}
+	result.AddWarning("untracked_files", "Found 2 untracked files", "")
+	result.SetAction("untracked_files", &git.FixAction{Label: "Ignore them", Run: func() error {
+		fixed = true
+		return nil
+	}})
+	m.validationSummary = &git.ValidationSummary{CanProceed: true}
+	m.validationSummary.Add(result)
+
+	if view := m.validationView(); !strings.Contains(view, "Ignore them") {
+		t.Fatalf("Expected the fix in the view, got:\n%s", view)
+	}
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd == nil || !model.(MainModel).fixing {
+		t.Fatalf("Expected f to run the fix")
+	}
+
+	model, _ = model.(MainModel).Update(cmd())
+	m = model.(MainModel)
+	if !fixed || m.fixNote != "Ignore them" {
+		t.Errorf("Expected the fix to run and be reported, got note %q", m.fixNote)
+	}
+	if m.validationSummary != nil || m.validationID != 2 {
+		t.Errorf("Expected the checks to run again after the fix")
+	}
+}
+
+func TestConfirmExistingEntry(t *testing.T) {
+	m := NewMainModel(Options{})
+	m.state = confirmationView
+	m.newVersion = "1.1.0"
+	m.changelogEntryExists = true
+	// Typing the version stops the release from starting in the test
+	m.settings.Release.StrictConfirm = true
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = model.(MainModel)
+	if m.state != confirmationView || m.confirmTyping || cmd != nil {
+		t.Fatalf("Expected y not to start the release over an existing entry")
+	}
+	if view := m.confirmationView(); !strings.Contains(view, "press r to replace it") {
+		t.Errorf("Expected y to explain how to replace the entry, got:\n%s", view)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = model.(MainModel)
+	if !m.replaceChangelogEntry || !m.confirmTyping {
+		t.Errorf("Expected r to replace the entry and continue to the typed confirmation")
+	}
+}
+
+func TestUpdateCheckFollowsSettings(t *testing.T) {
+	// The settings loaded by initProject decide, not a fresh read of .bump.toml
+	m := NewMainModel(Options{Version: "1.0.0"})
+	m.settings.Updates.Check = false
+	if msg := m.checkForUpdate(); msg != nil {
+		t.Errorf("Expected no update check when disabled, got %v", msg)
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	yes := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+	m := NewMainModel(Options{})
+	m.state = confirmationView
+	m.newVersion = "1.1.0"
+
+	// Off by default: y starts the release right away
+	if model, _ := m.Update(yes); model.(MainModel).state != progressView {
+		t.Fatalf("Expected the release to start without a countdown")
+	}
+
+	m.settings.Release.CountdownSeconds = 3
+	model, cmd := m.Update(yes)
+	m = model.(MainModel)
+	if m.state != countdownView || m.countdown != 3 || cmd == nil {
+		t.Fatalf("Expected a 3 second countdown, got state %d at %d", m.state, m.countdown)
+	}
+	if view := m.countdownView(); !strings.Contains(view, "Releasing v1.1.0 in 3...") {
+		t.Errorf("Expected the countdown in the view, got:\n%s", view)
+	}
+	first := m.countdownID
+
+	model, _ = m.Update(countdownTickMsg{id: first})
+	m = model.(MainModel)
+	if m.countdown != 2 || !strings.Contains(m.countdownView(), "in 2...") {
+		t.Fatalf("Expected a tick to count down to 2, got %d", m.countdown)
+	}
+
+	// esc aborts, and ticks already scheduled by the aborted countdown are dropped
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(MainModel)
+	if m.state != confirmationView {
+		t.Fatalf("Expected esc to go back to the confirmation")
+	}
+	model, _ = m.Update(countdownTickMsg{id: first})
+	if model.(MainModel).state != confirmationView {
+		t.Fatalf("Expected a stale tick to be ignored after aborting")
+	}
+
+	model, _ = m.Update(yes)
+	m = model.(MainModel)
+	model, _ = m.Update(countdownTickMsg{id: first})
+	if model.(MainModel).countdown != 3 {
+		t.Fatalf("Expected a tick of the aborted countdown not to shorten the new one")
+	}
+
+	for i := 0; i < 3; i++ {
+		model, _ = m.Update(countdownTickMsg{id: m.countdownID})
+		m = model.(MainModel)
+	}
+	if m.state != progressView {
+		t.Errorf("Expected the release to start when the countdown ends, got state %d", m.state)
+	}
+}
+
+func TestStrictConfirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		typed  string
+		starts bool
+	}{
+		{"exact version", "1.1.0", true},
+		{"leading v", "v1.1.0", true},
+		{"surrounding spaces", " 1.1.0 ", true},
+		{"other version", "1.0.1", false},
+		{"prefix only", "1.1", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMainModel(Options{})
+			m.state = confirmationView
+			m.newVersion = "1.1.0"
+			m.settings.Release.StrictConfirm = true
+
+			model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+			m = model.(MainModel)
+			if !m.confirmTyping || m.state != confirmationView {
+				t.Fatalf("Expected y to ask for the version")
+			}
+			if view := m.confirmationView(); !strings.Contains(view, "Type 1.1.0 to confirm the release") {
+				t.Errorf("Expected the typing prompt, got:\n%s", view)
+			}
+
+			if tt.typed != "" {
+				model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.typed)})
+				m = model.(MainModel)
+			}
+			model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			m = model.(MainModel)
+			if started := m.state == progressView; started != tt.starts {
+				t.Fatalf("Expected the release to start: %v, got state %d", tt.starts, m.state)
+			}
+			if !tt.starts {
+				if !m.confirmTyping || !m.confirmMismatch || !strings.Contains(m.confirmationView(), "Version does not match") {
+					t.Errorf("Expected a mismatch to keep asking")
+				}
+				// Typing again clears the mismatch, esc cancels
+				model, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+				if model.(MainModel).confirmMismatch {
+					t.Errorf("Expected editing to clear the mismatch")
+				}
+				model, _ = model.(MainModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+				if m = model.(MainModel); m.confirmTyping || m.state != confirmationView {
+					t.Errorf("Expected esc to cancel typing")
+				}
+			}
+		})
+	}
+}
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }
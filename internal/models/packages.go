@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// updateAffectedPackages narrows the release to the changed packages and moves on to version selection
+func (m MainModel) updateAffectedPackages(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !key.Matches(msg, m.keys.Enter) || m.changedPackageCount() == 0 {
+		return m, nil
+	}
+	if err := m.versionManager.SelectChangedPackages(m.packageChanges); err != nil {
+		m.err = err
+		return m, nil
+	}
+	return m.startVersionSelect()
+}
+
+// changedPackageCount counts the packages with commits since their last tag
+func (m MainModel) changedPackageCount() int {
+	count := 0
+	for _, change := range m.packageChanges {
+		if change.Changed() {
+			count++
+		}
+	}
+	return count
+}
+
+// affectedPackagesView lists which packages the release bumps and which it leaves alone
+func (m MainModel) affectedPackagesView() string {
+	header := m.headerView("Affected Packages")
+
+	changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#a6da95"))
+	unchangedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d"))
+
+	var lines []string
+	for _, change := range m.packageChanges {
+		since := "never tagged"
+		if change.LastTag != "" {
+			since = "since " + change.LastTag
+		}
+		name := filepath.ToSlash(filepath.Dir(change.File.Path))
+		if name == "." {
+			name = "(root)"
+		}
+
+		version := "unknown"
+		if change.Version != nil {
+			version = change.Version.String()
+		}
+		line := fmt.Sprintf("%s (%s) — %d commits %s", name, version, change.Commits, since)
+		if change.Changed() {
+			lines = append(lines, changedStyle.Render("✓ "+line))
+		} else {
+			lines = append(lines, unchangedStyle.Render("  "+line+", unchanged"))
+		}
+	}
+
+	changed := m.changedPackageCount()
+	var status, help string
+	if changed == 0 {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f5a97f")).
+			Bold(true).
+			Render("⚠️  No package changed since its last tag, nothing to release")
+		help = "q: quit"
+	} else {
+		status = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8aadf4")).
+			Bold(true).
+			Render(fmt.Sprintf("%d of %d packages will be bumped, each from its own version", changed, len(m.packageChanges)))
+		help = "enter: choose version • q: quit"
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		header,
+		"",
+		status,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.footerView(help),
+	)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
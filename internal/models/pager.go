@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/clipboard"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+)
+
+// listPrefixRe matches the marker of a list item, including the cursor of the
+// bullet editor, so wrapped lines can hang under the item text
+var listPrefixRe = regexp.MustCompile(`^\s*(?:› )?\s*(?:[-*+]|\d+\.)\s+`)
+
+// setChangelogContent shows content in the changelog viewport, soft-wrapped to its
+// width. The raw content is kept so a resize can wrap it again.
+func (m MainModel) setChangelogContent(content string) MainModel {
+	m.changelogContent = content
+	return m.renderChangelog()
+}
+
+// renderChangelog wraps the changelog content and highlights the search matches
+func (m MainModel) renderChangelog() MainModel {
+	lines := strings.Split(wrapChangelog(m.changelogContent, m.changelogView.Width), "\n")
+	lines, m.searchMatches = highlightMatches(lines, m.searchQuery, m.searchCursor)
+	if m.searchCursor >= len(m.searchMatches) {
+		m.searchCursor = 0
+	}
+	m.changelogView.SetContent(strings.Join(lines, "\n"))
+	return m
+}
+
+// rewrapChangelog wraps the viewport content again after the width changed
+func (m MainModel) rewrapChangelog() MainModel {
+	if m.entryEditing {
+		return m.refreshEntryView()
+	}
+	return m.setChangelogContent(m.changelogContent)
+}
+
+// wrapChangelog soft-wraps every line of content to width
+func wrapChangelog(content string, width int) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		lines = append(lines, wrapLine(line, width)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine word-wraps a line to width, indenting continuation lines of list items
+// under the item text. Words longer than the line are broken.
+func wrapLine(line string, width int) []string {
+	if width <= 0 || lipgloss.Width(line) <= width {
+		return []string{line}
+	}
+
+	prefix := listPrefixRe.FindString(line)
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	textWidth := width - len(indent)
+	if textWidth < 10 {
+		prefix, indent, textWidth = "", "", width
+	}
+
+	text := strings.TrimPrefix(line, prefix)
+	wrapped := strings.Split(wrap.String(wordwrap.String(text, textWidth), textWidth), "\n")
+	for i := range wrapped {
+		if i == 0 {
+			wrapped[i] = prefix + wrapped[i]
+		} else {
+			wrapped[i] = indent + strings.TrimLeft(wrapped[i], " ")
+		}
+	}
+	return wrapped
+}
+
+// scrollIndicator describes the visible part of the changelog, e.g. "lines 1–20 of 57 • 35%"
+func (m MainModel) scrollIndicator() string {
+	total := m.changelogView.TotalLineCount()
+	if total <= m.changelogView.Height {
+		return ""
+	}
+	first := m.changelogView.YOffset + 1
+	last := m.changelogView.YOffset + m.changelogView.VisibleLineCount()
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#6e738d")).
+		Render(fmt.Sprintf("lines %d–%d of %d • %.0f%%", first, last, total, m.changelogView.ScrollPercent()*100))
+}
+
+// copyToClipboard copies text to the system or terminal clipboard in the background
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		if strings.TrimSpace(text) == "" {
+			return copiedMsg{err: fmt.Errorf("nothing to copy")}
+		}
+		target, err := clipboard.Copy(text)
+		return copiedMsg{target: target, err: err}
+	}
+}
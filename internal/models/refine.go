@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/changelog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// refinedMsg is sent when the AI revised the changelog following an instruction
+type refinedMsg struct {
+	changes string
+	usage   *changelog.Usage
+	err     error
+}
+
+// startRefine opens the input for an instruction to refine the changelog with
+func (m MainModel) startRefine() (tea.Model, tea.Cmd) {
+	m.refining = true
+	m.refineErr = nil
+	m.refineInput.SetValue("")
+	return m, m.refineInput.Focus()
+}
+
+// updateRefine handles keys while the instruction is typed. Enter asks the AI to
+// revise the current draft.
+func (m MainModel) updateRefine(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.refining = false
+		m.refineInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		instruction := strings.TrimSpace(m.refineInput.Value())
+		if instruction == "" {
+			return m, nil
+		}
+		m.refining = false
+		m.refineInput.Blur()
+		m.refineRunning = true
+		return m, m.refineChangelog(m.generatedChanges, instruction)
+	}
+
+	var cmd tea.Cmd
+	m.refineInput, cmd = m.refineInput.Update(msg)
+	return m, cmd
+}
+
+// refineChangelog asks the AI generators to revise changes in the background
+func (m MainModel) refineChangelog(changes, instruction string) tea.Cmd {
+	return func() tea.Msg {
+		refined, err := m.changelogManager.Refine(changes, instruction)
+		if err != nil {
+			return refinedMsg{err: err}
+		}
+		return refinedMsg{changes: refined, usage: m.changelogManager.LastUsage()}
+	}
+}
+
+// applyRefined records the revised changelog as a new draft and shows it
+func (m MainModel) applyRefined(msg refinedMsg) MainModel {
+	m.refineRunning = false
+	if msg.usage != nil {
+		m.aiMonthSpend += msg.usage.CostUSD
+	}
+	if msg.err != nil {
+		m.refineErr = msg.err
+		return m
+	}
+
+	changes, lintFixes := m.lintChanges(msg.changes)
+	m = m.recordRevision(changes, "refined")
+	m.lintFixes = lintFixes
+	// The self-check compared the first version with the commits
+	m.qualityIssues = nil
+	if m.showPrompt || m.entryEditing {
+		return m
+	}
+	return m.setChangelogContent(m.generatedChanges)
+}
+
+// refineStatus shows the instruction input, the running refinement or its failure
+func (m MainModel) refineStatus() string {
+	switch {
+	case m.refining:
+		return m.refineInput.View()
+	case m.refineRunning:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#8aadf4")).Render("✨ Refining the changelog...")
+	case m.refineErr != nil:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#ed8796")).Render(fmt.Sprintf("⚠️  Refinement failed: %v", m.refineErr))
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	searchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#24273a")).
+				Background(lipgloss.Color("#f5a97f"))
+	searchCurrentStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#24273a")).
+				Background(lipgloss.Color("#a6da95")).
+				Bold(true)
+)
+
+// startSearch opens the / search input of the changelog preview
+func (m MainModel) startSearch() (tea.Model, tea.Cmd) {
+	m.searching = true
+	m.searchInput.SetValue(m.searchQuery)
+	m.searchInput.CursorEnd()
+	return m, m.searchInput.Focus()
+}
+
+// updateSearch handles keys while the search query is typed. Enter jumps to the first
+// match below the top of the view; esc keeps the previous search.
+func (m MainModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.searching = false
+		m.searchInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.searching = false
+		m.searchInput.Blur()
+		m.searchQuery = m.searchInput.Value()
+		m.searchCursor = 0
+		m = m.renderChangelog()
+		for i, line := range m.searchMatches {
+			if line >= m.changelogView.YOffset {
+				m.searchCursor = i
+				break
+			}
+		}
+		return m.showSearchMatch(), nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
+// nextSearchMatch moves to the next (step 1) or previous (step -1) match, wrapping around
+func (m MainModel) nextSearchMatch(step int) MainModel {
+	if len(m.searchMatches) == 0 {
+		return m
+	}
+	m.searchCursor = (m.searchCursor + step + len(m.searchMatches)) % len(m.searchMatches)
+	return m.showSearchMatch()
+}
+
+// showSearchMatch highlights the current match and scrolls it into view
+func (m MainModel) showSearchMatch() MainModel {
+	m = m.renderChangelog()
+	if len(m.searchMatches) == 0 {
+		return m
+	}
+	line := m.searchMatches[m.searchCursor]
+	if line < m.changelogView.YOffset || line >= m.changelogView.YOffset+m.changelogView.Height {
+		m.changelogView.SetYOffset(line - m.changelogView.Height/2)
+	}
+	return m
+}
+
+// highlightMatches marks every case-insensitive occurrence of query in the wrapped
+// lines, returning the lines and the indexes of the lines that matched
+func highlightMatches(lines []string, query string, current int) ([]string, []int) {
+	if query == "" {
+		return lines, nil
+	}
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+
+	var matches []int
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		style := searchMatchStyle
+		if len(matches) == current {
+			style = searchCurrentStyle
+		}
+		matches = append(matches, i)
+		lines[i] = re.ReplaceAllStringFunc(line, func(match string) string {
+			return style.Render(match)
+		})
+	}
+	return lines, matches
+}
+
+// searchStatus shows the search input while typing, or the matches of the last search
+func (m MainModel) searchStatus() string {
+	if m.searching {
+		return m.searchInput.View()
+	}
+	if m.searchQuery == "" {
+		return ""
+	}
+
+	status := fmt.Sprintf("/%s: no matches", m.searchQuery)
+	if len(m.searchMatches) > 0 {
+		status = fmt.Sprintf("/%s: match %d of %d • n/N: next/previous", m.searchQuery, m.searchCursor+1, len(m.searchMatches))
+	}
+	status += " • esc: clear"
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#6e738d")).Render(status)
+}
+
+// changelogStatus joins the search status and the scroll indicator below the changelog
+func (m MainModel) changelogStatus() string {
+	var parts []string
+	for _, part := range []string{m.searchStatus(), m.scrollIndicator()} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "   ")
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusTickMsg refreshes the elapsed time in the status bar
+type statusTickMsg struct{}
+
+// statusTick schedules the next status bar refresh
+func (m MainModel) statusTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return statusTickMsg{}
+	})
+}
+
+// statusBarView is the bar on the last line of every view: the repository, branch,
+// version change and changelog generator, and how long the session has been running.
+// Parts not known yet are left out.
+func (m MainModel) statusBarView() string {
+	var parts []string
+	if m.workspace != nil {
+		parts = append(parts, m.workspace.repo, m.workspace.branch)
+	}
+	if m.projectLoaded {
+		versions := m.versionManager.CurrentVersion.String()
+		if m.newVersion != "" {
+			versions += " → " + m.newVersion
+		}
+		parts = append(parts, versions)
+	}
+	if generator := m.statusGenerator(); generator != "" {
+		parts = append(parts, generator)
+	}
+
+	barStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#cad3f5")).
+		Background(lipgloss.Color("#494d64"))
+	left := barStyle.Render(" " + strings.Join(parts, " • "))
+	right := barStyle.Render(fmt.Sprintf("⏱ %s ", time.Since(m.startedAt).Truncate(time.Second)))
+
+	gap := m.width - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(left + barStyle.Render(strings.Repeat(" ", gap)) + right)
+}
+
+// statusGenerator names the generator that wrote the changelog, or before that the
+// first one of the chain that can run
+func (m MainModel) statusGenerator() string {
+	if m.changesGenerator != "" {
+		return m.changesGenerator
+	}
+	for _, generator := range m.generators {
+		if generator.Available {
+			return generator.Name
+		}
+	}
+	return ""
+}
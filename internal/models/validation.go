@@ -0,0 +1,265 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/registry"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// validationEventBuffer holds every progress event of a validation run, so a run
+// abandoned by "r" never blocks on sending
+const validationEventBuffer = 32
+
+// remoteCheckDelay debounces the network checks: re-running the validation within it
+// starts them only once, for the latest run
+const remoteCheckDelay = 300 * time.Millisecond
+
+// remoteCheck is a validation check that needs the network, run in the background
+// once the local checks are shown
+type remoteCheck struct {
+	description string
+	// run returns nil when the check doesn't apply
+	run func() *git.ValidationResult
+}
+
+// remoteChecksDueMsg starts the remote checks; id discards a trigger from an earlier run
+type remoteChecksDueMsg struct {
+	id int
+}
+
+// remoteCheckMsg carries the result of one remote check, indexed like remoteChecks
+type remoteCheckMsg struct {
+	id     int
+	index  int
+	result *git.ValidationResult
+}
+
+// waitForValidation delivers the next event of a validation run
+func waitForValidation(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// recordValidationStep tracks a local validation step starting or finishing
+func (m MainModel) recordValidationStep(msg validationStepMsg) MainModel {
+	var running []git.ValidationStep
+	for _, step := range m.runningSteps {
+		if step.Name != msg.step.Name {
+			running = append(running, step)
+		}
+	}
+	if msg.result == nil {
+		running = append(running, msg.step)
+	} else {
+		m.stepResults = append(append([]git.ValidationResult(nil), m.stepResults...), *msg.result)
+	}
+	m.runningSteps = running
+	return m
+}
+
+// remoteChecks lists the network-dependent checks for this project
+func (m MainModel) remoteChecks() []remoteCheck {
+	checks := []remoteCheck{{
+		description: "Comparing with the remote branch...",
+		run: func() *git.ValidationResult {
+			result := m.gitManager.ValidateRemoteStatus()
+			return &result
+		},
+	}}
+	if m.settings.GitHub.LatestRelease != config.LatestReleaseOff {
+		checks = append(checks, remoteCheck{
+			description: "Checking the latest GitHub release...",
+			run: func() *git.ValidationResult {
+				return m.releaseManager.CheckLatestRelease(m.versionManager.CurrentVersion.String())
+			},
+		})
+	}
+	if m.settings.Registry.Check {
+		checks = append(checks, remoteCheck{
+			description: "Checking the package registries...",
+			run: func() *git.ValidationResult {
+				result := m.registryManager.ValidatePublished(registry.FindPackages(m.versionManager.ProjectFiles))
+				return &result
+			},
+		})
+	}
+	return checks
+}
+
+// scheduleRemoteChecks lists the remote checks as pending and starts them after
+// remoteCheckDelay
+func (m MainModel) scheduleRemoteChecks() (MainModel, tea.Cmd) {
+	checks := m.remoteChecks()
+	m.remotePending = make([]string, len(checks))
+	for i, check := range checks {
+		m.remotePending[i] = check.description
+	}
+	id := m.validationID
+	return m, tea.Tick(remoteCheckDelay, func(time.Time) tea.Msg {
+		return remoteChecksDueMsg{id: id}
+	})
+}
+
+// runRemoteChecks starts every remote check at once; each result is shown as it arrives
+func (m MainModel) runRemoteChecks() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, check := range m.remoteChecks() {
+		id, index, run := m.validationID, i, check.run
+		cmds = append(cmds, func() tea.Msg {
+			return remoteCheckMsg{id: id, index: index, result: run()}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// remoteChecksRunning reports whether any remote check hasn't reported back yet
+func (m MainModel) remoteChecksRunning() bool {
+	for _, description := range m.remotePending {
+		if description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validationDone reports whether the local and remote checks have all finished
+func (m MainModel) validationDone() bool {
+	return m.validationSummary != nil && !m.remoteChecksRunning()
+}
+
+// handleRemoteCheck adds a remote check's result to the summary
+func (m MainModel) handleRemoteCheck(msg remoteCheckMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.validationID || m.validationSummary == nil {
+		return m, nil
+	}
+	pending := append([]string(nil), m.remotePending...)
+	pending[msg.index] = ""
+	m.remotePending = pending
+	if msg.result != nil {
+		m.validationSummary.Add(*msg.result)
+	}
+	return m.validationSettled()
+}
+
+// validationSettled continues a quick patch once every check passed without warnings
+func (m MainModel) validationSettled() (tea.Model, tea.Cmd) {
+	if m.state == validationView && m.quickPatch && m.validationDone() &&
+		m.validationSummary.CanProceed && m.countWarnings() == 0 {
+		return m.continueFromValidation()
+	}
+	return m, nil
+}
+
+// rerunValidation runs the checks again, e.g. after pulling in another terminal
+func (m MainModel) rerunValidation() (tea.Model, tea.Cmd) {
+	m.validationSummary = nil
+	return m.startValidation()
+}
+
+// pendingChecksView lists the local steps and remote checks still running, each
+// with the spinner
+func (m MainModel) pendingChecksView() []string {
+	var lines []string
+	for _, step := range m.runningSteps {
+		lines = append(lines, fmt.Sprintf("%s %s", m.spinner.View(), step.Description))
+	}
+	for _, description := range m.remotePending {
+		if description != "" {
+			lines = append(lines, fmt.Sprintf("%s %s", m.spinner.View(), description))
+		}
+	}
+	return lines
+}
+
+// fixAppliedMsg reports the outcome of a fix action run from the validation view
+type fixAppliedMsg struct {
+	label string
+	err   error
+}
+
+// fixableFindings lists the findings of a finished validation that have a fix action
+func (m MainModel) fixableFindings() []git.Finding {
+	if !m.validationDone() {
+		return nil
+	}
+	var findings []git.Finding
+	for _, result := range m.validationSummary.Results {
+		for _, finding := range result.Findings() {
+			if finding.Action != nil {
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
+
+// applyFix runs the selected finding's fix action
+func (m MainModel) applyFix() (tea.Model, tea.Cmd) {
+	findings := m.fixableFindings()
+	if m.fixing || m.fixCursor >= len(findings) {
+		return m, nil
+	}
+	action := findings[m.fixCursor].Action
+	m.fixing = true
+	m.fixErr = nil
+	m.fixNote = ""
+	return m, func() tea.Msg {
+		return fixAppliedMsg{label: action.Label, err: action.Run()}
+	}
+}
+
+// handleFixApplied runs the checks again after a successful fix, so the finding disappears
+func (m MainModel) handleFixApplied(msg fixAppliedMsg) (tea.Model, tea.Cmd) {
+	m.fixing = false
+	if msg.err != nil {
+		m.fixErr = msg.err
+		return m, nil
+	}
+	m.fixNote = msg.label
+	m.fixCursor = 0
+	return m.rerunValidation()
+}
+
+// fixesView lists the fix actions with the selected one highlighted, and the outcome
+// of the last one run
+func (m MainModel) fixesView() []string {
+	findings := m.fixableFindings()
+	var lines []string
+	if len(findings) > 0 {
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8aadf4")).
+			Bold(true).
+			Render("🔧 Fixes:"))
+		for i, finding := range findings {
+			line := fmt.Sprintf("  %s — %s", finding.Action.Label, finding.Message)
+			if i == m.fixCursor {
+				line = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#c6a0f6")).
+					Bold(true).
+					Render("▶ " + line[2:])
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	switch {
+	case m.fixing:
+		lines = append(lines, "", fmt.Sprintf("%s Applying the fix...", m.spinner.View()))
+	case m.fixErr != nil:
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#ed8796")).
+			Render(fmt.Sprintf("❌ Fix failed: %v", m.fixErr)))
+	case m.fixNote != "":
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#a6da95")).
+			Render(fmt.Sprintf("✅ %s: done", m.fixNote)))
+	}
+	return lines
+}
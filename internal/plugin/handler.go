@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"bump-tui/internal/version"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// fileHandler manages version files through a plugin's extract and update actions
+type fileHandler struct {
+	plugin *Plugin
+}
+
+// Detect matches a file's name, or its path relative to the project root, against the
+// patterns the plugin described
+func (h fileHandler) Detect(path string) bool {
+	for _, pattern := range h.plugin.Files {
+		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.ToSlash(path)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (h fileHandler) Extract(path, content string) (*semver.Version, error) {
+	response, err := h.plugin.call(Timeout, Request{Action: ActionExtract, Path: path, Content: content})
+	if err != nil {
+		return nil, err
+	}
+	if response.Version == "" {
+		return nil, fmt.Errorf("no version found in %s", path)
+	}
+	return semver.NewVersion(response.Version)
+}
+
+func (h fileHandler) Update(path, content, newVersion string) (string, error) {
+	response, err := h.plugin.call(Timeout, Request{Action: ActionUpdate, Path: path, Content: content, Version: newVersion})
+	if err != nil {
+		return "", err
+	}
+	if response.Content == "" {
+		return "", fmt.Errorf("plugin %s returned an empty %s", h.plugin.Name, path)
+	}
+	return response.Content, nil
+}
+
+// RegisterHandlers registers the plugins that handle version files with the version
+// package, under their plugin name as project type
+func RegisterHandlers(plugins []*Plugin) {
+	for _, p := range plugins {
+		if len(p.Files) > 0 {
+			version.RegisterHandler(version.ProjectType(p.Name), p.Description, fileHandler{p}, p.Files...)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"bump-tui/internal/git"
+)
+
+const (
+	// ExecutablePrefix is prepended to a plugin name to find its executable on PATH,
+	// e.g. bump-plugin-helm for the plugin "helm"
+	ExecutablePrefix = "bump-plugin-"
+	// Timeout bounds plugin calls without a timeout of their own
+	Timeout = 60 * time.Second
+)
+
+// Actions a plugin is asked to perform. The action is passed as the first argument
+// and in the request, so simple scripts can dispatch on $1.
+const (
+	// ActionDescribe asks which capabilities the plugin has
+	ActionDescribe = "describe"
+	// ActionExtract reads the version from a version file
+	ActionExtract = "extract"
+	// ActionUpdate returns a version file with the new version
+	ActionUpdate = "update"
+	// ActionGenerate writes the changelog of the release's commits
+	ActionGenerate = "generate"
+	// ActionPostRelease runs after the tag is pushed
+	ActionPostRelease = "post-release"
+)
+
+// Request is written to the plugin's stdin as JSON
+type Request struct {
+	Action string `json:"action"`
+	// Path and Content are the version file, for extract and update
+	Path    string `json:"path,omitempty"`
+	Content string `json:"content,omitempty"`
+	// Version is the new version, for update and post-release
+	Version string `json:"version,omitempty"`
+	// PreviousVersion is the version released before, for generate and post-release
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// Commits are the commits since the previous release, for generate
+	Commits []git.Commit `json:"commits,omitempty"`
+}
+
+// Response is read from the plugin's stdout as JSON. A non-empty Error fails the call.
+type Response struct {
+	// Description, Files, Generator and PostRelease answer describe
+	Description string `json:"description,omitempty"`
+	// Files are the file names or glob patterns of the version files the plugin handles
+	Files       []string `json:"files,omitempty"`
+	Generator   bool     `json:"generator,omitempty"`
+	PostRelease bool     `json:"post_release,omitempty"`
+	// Version answers extract
+	Version string `json:"version,omitempty"`
+	// Content answers update
+	Content string `json:"content,omitempty"`
+	// Changelog answers generate, as markdown bullets
+	Changelog string `json:"changelog,omitempty"`
+	// Notes answer post-release, one line per action taken
+	Notes []string `json:"notes,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// Plugin is a bump-plugin-<name> executable, or a WASM module, and the capabilities
+// it described
+type Plugin struct {
+	Name        string
+	Path        string
+	Description string
+	Files       []string
+	Generator   bool
+	PostRelease bool
+	// module runs a WASM plugin in place of an executable
+	module *wasmModule
+}
+
+// Load finds the executable of each named plugin on PATH and asks it to describe itself
+func Load(names []string) ([]*Plugin, error) {
+	var plugins []*Plugin
+	for _, name := range names {
+		path, err := exec.LookPath(ExecutablePrefix + name)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %s%s not found on PATH", name, ExecutablePrefix, name)
+		}
+
+		p := &Plugin{Name: name, Path: path}
+		if err := p.describe(); err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// describe asks the plugin for its capabilities
+func (p *Plugin) describe() error {
+	response, err := p.call(Timeout, Request{Action: ActionDescribe})
+	if err != nil {
+		return fmt.Errorf("plugin %s: %v", p.Name, err)
+	}
+	p.Description = response.Description
+	if p.Description == "" {
+		p.Description = p.Name + " plugin"
+	}
+	p.Files = response.Files
+	p.Generator = response.Generator
+	p.PostRelease = response.PostRelease
+	return nil
+}
+
+// Find returns the plugin with the given name, or nil
+func Find(plugins []*Plugin, name string) *Plugin {
+	for _, p := range plugins {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// call runs the plugin for one request within timeout
+func (p *Plugin) call(timeout time.Duration, request Request) (*Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return p.Call(ctx, request)
+}
+
+// Call runs the plugin for one request and decodes its response
+func (p *Plugin) Call(ctx context.Context, request Request) (*Response, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	if p.module != nil {
+		output, err = p.module.call(ctx, input)
+	} else {
+		output, err = p.run(ctx, request.Action, input)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("%s timed out", request.Action)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %v", request.Action, err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("%s returned invalid JSON: %v", request.Action, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s failed: %s", request.Action, response.Error)
+	}
+	return &response, nil
+}
+
+// run runs the plugin executable with the request on stdin and returns its stdout.
+// The tail of stderr is included when it exits with an error.
+func (p *Plugin) run(ctx context.Context, action string, input []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, p.Path, action)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, stderrTail(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// stderrTail returns the last lines a plugin wrote to stderr
+func stderrTail(stderr string) string {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) > 5 {
+		lines = lines[len(lines)-5:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,106 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testPlugin is a plugin script handling VERSION files that hold nothing but the version
+const testPlugin = `#!/bin/sh
+input=$(cat)
+case "$1" in
+describe)
+	echo '{"description": "Plain version file", "files": ["VERSION"], "post_release": true}' ;;
+extract)
+	version=$(printf '%s' "$input" | sed -n 's/.*"content":"\([^"\\]*\).*/\1/p')
+	echo "{\"version\": \"$version\"}" ;;
+update)
+	version=$(printf '%s' "$input" | sed -n 's/.*"version":"\([^"]*\)".*/\1/p')
+	printf '{"content": "%s\\n"}\n' "$version" ;;
+post-release)
+	echo '{"error": "registry is down"}' ;;
+*)
+	echo "unknown action $1" >&2
+	exit 1 ;;
+esac
+`
+
+// installPlugin writes a plugin script to a temporary directory on PATH
+func installPlugin(t *testing.T, name, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ExecutablePrefix+name), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestLoad(t *testing.T) {
+	installPlugin(t, "plain", testPlugin)
+
+	plugins, err := Load([]string{"plain"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	p := plugins[0]
+	if p.Description != "Plain version file" || len(p.Files) != 1 || !p.PostRelease || p.Generator {
+		t.Errorf("Expected the described capabilities, got %+v", p)
+	}
+
+	if _, err := Load([]string{"missing"}); err == nil || !strings.Contains(err.Error(), "bump-plugin-missing not found") {
+		t.Errorf("Expected a missing plugin error, got %v", err)
+	}
+}
+
+func TestFileHandler(t *testing.T) {
+	installPlugin(t, "plain", testPlugin)
+	plugins, err := Load([]string{"plain"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	handler := fileHandler{plugins[0]}
+
+	if !handler.Detect("VERSION") || !handler.Detect("sub/VERSION") || handler.Detect("VERSION.txt") {
+		t.Errorf("Expected only VERSION files to be detected")
+	}
+
+	version, err := handler.Extract("VERSION", "1.2.3")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if version.String() != "1.2.3" {
+		t.Errorf("Expected 1.2.3, got %s", version)
+	}
+
+	updated, err := handler.Update("VERSION", "1.2.3\n", "1.3.0")
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated != "1.3.0\n" {
+		t.Errorf("Expected %q, got %q", "1.3.0\n", updated)
+	}
+}
+
+func TestCallErrors(t *testing.T) {
+	installPlugin(t, "plain", testPlugin)
+	p := &Plugin{Name: "plain", Path: ExecutablePrefix + "plain"}
+
+	tests := []struct {
+		action   string
+		expected string
+	}{
+		{ActionPostRelease, "post-release failed: registry is down"},
+		{"publish", "unknown action publish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			_, err := p.call(Timeout, Request{Action: tt.action})
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
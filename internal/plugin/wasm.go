@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wasmMemoryPages caps the memory of a WASM plugin at 16 MiB (64 KiB pages)
+const wasmMemoryPages = 256
+
+// wasmModule is a compiled WASM plugin. Each call runs in a fresh instance without
+// filesystem, network, environment or arguments: the request is all it sees.
+type wasmModule struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+}
+
+// LoadWASM compiles the WASM plugins at paths and asks each to describe itself. WASM
+// plugins are sandboxed and only handle version files; the plugin name is the file
+// name without .wasm.
+//
+// A module exports its memory, alloc(size i32) i32, which returns a buffer for the
+// request, and handle(ptr i32, len i32) i64, which takes the JSON request and returns
+// the address of the JSON response in the upper 32 bits and its length in the lower.
+// WASI imports are available, so modules built for wasip1 run, but no directory is
+// mounted.
+func LoadWASM(paths []string) ([]*Plugin, error) {
+	var plugins []*Plugin
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".wasm")
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %v", name, err)
+		}
+		module, err := compileWASM(code)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %v", name, err)
+		}
+
+		p := &Plugin{Name: name, Path: path, module: module}
+		if err := p.describe(); err != nil {
+			return nil, err
+		}
+		p.Generator = false
+		p.PostRelease = false
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// compileWASM compiles a module in its own runtime, whose only host functions are WASI
+func compileWASM(code []byte) (*wasmModule, error) {
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(wasmMemoryPages).
+		WithCloseOnContextDone(true))
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+		return nil, err
+	}
+	compiled, err := runtime.CompileModule(ctx, code)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("invalid WASM module: %v", err)
+	}
+	for _, name := range []string{"alloc", "handle"} {
+		if _, ok := compiled.ExportedFunctions()[name]; !ok {
+			_ = runtime.Close(ctx)
+			return nil, fmt.Errorf("the module doesn't export %s", name)
+		}
+	}
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("the module doesn't export its memory")
+	}
+	return &wasmModule{runtime: runtime, compiled: compiled}, nil
+}
+
+// call passes the JSON request to a fresh instance of the module and returns its
+// JSON response. Anything the module writes to stderr is included in errors.
+func (w *wasmModule) call(ctx context.Context, input []byte) ([]byte, error) {
+	var stderr bytes.Buffer
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithStderr(&stderr).
+		// Reactor modules initialize their runtime in _initialize; _start would run main and exit
+		WithStartFunctions("_initialize")
+
+	module, err := w.runtime.InstantiateModule(ctx, w.compiled, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to instantiate the module: %v", err)
+	}
+	defer func() {
+		_ = module.Close(ctx)
+	}()
+
+	memory := module.ExportedMemory("memory")
+
+	results, err := module.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("alloc: %v\n%s", err, stderrTail(stderr.String()))
+	}
+	ptr := uint32(results[0])
+	if !memory.Write(ptr, input) {
+		return nil, fmt.Errorf("alloc returned a buffer outside the module's memory")
+	}
+
+	results, err = module.ExportedFunction("handle").Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, stderrTail(stderr.String()))
+	}
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0])
+	output, ok := memory.Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("handle returned a response outside the module's memory")
+	}
+	// The view into the module's memory is gone once the instance is closed
+	return bytes.Clone(output), nil
+}
@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// responseOffset is where fixedModule keeps its response, past the request buffer at 0
+const responseOffset = 1024
+
+// fixedModule assembles a WASM plugin that answers every request with response. It
+// exports memory, alloc, which always returns address 0, and handle.
+func fixedModule(response string, exports ...string) []byte {
+	section := func(id byte, content ...byte) []byte {
+		return append([]byte{id, byte(len(content))}, content...)
+	}
+	name := func(s string) []byte {
+		return append([]byte{byte(len(s))}, s...)
+	}
+
+	// handle returns the response address in the upper 32 bits and its length in the lower
+	packed := int64(responseOffset)<<32 | int64(len(response))
+	var value []byte
+	for {
+		b := byte(packed & 0x7f)
+		packed >>= 7
+		if (packed == 0 && b&0x40 == 0) || (packed == -1 && b&0x40 != 0) {
+			value = append(value, b)
+			break
+		}
+		value = append(value, b|0x80)
+	}
+	handleBody := append(append([]byte{0x00, 0x42}, value...), 0x0b)
+
+	var exportEntries []byte
+	for _, export := range exports {
+		switch export {
+		case "memory":
+			exportEntries = append(append(exportEntries, name(export)...), 0x02, 0x00)
+		case "alloc":
+			exportEntries = append(append(exportEntries, name(export)...), 0x00, 0x00)
+		case "handle":
+			exportEntries = append(append(exportEntries, name(export)...), 0x00, 0x01)
+		}
+	}
+
+	data := append([]byte{0x01, 0x00, 0x41, 0x80, 0x08, 0x0b, byte(len(response))}, response...)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, section(0x01, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e)...)
+	module = append(module, section(0x03, 0x02, 0x00, 0x01)...)
+	module = append(module, section(0x05, 0x01, 0x00, 0x01)...)
+	module = append(module, section(0x07, append([]byte{byte(len(exports))}, exportEntries...)...)...)
+	module = append(module, section(0x0a, append(append([]byte{0x02, 0x04, 0x00, 0x41, 0x00, 0x0b}, byte(len(handleBody))), handleBody...)...)...)
+	module = append(module, section(0x0b, data...)...)
+	return module
+}
+
+// writeModule writes a WASM plugin to a temporary directory and returns its path
+func writeModule(t *testing.T, name string, module []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name+".wasm")
+	if err := os.WriteFile(path, module, 0644); err != nil {
+		t.Fatalf("Failed to write module: %v", err)
+	}
+	return path
+}
+
+func TestLoadWASM(t *testing.T) {
+	response := `{"description":"Fixed","files":["VERSION"],"post_release":true,"version":"1.2.3","content":"1.3.0\n"}`
+	path := writeModule(t, "fixed", fixedModule(response, "memory", "alloc", "handle"))
+
+	plugins, err := LoadWASM([]string{path})
+	if err != nil {
+		t.Fatalf("LoadWASM failed: %v", err)
+	}
+	p := plugins[0]
+	if p.Name != "fixed" || p.Description != "Fixed" || len(p.Files) != 1 {
+		t.Errorf("Expected the described capabilities, got %+v", p)
+	}
+	if p.PostRelease {
+		t.Errorf("Expected WASM plugins to only handle version files")
+	}
+
+	handler := fileHandler{p}
+	version, err := handler.Extract("VERSION", "1.2.3\n")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if version.String() != "1.2.3" {
+		t.Errorf("Expected 1.2.3, got %s", version)
+	}
+	updated, err := handler.Update("VERSION", "1.2.3\n", "1.3.0")
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated != "1.3.0\n" {
+		t.Errorf("Expected %q, got %q", "1.3.0\n", updated)
+	}
+}
+
+func TestLoadWASMErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		module   []byte
+		expected string
+	}{
+		{"not wasm", []byte("#!/bin/sh\n"), "invalid WASM module"},
+		{"no handle", fixedModule(`{}`, "memory", "alloc"), "doesn't export handle"},
+		{"no memory", fixedModule(`{}`, "alloc", "handle"), "doesn't export its memory"},
+		{"error response", fixedModule(`{"error":"unsupported"}`, "memory", "alloc", "handle"), "describe failed: unsupported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadWASM([]string{writeModule(t, "broken", tt.module)})
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
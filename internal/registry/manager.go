@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"bump-tui/internal/git"
+	"bump-tui/internal/version"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	// CratesIOURL is the crates.io API endpoint for crate metadata
+	CratesIOURL = "https://crates.io/api/v1/crates/"
+	// NPMURL is the npm registry endpoint for package metadata
+	NPMURL = "https://registry.npmjs.org/"
+	// RequestTimeout keeps validation from lingering on slow networks
+	RequestTimeout = 5 * time.Second
+	// userAgent identifies requests, as required by the crates.io crawler policy
+	userAgent = "bump-tui (https://github.com/MattressPadley/bump)"
+)
+
+// Package is a package published to a public registry
+type Package struct {
+	// Registry is "crates.io" or "npm"
+	Registry string
+	Name     string
+	// Manifest is the file the name was read from
+	Manifest string
+	// Version is the local version in the manifest
+	Version string
+}
+
+// Manager looks up published versions on crates.io and npm
+type Manager struct {
+	httpClient *http.Client
+	cratesURL  string
+	npmURL     string
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		httpClient: &http.Client{Timeout: RequestTimeout},
+		cratesURL:  CratesIOURL,
+		npmURL:     NPMURL,
+	}
+}
+
+// FindPackages lists the publishable packages among the managed version files, plus a
+// root package.json. Crates with publish = false and private npm packages are skipped.
+func FindPackages(files []version.ProjectFile) []Package {
+	var packages []Package
+	for _, file := range files {
+		if file.Type != version.Rust {
+			continue
+		}
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			continue
+		}
+		var manifest struct {
+			Package struct {
+				Name    string `toml:"name"`
+				Version string `toml:"version"`
+				Publish any    `toml:"publish"`
+			} `toml:"package"`
+		}
+		if toml.Unmarshal(content, &manifest) != nil || manifest.Package.Name == "" || manifest.Package.Publish == false {
+			continue
+		}
+		packages = append(packages, Package{
+			Registry: "crates.io",
+			Name:     manifest.Package.Name,
+			Manifest: file.Path,
+			Version:  manifest.Package.Version,
+		})
+	}
+
+	if content, err := os.ReadFile("package.json"); err == nil {
+		var manifest struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Private bool   `json:"private"`
+			Engines struct {
+				VSCode string `json:"vscode"`
+			} `json:"engines"`
+		}
+		// VS Code extensions are published to the Marketplace, not npm
+		if json.Unmarshal(content, &manifest) == nil && manifest.Name != "" && !manifest.Private && manifest.Engines.VSCode == "" {
+			packages = append(packages, Package{Registry: "npm", Name: manifest.Name, Manifest: "package.json", Version: manifest.Version})
+		}
+	}
+
+	return packages
+}
+
+// LatestVersion returns the latest published version of a package, or "" when it
+// has never been published
+func (r *Manager) LatestVersion(pkg Package) (string, error) {
+	switch pkg.Registry {
+	case "crates.io":
+		var crate struct {
+			Crate struct {
+				MaxStableVersion string `json:"max_stable_version"`
+				MaxVersion       string `json:"max_version"`
+			} `json:"crate"`
+		}
+		found, err := r.getJSON(r.cratesURL+url.PathEscape(pkg.Name), &crate)
+		if err != nil || !found {
+			return "", err
+		}
+		if crate.Crate.MaxStableVersion != "" {
+			return crate.Crate.MaxStableVersion, nil
+		}
+		return crate.Crate.MaxVersion, nil
+	case "npm":
+		var latest struct {
+			Version string `json:"version"`
+		}
+		found, err := r.getJSON(r.npmURL+url.PathEscape(pkg.Name)+"/latest", &latest)
+		if err != nil || !found {
+			return "", err
+		}
+		return latest.Version, nil
+	}
+	return "", fmt.Errorf("unsupported registry %q", pkg.Registry)
+}
+
+// getJSON decodes the response of a GET request into v, reporting false for a 404
+func (r *Manager) getJSON(requestURL string, v any) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("unable to parse the registry response: %v", err)
+	}
+	return true, nil
+}
+
+// ValidatePublished compares the local version of every package with its latest
+// published version, as a repository validation step. Differences are warnings,
+// e.g. a previous release that was tagged but never published.
+func (r *Manager) ValidatePublished(packages []Package) git.ValidationResult {
+	result := git.ValidationResult{
+		Step:    git.ValidationStep{Name: "registry", Description: "Checking published versions..."},
+		Success: true,
+	}
+
+	for _, pkg := range packages {
+		label := fmt.Sprintf("%s on %s", pkg.Name, pkg.Registry)
+		current, err := semver.NewVersion(pkg.Version)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s has no valid local version in %s", label, pkg.Manifest))
+			continue
+		}
+
+		published, err := r.LatestVersion(pkg)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Unable to check %s: %v", label, err))
+			continue
+		}
+		if published == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s has never been published", label))
+			continue
+		}
+
+		publishedVersion, err := semver.NewVersion(published)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s has an unparseable published version %q", label, published))
+			continue
+		}
+
+		switch {
+		case current.GreaterThan(publishedVersion):
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"Local version %s of %s is ahead of the published %s; the %s release may never have shipped",
+				current, label, published, current))
+		case current.LessThan(publishedVersion):
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"Local version %s of %s is behind the published %s; pull or check %s",
+				current, label, published, pkg.Manifest))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidatePublished(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crates/shipped":
+			_, _ = w.Write([]byte(`{"crate":{"max_stable_version":"1.2.0","max_version":"1.3.0-beta.1"}}`))
+		case "/npm/@scope%2Fbehind/latest", "/npm/@scope/behind/latest":
+			_, _ = w.Write([]byte(`{"version":"1.4.0"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	r := NewManager()
+	r.cratesURL = server.URL + "/crates/"
+	r.npmURL = server.URL + "/npm/"
+
+	tests := []struct {
+		name     string
+		pkg      Package
+		expected string
+	}{
+		{"in sync", Package{Registry: "crates.io", Name: "shipped", Version: "1.2.0"}, ""},
+		{"never published", Package{Registry: "crates.io", Name: "unknown", Version: "1.2.0"}, "never been published"},
+		{"behind", Package{Registry: "npm", Name: "@scope/behind", Manifest: "package.json", Version: "1.2.0"}, "is behind the published 1.4.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := r.ValidatePublished([]Package{tt.pkg})
+			if !result.Success {
+				t.Errorf("Expected registry differences to be warnings only")
+			}
+
+			warnings := strings.Join(result.Warnings, "\n")
+			if tt.expected == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %q", warnings)
+			}
+			if !strings.Contains(warnings, tt.expected) {
+				t.Errorf("Expected a warning containing %q, got %q", tt.expected, warnings)
+			}
+		})
+	}
+
+	ahead := r.ValidatePublished([]Package{{Registry: "crates.io", Name: "shipped", Version: "1.3.0"}})
+	if len(ahead.Warnings) != 1 || !strings.Contains(ahead.Warnings[0], "may never have shipped") {
+		t.Errorf("Expected an ahead-of-registry warning, got %v", ahead.Warnings)
+	}
+}
@@ -0,0 +1,134 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"bump-tui/internal/changelog"
+)
+
+// BackupDir holds copies of the files a release writes, under the git directory
+const BackupDir = "bump-backup"
+
+// backupManifest is the list of backed up files, written next to the copies
+const backupManifest = "manifest.json"
+
+// fileBackup is the state of a file before the release wrote it
+type fileBackup struct {
+	Path string `json:"path"`
+	// Existed is false for files the release creates, which are removed on restore
+	Existed bool        `json:"existed"`
+	Mode    os.FileMode `json:"mode,omitempty"`
+	// Copy is the name of the copy in the backup directory
+	Copy string `json:"copy,omitempty"`
+}
+
+// backup holds the files written by a release as they were before it started
+type backup struct {
+	dir   string
+	files []fileBackup
+}
+
+// backupFiles copies every file the release may write to .git/bump-backup/, so a
+// replacement that mangles a manifest can be undone byte for byte
+func (r *Manager) backupFiles(plan Plan) (*backup, error) {
+	gitDir, err := r.gitManager.GetGitDir()
+	if err != nil {
+		return nil, err
+	}
+	return createBackup(filepath.Join(gitDir, BackupDir), r.ReleaseFiles(plan.Version))
+}
+
+// ReleaseFiles lists the files a release of version may write, including files it
+// creates. Paths are relative to the project root and may repeat.
+func (r *Manager) ReleaseFiles(version string) []string {
+	paths := append(r.versionManager.WrittenFiles(), r.changelogManager.ChangelogPath())
+	if notes, err := r.changelogManager.NotesPath(version); err == nil && notes != "" {
+		paths = append(paths, notes)
+	}
+	if r.settings.Debian.Enabled {
+		paths = append(paths, changelog.DebianChangelogPath)
+	}
+	if r.firmwareEnabled() && r.settings.Firmware.Header != "" {
+		paths = append(paths, r.settings.Firmware.Header)
+	}
+	return paths
+}
+
+// createBackup copies paths into dir along with a manifest. A backup left by an
+// interrupted release is never overwritten.
+func createBackup(dir string, paths []string) (*backup, error) {
+	if _, err := os.Stat(filepath.Join(dir, backupManifest)); err == nil {
+		return nil, fmt.Errorf("%s holds the backup of an interrupted release; copy back what you need and delete it", dir)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+
+	b := &backup{dir: dir}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		if seen[abs] {
+			continue
+		}
+		seen[abs] = true
+
+		file := fileBackup{Path: abs}
+		info, err := os.Stat(abs)
+		if err == nil {
+			file.Existed = true
+			file.Mode = info.Mode().Perm()
+			file.Copy = strconv.Itoa(len(b.files)) + ".bak"
+			if err := copyFile(abs, filepath.Join(dir, file.Copy)); err != nil {
+				return nil, fmt.Errorf("unable to back up %s: %v", path, err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to back up %s: %v", path, err)
+		}
+		b.files = append(b.files, file)
+	}
+
+	content, err := json.MarshalIndent(b.files, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupManifest), content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write the backup manifest: %v", err)
+	}
+	return b, nil
+}
+
+// restore puts every backed up file back, removes the files the release created
+// and deletes the backup
+func (b *backup) restore() error {
+	for _, file := range b.files {
+		if !file.Existed {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("unable to remove %s: %v", file.Path, err)
+			}
+			continue
+		}
+		if err := copyFile(filepath.Join(b.dir, file.Copy), file.Path); err != nil {
+			return fmt.Errorf("unable to restore %s: %v", file.Path, err)
+		}
+		if err := os.Chmod(file.Path, file.Mode); err != nil {
+			return fmt.Errorf("unable to restore the mode of %s: %v", file.Path, err)
+		}
+	}
+	return b.discard()
+}
+
+// discard deletes the backup once the release no longer needs it
+func (b *backup) discard() error {
+	return os.RemoveAll(b.dir)
+}
@@ -0,0 +1,105 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"bump-tui/internal/config"
+)
+
+// ActionData is the data available to checklist command and message templates
+type ActionData struct {
+	Version string
+	Tag     string
+}
+
+// IsAutomated reports whether a checklist item runs an action rather than being a manual reminder
+func IsAutomated(item config.ChecklistItem) bool {
+	return item.Webhook != "" || item.Command != ""
+}
+
+// RunChecklistAction runs the webhook or command of a checklist item
+func (r *Manager) RunChecklistAction(item config.ChecklistItem, data ActionData) error {
+	switch {
+	case item.Webhook != "":
+		message := item.Message
+		if message == "" {
+			message = "Released {{.Tag}}"
+		}
+		text, err := expand(message, data)
+		if err != nil {
+			return err
+		}
+		return r.postWebhook(item.Webhook, text, data)
+	case item.Command != "":
+		command, err := expand(item.Command, data)
+		if err != nil {
+			return err
+		}
+		return runCommand(command)
+	default:
+		return nil
+	}
+}
+
+// expand renders a checklist template with the release data
+func expand(text string, data ActionData) (string, error) {
+	tmpl, err := template.New("checklist").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid checklist template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render checklist template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// postWebhook sends a Slack-compatible JSON payload to a webhook URL
+func (r *Manager) postWebhook(url, text string, data ActionData) error {
+	payload, err := json.Marshal(map[string]string{
+		"text":    text,
+		"version": data.Version,
+		"tag":     data.Tag,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.httpClient.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// runCommand runs a checklist command through the shell
+func runCommand(command string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ActionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
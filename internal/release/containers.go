@@ -0,0 +1,177 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"bump-tui/internal/config"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Container image retagging tools for the release.containers tool setting
+const (
+	ContainerToolBuildx = "buildx"
+	ContainerToolCrane  = "crane"
+)
+
+// Defaults for release.containers entries that leave source or tags empty
+var (
+	defaultContainerSource = "sha-{{.ShortCommit}}"
+	defaultContainerTags   = []string{"{{.Version}}", "{{.Major}}.{{.Minor}}", "latest"}
+)
+
+// ContainerData is the data available to container source and tag templates
+type ContainerData struct {
+	Version string
+	Tag     string
+	Major   uint64
+	Minor   uint64
+	Patch   uint64
+	// Commit and ShortCommit identify the release commit
+	Commit      string
+	ShortCommit string
+}
+
+// SyncContainerImages points the configured tags of each container image at the
+// image built from the release commit. It returns one note per retagged image;
+// images synced before a failure are still reported.
+func (r *Manager) SyncContainerImages(version string) ([]string, error) {
+	images := r.settings.Release.Containers
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	data, err := r.containerData(version)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []string
+	for _, image := range images {
+		source, tags, err := renderContainerTags(image, data)
+		if err != nil {
+			return notes, err
+		}
+
+		if err := retagImage(image, source, tags); err != nil {
+			return notes, fmt.Errorf("unable to retag %s: %v", image.Image, err)
+		}
+		notes = append(notes, fmt.Sprintf("Tagged %s:%s as %s", image.Image, source, strings.Join(tags, ", ")))
+	}
+	return notes, nil
+}
+
+// containerData collects the template data for the release commit of version
+func (r *Manager) containerData(version string) (ContainerData, error) {
+	parsed, err := semver.NewVersion(version)
+	if err != nil {
+		return ContainerData{}, fmt.Errorf("invalid version %s: %v", version, err)
+	}
+
+	commit, err := r.gitManager.ResolveRef("v" + version)
+	if err != nil {
+		return ContainerData{}, err
+	}
+
+	short := commit
+	if len(short) > 7 {
+		short = short[:7]
+	}
+
+	return ContainerData{
+		Version:     version,
+		Tag:         "v" + version,
+		Major:       parsed.Major(),
+		Minor:       parsed.Minor(),
+		Patch:       parsed.Patch(),
+		Commit:      commit,
+		ShortCommit: short,
+	}, nil
+}
+
+// renderContainerTags renders the source tag and target tags of an image, applying defaults
+func renderContainerTags(image config.ContainerImage, data ContainerData) (string, []string, error) {
+	sourceTemplate := image.Source
+	if sourceTemplate == "" {
+		sourceTemplate = defaultContainerSource
+	}
+	tagTemplates := image.Tags
+	if len(tagTemplates) == 0 {
+		tagTemplates = defaultContainerTags
+	}
+
+	source, err := renderContainerTemplate(sourceTemplate, data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var tags []string
+	for _, text := range tagTemplates {
+		tag, err := renderContainerTemplate(text, data)
+		if err != nil {
+			return "", nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return source, tags, nil
+}
+
+func renderContainerTemplate(text string, data ContainerData) (string, error) {
+	tmpl, err := template.New("container").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid container template %q: %v", text, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render container template %q: %v", text, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// retagImage adds tags to an existing image in the registry without pulling it
+func retagImage(image config.ContainerImage, source string, tags []string) error {
+	sourceRef := image.Image + ":" + source
+
+	if image.Tool == ContainerToolCrane {
+		for _, tag := range tags {
+			if err := runTool("crane", "tag", sourceRef, tag); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	args := []string{"buildx", "imagetools", "create"}
+	for _, tag := range tags {
+		args = append(args, "-t", image.Image+":"+tag)
+	}
+	return runTool("docker", append(args, sourceRef)...)
+}
+
+// runTool runs an external program without a shell
+func runTool(name string, args ...string) error {
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s is not installed", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ActionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/version"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// FirmwareBuildTimeout bounds the firmware build run after the tag is pushed
+const FirmwareBuildTimeout = 30 * time.Minute
+
+// FirmwareData is the data available to the firmware.name template
+type FirmwareData struct {
+	Version string
+	// Env is the directory the binary was built in, the PlatformIO environment for .pio/build/<env>/firmware.bin
+	Env string
+	// Name and Ext split the built file name, e.g. "firmware" and ".bin"
+	Name string
+	Ext  string
+}
+
+// firmwareEnabled reports whether firmware artifacts are configured for a PlatformIO project
+func (r *Manager) firmwareEnabled() bool {
+	return r.settings.Firmware.Enabled && r.versionManager.HasProjectType(version.PlatformIO)
+}
+
+// ShouldPublishFirmware reports whether firmware binaries are built and renamed after
+// the tag is pushed. The pull-request workflow doesn't tag, so it never runs there.
+func (r *Manager) ShouldPublishFirmware() bool {
+	return r.firmwareEnabled() && r.settings.Release.Workflow != config.WorkflowPullRequest
+}
+
+// writeFirmwareHeader generates the version header committed with the release
+func (r *Manager) writeFirmwareHeader(newVersion string) error {
+	path := r.settings.Firmware.Header
+	parsed, err := semver.NewVersion(newVersion)
+	if err != nil {
+		return fmt.Errorf("invalid version %s: %v", newVersion, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %v", path, err)
+	}
+	return os.WriteFile(path, []byte(version.Header("FIRMWARE", parsed)), 0644)
+}
+
+// PublishFirmware builds the release commit, copies the built binaries to versioned
+// names in firmware.output_dir and, with firmware.attach, uploads them to the GitHub
+// release of the tag. It returns one note per published file.
+func (r *Manager) PublishFirmware(newVersion string) ([]string, error) {
+	settings := r.settings.Firmware
+
+	if settings.BuildCommand != "" {
+		if err := runFirmwareBuild(settings.BuildCommand); err != nil {
+			return nil, fmt.Errorf("firmware build failed: %v", err)
+		}
+	}
+
+	built, err := findFirmware(settings.Artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := renameFirmware(built, settings.Name, settings.OutputDir, newVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []string
+	for _, path := range copies {
+		notes = append(notes, "Built "+path)
+	}
+
+	if settings.Attach {
+		tag := "v" + newVersion
+		if err := r.githubManager.UploadReleaseAssets(tag, copies); err != nil {
+			return notes, fmt.Errorf("unable to attach firmware to the %s release: %v", tag, err)
+		}
+		notes = append(notes, fmt.Sprintf("Attached %d firmware file(s) to the %s release", len(copies), tag))
+	}
+	return notes, nil
+}
+
+// findFirmware expands the artifact globs, failing when nothing was built
+func findFirmware(patterns []string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid firmware artifact pattern %q: %v", pattern, err)
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no firmware binaries match %s", strings.Join(patterns, ", "))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// renameFirmware copies each binary into outputDir under the name rendered from the
+// template, refusing names that collide, e.g. several environments without {{.Env}}
+func renameFirmware(paths []string, nameTemplate, outputDir, newVersion string) ([]string, error) {
+	tmpl, err := template.New("firmware").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid firmware.name template: %v", err)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %v", outputDir, err)
+	}
+
+	seen := make(map[string]string)
+	var copies []string
+	for _, path := range paths {
+		base := filepath.Base(path)
+		ext := filepath.Ext(base)
+		data := FirmwareData{
+			Version: newVersion,
+			Env:     filepath.Base(filepath.Dir(path)),
+			Name:    strings.TrimSuffix(base, ext),
+			Ext:     ext,
+		}
+
+		var name bytes.Buffer
+		if err := tmpl.Execute(&name, data); err != nil {
+			return copies, fmt.Errorf("unable to render firmware.name for %s: %v", path, err)
+		}
+		target := filepath.Join(outputDir, name.String())
+		if other, ok := seen[target]; ok {
+			return copies, fmt.Errorf("%s and %s both map to %s; add {{.Env}} to firmware.name", other, path, target)
+		}
+		seen[target] = path
+
+		if err := copyFile(path, target); err != nil {
+			return copies, fmt.Errorf("unable to copy %s to %s: %v", path, target, err)
+		}
+		copies = append(copies, target)
+	}
+	return copies, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// runFirmwareBuild runs the build command through the shell and returns the tail of
+// its output on failure
+func runFirmwareBuild(command string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), FirmwareBuildTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if len(lines) > 5 {
+			lines = lines[len(lines)-5:]
+		}
+		return fmt.Errorf("%v\n%s", err, strings.Join(lines, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+)
+
+// GoreleaserTimeout bounds a local `goreleaser release`, which builds every target
+const GoreleaserTimeout = 30 * time.Minute
+
+// goreleaserConfigFiles are the config file names GoReleaser looks for, in order
+var goreleaserConfigFiles = []string{".goreleaser.yaml", ".goreleaser.yml", "goreleaser.yaml", "goreleaser.yml"}
+
+// GoreleaserConfig returns the GoReleaser config file in the project root, or "" if there is none
+func GoreleaserConfig() string {
+	for _, name := range goreleaserConfigFiles {
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// CheckGoreleaser validates the GoReleaser config with `goreleaser check`. It returns
+// nil when the project has no config or the check is disabled.
+func (r *Manager) CheckGoreleaser() *git.ValidationResult {
+	configFile := GoreleaserConfig()
+	if configFile == "" || !r.settings.Goreleaser.Check {
+		return nil
+	}
+
+	result := &git.ValidationResult{
+		Step:    git.ValidationStep{Name: "goreleaser", Description: "Checking GoReleaser config..."},
+		Success: true,
+	}
+
+	if _, err := exec.LookPath("goreleaser"); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("goreleaser is not installed; %s was not checked", configFile))
+		return result
+	}
+
+	if output, err := runGoreleaser(git.GitCommandTimeout, "check", "--config", configFile); err != nil {
+		result.Success = false
+		result.Errors = append(result.Errors, fmt.Sprintf("goreleaser check failed for %s: %s", configFile, output))
+	}
+	return result
+}
+
+// ShouldRunGoreleaser reports whether `goreleaser release` runs after the tag is pushed.
+// The pull-request workflow doesn't tag, so it never runs there.
+func (r *Manager) ShouldRunGoreleaser() bool {
+	return r.settings.Goreleaser.Release &&
+		r.settings.Release.Workflow != config.WorkflowPullRequest &&
+		GoreleaserConfig() != ""
+}
+
+// RunGoreleaser runs `goreleaser release --clean` for the tag at HEAD
+func (r *Manager) RunGoreleaser() error {
+	configFile := GoreleaserConfig()
+	if configFile == "" {
+		return fmt.Errorf("no GoReleaser config found")
+	}
+	if _, err := exec.LookPath("goreleaser"); err != nil {
+		return fmt.Errorf("goreleaser is not installed")
+	}
+
+	if output, err := runGoreleaser(GoreleaserTimeout, "release", "--clean", "--config", configFile); err != nil {
+		return fmt.Errorf("goreleaser release failed: %s", output)
+	}
+	return nil
+}
+
+// runGoreleaser runs goreleaser and returns the tail of its output on failure
+func runGoreleaser(timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "goreleaser", args...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		if len(lines) > 5 {
+			lines = lines[len(lines)-5:]
+		}
+		if len(lines) == 1 && lines[0] == "" {
+			return err.Error(), err
+		}
+		return strings.Join(lines, "\n"), err
+	}
+	return "", nil
+}
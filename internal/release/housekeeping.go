@@ -0,0 +1,160 @@
+package release
+
+import (
+	"fmt"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/github"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// NotifyReleasedPullRequests labels and/or comments on every pull request merged
+// since the previous release. The updates are sent in batched GraphQL mutations of
+// github.NotifyBatchSize pull requests, paced by WriteRequestInterval. It returns the
+// number of pull requests notified; when a batch fails, the count of the batches
+// before it is returned with the error.
+func (r *Manager) NotifyReleasedPullRequests(settings config.ReleasedPRSettings, previousVersion, version string) (int, error) {
+	if !settings.Label && !settings.Comment {
+		return 0, nil
+	}
+	if !r.githubManager.IsAvailable() {
+		return 0, fmt.Errorf("gh CLI is not installed")
+	}
+
+	since, err := r.gitManager.GetTagDate("v" + previousVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	pullRequests, err := r.githubManager.ListMergedPullRequests(
+		fmt.Sprintf("merged:>=%s", since.UTC().Format("2006-01-02")))
+	if err != nil {
+		return 0, err
+	}
+
+	// The search is day-granular; drop pull requests merged before the previous tag
+	var released []github.PullRequest
+	for _, pr := range pullRequests {
+		if pr.MergedAt.After(since) {
+			released = append(released, pr)
+		}
+	}
+	if len(released) == 0 {
+		return 0, nil
+	}
+
+	data := ActionData{Version: version, Tag: "v" + version}
+	label, err := expand(settings.LabelName, data)
+	if err != nil {
+		return 0, err
+	}
+	comment := ""
+	if settings.Comment {
+		if comment, err = expand(settings.CommentBody, data); err != nil {
+			return 0, err
+		}
+	}
+
+	// Make sure every batch, plus the label lookup, fits in the remaining rate limit
+	// before starting
+	calls := (len(released) + github.NotifyBatchSize - 1) / github.NotifyBatchSize
+	if settings.Label {
+		calls++
+	}
+	if limit, err := r.githubManager.GetRateLimit("graphql"); err == nil && limit.Remaining < calls {
+		return 0, fmt.Errorf("GitHub rate limit too low (%d requests left, %d needed); resets at %s",
+			limit.Remaining, calls, limit.Reset.Format("15:04"))
+	}
+
+	labelID := ""
+	if settings.Label {
+		if labelID, err = r.githubManager.EnsureLabel(label, releasedLabelColor, "Shipped in "+data.Tag); err != nil {
+			return 0, fmt.Errorf("failed to create label %q: %v", label, err)
+		}
+	}
+
+	for start := 0; start < len(released); start += github.NotifyBatchSize {
+		end := min(start+github.NotifyBatchSize, len(released))
+		if start > 0 {
+			time.Sleep(WriteRequestInterval)
+		}
+		if err := r.githubManager.NotifyPullRequests(released[start:end], labelID, comment); err != nil {
+			return start, notifyError(released[start].Number, err)
+		}
+	}
+
+	return len(released), nil
+}
+
+// notifyError describes a failure to label or comment on the batch of pull requests
+// starting at number
+func notifyError(number int, err error) error {
+	if github.IsRateLimitError(err) {
+		return fmt.Errorf("stopped at the batch starting at #%d: GitHub rate limit reached", number)
+	}
+	return fmt.Errorf("failed to update the batch starting at #%d: %v", number, err)
+}
+
+// UpdateMilestones closes the milestone of the released version and creates the
+// next one as configured. It returns a description of every change made.
+func (r *Manager) UpdateMilestones(settings config.MilestoneSettings, version string) ([]string, error) {
+	var notes []string
+	if !settings.Close && !settings.CreateNext {
+		return notes, nil
+	}
+
+	if settings.Close {
+		title := settings.TitlePrefix + version
+		milestone, err := r.githubManager.FindMilestone(title)
+		if err != nil {
+			return notes, fmt.Errorf("failed to look up milestone %s: %v", title, err)
+		}
+		if milestone != nil && milestone.State == "open" {
+			if err := r.githubManager.CloseMilestone(milestone.Number); err != nil {
+				return notes, fmt.Errorf("failed to close milestone %s: %v", title, err)
+			}
+			notes = append(notes, fmt.Sprintf("Closed milestone %s", title))
+		}
+	}
+
+	if settings.CreateNext {
+		next, err := nextVersion(version, settings.NextBump)
+		if err != nil {
+			return notes, err
+		}
+		title := settings.TitlePrefix + next
+		milestone, err := r.githubManager.FindMilestone(title)
+		if err != nil {
+			return notes, fmt.Errorf("failed to look up milestone %s: %v", title, err)
+		}
+		if milestone == nil {
+			if err := r.githubManager.CreateMilestone(title); err != nil {
+				return notes, fmt.Errorf("failed to create milestone %s: %v", title, err)
+			}
+			notes = append(notes, fmt.Sprintf("Created milestone %s", title))
+		}
+	}
+
+	return notes, nil
+}
+
+// nextVersion returns the version following version for the given bump type
+func nextVersion(version, bump string) (string, error) {
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q: %v", version, err)
+	}
+
+	var next semver.Version
+	switch bump {
+	case "major":
+		next = current.IncMajor()
+	case "patch":
+		next = current.IncPatch()
+	default:
+		next = current.IncMinor()
+	}
+	return next.String(), nil
+}
@@ -0,0 +1,103 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// CheckLatestRelease compares the current version with the newest version released
+// or tagged on GitHub, catching stale local state that would create a duplicate or
+// regressed tag. Mismatches fail validation in "block" mode and are warnings in
+// "warn" mode; it returns nil when github.latest_release is "off".
+func (r *Manager) CheckLatestRelease(currentVersion string) *git.ValidationResult {
+	mode := r.settings.GitHub.LatestRelease
+	if mode == config.LatestReleaseOff {
+		return nil
+	}
+
+	result := &git.ValidationResult{
+		Step:    git.ValidationStep{Name: "latest_release", Description: "Checking the latest GitHub release..."},
+		Success: true,
+	}
+
+	if !r.githubManager.IsAvailable() {
+		result.Warnings = append(result.Warnings, "gh is not installed; the latest GitHub release was not checked")
+		return result
+	}
+
+	remote, source, err := r.latestRemoteVersion()
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Unable to check the latest GitHub release: %v", err))
+		return result
+	}
+	if remote == nil {
+		return result // Nothing released yet
+	}
+
+	current, err := semver.NewVersion(currentVersion)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Current version %q is not a semantic version", currentVersion))
+		return result
+	}
+
+	var code, message, fix string
+	var action *git.FixAction
+	switch {
+	case current.LessThan(remote):
+		code = "newer_remote_release"
+		message = fmt.Sprintf("GitHub already has %s, newer than the current version %s. Pull and fetch tags before releasing, or the new tag may duplicate or regress it", source, current)
+		fix = "git pull --tags"
+		action = &git.FixAction{Label: "Fetch tags from origin", Run: r.gitManager.FetchTags}
+	case current.GreaterThan(remote):
+		code = "release_not_pushed"
+		message = fmt.Sprintf("The current version %s is ahead of the latest GitHub %s. The v%s release may never have been pushed", current, source, current)
+		fix = fmt.Sprintf("git push origin v%s", current)
+	default:
+		return result
+	}
+
+	if mode == config.LatestReleaseBlock {
+		result.AddError(code, message, fix)
+	} else {
+		result.AddWarning(code, message, fix)
+	}
+	result.SetAction(code, action)
+	return result
+}
+
+// latestRemoteVersion returns the highest version among the latest GitHub release and
+// the remote tags, with a description of where it was found, or nil when there is none
+func (r *Manager) latestRemoteVersion() (*semver.Version, string, error) {
+	releaseTag, err := r.githubManager.LatestReleaseTag()
+	if err != nil {
+		return nil, "", err
+	}
+	tags, err := r.githubManager.ListTags()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var latest *semver.Version
+	source := ""
+	if version, err := semver.NewVersion(strings.TrimPrefix(releaseTag, "v")); err == nil {
+		latest = version
+		source = "release " + releaseTag
+	}
+	for _, tag := range tags {
+		version, err := semver.NewVersion(strings.TrimPrefix(tag, "v"))
+		// Alias tags like v1 parse as 1.0.0, so only full versions count
+		if err != nil || strings.Count(strings.TrimPrefix(tag, "v"), ".") < 2 {
+			continue
+		}
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+			source = "tag " + tag
+		}
+	}
+	return latest, source, nil
+}
@@ -0,0 +1,557 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"bump-tui/internal/changelog"
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/github"
+	"bump-tui/internal/plugin"
+	"bump-tui/internal/version"
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	// ActionTimeout is the timeout for post-release checklist commands and webhooks
+	ActionTimeout = 60 * time.Second
+	// WriteRequestInterval paces content-creating GitHub API calls to stay clear of secondary rate limits
+	WriteRequestInterval = time.Second
+	// releasedLabelColor is the color of the "released in" label
+	releasedLabelColor = "0E8A16"
+)
+
+// ErrCanceled is returned by the step that was about to run when Cancel stopped a release
+var ErrCanceled = errors.New("the release was canceled")
+
+// Manager runs the release pipeline and post-release follow-up actions. It is shared
+// by the TUI and the prompt-based CLI so both release the same way.
+type Manager struct {
+	versionManager   *version.Manager
+	changelogManager *changelog.Manager
+	gitManager       *git.Manager
+	githubManager    *github.Manager
+	settings         *config.Settings
+	httpClient       *http.Client
+	// forceWithLease allows replacing a release branch that already exists on the remote
+	forceWithLease bool
+	// plugins may run post-release actions
+	plugins []*plugin.Plugin
+	// canceled is set by Cancel while Execute runs
+	canceled atomic.Bool
+}
+
+func NewManager(versionManager *version.Manager, changelogManager *changelog.Manager) *Manager {
+	return &Manager{
+		versionManager:   versionManager,
+		changelogManager: changelogManager,
+		gitManager:       git.NewManager(),
+		githubManager:    github.NewManager(),
+		settings:         config.DefaultSettings(),
+		httpClient:       &http.Client{Timeout: ActionTimeout},
+	}
+}
+
+// SetSettings applies project settings loaded from .bump.toml
+func (r *Manager) SetSettings(settings *config.Settings) {
+	r.settings = settings
+	r.gitManager.SetNoVerify(settings.Git.NoVerify)
+	texts := settings.Messages.Texts()
+	r.gitManager.SetReleaseMessages(git.ReleaseMessages{Commit: texts.Commit, Tag: texts.Tag})
+}
+
+// SetForceWithLease allows the pull-request workflow to replace an existing remote
+// release branch with --force-with-lease, e.g. when re-running an unmerged release
+func (r *Manager) SetForceWithLease(enabled bool) {
+	r.forceWithLease = enabled
+}
+
+// Cancel asks a running Execute to stop before its next step and restore the
+// repository as after a failure. Once the push started the release runs to the end,
+// as what was pushed can't be taken back.
+func (r *Manager) Cancel() {
+	r.canceled.Store(true)
+}
+
+// Plan describes a version bump to perform
+type Plan struct {
+	PreviousVersion string
+	Version         string
+	// Changes is the changelog entry body written for Version
+	Changes string
+	// ReplaceChangelogEntry overwrites an existing changelog entry for Version
+	ReplaceChangelogEntry bool
+	// LocalOnly stops after the release commit and tag; Publish pushes them later
+	LocalOnly bool
+	// Generator is the changelog generator that wrote Changes, recorded in the tag
+	Generator string
+}
+
+// Outcome describes the result of a release
+type Outcome struct {
+	// PullRequestURL is set when the pull-request workflow opened a version bump pull request
+	PullRequestURL string
+	// Timings lists the duration of every pipeline step that ran
+	Timings []StepTiming
+	// Resumed is set when HEAD already was the release commit, so only tagging and
+	// publishing ran
+	Resumed bool
+	// canceled is the Manager's cancel request while the running steps can be undone
+	canceled *atomic.Bool
+}
+
+// Execute updates version files and the changelog, then commits, tags and pushes,
+// or opens a release pull request when the pull-request workflow is configured.
+// The outcome is returned even on failure so the timings of completed steps are kept.
+// A failure before the push, or while opening the release pull request, restores the
+// repository to the snapshot taken before the first change and every written file to
+// its backup.
+func (r *Manager) Execute(plan Plan) (*Outcome, error) {
+	outcome := &Outcome{}
+	r.canceled.Store(false)
+
+	if r.settings.Release.TagOnly {
+		return outcome, r.tagOnly(outcome, plan)
+	}
+
+	// An earlier run already committed this release; finish it rather than bump twice
+	if r.canResume() && r.gitManager.IsReleaseCommit(plan.Version) {
+		outcome.Resumed = true
+		return outcome, r.resume(outcome, plan)
+	}
+
+	// Render commit trailers first so a bad template fails before anything changes
+	trailers, err := r.trailers(plan.Version)
+	if err != nil {
+		return outcome, err
+	}
+
+	snapshot, err := r.gitManager.CreateSnapshot()
+	if err != nil {
+		return outcome, fmt.Errorf("unable to snapshot the repository before the release: %v", err)
+	}
+	backup, err := r.backupFiles(plan)
+	if err != nil {
+		r.dropSnapshot(nil)
+		return outcome, fmt.Errorf("unable to back up the files of the release: %v", err)
+	}
+	// Only the steps up to the push can be canceled, as the snapshot undoes them
+	outcome.canceled = &r.canceled
+
+	if err := r.writeReleaseFiles(outcome, plan); err != nil {
+		return outcome, r.restore(snapshot, backup, err)
+	}
+	trailers = r.withChangelogHash(trailers, plan.Version)
+
+	if r.settings.Release.Workflow == config.WorkflowPullRequest {
+		err := outcome.timeStep("pull request", func() error {
+			url, err := r.openReleasePullRequest(plan, trailers)
+			outcome.PullRequestURL = url
+			return err
+		})
+		if err != nil {
+			return outcome, r.restore(snapshot, backup, err)
+		}
+		r.dropSnapshot(backup)
+		return outcome, nil
+	}
+
+	if err := r.commitAndTag(outcome, plan, trailers); err != nil {
+		return outcome, r.restore(snapshot, backup, err)
+	}
+	if outcome.isCanceled() {
+		return outcome, r.restore(snapshot, backup, ErrCanceled)
+	}
+	r.dropSnapshot(backup)
+	outcome.canceled = nil
+
+	if plan.LocalOnly {
+		return outcome, nil
+	}
+	return outcome, r.publish(outcome, plan.Version)
+}
+
+// restore puts the repository back in its pre-release state after a step failed,
+// then copies back the backed up files, returning the failure
+func (r *Manager) restore(snapshot *git.Snapshot, backup *backup, cause error) error {
+	if err := r.gitManager.RestoreSnapshot(snapshot); err != nil {
+		return fmt.Errorf("%v\nRestoring the pre-release state also failed; it is kept in %s (git stash apply %s) and %s: %v",
+			cause, git.SnapshotRef, git.SnapshotRef, backup.dir, err)
+	}
+	if err := backup.restore(); err != nil {
+		return fmt.Errorf("%v\nRestoring the backed up files also failed; copies are kept in %s: %v", cause, backup.dir, err)
+	}
+	return fmt.Errorf("%v\nThe repository was restored to its state before the release", cause)
+}
+
+// dropSnapshot deletes the snapshot and backup once the local release steps
+// succeeded. Failing to delete them is only logged, as the release itself went through.
+func (r *Manager) dropSnapshot(backup *backup) {
+	if err := r.gitManager.DropSnapshot(); err != nil {
+		log.Printf("Failed to drop the release snapshot: %v", err)
+	}
+	if backup == nil {
+		return
+	}
+	if err := backup.discard(); err != nil {
+		log.Printf("Failed to delete the release backup: %v", err)
+	}
+}
+
+// writeReleaseFiles updates the version files, generated headers and changelogs
+func (r *Manager) writeReleaseFiles(outcome *Outcome, plan Plan) error {
+	// Update all version files
+	if err := outcome.timeStep("version files", func() error {
+		return r.versionManager.UpdateAllVersions(plan.Version)
+	}); err != nil {
+		return err
+	}
+
+	if r.settings.CMake.Header != "" && r.versionManager.HasProjectType(version.Cpp) {
+		if err := outcome.timeStep("cmake header", func() error {
+			return r.versionManager.UpdateCMakeHeader(plan.Version)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.firmwareEnabled() && r.settings.Firmware.Header != "" {
+		if err := outcome.timeStep("firmware header", func() error {
+			return r.writeFirmwareHeader(plan.Version)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.settings.Nix.UpdateHashes {
+		if err := outcome.timeStep("nix hashes", r.versionManager.UpdateNixHashes); err != nil {
+			return err
+		}
+	}
+
+	// Update changelog
+	if err := outcome.timeStep("write changelog", func() error {
+		if plan.ReplaceChangelogEntry {
+			return r.changelogManager.ReplaceChangelogEntry(plan.Version, plan.Changes)
+		}
+		return r.changelogManager.UpdateChangelog(plan.Version, plan.Changes)
+	}); err != nil {
+		return err
+	}
+
+	if r.settings.Changelog.NotesOut != "" {
+		if err := outcome.timeStep("release notes", func() error {
+			_, err := r.changelogManager.WriteNotes(plan.Version, plan.Changes)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	if r.settings.Debian.Enabled {
+		if err := outcome.timeStep("debian changelog", func() error {
+			return r.writeDebianChangelog(plan)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if specs := r.versionManager.SpecFiles(); len(specs) > 0 {
+		if err := outcome.timeStep("rpm changelog", func() error {
+			return r.writeRPMChangelogs(specs, plan)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commitAndTag creates the release commit, the version tag, any package tags and the
+// release note
+func (r *Manager) commitAndTag(outcome *Outcome, plan Plan, trailers []string) error {
+	if err := outcome.timeStep("commit", func() error {
+		return r.gitManager.CommitVersionBump(plan.Version, trailers...)
+	}); err != nil {
+		return err
+	}
+
+	if err := outcome.timeStep("tag", func() error {
+		return r.gitManager.CreateTag(plan.Version, r.metadata(plan)...)
+	}); err != nil {
+		return err
+	}
+
+	if tags := r.PackageTags(plan.Version); len(tags) > 0 {
+		if err := outcome.timeStep("package tags", func() error {
+			for _, tag := range tags {
+				if err := r.gitManager.CreateNamedTag(tag, r.tagAnnotation(tag, plan)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return r.addReleaseNote(outcome, plan)
+}
+
+// Publish pushes a release created with Plan.LocalOnly, including alias tags
+func (r *Manager) Publish(plan Plan) (*Outcome, error) {
+	outcome := &Outcome{}
+	return outcome, r.publish(outcome, plan.Version)
+}
+
+// publish pushes the release commit and tag, moves the alias tags and pushes the
+// release notes
+func (r *Manager) publish(outcome *Outcome, version string) error {
+	if err := outcome.timeStep("push", func() error {
+		return r.push(version)
+	}); err != nil {
+		return err
+	}
+
+	if tags := r.PackageTags(version); len(tags) > 0 {
+		if err := outcome.timeStep("push package tags", func() error {
+			return r.gitManager.PushTags(tags...)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := r.pushAliasTags(outcome, version); err != nil {
+		return err
+	}
+	return r.pushReleaseNotes(outcome)
+}
+
+// writeDebianChangelog adds the release to debian/changelog, signed by the git user
+// unless debian.maintainer is set
+func (r *Manager) writeDebianChangelog(plan Plan) error {
+	maintainer := r.settings.Debian.Maintainer
+	if maintainer == "" {
+		identity, err := r.packager()
+		if err != nil {
+			return err
+		}
+		maintainer = identity
+	}
+	return r.changelogManager.WriteDebianChangelog(plan.Version, plan.Changes, maintainer, plan.ReplaceChangelogEntry)
+}
+
+// writeRPMChangelogs adds the release to the %changelog of every managed spec file
+func (r *Manager) writeRPMChangelogs(specs []string, plan Plan) error {
+	packager, err := r.packager()
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		if err := r.changelogManager.WriteRPMChangelog(spec, plan.Version, plan.Changes, packager); err != nil {
+			return fmt.Errorf("failed to update the %%changelog of %s: %v", spec, err)
+		}
+	}
+	return nil
+}
+
+// packager returns the git user as "Name <email>" for packaging changelogs
+func (r *Manager) packager() (string, error) {
+	name, email, err := r.gitManager.UserIdentity()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s <%s>", name, email), nil
+}
+
+// PackageTags returns the per-package tags released with version in changed-only
+// monorepo mode, e.g. packages/api/v1.2.0
+func (r *Manager) PackageTags(version string) []string {
+	if !r.settings.Monorepo.ChangedOnly {
+		return nil
+	}
+	return r.versionManager.PackageTags(version)
+}
+
+// AliasTags returns the floating alias tags (e.g. v1, v1.3) moved to version.
+// Prereleases never move aliases.
+func AliasTags(version string, kinds []string) ([]string, error) {
+	parsed, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %s: %v", version, err)
+	}
+	if parsed.Prerelease() != "" {
+		return nil, nil
+	}
+
+	var aliases []string
+	for _, kind := range kinds {
+		switch kind {
+		case "major":
+			aliases = append(aliases, fmt.Sprintf("v%d", parsed.Major()))
+		case "minor":
+			aliases = append(aliases, fmt.Sprintf("v%d.%d", parsed.Major(), parsed.Minor()))
+		}
+	}
+	return aliases, nil
+}
+
+// pushAliasTags moves and force-pushes the configured alias tags to the release
+func (r *Manager) pushAliasTags(outcome *Outcome, version string) error {
+	aliases, err := AliasTags(version, r.settings.Release.AliasTags)
+	if err != nil || len(aliases) == 0 {
+		return err
+	}
+
+	return outcome.timeStep("alias tags", func() error {
+		for _, alias := range aliases {
+			if err := r.gitManager.MoveAliasTag(alias, version); err != nil {
+				return err
+			}
+			if err := r.gitManager.PushAliasTag(alias); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// tagOnly creates an annotated tag for the version at HEAD and pushes just the tag,
+// for projects whose version lives entirely in git tags
+func (r *Manager) tagOnly(outcome *Outcome, plan Plan) error {
+	if err := outcome.timeStep("tag", func() error {
+		return r.gitManager.CreateTag(plan.Version, r.metadata(plan)...)
+	}); err != nil {
+		return err
+	}
+
+	if err := outcome.timeStep("push", func() error {
+		return r.gitManager.PushTag(plan.Version)
+	}); err != nil {
+		return err
+	}
+
+	return r.pushAliasTags(outcome, plan.Version)
+}
+
+// CanRetryPush reports whether a rejected push can be rebased and retried: only in
+// the direct workflow, and only while the release commit of plan is HEAD. A pull
+// request release has already been rolled back by the time its push is rejected.
+func (r *Manager) CanRetryPush(plan Plan, err error) bool {
+	return git.IsNonFastForward(err) &&
+		r.settings.Release.Workflow == config.WorkflowDirect &&
+		r.gitManager.IsReleaseCommit(plan.Version)
+}
+
+// RetryPush rebases the release commit onto the remote branch, moves the version
+// tag and release note to the rebased commit and pushes again. It is offered after a
+// push was rejected because the remote moved between validation and push.
+func (r *Manager) RetryPush(plan Plan) (*Outcome, error) {
+	outcome := &Outcome{}
+	if r.settings.Release.Workflow != config.WorkflowDirect || !r.gitManager.IsReleaseCommit(plan.Version) {
+		return outcome, fmt.Errorf("HEAD is not the release commit of %s, so there is nothing to rebase and push", plan.Version)
+	}
+
+	if err := outcome.timeStep("rebase", func() error {
+		released, err := r.gitManager.ResolveRef("HEAD")
+		if err != nil {
+			return err
+		}
+		if err := r.gitManager.RebaseOnRemote(); err != nil {
+			return fmt.Errorf("%v. The rebase was aborted: the release commit and tag v%s are still local and unpushed", err, plan.Version)
+		}
+		for _, tag := range r.PackageTags(plan.Version) {
+			if err := r.gitManager.MoveNamedTag(tag, r.tagAnnotation(tag, plan)); err != nil {
+				return err
+			}
+		}
+		if err := r.gitManager.MoveTag(plan.Version, r.metadata(plan)...); err != nil {
+			return err
+		}
+		return r.moveReleaseNote(released)
+	}); err != nil {
+		return outcome, err
+	}
+
+	return outcome, r.publish(outcome, plan.Version)
+}
+
+// push sends the release commit and tag to origin using the configured push mode
+func (r *Manager) push(version string) error {
+	var err error
+	if r.settings.Release.PushMode == config.PushAtomic {
+		err = r.gitManager.PushAtomic(version)
+	} else {
+		// Push changes and tag separately to GitHub (ensures workflow triggers)
+		err = r.gitManager.PushChanges()
+		if err == nil {
+			err = r.gitManager.PushTag(version)
+		}
+	}
+
+	if git.IsNonFastForward(err) {
+		return fmt.Errorf("the remote branch moved since validation (someone pushed in the meantime), so the push was rejected. "+
+			"The release commit and tag exist locally; rebase onto the remote and push again: %v", err)
+	}
+	return err
+}
+
+// openReleasePullRequest commits the version bump to a release branch and opens a
+// pull request for it instead of tagging and pushing the current branch
+func (r *Manager) openReleasePullRequest(plan Plan, trailers []string) (string, error) {
+	base, err := r.gitManager.GetCurrentBranch()
+	if err != nil {
+		return "", err
+	}
+
+	branch := r.settings.GitHub.PullRequest.BranchPrefix + plan.Version
+	if err := r.gitManager.CreateBranch(branch); err != nil {
+		return "", err
+	}
+
+	if err := r.gitManager.CommitVersionBump(plan.Version, trailers...); err != nil {
+		return "", err
+	}
+
+	push := r.gitManager.PushBranch
+	if r.forceWithLease {
+		push = r.gitManager.PushBranchWithLease
+	}
+	if err := push(branch); err != nil {
+		if git.IsNonFastForward(err) && !r.forceWithLease {
+			return "", fmt.Errorf("release branch %s already exists on the remote with other commits; "+
+				"delete it or rerun with -force-with-lease to replace it: %v", branch, err)
+		}
+		return "", err
+	}
+
+	data := PullRequestData{
+		Version:     plan.Version,
+		Tag:         "v" + plan.Version,
+		PreviousTag: "v" + plan.PreviousVersion,
+		Changelog:   plan.Changes,
+	}
+	for _, item := range r.settings.Release.Checklist {
+		data.Checklist = append(data.Checklist, item.Title)
+	}
+	if url, err := r.githubManager.RepoURL(); err == nil {
+		data.CompareURL = fmt.Sprintf("%s/compare/%s...%s", url, data.PreviousTag, branch)
+	}
+
+	body, err := RenderPullRequestBody(r.settings.GitHub.PullRequest.BodyTemplate, data)
+	if err != nil {
+		return "", err
+	}
+
+	title := r.gitManager.ReleaseCommitSubject(plan.Version)
+	url, err := r.githubManager.CreatePullRequest(title, body, base, branch)
+	if err != nil {
+		return "", fmt.Errorf("unable to open release pull request: %v", err)
+	}
+	return url, nil
+}
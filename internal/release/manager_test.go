@@ -0,0 +1,502 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+	"bump-tui/internal/github"
+)
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		bump     string
+		expected string
+	}{
+		{"1.3.0", "minor", "1.4.0"},
+		{"1.3.2", "patch", "1.3.3"},
+		{"1.3.2", "major", "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.bump, func(t *testing.T) {
+			got, err := nextVersion(tt.version, tt.bump)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := nextVersion("not-a-version", "minor"); err == nil {
+		t.Errorf("Expected error for invalid version")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got, err := expand("Released {{.Tag}} ({{.Version}})", ActionData{Version: "1.2.0", Tag: "v1.2.0"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != "Released v1.2.0 (1.2.0)" {
+		t.Errorf("Expected expanded message, got %q", got)
+	}
+}
+
+func TestRenderPullRequestBody(t *testing.T) {
+	data := PullRequestData{
+		Version:     "1.4.0",
+		Tag:         "v1.4.0",
+		PreviousTag: "v1.3.0",
+		Changelog:   "- ✨ Add export",
+		CompareURL:  "https://github.com/acme/app/compare/v1.3.0...release/v1.4.0",
+		Checklist:   []string{"Announce in Slack"},
+	}
+
+	body, err := RenderPullRequestBody("", data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, expected := range []string{"## Release v1.4.0", "- ✨ Add export", "- [ ] Announce in Slack", data.CompareURL} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected body to contain %q, got:\n%s", expected, body)
+		}
+	}
+
+	custom, err := RenderPullRequestBody("Release {{.Tag}} (previous: {{.PreviousTag}})", data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if custom != "Release v1.4.0 (previous: v1.3.0)" {
+		t.Errorf("Expected custom template to be used, got %q", custom)
+	}
+}
+
+func TestFormatTimings(t *testing.T) {
+	timings := SetTiming(nil, "validation", 400*time.Millisecond)
+	timings = SetTiming(timings, "push", 2100*time.Millisecond)
+	timings = SetTiming(timings, "validation", 500*time.Millisecond)
+
+	expected := "validation 500ms • push 2.1s • total 2.6s"
+	if got := FormatTimings(timings); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if got := FormatTimings(nil); got != "" {
+		t.Errorf("Expected empty string without timings, got %q", got)
+	}
+}
+
+func TestRenderTrailers(t *testing.T) {
+	data := TrailerData{Version: "1.2.0", Tag: "v1.2.0", UserName: "Ada Lovelace", UserEmail: "ada@example.com"}
+
+	trailers, err := RenderTrailers([]string{
+		"Signed-off-by: {{.UserName}} <{{.UserEmail}}>",
+		"Release-Id: {{.Tag}}",
+	}, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"Signed-off-by: Ada Lovelace <ada@example.com>", "Release-Id: v1.2.0"}
+	if strings.Join(trailers, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %q, got %q", expected, trailers)
+	}
+
+	for _, invalid := range []string{"not a trailer", "Release Id: {{.Tag}}", "Empty: "} {
+		if _, err := RenderTrailers([]string{invalid}, data); err == nil {
+			t.Errorf("Expected an error for trailer %q", invalid)
+		}
+	}
+}
+
+func TestAliasTags(t *testing.T) {
+	tests := []struct {
+		version  string
+		kinds    []string
+		expected string
+	}{
+		{"1.3.2", []string{"major", "minor"}, "v1,v1.3"},
+		{"2.0.0", []string{"major"}, "v2"},
+		{"1.3.2", nil, ""},
+		{"1.4.0-rc.1", []string{"major", "minor"}, ""},
+	}
+
+	for _, tt := range tests {
+		aliases, err := AliasTags(tt.version, tt.kinds)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := strings.Join(aliases, ","); got != tt.expected {
+			t.Errorf("Expected %q for %s, got %q", tt.expected, tt.version, got)
+		}
+	}
+}
+
+func TestBumpType(t *testing.T) {
+	tests := []struct {
+		previous string
+		version  string
+		expected string
+	}{
+		{"1.3.2", "2.0.0", "major"},
+		{"1.3.2", "1.4.0", "minor"},
+		{"1.3.2", "1.3.3", "patch"},
+		{"1.3.2", "1.4.0-rc.1", "prerelease"},
+		{"1.4.0-rc.1", "1.4.0", "patch"},
+		{"1.3.2", "1.3.2", ""},
+		{"", "1.0.0", ""},
+	}
+
+	for _, tt := range tests {
+		if got := BumpType(tt.previous, tt.version); got != tt.expected {
+			t.Errorf("Expected %q for %s → %s, got %q", tt.expected, tt.previous, tt.version, got)
+		}
+	}
+}
+
+func TestMetadataTrailers(t *testing.T) {
+	metadata := Metadata{BumpType: "minor", PreviousVersion: "1.3.2", ConfigHash: ConfigHash(config.DefaultSettings())}
+	trailers := metadata.Trailers()
+	if len(trailers) != 3 || trailers[0] != "Bump-Type: minor" || trailers[1] != "Bump-Previous-Version: 1.3.2" {
+		t.Errorf("Expected the known values as trailers, got %v", trailers)
+	}
+	if !strings.HasPrefix(trailers[2], "Bump-Config-Hash: ") || len(metadata.ConfigHash) != 12 {
+		t.Errorf("Expected a 12 character config hash, got %v", trailers[2])
+	}
+
+	changed := config.DefaultSettings()
+	changed.Git.NoVerify = true
+	if ConfigHash(changed) == metadata.ConfigHash {
+		t.Errorf("Expected different settings to hash differently")
+	}
+}
+
+func TestRenderContainerTags(t *testing.T) {
+	data := ContainerData{Version: "1.3.2", Tag: "v1.3.2", Major: 1, Minor: 3, Patch: 2, Commit: "abc1234def", ShortCommit: "abc1234"}
+
+	source, tags, err := renderContainerTags(config.ContainerImage{Image: "ghcr.io/org/app"}, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if source != "sha-abc1234" {
+		t.Errorf("Expected default source sha-abc1234, got %q", source)
+	}
+	if got := strings.Join(tags, ","); got != "1.3.2,1.3,latest" {
+		t.Errorf("Expected default tags 1.3.2,1.3,latest, got %q", got)
+	}
+
+	source, tags, err = renderContainerTags(config.ContainerImage{
+		Image:  "org/app",
+		Source: "{{.Commit}}",
+		Tags:   []string{"{{.Tag}}", "stable"},
+	}, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if source != "abc1234def" || strings.Join(tags, ",") != "v1.3.2,stable" {
+		t.Errorf("Expected custom source and tags, got %q and %q", source, tags)
+	}
+}
+
+func TestTrainSchedule(t *testing.T) {
+	settings := config.TrainSettings{Start: "2024-01-02", IntervalDays: 14}
+	date := func(value string) time.Time {
+		parsed, _ := time.Parse(config.TrainDateLayout, value)
+		return parsed
+	}
+
+	tests := []struct {
+		name          string
+		lastRelease   time.Time
+		now           time.Time
+		expectedDue   bool
+		expectedTrain string
+		expectedNext  string
+	}{
+		{"before the first train", time.Time{}, date("2023-12-30"), false, "", "2024-01-02"},
+		{"first train without releases", time.Time{}, date("2024-01-02"), true, "2024-01-02", "2024-01-16"},
+		{"released since the last train", date("2024-01-17"), date("2024-01-20"), false, "2024-01-16", "2024-01-30"},
+		{"released before the last train", date("2024-01-03"), date("2024-01-20"), true, "2024-01-16", "2024-01-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := TrainSchedule(settings, tt.lastRelease, tt.now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if status.Due != tt.expectedDue {
+				t.Errorf("Expected due %v, got %v", tt.expectedDue, status.Due)
+			}
+			if tt.expectedTrain != "" && status.Train.Format(config.TrainDateLayout) != tt.expectedTrain {
+				t.Errorf("Expected train %s, got %s", tt.expectedTrain, status.Train.Format(config.TrainDateLayout))
+			}
+			if status.Next.Format(config.TrainDateLayout) != tt.expectedNext {
+				t.Errorf("Expected next train %s, got %s", tt.expectedNext, status.Next.Format(config.TrainDateLayout))
+			}
+		})
+	}
+
+	if _, err := TrainSchedule(config.TrainSettings{IntervalDays: 14}, time.Time{}, date("2024-01-02")); err == nil {
+		t.Errorf("Expected error when no train is configured")
+	}
+}
+
+func TestRenameFirmware(t *testing.T) {
+	dir := t.TempDir()
+	var built []string
+	for _, env := range []string{"esp32", "nano"} {
+		path := filepath.Join(dir, "build", env, "firmware.bin")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(env), 0644); err != nil {
+			t.Fatal(err)
+		}
+		built = append(built, path)
+	}
+	out := filepath.Join(dir, "release")
+
+	copies, err := renameFirmware(built, "{{.Name}}-{{.Env}}-v{{.Version}}{{.Ext}}", out, "1.2.3")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{filepath.Join(out, "firmware-esp32-v1.2.3.bin"), filepath.Join(out, "firmware-nano-v1.2.3.bin")}
+	if strings.Join(copies, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, copies)
+	}
+	if content, err := os.ReadFile(expected[1]); err != nil || string(content) != "nano" {
+		t.Errorf("Expected copy of the nano binary, got %q (%v)", content, err)
+	}
+
+	if _, err := renameFirmware(built, "{{.Name}}-v{{.Version}}{{.Ext}}", out, "1.2.3"); err == nil {
+		t.Errorf("Expected error for colliding names without {{.Env}}")
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "Cargo.toml")
+	created := filepath.Join(dir, "debian", "changelog")
+	if err := os.WriteFile(manifest, []byte("[package]\nversion = \"1.0.0\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := createBackup(filepath.Join(dir, BackupDir), []string{manifest, created, manifest})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(b.files) != 2 {
+		t.Errorf("Expected 2 backed up files, got %d", len(b.files))
+	}
+	if _, err := createBackup(filepath.Join(dir, BackupDir), []string{manifest}); err == nil {
+		t.Errorf("Expected error for a backup that is still in place")
+	}
+
+	// A mangled manifest and a new file
+	if err := os.WriteFile(manifest, []byte("[package]\nversion = \"1.1.0\"\nversion = \"1.1.0\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(created), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(created, []byte("entry"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.restore(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content, err := os.ReadFile(manifest)
+	if err != nil || string(content) != "[package]\nversion = \"1.0.0\"\n" {
+		t.Errorf("Expected the original manifest, got %q (%v)", content, err)
+	}
+	if info, err := os.Stat(manifest); err != nil {
+		t.Errorf("Expected the manifest to exist, got %v", err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600 to be restored, got %v", info.Mode().Perm())
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("Expected the created file to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, BackupDir)); !os.IsNotExist(err) {
+		t.Errorf("Expected the backup to be deleted, got %v", err)
+	}
+}
+
+func TestTimeStepCanceled(t *testing.T) {
+	var canceled atomic.Bool
+	outcome := &Outcome{canceled: &canceled}
+
+	ran := false
+	if err := outcome.timeStep("commit", func() error { ran = true; return nil }); err != nil || !ran {
+		t.Fatalf("Expected the step to run, got ran=%v err=%v", ran, err)
+	}
+
+	canceled.Store(true)
+	ran = false
+	if err := outcome.timeStep("tag", func() error { ran = true; return nil }); err != ErrCanceled {
+		t.Errorf("Expected ErrCanceled, got %v", err)
+	}
+	if ran {
+		t.Errorf("Expected a canceled release to skip the step")
+	}
+	if len(outcome.Timings) != 1 {
+		t.Errorf("Expected only the step that ran to be timed, got %v", outcome.Timings)
+	}
+
+	// Steps after the push aren't cancelable
+	outcome.canceled = nil
+	if err := outcome.timeStep("push", func() error { return nil }); err != nil {
+		t.Errorf("Expected the step to run once cancel no longer applies, got %v", err)
+	}
+}
+
+// gitStub answers git commands from a map keyed by the joined arguments
+type gitStub map[string]string
+
+func (s gitStub) Run(env []string, args ...string) (string, string, error) {
+	if stdout, ok := s[strings.Join(args, " ")]; ok {
+		return stdout, "", nil
+	}
+	return "", "", fmt.Errorf("unexpected git %s", strings.Join(args, " "))
+}
+
+// ghStub scripts the gh calls of NotifyReleasedPullRequests
+type ghStub struct {
+	pullRequests []github.PullRequest
+	remaining    int
+	// failMutation fails the mutation with this 1-based index
+	failMutation int
+	labels       int
+	mutations    []string
+}
+
+func (s *ghStub) Run(timeout time.Duration, args ...string) (string, string, error) {
+	joined := strings.Join(args, " ")
+	switch {
+	case joined == "--version":
+		return "gh version 2.60.0", "", nil
+	case strings.HasPrefix(joined, "pr list"):
+		output, err := json.Marshal(s.pullRequests)
+		return string(output), "", err
+	case strings.HasPrefix(joined, "api rate_limit"):
+		return fmt.Sprintf(`{"remaining": %d, "reset": 0}`, s.remaining), "", nil
+	case strings.HasPrefix(joined, "label create"):
+		s.labels++
+		return "", "", nil
+	case strings.Contains(joined, "label(name: $name)"):
+		return "LA_1\n", "", nil
+	case strings.Contains(joined, "query=mutation"):
+		s.mutations = append(s.mutations, joined)
+		if len(s.mutations) == s.failMutation {
+			return "", "API rate limit exceeded", errors.New("exit status 1")
+		}
+		return "{}", "", nil
+	}
+	return "", "", fmt.Errorf("unexpected gh %s", joined)
+}
+
+func TestNotifyReleasedPullRequests(t *testing.T) {
+	tagged := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	var merged []github.PullRequest
+	// Merged the day of the previous tag but before it, so not part of the release
+	merged = append(merged, github.PullRequest{ID: "PR_0", Number: 100, MergedAt: tagged.Add(-time.Hour)})
+	for i := 1; i <= 25; i++ {
+		merged = append(merged, github.PullRequest{ID: fmt.Sprintf("PR_%d", i), Number: i, MergedAt: tagged.Add(time.Duration(i) * time.Hour)})
+	}
+
+	tests := []struct {
+		name         string
+		settings     config.ReleasedPRSettings
+		remaining    int
+		failMutation int
+		notified     int
+		labels       int
+		mutations    int
+		expectErr    string
+	}{
+		{
+			name:      "label and comment in batches",
+			settings:  config.ReleasedPRSettings{Label: true, Comment: true, LabelName: "released in {{.Tag}}", CommentBody: "Shipped in {{.Tag}}"},
+			remaining: 100,
+			notified:  25,
+			labels:    1,
+			mutations: 2,
+		},
+		{
+			name:      "comment only",
+			settings:  config.ReleasedPRSettings{Comment: true, CommentBody: "Shipped in {{.Tag}}"},
+			remaining: 2,
+			notified:  25,
+			mutations: 2,
+		},
+		{
+			name:      "rate limit too low for every batch",
+			settings:  config.ReleasedPRSettings{Label: true, LabelName: "released"},
+			remaining: 2,
+			expectErr: "GitHub rate limit too low (2 requests left, 3 needed)",
+		},
+		{
+			name:         "rate limit reached part way",
+			settings:     config.ReleasedPRSettings{Label: true, LabelName: "released"},
+			remaining:    100,
+			failMutation: 2,
+			notified:     20,
+			labels:       1,
+			mutations:    2,
+			expectErr:    "stopped at the batch starting at #21: GitHub rate limit reached",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitManager := git.NewManager()
+			gitManager.SetRunner(gitStub{"log -1 --format=%cI v1.1.0": tagged.Format(time.RFC3339) + "\n"})
+			gh := &ghStub{pullRequests: merged, remaining: tt.remaining, failMutation: tt.failMutation}
+			githubManager := github.NewManager()
+			githubManager.SetRunner(gh)
+			manager := &Manager{gitManager: gitManager, githubManager: githubManager}
+
+			notified, err := manager.NotifyReleasedPullRequests(tt.settings, "1.1.0", "1.2.0")
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("Expected an error containing %q, got %v", tt.expectErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("NotifyReleasedPullRequests failed: %v", err)
+			}
+			if notified != tt.notified || gh.labels != tt.labels || len(gh.mutations) != tt.mutations {
+				t.Errorf("Expected %d notified, %d labels and %d mutations, got %d, %d and %d",
+					tt.notified, tt.labels, tt.mutations, notified, gh.labels, len(gh.mutations))
+			}
+
+			for _, mutation := range gh.mutations {
+				if strings.Contains(mutation, "pr0=PR_0 ") {
+					t.Errorf("Expected the pull request merged before the tag to be skipped")
+				}
+				if tt.settings.Comment && !strings.Contains(mutation, "body=Shipped in v1.2.0") {
+					t.Errorf("Expected the expanded comment in %q", mutation)
+				}
+				if strings.Contains(mutation, "addLabelsToLabelable") != tt.settings.Label {
+					t.Errorf("Expected labels only when enabled in %q", mutation)
+				}
+			}
+			if len(gh.mutations) > 0 && !strings.Contains(gh.mutations[0], "pr19=PR_20 ") {
+				t.Errorf("Expected the first batch to hold %d pull requests", github.NotifyBatchSize)
+			}
+		})
+	}
+}
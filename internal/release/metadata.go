@@ -0,0 +1,115 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/git"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Trailers of the version tag annotation describing how a release was made
+const (
+	TrailerBumpType        = "Bump-Type"
+	TrailerPreviousVersion = "Bump-Previous-Version"
+	TrailerGenerator       = "Bump-Generator"
+	TrailerConfigHash      = "Bump-Config-Hash"
+)
+
+// Metadata describes how a release was made. It is written as trailers to the
+// annotation of the version tag, where later runs and other tools can read it.
+type Metadata struct {
+	// BumpType is "major", "minor", "patch" or "prerelease"
+	BumpType        string
+	PreviousVersion string
+	// Generator is the changelog generator that wrote the entry, e.g. "claude-cli"
+	Generator string
+	// ConfigHash identifies the effective settings, see ConfigHash
+	ConfigHash string
+}
+
+// Trailers returns the metadata as "Token: value" trailers, leaving out unknown values
+func (m Metadata) Trailers() []string {
+	var trailers []string
+	for _, field := range []struct{ token, value string }{
+		{TrailerBumpType, m.BumpType},
+		{TrailerPreviousVersion, m.PreviousVersion},
+		{TrailerGenerator, m.Generator},
+		{TrailerConfigHash, m.ConfigHash},
+	} {
+		if field.value != "" {
+			trailers = append(trailers, field.token+": "+field.value)
+		}
+	}
+	return trailers
+}
+
+// BumpType names the bump from previous to version, or "" if either doesn't parse
+// or version isn't newer
+func BumpType(previous, version string) string {
+	from, err := semver.NewVersion(previous)
+	if err != nil {
+		return ""
+	}
+	to, err := semver.NewVersion(version)
+	if err != nil || !to.GreaterThan(from) {
+		return ""
+	}
+
+	switch {
+	case to.Prerelease() != "":
+		return "prerelease"
+	case to.Major() != from.Major():
+		return "major"
+	case to.Minor() != from.Minor():
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+// ConfigHash returns a short hash of the effective settings, defaults and command-line
+// overrides included, so releases made with different settings can be told apart
+func ConfigHash(settings *config.Settings) string {
+	content, err := toml.Marshal(settings)
+	if err != nil {
+		log.Printf("Failed to hash the settings: %v", err)
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// metadata returns the tag annotation trailers of a release
+func (r *Manager) metadata(plan Plan) []string {
+	return Metadata{
+		BumpType:        BumpType(plan.PreviousVersion, plan.Version),
+		PreviousVersion: plan.PreviousVersion,
+		Generator:       plan.Generator,
+		ConfigHash:      ConfigHash(r.settings),
+	}.Trailers()
+}
+
+// ReleaseMetadata reads the metadata from the annotation of a version's tag. Tags
+// made by hand or by older versions of bump have none, giving an empty Metadata.
+func (r *Manager) ReleaseMetadata(version string) (Metadata, error) {
+	trailers, err := r.gitManager.TagTrailers("v" + version)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return Metadata{
+		BumpType:        trailers[TrailerBumpType],
+		PreviousVersion: trailers[TrailerPreviousVersion],
+		Generator:       trailers[TrailerGenerator],
+		ConfigHash:      trailers[TrailerConfigHash],
+	}, nil
+}
+
+// tagAnnotation is the annotation of a package tag, with the release metadata
+func (r *Manager) tagAnnotation(name string, plan Plan) string {
+	return git.WithTrailers(r.gitManager.TagMessage(name, plan.Version), r.metadata(plan))
+}
@@ -0,0 +1,56 @@
+package release
+
+import (
+	"strings"
+
+	"bump-tui/internal/git"
+)
+
+// addReleaseNote attaches the changelog entry to the release commit at HEAD as a git
+// note when git.notes is set. Tag-only releases have no entry and get no note.
+func (r *Manager) addReleaseNote(outcome *Outcome, plan Plan) error {
+	if !r.settings.Git.Notes || strings.TrimSpace(plan.Changes) == "" {
+		return nil
+	}
+	return outcome.timeStep("release note", func() error {
+		if err := r.gitManager.FetchReleaseNotes(); err != nil {
+			return err
+		}
+		return r.gitManager.AddReleaseNote("HEAD", r.releaseNote(plan))
+	})
+}
+
+// releaseNote is the note of a release: the tag message followed by the changelog entry
+func (r *Manager) releaseNote(plan Plan) string {
+	return r.gitManager.TagMessage("v"+plan.Version, plan.Version) + "\n\n" + strings.TrimSpace(plan.Changes)
+}
+
+// moveReleaseNote moves the release note of a commit to HEAD, e.g. after the release
+// commit was rebased
+func (r *Manager) moveReleaseNote(commit string) error {
+	if !r.settings.Git.Notes {
+		return nil
+	}
+	note := r.gitManager.ReleaseNote(commit)
+	if note == "" {
+		return nil
+	}
+	if err := r.gitManager.RemoveReleaseNote(commit); err != nil {
+		return err
+	}
+	if err := r.gitManager.FetchReleaseNotes(); err != nil {
+		return err
+	}
+	return r.gitManager.AddReleaseNote("HEAD", note)
+}
+
+// pushReleaseNotes pushes the release notes when git.notes is set and there are any
+func (r *Manager) pushReleaseNotes(outcome *Outcome) error {
+	if !r.settings.Git.Notes {
+		return nil
+	}
+	if _, err := r.gitManager.ResolveRef(git.ReleaseNotesRef); err != nil {
+		return nil
+	}
+	return outcome.timeStep("push release notes", r.gitManager.PushReleaseNotes)
+}
@@ -0,0 +1,61 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/plugin"
+)
+
+// PluginTimeout bounds the post-release action of each plugin, which may publish packages
+const PluginTimeout = 10 * time.Minute
+
+// SetPlugins makes loaded plugins available for post-release actions
+func (r *Manager) SetPlugins(plugins []*plugin.Plugin) {
+	r.plugins = plugins
+}
+
+// PostReleasePlugins names the loaded plugins that run after the tag is pushed. The
+// pull-request workflow doesn't tag, so they never run there.
+func (r *Manager) PostReleasePlugins() []string {
+	if r.settings.Release.Workflow == config.WorkflowPullRequest {
+		return nil
+	}
+	var names []string
+	for _, p := range r.plugins {
+		if p.PostRelease {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// RunPostReleasePlugins runs the post-release action of every plugin that has one, in
+// the order they are enabled. It returns the notes of the plugins that ran; a failure
+// stops the remaining plugins.
+func (r *Manager) RunPostReleasePlugins(previousVersion, version string) ([]string, error) {
+	if len(r.PostReleasePlugins()) == 0 {
+		return nil, nil
+	}
+
+	var notes []string
+	for _, p := range r.plugins {
+		if !p.PostRelease {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), PluginTimeout)
+		response, err := p.Call(ctx, plugin.Request{
+			Action:          plugin.ActionPostRelease,
+			Version:         version,
+			PreviousVersion: previousVersion,
+		})
+		cancel()
+		if err != nil {
+			return notes, fmt.Errorf("plugin %s: %v", p.Name, err)
+		}
+		notes = append(notes, response.Notes...)
+	}
+	return notes, nil
+}
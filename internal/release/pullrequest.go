@@ -0,0 +1,57 @@
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// DefaultPullRequestTemplate is the body of version bump pull requests when no template is configured
+const DefaultPullRequestTemplate = `## Release {{.Tag}}
+
+This pull request bumps the version to {{.Version}}. Once it is merged, tag the merge commit with ` + "`{{.Tag}}`" + ` to publish the release.
+
+## Changelog
+
+{{.Changelog}}
+
+## Checklist
+
+- [ ] Version files and changelog reviewed
+- [ ] CI is green
+{{- range .Checklist}}
+- [ ] {{.}}
+{{- end}}
+{{if .CompareURL}}
+**Full diff**: {{.CompareURL}}
+{{end}}`
+
+// PullRequestData is the data available to pull request body templates
+type PullRequestData struct {
+	Version     string
+	Tag         string
+	PreviousTag string
+	Changelog   string
+	// CompareURL links the previous tag to the release branch, empty if unknown
+	CompareURL string
+	// Checklist holds the titles of the configured release.checklist items
+	Checklist []string
+}
+
+// RenderPullRequestBody renders a pull request body, using DefaultPullRequestTemplate when tmpl is empty
+func RenderPullRequestBody(tmpl string, data PullRequestData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultPullRequestTemplate
+	}
+
+	parsed, err := template.New("pull_request").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid pull request template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render pull request body: %v", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"bump-tui/internal/config"
+)
+
+// PendingRelease returns the version of a release an earlier run committed but didn't
+// finish, and the version released before it: HEAD is its release commit, and the
+// version tag is missing or exists at HEAD without HEAD having been pushed. The
+// version is "" when there is none.
+func (r *Manager) PendingRelease(currentVersion string) (string, string) {
+	if !r.canResume() || !r.gitManager.IsReleaseCommit(currentVersion) {
+		return "", ""
+	}
+	exists, atHead := r.gitManager.TagStatus("v" + currentVersion)
+	if exists && (!atHead || r.gitManager.IsPushed()) {
+		return "", ""
+	}
+
+	previous, err := r.gitManager.GetPreviousTag("HEAD")
+	if err != nil {
+		return "", ""
+	}
+	return currentVersion, strings.TrimPrefix(previous, "v")
+}
+
+// canResume reports whether releases are committed to the current branch, the only
+// workflow whose unfinished release can be recognized at HEAD
+func (r *Manager) canResume() bool {
+	return !r.settings.Release.TagOnly && r.settings.Release.Workflow != config.WorkflowPullRequest
+}
+
+// resume finishes a release whose commit is already at HEAD instead of updating the
+// files again: missing tags are created and the release is published
+func (r *Manager) resume(outcome *Outcome, plan Plan) error {
+	tag := "v" + plan.Version
+	exists, atHead := r.gitManager.TagStatus(tag)
+	if exists && !atHead {
+		return fmt.Errorf("HEAD is the release commit of %s, but tag %s points at another commit; delete or move the tag", plan.Version, tag)
+	}
+	if !exists {
+		if err := outcome.timeStep("tag", func() error {
+			return r.gitManager.CreateTag(plan.Version, r.metadata(plan)...)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range r.PackageTags(plan.Version) {
+		if exists, _ := r.gitManager.TagStatus(name); exists {
+			continue
+		}
+		if err := outcome.timeStep("package tags", func() error {
+			return r.gitManager.CreateNamedTag(name, r.tagAnnotation(name, plan))
+		}); err != nil {
+			return err
+		}
+	}
+
+	if plan.LocalOnly {
+		return nil
+	}
+	return r.publish(outcome, plan.Version)
+}
@@ -0,0 +1,69 @@
+package release
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// StepTiming records how long one release step took
+type StepTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// SetTiming records the duration of a step, replacing an earlier measurement of the
+// same step (e.g. when the changelog is regenerated)
+func SetTiming(timings []StepTiming, name string, duration time.Duration) []StepTiming {
+	log.Printf("Step %q took %s", name, duration.Round(time.Millisecond))
+
+	updated := append([]StepTiming(nil), timings...)
+	for i := range updated {
+		if updated[i].Name == name {
+			updated[i].Duration = duration
+			return updated
+		}
+	}
+	return append(updated, StepTiming{Name: name, Duration: duration})
+}
+
+// FormatTimings renders timings on one line, e.g. "validation 0.4s • push 2.1s • total 2.5s"
+func FormatTimings(timings []StepTiming) string {
+	if len(timings) == 0 {
+		return ""
+	}
+
+	var parts []string
+	var total time.Duration
+	for _, timing := range timings {
+		parts = append(parts, fmt.Sprintf("%s %s", timing.Name, formatDuration(timing.Duration)))
+		total += timing.Duration
+	}
+	parts = append(parts, fmt.Sprintf("total %s", formatDuration(total)))
+	return strings.Join(parts, " • ")
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// timeStep runs a pipeline step and records its duration in the outcome. A canceled
+// release skips the step and returns ErrCanceled instead.
+func (o *Outcome) timeStep(name string, step func() error) error {
+	if o.isCanceled() {
+		return ErrCanceled
+	}
+	start := time.Now()
+	err := step()
+	o.Timings = SetTiming(o.Timings, name, time.Since(start))
+	return err
+}
+
+// isCanceled reports whether the release was canceled while it still could be
+func (o *Outcome) isCanceled() bool {
+	return o.canceled != nil && o.canceled.Load()
+}
@@ -0,0 +1,89 @@
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// TrailerChangelogHash is the release commit trailer holding the hash of the changelog
+// entry the commit introduces, see changelog.Manager.EntryHash
+const TrailerChangelogHash = "Changelog-Hash"
+
+// TrailerData is the data available to release commit trailer templates
+type TrailerData struct {
+	Version string
+	Tag     string
+	// UserName and UserEmail come from git config user.name and user.email
+	UserName  string
+	UserEmail string
+}
+
+// RenderTrailers renders git.trailers templates such as
+// "Signed-off-by: {{.UserName}} <{{.UserEmail}}>" into "Token: value" lines
+func RenderTrailers(templates []string, data TrailerData) ([]string, error) {
+	var trailers []string
+	for _, text := range templates {
+		tmpl, err := template.New("trailer").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trailer template %q: %v", text, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render trailer %q: %v", text, err)
+		}
+
+		trailer := strings.TrimSpace(buf.String())
+		token, value, ok := strings.Cut(trailer, ": ")
+		if !ok || token == "" || strings.ContainsAny(token, " \t") || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("trailer %q must look like \"Token: value\"", trailer)
+		}
+		trailers = append(trailers, trailer)
+	}
+	return trailers, nil
+}
+
+// trailers renders the configured release commit trailers for version
+func (r *Manager) trailers(version string) ([]string, error) {
+	if len(r.settings.Git.Trailers) == 0 {
+		return nil, nil
+	}
+
+	name, email, err := r.gitManager.UserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return RenderTrailers(r.settings.Git.Trailers, TrailerData{
+		Version:   version,
+		Tag:       "v" + version,
+		UserName:  name,
+		UserEmail: email,
+	})
+}
+
+// withChangelogHash appends the Changelog-Hash trailer of the written changelog entry
+// to trailers. An entry the changelog can't find again is logged and left unhashed.
+func (r *Manager) withChangelogHash(trailers []string, version string) []string {
+	hash, err := r.changelogManager.EntryHash(version)
+	if err != nil {
+		log.Printf("Failed to hash the changelog entry: %v", err)
+		return trailers
+	}
+	return append(trailers, TrailerChangelogHash+": "+hash)
+}
+
+// ChangelogChanged reports whether the changelog entry of a release no longer matches
+// the Changelog-Hash trailer of its release commit. Releases without the trailer, such
+// as tag-only releases, never count as changed.
+func (r *Manager) ChangelogChanged(version string) bool {
+	trailers, err := r.gitManager.CommitTrailers("v" + version)
+	if err != nil || trailers[TrailerChangelogHash] == "" {
+		return false
+	}
+	hash, err := r.changelogManager.EntryHash(version)
+	return err != nil || hash != trailers[TrailerChangelogHash]
+}
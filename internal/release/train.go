@@ -0,0 +1,50 @@
+package release
+
+import (
+	"fmt"
+	"time"
+
+	"bump-tui/internal/config"
+)
+
+// TrainStatus describes where a project is in its release train cadence
+type TrainStatus struct {
+	// Train is the latest train date on or before now; zero before the first train
+	Train time.Time
+	// Next is the first train date after now
+	Next time.Time
+	// Due is true when a train date has passed without a release since
+	Due bool
+}
+
+// TrainSchedule works out the train dates around now and whether a release is due.
+// A zero lastRelease means the project has not been released yet.
+func TrainSchedule(settings config.TrainSettings, lastRelease, now time.Time) (TrainStatus, error) {
+	if settings.Start == "" {
+		return TrainStatus{}, fmt.Errorf("no release train configured; set train.start in %s", config.SettingsFileName)
+	}
+	start, err := time.ParseInLocation(config.TrainDateLayout, settings.Start, now.Location())
+	if err != nil {
+		return TrainStatus{}, fmt.Errorf("invalid train.start: %v", err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if today.Before(start) {
+		return TrainStatus{Next: start}, nil
+	}
+
+	// Count calendar days in UTC so daylight saving changes don't shift the schedule
+	days := int(dateUTC(today).Sub(dateUTC(start)).Hours()) / 24
+	periods := days / settings.IntervalDays
+
+	status := TrainStatus{
+		Train: start.AddDate(0, 0, periods*settings.IntervalDays),
+		Next:  start.AddDate(0, 0, (periods+1)*settings.IntervalDays),
+	}
+	status.Due = lastRelease.IsZero() || lastRelease.Before(status.Train)
+	return status, nil
+}
+
+func dateUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
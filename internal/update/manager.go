@@ -0,0 +1,142 @@
+package update
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	// LatestReleaseURL is the GitHub API endpoint for the newest bump-tui release
+	LatestReleaseURL = "https://api.github.com/repos/MattressPadley/bump/releases/latest"
+	// CheckInterval is how long a release check is cached before GitHub is asked again
+	CheckInterval = 24 * time.Hour
+	// RequestTimeout keeps the check from lingering on slow networks
+	RequestTimeout = 5 * time.Second
+)
+
+// Release describes a published bump-tui release
+type Release struct {
+	Version string `json:"version"`
+	// URL is the release page with the changelog
+	URL string `json:"url"`
+}
+
+// cacheEntry is the cached result of the last release check
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    Release   `json:"latest"`
+}
+
+// Manager checks GitHub for newer bump-tui releases
+type Manager struct {
+	currentVersion string
+	httpClient     *http.Client
+	// releaseURL overrides LatestReleaseURL, for tests
+	releaseURL string
+}
+
+func NewManager(currentVersion string) *Manager {
+	return &Manager{
+		currentVersion: currentVersion,
+		httpClient:     &http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// CheckForUpdate returns the latest release when it is newer than the running
+// version, or nil. Development builds are never reported as outdated.
+func (u *Manager) CheckForUpdate() (*Release, error) {
+	current, err := semver.NewVersion(u.currentVersion)
+	if err != nil {
+		return nil, nil // "dev" and other unversioned builds
+	}
+
+	latest, err := u.latestRelease()
+	if err != nil {
+		return nil, err
+	}
+
+	latestVersion, err := semver.NewVersion(latest.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release version %q: %v", latest.Version, err)
+	}
+	if !latestVersion.GreaterThan(current) {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// latestRelease returns the cached latest release, asking GitHub at most once per CheckInterval
+func (u *Manager) latestRelease() (*Release, error) {
+	path, pathErr := cachePath()
+	if pathErr == nil {
+		if content, err := os.ReadFile(path); err == nil {
+			var entry cacheEntry
+			if json.Unmarshal(content, &entry) == nil && time.Since(entry.CheckedAt) < CheckInterval {
+				return &entry.Latest, nil
+			}
+		}
+	}
+
+	latest, err := u.fetchLatestRelease()
+	if err != nil {
+		return nil, err
+	}
+
+	if pathErr == nil {
+		if content, err := json.Marshal(cacheEntry{CheckedAt: time.Now(), Latest: *latest}); err == nil {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+				_ = os.WriteFile(path, content, 0644)
+			}
+		}
+	}
+
+	return latest, nil
+}
+
+// fetchLatestRelease asks the GitHub API for the newest release
+func (u *Manager) fetchLatestRelease() (*Release, error) {
+	url := LatestReleaseURL
+	if u.releaseURL != "" {
+		url = u.releaseURL
+	}
+	resp, err := u.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check for updates: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to check for updates: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("unable to parse latest release: %v", err)
+	}
+
+	return &Release{
+		Version: strings.TrimPrefix(release.TagName, "v"),
+		URL:     release.HTMLURL,
+	}, nil
+}
+
+// cachePath returns the per-user location of the release check cache
+func cachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate user cache directory: %v", err)
+	}
+	return filepath.Join(dir, "bump-tui", "update-check.json"), nil
+}
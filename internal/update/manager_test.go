@@ -0,0 +1,122 @@
+package update
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newReleaseServer serves tag as the latest release and counts the requests
+func newReleaseServer(t *testing.T, tag string, status int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if status != http.StatusOK {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"tag_name": tag,
+			"html_url": "https://github.com/MattressPadley/bump/releases/tag/" + tag,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// useTempCache points the user cache directory at a fresh temp dir
+func useTempCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		tag       string
+		status    int
+		expected  string
+		expectErr bool
+		requests  int32
+	}{
+		{name: "newer release", current: "1.2.0", tag: "v1.3.0", status: http.StatusOK, expected: "1.3.0", requests: 1},
+		{name: "same release", current: "1.2.0", tag: "v1.2.0", status: http.StatusOK, requests: 1},
+		{name: "older release", current: "1.3.0-rc.1", tag: "v1.2.0", status: http.StatusOK, requests: 1},
+		{name: "prerelease of the latest", current: "1.3.0-rc.1", tag: "v1.3.0", status: http.StatusOK, expected: "1.3.0", requests: 1},
+		{name: "development build", current: "dev", tag: "v1.3.0", status: http.StatusOK},
+		{name: "invalid tag", current: "1.2.0", tag: "latest", status: http.StatusOK, expectErr: true, requests: 1},
+		{name: "server error", current: "1.2.0", tag: "v1.3.0", status: http.StatusInternalServerError, expectErr: true, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempCache(t)
+			server, requests := newReleaseServer(t, tt.tag, tt.status)
+			manager := NewManager(tt.current)
+			manager.releaseURL = server.URL
+
+			release, err := manager.CheckForUpdate()
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			version := ""
+			if release != nil {
+				version = release.Version
+			}
+			if version != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, version)
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("Expected %d requests, got %d", tt.requests, got)
+			}
+		})
+	}
+}
+
+func TestCheckForUpdateCache(t *testing.T) {
+	useTempCache(t)
+	server, requests := newReleaseServer(t, "v1.3.0", http.StatusOK)
+	manager := NewManager("1.2.0")
+	manager.releaseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		release, err := manager.CheckForUpdate()
+		if err != nil || release == nil || release.Version != "1.3.0" {
+			t.Fatalf("Expected 1.3.0, got %+v (%v)", release, err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected the second check to use the cache, got %d requests", got)
+	}
+
+	// A check older than CheckInterval asks GitHub again
+	path, err := cachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, _ := json.Marshal(cacheEntry{CheckedAt: time.Now().Add(-CheckInterval - time.Minute), Latest: Release{Version: "1.2.5"}})
+	if err := os.WriteFile(path, stale, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if release, _ := manager.CheckForUpdate(); release == nil || release.Version != "1.3.0" {
+		t.Errorf("Expected a fresh check, got %+v", release)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected a stale cache to be refreshed, got %d requests", got)
+	}
+
+	// A cached result is used without the network
+	fresh, _ := json.Marshal(cacheEntry{CheckedAt: time.Now(), Latest: Release{Version: "1.4.0"}})
+	if err := os.WriteFile(path, fresh, 0644); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	if release, err := manager.CheckForUpdate(); err != nil || release == nil || release.Version != "1.4.0" {
+		t.Errorf("Expected the cached 1.4.0, got %+v (%v)", release, err)
+	}
+}
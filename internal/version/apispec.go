@@ -0,0 +1,114 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// openAPIInfoRe matches the top-level info key of a YAML spec
+	openAPIInfoRe = regexp.MustCompile(`^info:\s*(#.*)?$`)
+	// openAPIVersionRe matches a version key, keeping its indentation, quotes and trailing comment
+	openAPIVersionRe = regexp.MustCompile(`^(\s+version:\s*)(['"]?)([^'"#\s]+)(['"]?)(.*)$`)
+	// jsonInfoVersionRe matches the version string directly inside the info object of a
+	// JSON spec, stepping over nested objects such as contact and license
+	jsonInfoVersionRe = regexp.MustCompile(`("info"\s*:\s*\{(?:[^{}]|\{[^{}]*\})*?"version"\s*:\s*")([^"]*)(")`)
+	// protoVersionOptionRe matches file options holding a version, e.g. option (api_version) = "1.2.0";
+	protoVersionOptionRe = regexp.MustCompile(`(?im)^(\s*option\s+\(?[\w.]*version\)?\s*=\s*")([^"]+)(")`)
+)
+
+// isOpenAPIFile reports whether a file name is an OpenAPI or Swagger spec
+func isOpenAPIFile(fileName string) bool {
+	switch fileName {
+	case "openapi.yaml", "openapi.yml", "openapi.json", "swagger.yaml", "swagger.yml", "swagger.json":
+		return true
+	}
+	return false
+}
+
+// openAPIVersionLine returns the index of the info.version line of a YAML spec, or -1
+func openAPIVersionLine(lines []string) int {
+	inInfo := false
+	childIndent := -1
+	for i, line := range lines {
+		if !inInfo {
+			inInfo = openAPIInfoRe.MatchString(line)
+			continue
+		}
+
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		if indent == 0 {
+			return -1
+		}
+		if childIndent < 0 {
+			childIndent = indent
+		}
+		if indent == childIndent && openAPIVersionRe.MatchString(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+func extractOpenAPIVersion(filePath, content string) (*semver.Version, error) {
+	if strings.HasSuffix(filePath, ".json") {
+		var spec struct {
+			Info struct {
+				Version string `json:"version"`
+			} `json:"info"`
+		}
+		if err := json.Unmarshal([]byte(content), &spec); err != nil {
+			return nil, err
+		}
+		if spec.Info.Version == "" {
+			return nil, fmt.Errorf("no info.version found in %s", filePath)
+		}
+		return semver.NewVersion(spec.Info.Version)
+	}
+
+	lines := strings.Split(content, "\n")
+	i := openAPIVersionLine(lines)
+	if i < 0 {
+		return nil, fmt.Errorf("no info.version found in %s", filePath)
+	}
+	return semver.NewVersion(openAPIVersionRe.FindStringSubmatch(lines[i])[3])
+}
+
+func updateOpenAPIVersion(filePath, content, newVersion string) (string, error) {
+	if strings.HasSuffix(filePath, ".json") {
+		// Replace the string in place so the spec keeps its key order and formatting
+		if !jsonInfoVersionRe.MatchString(content) {
+			return "", fmt.Errorf("no info.version found in %s", filePath)
+		}
+		loc := jsonInfoVersionRe.FindStringSubmatchIndex(content)
+		return content[:loc[4]] + newVersion + content[loc[5]:], nil
+	}
+
+	lines := strings.Split(content, "\n")
+	i := openAPIVersionLine(lines)
+	if i < 0 {
+		return "", fmt.Errorf("no info.version found in %s", filePath)
+	}
+	lines[i] = openAPIVersionRe.ReplaceAllString(lines[i], "${1}${2}"+newVersion+"${4}${5}")
+	return strings.Join(lines, "\n"), nil
+}
+
+func extractProtoVersion(content string) (*semver.Version, error) {
+	matches := protoVersionOptionRe.FindStringSubmatch(content)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("no version option found in .proto file")
+	}
+	return semver.NewVersion(matches[2])
+}
+
+func updateProtoVersion(content, newVersion string) string {
+	return protoVersionOptionRe.ReplaceAllString(content, "${1}"+newVersion+"${3}")
+}
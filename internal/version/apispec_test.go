@@ -0,0 +1,148 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		version  string
+		expected string
+	}{
+		{
+			name:     "unquoted",
+			path:     "openapi.yaml",
+			content:  "openapi: 3.0.3\ninfo:\n  title: API\n  version: 1.2.0\npaths: {}\n",
+			version:  "1.2.0",
+			expected: "openapi: 3.0.3\ninfo:\n  title: API\n  version: 1.3.0\npaths: {}\n",
+		},
+		{
+			name:     "quoted with a comment",
+			path:     "openapi.yml",
+			content:  "info:\n  version: \"1.2.0\" # bumped by CI\n",
+			version:  "1.2.0",
+			expected: "info:\n  version: \"1.3.0\" # bumped by CI\n",
+		},
+		{
+			name:     "single quotes",
+			path:     "swagger.yaml",
+			content:  "swagger: '2.0'\ninfo:\n  version: '1.2.0'\n",
+			version:  "1.2.0",
+			expected: "swagger: '2.0'\ninfo:\n  version: '1.3.0'\n",
+		},
+		{
+			name:     "comment after info",
+			path:     "openapi.yaml",
+			content:  "info: # service metadata\n  # the public version\n  version: 1.2.0\n",
+			version:  "1.2.0",
+			expected: "info: # service metadata\n  # the public version\n  version: 1.3.0\n",
+		},
+		{
+			name: "nested contact",
+			path: "openapi.yaml",
+			content: "info:\n  title: API\n  contact:\n    name: Team\n    version: 9.9.9\n  version: 1.2.0\n" +
+				"components:\n  schemas:\n    Item:\n      version: 7.0.0\n",
+			version: "1.2.0",
+			expected: "info:\n  title: API\n  contact:\n    name: Team\n    version: 9.9.9\n  version: 1.3.0\n" +
+				"components:\n  schemas:\n    Item:\n      version: 7.0.0\n",
+		},
+		{
+			name:     "json",
+			path:     "openapi.json",
+			content:  "{\n  \"openapi\": \"3.1.0\",\n  \"info\": {\n    \"title\": \"API\",\n    \"version\": \"1.2.0\"\n  }\n}\n",
+			version:  "1.2.0",
+			expected: "{\n  \"openapi\": \"3.1.0\",\n  \"info\": {\n    \"title\": \"API\",\n    \"version\": \"1.3.0\"\n  }\n}\n",
+		},
+		{
+			name: "swagger.json with a license",
+			path: "swagger.json",
+			content: `{"swagger": "2.0", "info": {"license": {"name": "MIT", "version": "3.0.0"}, ` +
+				`"contact": {"email": "a@b.c"}, "version": "1.2.0"}, "x-version": "0.0.1"}`,
+			version: "1.2.0",
+			expected: `{"swagger": "2.0", "info": {"license": {"name": "MIT", "version": "3.0.0"}, ` +
+				`"contact": {"email": "a@b.c"}, "version": "1.3.0"}, "x-version": "0.0.1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractOpenAPIVersion(tt.path, tt.content)
+			if err != nil || version.String() != tt.version {
+				t.Fatalf("Expected %s, got %v (%v)", tt.version, version, err)
+			}
+
+			updated, err := updateOpenAPIVersion(tt.path, tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+			if version, err := extractOpenAPIVersion(tt.path, updated); err != nil || version.String() != "1.3.0" {
+				t.Errorf("Expected 1.3.0 after the update, got %v (%v)", version, err)
+			}
+		})
+	}
+}
+
+func TestOpenAPIVersionMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{"no info", "openapi.yaml", "openapi: 3.0.3\nversion: 1.0.0\n"},
+		{"version only in a nested object", "openapi.yaml", "info:\n  contact:\n    version: 1.0.0\n"},
+		{"version after info ends", "openapi.yaml", "info:\n  title: API\npaths:\n  version: 1.0.0\n"},
+		{"json without info.version", "openapi.json", `{"info": {"title": "API"}, "version": "1.0.0"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if version, err := extractOpenAPIVersion(tt.path, tt.content); err == nil {
+				t.Errorf("Expected an error, got %s", version)
+			}
+			if updated, err := updateOpenAPIVersion(tt.path, tt.content, "2.0.0"); err == nil {
+				t.Errorf("Expected the update to fail, got:\n%s", updated)
+			}
+		})
+	}
+}
+
+func TestProtoVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"custom option", "syntax = \"proto3\";\noption (api_version) = \"1.2.0\";\n", "option (api_version) = \"1.3.0\";"},
+		{"qualified option", "option (acme.api.version) = \"1.2.0\";\n", "option (acme.api.version) = \"1.3.0\";"},
+		{"plain option", "  option version = \"1.2.0\";\n", "  option version = \"1.3.0\";"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractProtoVersion(tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated := updateProtoVersion(tt.content, "1.3.0")
+			if !strings.Contains(updated, tt.expected) {
+				t.Errorf("Expected %q in:\n%s", tt.expected, updated)
+			}
+		})
+	}
+
+	// Other options are left alone
+	content := "option go_package = \"example.com/api/v1\";\noption java_package = \"com.example\";\n"
+	if _, err := extractProtoVersion(content); err == nil {
+		t.Errorf("Expected no version option")
+	}
+	if updated := updateProtoVersion(content, "1.3.0"); updated != content {
+		t.Errorf("Expected other options unchanged, got:\n%s", updated)
+	}
+}
@@ -0,0 +1,130 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/config"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// cmakeProjectNameRe matches the name in a project() call
+	cmakeProjectNameRe = regexp.MustCompile(`(?i)project\s*\(\s*([A-Za-z_][\w-]*)`)
+	// macroUnsafeRe matches characters that can't appear in a macro name
+	macroUnsafeRe = regexp.MustCompile(`[^A-Z0-9_]`)
+)
+
+// Header renders a C header defining prefix_VERSION as a string and its components
+// as integers, e.g. FIRMWARE_VERSION_MAJOR
+func Header(prefix string, v *semver.Version) string {
+	var b strings.Builder
+	b.WriteString("// Generated by bump at each release. Do not edit.\n")
+	b.WriteString("#pragma once\n\n")
+	fmt.Fprintf(&b, "#define %s_VERSION \"%s\"\n", prefix, v.String())
+	fmt.Fprintf(&b, "#define %s_VERSION_MAJOR %d\n", prefix, v.Major())
+	fmt.Fprintf(&b, "#define %s_VERSION_MINOR %d\n", prefix, v.Minor())
+	fmt.Fprintf(&b, "#define %s_VERSION_PATCH %d\n", prefix, v.Patch())
+	return b.String()
+}
+
+// cmakeHeaderTemplate renders a configure_file() template filled in from the project() version
+func cmakeHeaderTemplate(prefix, name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Generated by CMake from %s. Edit the version in CMakeLists.txt.\n", name)
+	b.WriteString("#pragma once\n\n")
+	fmt.Fprintf(&b, "#define %s_VERSION \"@PROJECT_VERSION@\"\n", prefix)
+	fmt.Fprintf(&b, "#define %s_VERSION_MAJOR @PROJECT_VERSION_MAJOR@\n", prefix)
+	fmt.Fprintf(&b, "#define %s_VERSION_MINOR @PROJECT_VERSION_MINOR@\n", prefix)
+	fmt.Fprintf(&b, "#define %s_VERSION_PATCH @PROJECT_VERSION_PATCH@\n", prefix)
+	return b.String()
+}
+
+// cmakeMacroPrefix derives the macro prefix from the project() name, e.g. MY_LIB for
+// project(my-lib ...), falling back to PROJECT when the name is missing or a variable
+func cmakeMacroPrefix(content string) string {
+	matches := cmakeProjectNameRe.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return "PROJECT"
+	}
+	prefix := macroUnsafeRe.ReplaceAllString(strings.ToUpper(matches[1]), "_")
+	if prefix[0] >= '0' && prefix[0] <= '9' {
+		prefix = "_" + prefix
+	}
+	return prefix
+}
+
+// UpdateCMakeHeader keeps the cmake.header of the first managed CMakeLists.txt up to
+// date. In plain mode the header is rewritten with the new version; in configure mode
+// a missing template is created and a configure_file() call for it is appended to
+// CMakeLists.txt, after which CMake takes the version from project().
+func (m *Manager) UpdateCMakeHeader(newVersion string) error {
+	if m.cmake.Header == "" {
+		return nil
+	}
+
+	var cmakeLists string
+	for _, file := range m.ProjectFiles {
+		if file.Type == Cpp {
+			cmakeLists = file.Path
+			break
+		}
+	}
+	if cmakeLists == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(cmakeLists)
+	if err != nil {
+		return err
+	}
+	prefix := m.cmake.Prefix
+	if prefix == "" {
+		prefix = cmakeMacroPrefix(string(content))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.cmake.Header), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %v", m.cmake.Header, err)
+	}
+
+	if m.cmake.Mode != config.CMakeHeaderConfigure {
+		parsed, err := semver.NewVersion(newVersion)
+		if err != nil {
+			return fmt.Errorf("invalid version %s: %v", newVersion, err)
+		}
+		return os.WriteFile(m.cmake.Header, []byte(Header(prefix, parsed)), 0644)
+	}
+
+	if _, err := os.Stat(m.cmake.Header); os.IsNotExist(err) {
+		if err := os.WriteFile(m.cmake.Header, []byte(cmakeHeaderTemplate(prefix, filepath.Base(m.cmake.Header))), 0644); err != nil {
+			return err
+		}
+	}
+	return addConfigureFile(cmakeLists, string(content), m.cmake.Header)
+}
+
+// addConfigureFile appends a configure_file() call generating the header from template
+// to CMakeLists.txt, unless the file already configures the template
+func addConfigureFile(cmakeLists, content, template string) error {
+	rel, err := filepath.Rel(filepath.Dir(cmakeLists), template)
+	if err != nil {
+		return err
+	}
+	rel = filepath.ToSlash(rel)
+	if strings.Contains(content, rel) {
+		return nil
+	}
+
+	output := strings.TrimSuffix(filepath.Base(template), ".in")
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += fmt.Sprintf("\n# Version header, added by bump. Add ${CMAKE_CURRENT_BINARY_DIR} to your\n"+
+		"# target's include directories to use it\n"+
+		"configure_file(%s ${CMAKE_CURRENT_BINARY_DIR}/%s @ONLY)\n", rel, output)
+	return os.WriteFile(cmakeLists, []byte(content), 0644)
+}
@@ -0,0 +1,432 @@
+package version
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Handler reads and writes the version of one kind of version file. The Manager
+// looks files up in a registry of handlers, so supporting another ecosystem only
+// takes a RegisterHandler call from within this module, as the plugin package does;
+// the package is internal, so other modules can't register handlers.
+type Handler interface {
+	// Detect reports whether a file, given by its path relative to the project root,
+	// is handled, e.g. by its name or extension
+	Detect(path string) bool
+	// Extract reads the version from the content of a file
+	Extract(path, content string) (*semver.Version, error)
+	// Update returns the content of a file with its version set to newVersion
+	Update(path, content, newVersion string) (string, error)
+}
+
+// OptionalHandler is implemented by handlers whose files are only version files when
+// they carry a version, like .proto files without a version option. Detection skips
+// such files instead of failing on them.
+type OptionalHandler interface {
+	Handler
+	Optional(path string) bool
+}
+
+// registration ties a handler to its project type
+type registration struct {
+	projectType ProjectType
+	description string
+	handler     Handler
+	// roots lists the files looked for in the project root during automatic detection
+	roots func(projectRoot string) ([]rootFile, error)
+}
+
+// rootFile is a candidate version file in the project root
+type rootFile struct {
+	path        string
+	description string
+}
+
+var (
+	// handlers is the registry, tried in order; files matching several handlers go to the first
+	handlers = builtinHandlers()
+	// handlersMu guards handlers, which RegisterHandler replaces while managers may read it
+	handlersMu sync.RWMutex
+)
+
+// RegisterHandler adds a handler for files of projectType, taking precedence over the
+// built-in handlers and replacing an earlier handler of the same type. rootFiles are
+// the file names or glob patterns automatic detection looks for in the project root;
+// files listed in .bump or found in subdirectories are matched with Detect.
+func RegisterHandler(projectType ProjectType, description string, handler Handler, rootFiles ...string) {
+	registered := []registration{{
+		projectType: projectType,
+		description: description,
+		handler:     handler,
+		roots:       globFiles(description, rootFiles...),
+	}}
+
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	for _, entry := range handlers {
+		if entry.projectType != projectType {
+			registered = append(registered, entry)
+		}
+	}
+	handlers = registered
+}
+
+// registeredHandlers returns the registry in order. RegisterHandler replaces the
+// slice instead of changing it, so it is safe to range over.
+func registeredHandlers() []registration {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	return handlers
+}
+
+// handlerFor returns the registration of a project type
+func handlerFor(projectType ProjectType) (registration, bool) {
+	for _, entry := range registeredHandlers() {
+		if entry.projectType == projectType {
+			return entry, true
+		}
+	}
+	return registration{}, false
+}
+
+// optional reports whether a file of the registration only counts when it carries a version
+func (r registration) optional(path string) bool {
+	if handler, ok := r.handler.(OptionalHandler); ok {
+		return handler.Optional(path)
+	}
+	return false
+}
+
+// namedFiles lists fixed file names sharing one description
+func namedFiles(description string, names ...string) func(string) ([]rootFile, error) {
+	return func(string) ([]rootFile, error) {
+		var files []rootFile
+		for _, name := range names {
+			files = append(files, rootFile{name, description})
+		}
+		return files, nil
+	}
+}
+
+// globFiles lists the files in the project root matching glob patterns
+func globFiles(description string, patterns ...string) func(string) ([]rootFile, error) {
+	return func(projectRoot string) ([]rootFile, error) {
+		var files []rootFile
+		for _, pattern := range patterns {
+			matches, err := filepath.Glob(filepath.Join(projectRoot, pattern))
+			if err != nil {
+				return nil, fmt.Errorf("invalid file pattern %q: %v", pattern, err)
+			}
+			for _, match := range matches {
+				files = append(files, rootFile{filepath.Base(match), description})
+			}
+		}
+		return files, nil
+	}
+}
+
+// fileHandler is a Handler built from functions, used for the built-in file types
+type fileHandler struct {
+	detect   func(path string) bool
+	optional func(path string) bool
+	extract  func(path, content string) (*semver.Version, error)
+	update   func(path, content, newVersion string) (string, error)
+}
+
+func (h fileHandler) Detect(path string) bool {
+	return h.detect(path)
+}
+
+func (h fileHandler) Extract(path, content string) (*semver.Version, error) {
+	return h.extract(path, content)
+}
+
+func (h fileHandler) Update(path, content, newVersion string) (string, error) {
+	return h.update(path, content, newVersion)
+}
+
+func (h fileHandler) Optional(path string) bool {
+	return h.optional != nil && h.optional(path)
+}
+
+// fileNamed matches files by base name
+func fileNamed(names ...string) func(string) bool {
+	return func(path string) bool {
+		base := filepath.Base(path)
+		for _, name := range names {
+			if base == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// withExtension matches files by extension
+func withExtension(ext string) func(string) bool {
+	return func(path string) bool {
+		return filepath.Ext(path) == ext
+	}
+}
+
+// always marks every file of a type as optional
+func always(string) bool {
+	return true
+}
+
+// builtinHandlers returns the handlers of the file types bump supports out of the box.
+// Specific file names come before extensions, so setup.py is a Python project file
+// rather than a Python source.
+func builtinHandlers() []registration {
+	return []registration{
+		{
+			projectType: Go,
+			description: "Go module file",
+			// Go versions live in git tags, which are created with the release
+			handler: fileHandler{
+				detect:  fileNamed("go.mod"),
+				extract: func(string, string) (*semver.Version, error) { return extractGoVersion() },
+				update:  func(_, content, _ string) (string, error) { return content, nil },
+			},
+			roots: namedFiles("Go module file", "go.mod"),
+		},
+		{
+			projectType: Rust,
+			description: "Rust package manifest",
+			handler: fileHandler{
+				detect:  fileNamed("Cargo.toml"),
+				extract: func(_, content string) (*semver.Version, error) { return extractCargoVersion(content) },
+				update: func(_, content, newVersion string) (string, error) {
+					return updateCargoVersion(content, newVersion), nil
+				},
+			},
+			roots: namedFiles("Rust package manifest", "Cargo.toml"),
+		},
+		{
+			projectType: Python,
+			description: "Python project configuration",
+			handler: fileHandler{
+				detect: fileNamed("pyproject.toml", "setup.py", "setup.cfg"),
+				// setup.py and setup.cfg often only hold tool settings or read the version from the package
+				optional: isSetuptoolsFile,
+				extract: func(path, content string) (*semver.Version, error) {
+					if isSetuptoolsFile(path) {
+						return extractSetuptoolsVersion(path, content)
+					}
+					return extractPyprojectVersion(path, content)
+				},
+				update: func(path, content, newVersion string) (string, error) {
+					if isSetuptoolsFile(path) {
+						return updateSetuptoolsVersion(path, content, newVersion)
+					}
+					return updatePyprojectVersion(path, content, newVersion)
+				},
+			},
+			roots: func(string) ([]rootFile, error) {
+				return []rootFile{
+					{"pyproject.toml", "Python project configuration"},
+					{"setup.py", "Python setup script"},
+					{"setup.cfg", "Python setup configuration"},
+				}, nil
+			},
+		},
+		{
+			projectType: Cpp,
+			description: "CMake build configuration",
+			handler: fileHandler{
+				detect:  fileNamed("CMakeLists.txt"),
+				extract: func(_, content string) (*semver.Version, error) { return extractCMakeVersion(content) },
+				update:  func(_, content, newVersion string) (string, error) { return updateCMakeVersion(content, newVersion) },
+			},
+			roots: namedFiles("CMake build configuration", "CMakeLists.txt"),
+		},
+		{
+			projectType: PlatformIO,
+			description: "PlatformIO project configuration",
+			handler: fileHandler{
+				detect:  fileNamed("platformio.ini", "library.json", "library.properties"),
+				extract: extractPlatformIOVersion,
+				update:  updatePlatformIOVersion,
+			},
+			roots: func(string) ([]rootFile, error) {
+				return []rootFile{
+					{"platformio.ini", "PlatformIO project configuration"},
+					{"library.json", "PlatformIO library manifest"},
+					{"library.properties", "Arduino library properties"},
+				}, nil
+			},
+		},
+		{
+			projectType: OpenAPI,
+			description: "OpenAPI specification",
+			handler: fileHandler{
+				detect:  func(path string) bool { return isOpenAPIFile(filepath.Base(path)) },
+				extract: extractOpenAPIVersion,
+				update:  updateOpenAPIVersion,
+			},
+			roots: func(string) ([]rootFile, error) {
+				return []rootFile{
+					{"openapi.yaml", "OpenAPI specification"},
+					{"openapi.yml", "OpenAPI specification"},
+					{"openapi.json", "OpenAPI specification"},
+					{"swagger.yaml", "Swagger specification"},
+					{"swagger.yml", "Swagger specification"},
+					{"swagger.json", "Swagger specification"},
+				}, nil
+			},
+		},
+		{
+			projectType: Protobuf,
+			description: "Protobuf definition",
+			handler: fileHandler{
+				detect:   withExtension(".proto"),
+				optional: always,
+				extract:  func(_, content string) (*semver.Version, error) { return extractProtoVersion(content) },
+				update: func(_, content, newVersion string) (string, error) {
+					return updateProtoVersion(content, newVersion), nil
+				},
+			},
+			roots: namedFiles("Protobuf definition"),
+		},
+		{
+			projectType: Terraform,
+			description: "Terraform module",
+			handler: fileHandler{
+				detect:   func(path string) bool { return isTerraformFile(filepath.Base(path)) },
+				optional: always,
+				extract:  extractTerraformVersion,
+				update: func(path, content, newVersion string) (string, error) {
+					return updateTerraformVersion(path, content, newVersion), nil
+				},
+			},
+			roots: func(string) ([]rootFile, error) {
+				return []rootFile{
+					{"versions.tf", "Terraform module versions"},
+					{"main.tf", "Terraform module"},
+				}, nil
+			},
+		},
+		{
+			projectType: Nix,
+			description: "Nix derivation",
+			handler: fileHandler{
+				detect:   fileNamed("flake.nix", "default.nix"),
+				optional: always,
+				extract:  extractNixVersion,
+				update:   updateNixVersion,
+			},
+			roots: func(string) ([]rootFile, error) {
+				return []rootFile{
+					{"flake.nix", "Nix flake"},
+					{"default.nix", "Nix derivation"},
+				}, nil
+			},
+		},
+		{
+			projectType: WordPress,
+			description: "WordPress plugin",
+			handler: fileHandler{
+				detect:   func(path string) bool { return fileNamed("readme.txt")(path) || withExtension(".php")(path) },
+				optional: always,
+				extract:  extractWordPressVersion,
+				update:   updateWordPressVersion,
+			},
+			roots: func(projectRoot string) ([]rootFile, error) {
+				files := []rootFile{{"readme.txt", "WordPress plugin readme"}}
+				plugins, err := wordPressPluginFiles(projectRoot)
+				if err != nil {
+					return nil, err
+				}
+				for _, plugin := range plugins {
+					files = append(files, rootFile{plugin, "WordPress plugin header"})
+				}
+				return files, nil
+			},
+		},
+		{
+			projectType: VSCode,
+			description: "VS Code extension manifest",
+			handler: fileHandler{
+				detect:   fileNamed("package.json"),
+				optional: always,
+				extract:  extractVSCodeVersion,
+				update:   updateVSCodeVersion,
+			},
+			roots: namedFiles("VS Code extension manifest", "package.json"),
+		},
+		{
+			projectType: RPM,
+			description: "RPM spec file",
+			handler: fileHandler{
+				detect:   withExtension(".spec"),
+				optional: always,
+				extract:  extractSpecVersion,
+				update: func(_, content, newVersion string) (string, error) {
+					return updateSpecVersion(content, newVersion), nil
+				},
+			},
+			// Spec files are named after the package
+			roots: func(projectRoot string) ([]rootFile, error) {
+				specs, err := filepath.Glob(filepath.Join(projectRoot, "*.spec"))
+				if err != nil {
+					return nil, err
+				}
+				var files []rootFile
+				for _, spec := range specs {
+					files = append(files, rootFile{filepath.Base(spec), "RPM spec file"})
+				}
+				return files, nil
+			},
+		},
+		{
+			projectType: RustSource,
+			description: "Rust version constant",
+			handler: fileHandler{
+				detect:  withExtension(".rs"),
+				extract: extractRustSourceVersion,
+				update:  updateRustSourceVersion,
+			},
+			roots: namedFiles("Rust version constant"),
+		},
+		{
+			projectType: PythonSource,
+			description: "Python __version__ attribute",
+			handler: fileHandler{
+				detect:  withExtension(".py"),
+				extract: extractPythonSourceVersion,
+				update:  updatePythonSourceVersion,
+			},
+			roots: namedFiles("Python __version__ attribute"),
+		},
+	}
+}
+
+// extractPlatformIOVersion reads the version of platformio.ini, library.json or library.properties
+func extractPlatformIOVersion(path, content string) (*semver.Version, error) {
+	switch {
+	case strings.HasSuffix(path, ".ini"):
+		return extractPlatformIOIniVersion(content)
+	case strings.HasSuffix(path, ".json"):
+		return extractLibraryJsonVersion(content)
+	case strings.HasSuffix(path, ".properties"):
+		return extractLibraryPropertiesVersion(content)
+	}
+	return nil, fmt.Errorf("unsupported PlatformIO file: %s", path)
+}
+
+// updatePlatformIOVersion sets the version of platformio.ini, library.json or library.properties
+func updatePlatformIOVersion(path, content, newVersion string) (string, error) {
+	switch {
+	case strings.HasSuffix(path, ".ini"):
+		return updatePlatformIOIniVersion(content, newVersion), nil
+	case strings.HasSuffix(path, ".json"):
+		return updateLibraryJsonVersion(content, newVersion)
+	case strings.HasSuffix(path, ".properties"):
+		return updateLibraryPropertiesVersion(content, newVersion), nil
+	}
+	return "", fmt.Errorf("unsupported PlatformIO file: %s", path)
+}
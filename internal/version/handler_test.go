@@ -0,0 +1,112 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// lineHandler is a test handler for files holding "<prefix><version>" on their first line
+type lineHandler struct {
+	name   string
+	prefix string
+}
+
+func (h lineHandler) Detect(path string) bool { return filepath.Base(path) == h.name }
+
+func (h lineHandler) Extract(path, content string) (*semver.Version, error) {
+	line, _, _ := strings.Cut(content, "\n")
+	if !strings.HasPrefix(line, h.prefix) {
+		return nil, fmt.Errorf("no version found in %s", path)
+	}
+	return semver.NewVersion(strings.TrimPrefix(line, h.prefix))
+}
+
+func (h lineHandler) Update(path, content, newVersion string) (string, error) {
+	_, rest, _ := strings.Cut(content, "\n")
+	return h.prefix + newVersion + "\n" + rest, nil
+}
+
+// restoreHandlers puts the built-in registry back once a test is done
+func restoreHandlers(t *testing.T) {
+	saved := registeredHandlers()
+	t.Cleanup(func() {
+		handlersMu.Lock()
+		handlers = saved
+		handlersMu.Unlock()
+	})
+}
+
+func TestRegisterHandler(t *testing.T) {
+	restoreHandlers(t)
+	builtin := len(registeredHandlers())
+
+	// A registered handler takes precedence over the built-in one for the same file
+	RegisterHandler("custom", "Custom manifest", lineHandler{name: "package.json", prefix: "v="}, "package.json")
+	manager := NewManager()
+	if got := manager.detectProjectTypeFromPath("web/package.json"); got != "custom" {
+		t.Errorf("Expected the registered handler to win, got %q", got)
+	}
+	if got := manager.detectProjectTypeFromPath("Cargo.toml"); got != Rust {
+		t.Errorf("Expected built-in handlers to stay, got %q", got)
+	}
+
+	// Registering the same type again replaces the earlier handler
+	RegisterHandler("custom", "Custom version file", lineHandler{name: "VERSION.custom", prefix: "version: "}, "VERSION.custom")
+	if got := len(registeredHandlers()); got != builtin+1 {
+		t.Errorf("Expected %d handlers after replacing, got %d", builtin+1, got)
+	}
+	if got := manager.detectProjectTypeFromPath("package.json"); got == "custom" {
+		t.Error("Expected the replaced handler to be gone")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "VERSION.custom"), []byte("version: 2.4.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.DetectVersionFiles(dir); err != nil {
+		t.Fatalf("DetectVersionFiles failed: %v", err)
+	}
+	if len(manager.ProjectFiles) != 1 || manager.ProjectFiles[0].Type != "custom" || manager.ProjectFiles[0].Description != "Custom version file" {
+		t.Fatalf("Expected the registered root file to be detected, got %+v", manager.ProjectFiles)
+	}
+	if manager.CurrentVersion.String() != "2.4.0" {
+		t.Errorf("Expected version 2.4.0, got %s", manager.CurrentVersion)
+	}
+
+	if err := manager.UpdateAllVersions("2.5.0"); err != nil {
+		t.Fatalf("UpdateAllVersions failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "VERSION.custom")); string(data) != "version: 2.5.0\n" {
+		t.Errorf("Expected the handler's update to be written, got %q", data)
+	}
+}
+
+func TestRegisterHandlerConcurrently(t *testing.T) {
+	restoreHandlers(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			RegisterHandler(ProjectType(fmt.Sprintf("custom-%d", i)), "Custom", lineHandler{name: "VERSION.custom"})
+		}(i)
+		go func() {
+			defer wg.Done()
+			NewManager().detectProjectTypeFromPath("Cargo.toml")
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 8; i++ {
+		if _, ok := handlerFor(ProjectType(fmt.Sprintf("custom-%d", i))); !ok {
+			t.Errorf("Expected custom-%d to be registered", i)
+		}
+	}
+}
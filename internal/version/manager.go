@@ -2,14 +2,17 @@ package version
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"bump-tui/internal/config"
+	"bump-tui/internal/git"
 	"github.com/Masterminds/semver/v3"
 	"github.com/pelletier/go-toml/v2"
 )
@@ -17,11 +20,20 @@ import (
 type ProjectType string
 
 const (
-	Rust       ProjectType = "rust"
-	Python     ProjectType = "python"
-	Cpp        ProjectType = "cpp"
-	PlatformIO ProjectType = "platformio"
-	Go         ProjectType = "go"
+	Rust         ProjectType = "rust"
+	Python       ProjectType = "python"
+	Cpp          ProjectType = "cpp"
+	PlatformIO   ProjectType = "platformio"
+	Go           ProjectType = "go"
+	OpenAPI      ProjectType = "openapi"
+	Protobuf     ProjectType = "protobuf"
+	Terraform    ProjectType = "terraform"
+	Nix          ProjectType = "nix"
+	RPM          ProjectType = "rpm"
+	WordPress    ProjectType = "wordpress"
+	VSCode       ProjectType = "vscode"
+	RustSource   ProjectType = "rust-source"
+	PythonSource ProjectType = "python-source"
 )
 
 type ProjectFile struct {
@@ -34,8 +46,15 @@ type Manager struct {
 	CurrentVersion *semver.Version    `json:"current_version"`
 	ProjectFiles   []ProjectFile      `json:"project_files"`
 	BumpConfig     *config.BumpConfig `json:"bump_config,omitempty"`
+	detection      config.DetectionSettings
+	cmake          config.CMakeSettings
+	// packages are the changed packages selected in changed-only monorepo mode
+	packages []PackageChange
 }
 
+// ErrBumpConfig wraps failures to read or validate the .bump file list
+var ErrBumpConfig = errors.New("failed to load .bump config")
+
 func NewManager() *Manager {
 	return &Manager{
 		CurrentVersion: semver.MustParse("0.1.0"), // Default version
@@ -43,11 +62,27 @@ func NewManager() *Manager {
 	}
 }
 
+// SetSettings applies project settings loaded from .bump.toml
+func (m *Manager) SetSettings(settings *config.Settings) {
+	m.detection = settings.Detection
+	m.cmake = settings.CMake
+}
+
+// HasProjectType reports whether any managed version file is of the given type
+func (m *Manager) HasProjectType(projectType ProjectType) bool {
+	for _, file := range m.ProjectFiles {
+		if file.Type == projectType {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Manager) DetectVersionFiles(projectRoot string) error {
 	// First, try to load .bump configuration
 	bumpConfig, err := config.LoadBumpConfig(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load .bump config: %v", err)
+		return fmt.Errorf("%w: %w", ErrBumpConfig, err)
 	}
 
 	if bumpConfig != nil {
@@ -59,16 +94,43 @@ func (m *Manager) DetectVersionFiles(projectRoot string) error {
 	return m.detectVersionFilesAutomatically(projectRoot)
 }
 
-func (m *Manager) detectVersionFilesFromConfig(projectRoot string) error {
-	var versions []*semver.Version
+// SelectProjectFiles limits the managed files to the given subset of ProjectFiles and
+// re-reads the current version from the first selected file that has one
+func (m *Manager) SelectProjectFiles(files []ProjectFile) error {
+	if len(files) == 0 {
+		return fmt.Errorf("select at least one version file")
+	}
 
+	m.ProjectFiles = files
+	for _, file := range files {
+		if version, err := m.extractVersionFromFile(file.Path, file.Type); err == nil && version != nil {
+			m.CurrentVersion = version
+			break
+		}
+	}
+	return nil
+}
+
+// DetectVersionFromTags reads the current version from the latest git tag only, for
+// projects whose version lives entirely in tags. No version files are updated.
+func (m *Manager) DetectVersionFromTags() error {
+	version, err := extractGoVersion()
+	if err != nil {
+		return fmt.Errorf("latest tag is not a semantic version: %v", err)
+	}
+	m.CurrentVersion = version
+	m.ProjectFiles = []ProjectFile{}
+	return nil
+}
+
+func (m *Manager) detectVersionFilesFromConfig(projectRoot string) error {
 	for _, configFile := range m.BumpConfig.Files {
 		fullPath := filepath.Join(projectRoot, configFile.Path)
 
 		// Auto-detect project type based on file name/extension
 		projectType := m.detectProjectTypeFromPath(configFile.Path)
 		if projectType == "" {
-			return fmt.Errorf("unable to determine project type for file: %s", configFile.Path)
+			return &FileError{File: configFile, Err: fmt.Errorf("unable to determine project type from the file name")}
 		}
 
 		projectFile := ProjectFile{
@@ -80,11 +142,10 @@ func (m *Manager) detectVersionFilesFromConfig(projectRoot string) error {
 		// Extract version from this file
 		version, err := m.extractVersionFromFile(fullPath, projectType)
 		if err != nil {
-			return fmt.Errorf("failed to extract version from %s: %v", configFile.Path, err)
+			return &FileError{File: configFile, Err: err}
 		}
 
 		if version != nil {
-			versions = append(versions, version)
 			// Use the first valid version as current version
 			if m.CurrentVersion == nil || m.CurrentVersion.String() == "0.1.0" {
 				m.CurrentVersion = version
@@ -94,103 +155,307 @@ func (m *Manager) detectVersionFilesFromConfig(projectRoot string) error {
 		m.ProjectFiles = append(m.ProjectFiles, projectFile)
 	}
 
-	// Always check version sync when using .bump config
-	if err := m.checkVersionSync(versions); err != nil {
-		return err
+	// Version sync is checked during repository validation, see ValidateVersionSync
+	return nil
+}
+
+// PackageChange describes whether a package, the directory of a version file, has
+// commits since its last package tag
+type PackageChange struct {
+	File    ProjectFile
+	Version *semver.Version
+	// LastTag is the package's latest tag, "" if it was never tagged
+	LastTag string
+	// Commits counts the commits touching the package since LastTag
+	Commits int
+}
+
+// Changed reports whether the package needs a release
+func (c PackageChange) Changed() bool {
+	return c.Commits > 0
+}
+
+// PackageTagPrefix returns the tag prefix of the package a version file belongs to:
+// "<dir>/v" for files in subdirectories and "v" for the repository root
+func PackageTagPrefix(file ProjectFile) string {
+	dir := filepath.ToSlash(filepath.Dir(file.Path))
+	if dir == "." {
+		return "v"
+	}
+	return dir + "/v"
+}
+
+// DetectChangedPackages reports, for every managed version file, the commits touching
+// its directory since the package's last tag. Packages nested in another package's
+// directory, the root one included, don't count towards it.
+func (m *Manager) DetectChangedPackages() ([]PackageChange, error) {
+	gitManager := git.NewManager()
+
+	var dirs []string
+	for _, file := range m.ProjectFiles {
+		dirs = append(dirs, filepath.ToSlash(filepath.Dir(file.Path)))
+	}
+
+	var changes []PackageChange
+	for _, file := range m.ProjectFiles {
+		prefix := PackageTagPrefix(file)
+		lastTag, err := gitManager.LatestTag(prefix + "*.*.*")
+		if err != nil {
+			return nil, err
+		}
+		commits, err := gitManager.CountCommits(lastTag, packagePathspecs(filepath.ToSlash(filepath.Dir(file.Path)), dirs)...)
+		if err != nil {
+			return nil, err
+		}
+
+		version, err := m.extractVersionFromFile(file.Path, file.Type)
+		if err != nil {
+			return nil, &FileError{File: config.VersionFile{Path: file.Path}, Err: err}
+		}
+		// Versions kept in tags only, as for Go modules, come from the package's last tag
+		if version == nil && lastTag != "" {
+			version, _ = semver.NewVersion(strings.TrimPrefix(lastTag, prefix))
+		}
+
+		changes = append(changes, PackageChange{
+			File:    file,
+			Version: version,
+			LastTag: lastTag,
+			Commits: commits,
+		})
 	}
+	return changes, nil
+}
 
+// packagePathspecs returns the pathspecs of the package in dir: the directory itself
+// without the directories of the packages nested in it
+func packagePathspecs(dir string, dirs []string) []string {
+	pathspecs := []string{dir}
+	seen := map[string]bool{dir: true}
+	for _, other := range dirs {
+		if seen[other] {
+			continue
+		}
+		if dir == "." || strings.HasPrefix(other, dir+"/") {
+			seen[other] = true
+			pathspecs = append(pathspecs, ":(exclude)"+other)
+		}
+	}
+	return pathspecs
+}
+
+// SelectChangedPackages limits the managed files to the changed packages. Every
+// package keeps its own version and is bumped from it, see PackageVersion; the current
+// version, used for the release tag and changelog, becomes the highest among them.
+func (m *Manager) SelectChangedPackages(changes []PackageChange) error {
+	var selected []PackageChange
+	var files []ProjectFile
+	var highest *semver.Version
+	for _, change := range changes {
+		if !change.Changed() {
+			continue
+		}
+		selected = append(selected, change)
+		files = append(files, change.File)
+		if change.Version != nil && (highest == nil || change.Version.GreaterThan(highest)) {
+			highest = change.Version
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no package changed since its last tag")
+	}
+
+	m.ProjectFiles = files
+	m.packages = selected
+	if highest != nil {
+		m.CurrentVersion = highest
+	}
 	return nil
 }
 
-func (m *Manager) detectVersionFilesAutomatically(projectRoot string) error {
-	files := []struct {
-		path        string
-		projectType ProjectType
-		description string
-	}{
-		{"go.mod", Go, "Go module file"},
-		{"Cargo.toml", Rust, "Rust package manifest"},
-		{"pyproject.toml", Python, "Python project configuration"},
-		{"CMakeLists.txt", Cpp, "CMake build configuration"},
-		{"platformio.ini", PlatformIO, "PlatformIO project configuration"},
-		{"library.json", PlatformIO, "PlatformIO library manifest"},
-		{"library.properties", PlatformIO, "Arduino library properties"},
+// PackageVersion returns the version file is released at when the release is at
+// releaseVersion. Selected changed packages get the release's major, minor or patch
+// bump applied to their own version; anything else, including prereleases and
+// versions that aren't a plain bump, is released at releaseVersion.
+func (m *Manager) PackageVersion(file ProjectFile, releaseVersion string) string {
+	var own *semver.Version
+	for _, change := range m.packages {
+		if change.File.Path == file.Path {
+			own = change.Version
+		}
+	}
+	target, err := semver.NewVersion(releaseVersion)
+	if own == nil || err != nil || target.Prerelease() != "" {
+		return releaseVersion
 	}
 
-	for _, file := range files {
-		fullPath := filepath.Join(projectRoot, file.path)
-		if _, err := os.Stat(fullPath); err == nil {
-			projectFile := ProjectFile{
-				Path:        fullPath,
-				Type:        file.projectType,
-				Description: file.description,
+	switch {
+	case target.Equal(m.BumpMajor()):
+		return own.IncMajor().String()
+	case target.Equal(m.BumpMinor()):
+		return own.IncMinor().String()
+	case target.Equal(m.BumpPatch()):
+		return own.IncPatch().String()
+	}
+	return releaseVersion
+}
+
+// PackageTags returns the package tags for the managed files when the release is at
+// version, each at the package's own version, skipping the root package whose tag is
+// the release tag itself
+func (m *Manager) PackageTags(version string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, file := range m.ProjectFiles {
+		prefix := PackageTagPrefix(file)
+		if prefix == "v" || seen[prefix] {
+			continue
+		}
+		seen[prefix] = true
+		tags = append(tags, prefix+m.PackageVersion(file, version))
+	}
+	return tags
+}
+
+// FileError reports a version file listed in .bump that can't be managed
+type FileError struct {
+	File config.VersionFile
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	matchedBy := ""
+	if e.File.Pattern != "" {
+		matchedBy = fmt.Sprintf(" (matched by %s)", e.File.Pattern)
+	}
+	return fmt.Sprintf("failed to extract version from %s%s: %v", e.File.Path, matchedBy, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+func (m *Manager) detectVersionFilesAutomatically(projectRoot string) error {
+	seen := make(map[string]bool)
+	for _, entry := range registeredHandlers() {
+		files, err := entry.roots(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			fullPath := filepath.Join(projectRoot, file.path)
+			if seen[fullPath] {
+				continue
+			}
+			if _, err := os.Stat(fullPath); err != nil {
+				continue
 			}
 
 			// Try to extract version from this file
-			if version, err := m.extractVersionFromFile(fullPath, file.projectType); err == nil && version != nil {
+			version, err := m.extractVersionFromFile(fullPath, entry.projectType)
+			if err != nil && entry.optional(file.path) {
+				continue
+			}
+			if err == nil && version != nil {
 				m.CurrentVersion = version
 			}
 
-			m.ProjectFiles = append(m.ProjectFiles, projectFile)
+			seen[fullPath] = true
+			m.ProjectFiles = append(m.ProjectFiles, ProjectFile{
+				Path:        fullPath,
+				Type:        entry.projectType,
+				Description: file.description,
+			})
 		}
 	}
 
-	return nil
-}
+	if !m.detection.Recursive {
+		return nil
+	}
 
-// detectProjectTypeFromPath determines the project type based on file path
-func (m *Manager) detectProjectTypeFromPath(filePath string) ProjectType {
-	fileName := filepath.Base(filePath)
+	nested, err := m.scanSubdirectories(projectRoot, m.detection.MaxDepth)
+	if err != nil {
+		return err
+	}
+
+	// Files in the root decide the current version; nested files only fill in when the root has none
+	rootHasVersion := len(m.ProjectFiles) > 0
+	for _, path := range nested {
+		projectType := m.detectProjectTypeFromPath(path)
+		fullPath := filepath.Join(projectRoot, path)
+
+		if !rootHasVersion {
+			if version, err := m.extractVersionFromFile(fullPath, projectType); err == nil && version != nil {
+				m.CurrentVersion = version
+				rootHasVersion = true
+			}
+		}
 
-	switch fileName {
-	case "go.mod":
-		return Go
-	case "Cargo.toml":
-		return Rust
-	case "pyproject.toml":
-		return Python
-	case "CMakeLists.txt":
-		return Cpp
-	case "platformio.ini", "library.json", "library.properties":
-		return PlatformIO
-	default:
-		return "" // Unknown type
+		m.ProjectFiles = append(m.ProjectFiles, ProjectFile{
+			Path:        fullPath,
+			Type:        projectType,
+			Description: fmt.Sprintf("%s (%s)", m.getDefaultDescription(projectType), filepath.Dir(path)),
+		})
 	}
+
+	return nil
 }
 
-// getDefaultDescription returns a default description for a project type
-func (m *Manager) getDefaultDescription(projectType ProjectType) string {
-	switch projectType {
-	case Go:
-		return "Go module file"
-	case Rust:
-		return "Rust package manifest"
-	case Python:
-		return "Python project configuration"
-	case Cpp:
-		return "CMake build configuration"
-	case PlatformIO:
-		return "PlatformIO project configuration"
-	default:
-		return "Project configuration file"
+// scanSubdirectories lists version files below the project root up to maxDepth
+// directories deep, in sorted order. Files ignored by git and files without a
+// version that parses are skipped.
+func (m *Manager) scanSubdirectories(projectRoot string, maxDepth int) ([]string, error) {
+	cmd := exec.Command("git", "-C", projectRoot, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %v", err)
 	}
-}
 
-// checkVersionSync verifies that all versions are the same
-func (m *Manager) checkVersionSync(versions []*semver.Version) error {
-	if len(versions) <= 1 {
-		return nil // Nothing to sync or only one version
+	var paths []string
+	for _, path := range strings.Split(string(output), "\x00") {
+		depth := strings.Count(path, "/")
+		if path == "" || depth == 0 || depth > maxDepth {
+			continue
+		}
+		projectType := m.detectProjectTypeFromPath(path)
+		if projectType == "" {
+			continue
+		}
+		// tfenv keeps the Terraform CLI version in .terraform-version, and versions in
+		// Rust and Python sources are opt-in, so these are only managed when listed in .bump
+		if filepath.Base(path) == ".terraform-version" || projectType == RustSource || projectType == PythonSource {
+			continue
+		}
+		// Deleted but still tracked files are listed by git too, and nested manifests
+		// without a version, e.g. of private examples, aren't version files
+		if _, err := m.extractVersionFromFile(filepath.Join(projectRoot, path), projectType); err != nil {
+			continue
+		}
+		paths = append(paths, path)
 	}
 
-	firstVersion := versions[0]
-	for i, version := range versions[1:] {
-		if !version.Equal(firstVersion) {
-			return fmt.Errorf("version mismatch: file %d has version %s, but file 0 has version %s",
-				i+1, version.String(), firstVersion.String())
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// detectProjectTypeFromPath determines the project type of a file from the first
+// registered handler that detects it
+func (m *Manager) detectProjectTypeFromPath(filePath string) ProjectType {
+	for _, entry := range registeredHandlers() {
+		if entry.handler.Detect(filePath) {
+			return entry.projectType
 		}
 	}
+	return "" // Unknown type
+}
 
-	return nil
+// getDefaultDescription returns a default description for a project type
+func (m *Manager) getDefaultDescription(projectType ProjectType) string {
+	if entry, ok := handlerFor(projectType); ok {
+		return entry.description
+	}
+	return "Project configuration file"
 }
 
 // CheckAllVersionsInSync checks if all configured files have the same version
@@ -199,70 +464,102 @@ func (m *Manager) CheckAllVersionsInSync() error {
 		return nil // No config, nothing to check
 	}
 
-	var versions []*semver.Version
-	var filePaths []string
+	conflicts, err := m.versionConflicts()
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("version mismatch: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// ValidateVersionSync checks that every managed file has the same version, as a
+// repository validation step. Mismatches fail validation when the files are listed
+// in .bump and are warnings for automatically detected files.
+func (m *Manager) ValidateVersionSync() git.ValidationResult {
+	result := git.ValidationResult{
+		Step:    git.ValidationStep{Name: "version_sync", Description: "Checking version files are in sync..."},
+		Success: true,
+	}
+
+	conflicts, err := m.versionConflicts()
+	if err != nil {
+		result.Success = false
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	if len(conflicts) == 0 {
+		return result
+	}
+
+	message := fmt.Sprintf("Version files disagree: %s", strings.Join(conflicts, ", "))
+	fix := fmt.Sprintf("Set every version file to %s", m.CurrentVersion)
+	if m.BumpConfig != nil {
+		result.AddError("versions_out_of_sync", message+". Align them before bumping.", fix)
+		result.SetAction("versions_out_of_sync", &git.FixAction{Label: fix, Run: m.SyncVersions})
+	} else {
+		result.AddWarning("versions_out_of_sync", message+". All of them will be set to the new version.", "")
+	}
+	return result
+}
+
+// SyncVersions sets every managed file to the current version
+func (m *Manager) SyncVersions() error {
+	return m.UpdateAllVersions(m.CurrentVersion.String())
+}
+
+// versionConflicts lists "path has version" for every file when the managed files
+// don't all share one version
+func (m *Manager) versionConflicts() ([]string, error) {
+	var entries []string
+	var first *semver.Version
+	inSync := true
 
 	for _, projectFile := range m.ProjectFiles {
 		version, err := m.extractVersionFromFile(projectFile.Path, projectFile.Type)
 		if err != nil {
-			return fmt.Errorf("failed to extract version from %s: %v", projectFile.Path, err)
+			return nil, fmt.Errorf("failed to extract version from %s: %v", projectFile.Path, err)
 		}
-
-		if version != nil {
-			versions = append(versions, version)
-			filePaths = append(filePaths, projectFile.Path)
+		if version == nil {
+			continue
 		}
-	}
 
-	// Check if all versions are the same
-	if len(versions) <= 1 {
-		return nil
+		if first == nil {
+			first = version
+		} else if !version.Equal(first) {
+			inSync = false
+		}
+		entries = append(entries, fmt.Sprintf("%s has %s", projectFile.Path, version))
 	}
 
-	firstVersion := versions[0]
-	for i, version := range versions[1:] {
-		if !version.Equal(firstVersion) {
-			return fmt.Errorf("version mismatch: %s has version %s, but %s has version %s",
-				filePaths[i+1], version.String(), filePaths[0], firstVersion.String())
-		}
+	if inSync {
+		return nil, nil
 	}
+	return entries, nil
+}
 
-	return nil
+// FileVersion reads the version of a managed file; optional files without one give nil
+func (m *Manager) FileVersion(file ProjectFile) (*semver.Version, error) {
+	return m.extractVersionFromFile(file.Path, file.Type)
 }
 
 func (m *Manager) extractVersionFromFile(filePath string, projectType ProjectType) (*semver.Version, error) {
+	entry, ok := handlerFor(projectType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported project type: %s", projectType)
+	}
+
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
-
-	contentStr := string(content)
-
-	switch projectType {
-	case Go:
-		return m.extractGoVersion()
-	case Rust:
-		return m.extractCargoVersion(contentStr)
-	case Python:
-		return m.extractPyprojectVersion(contentStr)
-	case Cpp:
-		return m.extractCMakeVersion(contentStr)
-	case PlatformIO:
-		if strings.HasSuffix(filePath, ".ini") {
-			return m.extractPlatformIOIniVersion(contentStr)
-		} else if strings.HasSuffix(filePath, ".json") {
-			return m.extractLibraryJsonVersion(contentStr)
-		} else if strings.HasSuffix(filePath, ".properties") {
-			return m.extractLibraryPropertiesVersion(contentStr)
-		}
-	}
-
-	return nil, fmt.Errorf("unsupported project type: %s", projectType)
+	return entry.handler.Extract(filePath, string(content))
 }
 
-func (m *Manager) extractGoVersion() (*semver.Version, error) {
-	// For Go projects, get version from latest git tag
-	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
+func extractGoVersion() (*semver.Version, error) {
+	// For Go projects, get version from latest git tag, ignoring alias tags like v1 or v1.3
+	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0", "--match", "*.*.*")
 	output, err := cmd.Output()
 	if err != nil {
 		// If no tags exist, default to v0.1.0
@@ -276,7 +573,7 @@ func (m *Manager) extractGoVersion() (*semver.Version, error) {
 	return semver.NewVersion(tagStr)
 }
 
-func (m *Manager) extractCargoVersion(content string) (*semver.Version, error) {
+func extractCargoVersion(content string) (*semver.Version, error) {
 	var config struct {
 		Package struct {
 			Version string `toml:"version"`
@@ -295,47 +592,30 @@ func (m *Manager) extractCargoVersion(content string) (*semver.Version, error) {
 	return semver.NewVersion(config.Package.Version)
 }
 
-func (m *Manager) extractPyprojectVersion(content string) (*semver.Version, error) {
-	var config struct {
-		Tool struct {
-			Poetry struct {
-				Version string `toml:"version"`
-			} `toml:"poetry"`
-		} `toml:"tool"`
-	}
-
-	err := toml.Unmarshal([]byte(content), &config)
-	if err != nil {
-		return nil, err
-	}
-
-	if config.Tool.Poetry.Version == "" {
-		return nil, fmt.Errorf("no version found in pyproject.toml")
-	}
-
-	return semver.NewVersion(config.Tool.Poetry.Version)
-}
-
-func (m *Manager) extractCMakeVersion(content string) (*semver.Version, error) {
+func extractCMakeVersion(content string) (*semver.Version, error) {
 	// Try project() version first - support variables like ${PROJECT_NAME}
-	projectRe := regexp.MustCompile(`project\s*\(\s*[^)]+\s+VERSION\s+(\d+)\.(\d+)\.(\d+)`)
-	if matches := projectRe.FindStringSubmatch(content); len(matches) >= 4 {
-		versionStr := fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3])
-		return semver.NewVersion(versionStr)
+	projectRe := regexp.MustCompile(`project\s*\(\s*[^)]+\s+VERSION\s+(\d+)\.(\d+)\.(\d+)(\.\d+)?`)
+	matches := projectRe.FindStringSubmatch(content)
+	if matches == nil {
+		// Try set(PROJECT_VERSION) format
+		setRe := regexp.MustCompile(`set\s*\(\s*(?:PROJECT|CMAKE_PROJECT)_VERSION\s+(\d+)\.(\d+)\.(\d+)(\.\d+)?`)
+		matches = setRe.FindStringSubmatch(content)
 	}
-
-	// Try set(PROJECT_VERSION) format
-	setRe := regexp.MustCompile(`set\s*\(\s*(?:PROJECT|CMAKE_PROJECT)_VERSION\s+(\d+)\.(\d+)\.(\d+)`)
-	if matches := setRe.FindStringSubmatch(content); len(matches) >= 4 {
-		versionStr := fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3])
-		return semver.NewVersion(versionStr)
+	if matches == nil {
+		return nil, fmt.Errorf("no version found in CMakeLists.txt")
 	}
 
-	return nil, fmt.Errorf("no version found in CMakeLists.txt")
+	versionStr := fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3])
+	// A tweak component would be dropped by the next release
+	if matches[4] != "" {
+		return nil, fmt.Errorf("CMakeLists.txt version %s%s has four components; bump needs MAJOR.MINOR.PATCH", versionStr, matches[4])
+	}
+	return semver.NewVersion(versionStr)
 }
 
-func (m *Manager) extractPlatformIOIniVersion(content string) (*semver.Version, error) {
-	re := regexp.MustCompile(`version\s*=\s*["']?([\d\.]+)["']?`)
+func extractPlatformIOIniVersion(content string) (*semver.Version, error) {
+	// Only a version key of its own, not e.g. platform_version or a value mentioning one
+	re := regexp.MustCompile(`(?m)^[ \t]*version[ \t]*=[ \t]*["']?([\d\.]+)["']?[ \t]*\r?$`)
 	matches := re.FindStringSubmatch(content)
 	if len(matches) < 2 {
 		return nil, fmt.Errorf("no version found in platformio.ini")
@@ -344,7 +624,7 @@ func (m *Manager) extractPlatformIOIniVersion(content string) (*semver.Version,
 	return semver.NewVersion(matches[1])
 }
 
-func (m *Manager) extractLibraryJsonVersion(content string) (*semver.Version, error) {
+func extractLibraryJsonVersion(content string) (*semver.Version, error) {
 	var config struct {
 		Version string `json:"version"`
 	}
@@ -361,8 +641,8 @@ func (m *Manager) extractLibraryJsonVersion(content string) (*semver.Version, er
 	return semver.NewVersion(config.Version)
 }
 
-func (m *Manager) extractLibraryPropertiesVersion(content string) (*semver.Version, error) {
-	re := regexp.MustCompile(`version\s*=\s*([\d\.]+)`)
+func extractLibraryPropertiesVersion(content string) (*semver.Version, error) {
+	re := regexp.MustCompile(`(?m)^[ \t]*version[ \t]*=[ \t]*([\d\.]+)[ \t]*\r?$`)
 	matches := re.FindStringSubmatch(content)
 	if len(matches) < 2 {
 		return nil, fmt.Errorf("no version found in library.properties")
@@ -386,69 +666,86 @@ func (m *Manager) BumpPatch() *semver.Version {
 	return &newVersion
 }
 
+// UpdateAllVersions sets newVersion in every managed file. Files already holding it,
+// e.g. after an aborted run, are left alone. Each file is parsed again after writing
+// it, and the update stops at the first one that no longer parses or doesn't hold
+// newVersion.
 func (m *Manager) UpdateAllVersions(newVersion string) error {
+	if _, err := semver.NewVersion(newVersion); err != nil {
+		return fmt.Errorf("invalid version %s: %v", newVersion, err)
+	}
+
 	for _, projectFile := range m.ProjectFiles {
-		if err := m.updateVersionInFile(projectFile, newVersion); err != nil {
+		fileVersion := m.PackageVersion(projectFile, newVersion)
+		target, err := semver.NewVersion(fileVersion)
+		if err != nil {
+			return fmt.Errorf("invalid version %s: %v", fileVersion, err)
+		}
+		before := m.inspectVersionFile(projectFile)
+		if before.version != nil && before.version.Equal(target) && projectFile.Type != Go {
+			continue
+		}
+		if err := m.updateVersionInFile(projectFile, fileVersion); err != nil {
 			return fmt.Errorf("failed to update %s: %v", projectFile.Path, err)
 		}
+		if err := m.verifyVersionFile(projectFile, before, fileVersion); err != nil {
+			return fmt.Errorf("%s failed verification after the update: %v", projectFile.Path, err)
+		}
 	}
 	return nil
 }
 
-func (m *Manager) updateVersionInFile(projectFile ProjectFile, newVersion string) error {
-	content, err := os.ReadFile(projectFile.Path)
-	if err != nil {
-		return err
+// WrittenFiles lists the files a release may write through this manager: the version
+// files, uv.lock beside a pyproject.toml and the CMake version header
+func (m *Manager) WrittenFiles() []string {
+	var paths []string
+	for _, file := range m.ProjectFiles {
+		paths = append(paths, file.Path)
+		if file.Type == Python && !isSetuptoolsFile(file.Path) {
+			paths = append(paths, filepath.Join(filepath.Dir(file.Path), "uv.lock"))
+		}
+	}
+	if m.cmake.Header != "" {
+		paths = append(paths, m.cmake.Header)
 	}
+	return paths
+}
 
-	var updatedContent string
-
-	switch projectFile.Type {
-	case Go:
-		return m.updateGoVersion(newVersion)
-	case Rust:
-		updatedContent = m.updateCargoVersion(string(content), newVersion)
-	case Python:
-		updatedContent = m.updatePyprojectVersion(string(content), newVersion)
-	case Cpp:
-		updatedContent, err = m.updateCMakeVersion(string(content), newVersion)
-	case PlatformIO:
-		if strings.HasSuffix(projectFile.Path, ".ini") {
-			updatedContent = m.updatePlatformIOIniVersion(string(content), newVersion)
-		} else if strings.HasSuffix(projectFile.Path, ".json") {
-			updatedContent, err = m.updateLibraryJsonVersion(string(content), newVersion)
-		} else if strings.HasSuffix(projectFile.Path, ".properties") {
-			updatedContent = m.updateLibraryPropertiesVersion(string(content), newVersion)
-		}
-	default:
+func (m *Manager) updateVersionInFile(projectFile ProjectFile, newVersion string) error {
+	entry, ok := handlerFor(projectFile.Type)
+	if !ok {
 		return fmt.Errorf("unsupported project type: %s", projectFile.Type)
 	}
 
+	content, err := os.ReadFile(projectFile.Path)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(projectFile.Path, []byte(updatedContent), 0644)
-}
+	updatedContent, err := entry.handler.Update(projectFile.Path, string(content), newVersion)
+	if err != nil {
+		return err
+	}
+	if updatedContent == string(content) {
+		return nil
+	}
 
-func (m *Manager) updateGoVersion(newVersion string) error {
-	// For Go projects, we create a git tag instead of modifying files
-	// The go.mod file doesn't contain version information
-	// The actual git tag creation is handled by the git manager
+	if err := os.WriteFile(projectFile.Path, []byte(updatedContent), 0644); err != nil {
+		return err
+	}
+	// uv.lock, listed by WrittenFiles, follows the pyproject.toml it locks
+	if projectFile.Type == Python && !isSetuptoolsFile(projectFile.Path) {
+		return refreshUvLock(projectFile.Path, updatedContent, newVersion)
+	}
 	return nil
 }
 
-func (m *Manager) updateCargoVersion(content, newVersion string) string {
+func updateCargoVersion(content, newVersion string) string {
 	re := regexp.MustCompile(`(\[package\][\s\S]*?version\s*=\s*")([^"]+)(")`)
 	return re.ReplaceAllString(content, "${1}"+newVersion+"${3}")
 }
 
-func (m *Manager) updatePyprojectVersion(content, newVersion string) string {
-	re := regexp.MustCompile(`(\[tool\.poetry\][\s\S]*?version\s*=\s*")([^"]+)(")`)
-	return re.ReplaceAllString(content, "${1}"+newVersion+"${3}")
-}
-
-func (m *Manager) updateCMakeVersion(content, newVersion string) (string, error) {
+func updateCMakeVersion(content, newVersion string) (string, error) {
 	parts := strings.Split(newVersion, ".")
 	if len(parts) != 3 {
 		return "", fmt.Errorf("invalid version format: %s", newVersion)
@@ -465,12 +762,12 @@ func (m *Manager) updateCMakeVersion(content, newVersion string) (string, error)
 	return content, nil
 }
 
-func (m *Manager) updatePlatformIOIniVersion(content, newVersion string) string {
-	re := regexp.MustCompile(`(version\s*=\s*)([^\r\n]+)`)
+func updatePlatformIOIniVersion(content, newVersion string) string {
+	re := regexp.MustCompile(`(?m)^([ \t]*version[ \t]*=[ \t]*)([^\r\n]+)`)
 	return re.ReplaceAllString(content, "${1}\""+newVersion+"\"")
 }
 
-func (m *Manager) updateLibraryJsonVersion(content, newVersion string) (string, error) {
+func updateLibraryJsonVersion(content, newVersion string) (string, error) {
 	var config map[string]interface{}
 	err := json.Unmarshal([]byte(content), &config)
 	if err != nil {
@@ -487,7 +784,7 @@ func (m *Manager) updateLibraryJsonVersion(content, newVersion string) (string,
 	return string(updatedBytes), nil
 }
 
-func (m *Manager) updateLibraryPropertiesVersion(content, newVersion string) string {
-	re := regexp.MustCompile(`(version\s*=\s*)([^\r\n]+)`)
+func updateLibraryPropertiesVersion(content, newVersion string) string {
+	re := regexp.MustCompile(`(?m)^([ \t]*version[ \t]*=[ \t]*)([^\r\n]+)`)
 	return re.ReplaceAllString(content, "${1}"+newVersion)
 }
@@ -0,0 +1,296 @@
+package version
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bump-tui/internal/config"
+	"bump-tui/internal/gitfixture"
+	"github.com/Masterminds/semver/v3"
+)
+
+func TestExtractVersionMisparses(t *testing.T) {
+	tests := []struct {
+		name      string
+		extract   func(string) (*semver.Version, error)
+		content   string
+		expected  string
+		expectErr bool
+	}{
+		{"cmake project", extractCMakeVersion, "project(app VERSION 1.2.3 LANGUAGES CXX)\n", "1.2.3", false},
+		{"cmake tweak component", extractCMakeVersion, "project(app VERSION 1.2.3.4)\n", "", true},
+		{"cmake set", extractCMakeVersion, "set(PROJECT_VERSION 2.0.1)\n", "2.0.1", false},
+		{"ini key", extractPlatformIOIniVersion, "[env]\nplatform_version = 9.9.9\nversion = \"1.4.0\"\n", "1.4.0", false},
+		{"ini value mentioning a version", extractPlatformIOIniVersion, "build_flags = -D version=2.0.0\n", "", true},
+		{"properties key", extractLibraryPropertiesVersion, "name=App\nsentence=Needs core version=3.0.0\nversion=1.0.2\r\n", "1.0.2", false},
+		{"properties trailing text", extractLibraryPropertiesVersion, "version=1.0.2 beta\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.extract(tt.content)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %s", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if v.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestUpdateLibraryPropertiesVersionOnlyKey(t *testing.T) {
+	content := "sentence=Needs core version=3.0.0\nversion=1.0.2\n"
+	expected := "sentence=Needs core version=3.0.0\nversion=1.1.0\n"
+	if got := updateLibraryPropertiesVersion(content, "1.1.0"); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// fuzzRoundTrip checks that a version read from content is replaced by update,
+// so the next release reads back what the last one wrote
+func fuzzRoundTrip(t *testing.T, content string, extract func(string) (*semver.Version, error), update func(string, string) (string, error)) {
+	current, err := extract(content)
+	if err != nil {
+		return
+	}
+
+	next := current.IncMinor()
+	updated, err := update(content, next.String())
+	if err != nil {
+		t.Fatalf("Expected %s to update, got %v", next.String(), err)
+	}
+	got, err := extract(updated)
+	if err != nil {
+		t.Fatalf("Expected the updated content to have a version, got %v\n%q", err, updated)
+	}
+	if !got.Equal(&next) {
+		t.Errorf("Expected %s after the update, got %s\n%q", next.String(), got, updated)
+	}
+}
+
+func FuzzCMakeVersion(f *testing.F) {
+	f.Add("cmake_minimum_required(VERSION 3.16)\nproject(app VERSION 1.2.3 LANGUAGES CXX)\n")
+	f.Add("project(${PROJECT_NAME}\n  VERSION 0.9.12\n)\n")
+	f.Add("set(CMAKE_PROJECT_VERSION 2.0.1)\nproject(app VERSION 1.2.3.4)\n")
+	f.Add("project(app)\nset(PROJECT_VERSION 01.2.3)\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		fuzzRoundTrip(t, content, extractCMakeVersion, updateCMakeVersion)
+	})
+}
+
+func FuzzPlatformIOIniVersion(f *testing.F) {
+	f.Add("[env:esp32]\nplatform = espressif32\nversion = \"1.2.3\"\n")
+	f.Add("version = 1.2.3\r\nbuild_flags = -D version=2.0.0\n")
+	f.Add("  version='0.1.0'  \n[env]\nversion = 0.1.0\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		fuzzRoundTrip(t, content, extractPlatformIOIniVersion, func(content, newVersion string) (string, error) {
+			return updatePlatformIOIniVersion(content, newVersion), nil
+		})
+	})
+}
+
+func FuzzLibraryPropertiesVersion(f *testing.F) {
+	f.Add("name=App\nversion=1.0.2\nauthor=Jane\n")
+	f.Add("sentence=Needs core version=3.0.0\r\nversion = 1.0.2\r\n")
+	f.Add("version=1.0\nversion=1.0.0\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		fuzzRoundTrip(t, content, extractLibraryPropertiesVersion, func(content, newVersion string) (string, error) {
+			return updateLibraryPropertiesVersion(content, newVersion), nil
+		})
+	})
+}
+
+func TestPackageTagPrefix(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"Cargo.toml", "v"},
+		{"packages/api/Cargo.toml", "packages/api/v"},
+		{"services/web/app/package.json", "services/web/app/v"},
+	}
+
+	for _, tt := range tests {
+		if prefix := PackageTagPrefix(ProjectFile{Path: tt.path}); prefix != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.path, tt.expected, prefix)
+		}
+	}
+}
+
+func TestPackagePathspecs(t *testing.T) {
+	dirs := []string{".", "packages/api", "packages/api/client", "packages/web"}
+	tests := []struct {
+		dir      string
+		expected []string
+	}{
+		{".", []string{".", ":(exclude)packages/api", ":(exclude)packages/api/client", ":(exclude)packages/web"}},
+		{"packages/api", []string{"packages/api", ":(exclude)packages/api/client"}},
+		{"packages/web", []string{"packages/web"}},
+	}
+
+	for _, tt := range tests {
+		if pathspecs := packagePathspecs(tt.dir, dirs); !reflect.DeepEqual(pathspecs, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.dir, tt.expected, pathspecs)
+		}
+	}
+}
+
+func TestPackageVersion(t *testing.T) {
+	api := ProjectFile{Path: "packages/api/Cargo.toml", Type: Rust}
+	root := ProjectFile{Path: "Cargo.toml", Type: Rust}
+	manager := NewManager()
+	manager.ProjectFiles = []ProjectFile{root, api}
+	if err := manager.SelectChangedPackages([]PackageChange{
+		{File: root, Version: semver.MustParse("2.0.0"), Commits: 1},
+		{File: api, Version: semver.MustParse("0.3.0"), Commits: 2},
+		{File: ProjectFile{Path: "packages/web/Cargo.toml"}, Version: semver.MustParse("5.0.0")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if manager.CurrentVersion.String() != "2.0.0" || len(manager.ProjectFiles) != 2 {
+		t.Fatalf("Expected the changed packages from 2.0.0, got %s %+v", manager.CurrentVersion, manager.ProjectFiles)
+	}
+
+	tests := []struct {
+		release  string
+		root     string
+		api      string
+		expected []string
+	}{
+		{"3.0.0", "3.0.0", "1.0.0", []string{"packages/api/v1.0.0"}},
+		{"2.1.0", "2.1.0", "0.4.0", []string{"packages/api/v0.4.0"}},
+		{"2.0.1", "2.0.1", "0.3.1", []string{"packages/api/v0.3.1"}},
+		{"2.1.0-rc.1", "2.1.0-rc.1", "2.1.0-rc.1", []string{"packages/api/v2.1.0-rc.1"}},
+		{"4.2.0", "4.2.0", "4.2.0", []string{"packages/api/v4.2.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			if got := manager.PackageVersion(root, tt.release); got != tt.root {
+				t.Errorf("Expected the root at %s, got %s", tt.root, got)
+			}
+			if got := manager.PackageVersion(api, tt.release); got != tt.api {
+				t.Errorf("Expected the api package at %s, got %s", tt.api, got)
+			}
+			if tags := manager.PackageTags(tt.release); !reflect.DeepEqual(tags, tt.expected) {
+				t.Errorf("Expected tags %v, got %v", tt.expected, tags)
+			}
+		})
+	}
+
+	if err := manager.SelectChangedPackages([]PackageChange{{File: root, Version: semver.MustParse("2.0.0")}}); err == nil {
+		t.Errorf("Expected an error when no package changed")
+	}
+}
+
+func TestDetectChangedPackages(t *testing.T) {
+	repo := gitfixture.New(t)
+	cargo := func(version string) string {
+		return "[package]\nname = \"app\"\nversion = \"" + version + "\"\n"
+	}
+	repo.WriteFile("Cargo.toml", cargo("2.0.0"))
+	repo.WriteFile("packages/api/Cargo.toml", cargo("0.3.0"))
+	repo.WriteFile("packages/web/Cargo.toml", cargo("1.0.0"))
+	repo.Commit("chore: initial commit")
+	repo.Tag("2.0.0")
+	repo.Git("tag", "packages/api/v0.3.0")
+	repo.WriteFile("packages/api/src/lib.rs", "pub fn api() {}\n")
+	repo.Commit("feat: add api")
+	repo.Chdir()
+
+	manager := NewManager()
+	manager.ProjectFiles = []ProjectFile{
+		{Path: "Cargo.toml", Type: Rust},
+		{Path: "packages/api/Cargo.toml", Type: Rust},
+		{Path: "packages/web/Cargo.toml", Type: Rust},
+	}
+	changes, err := manager.DetectChangedPackages()
+	if err != nil {
+		t.Fatalf("DetectChangedPackages failed: %v", err)
+	}
+
+	expected := []struct {
+		lastTag string
+		commits int
+		version string
+	}{
+		// The api commit is outside the root package
+		{"v2.0.0", 0, "2.0.0"},
+		{"packages/api/v0.3.0", 1, "0.3.0"},
+		// Never tagged: every commit touching it counts
+		{"", 1, "1.0.0"},
+	}
+	for i, change := range changes {
+		if change.LastTag != expected[i].lastTag || change.Commits != expected[i].commits || change.Version.String() != expected[i].version {
+			t.Errorf("%s: expected %+v, got %s with %d commits at %s",
+				change.File.Path, expected[i], change.LastTag, change.Commits, change.Version)
+		}
+	}
+
+	// Only the api package is released, from its own version
+	if err := manager.SelectChangedPackages(changes[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.UpdateAllVersions(manager.BumpMinor().String()); err != nil {
+		t.Fatalf("UpdateAllVersions failed: %v", err)
+	}
+	if content := repo.ReadFile("packages/api/Cargo.toml"); !strings.Contains(content, `version = "0.4.0"`) {
+		t.Errorf("Expected the api package at 0.4.0, got:\n%s", content)
+	}
+	if content := repo.ReadFile("Cargo.toml"); !strings.Contains(content, `version = "2.0.0"`) {
+		t.Errorf("Expected the unchanged root to be left alone, got:\n%s", content)
+	}
+	if tags := manager.PackageTags("0.4.0"); !reflect.DeepEqual(tags, []string{"packages/api/v0.4.0"}) {
+		t.Errorf("Expected the api package tag, got %v", tags)
+	}
+}
+
+func TestDetectNestedVersionFiles(t *testing.T) {
+	repo := gitfixture.New(t)
+	repo.WriteFile("Cargo.toml", "[package]\nname = \"app\"\nversion = \"1.2.0\"\n")
+	repo.WriteFile("crates/core/Cargo.toml", "[package]\nname = \"core\"\nversion = \"1.2.0\"\n")
+	// A private example without a version isn't a version file
+	repo.WriteFile("examples/demo/Cargo.toml", "[package]\nname = \"demo\"\npublish = false\n")
+	// Deeper than max_depth
+	repo.WriteFile("a/b/c/Cargo.toml", "[package]\nname = \"deep\"\nversion = \"9.0.0\"\n")
+	// Ignored by git
+	repo.WriteFile(".gitignore", "vendor/\n")
+	repo.WriteFile("vendor/dep/Cargo.toml", "[package]\nname = \"dep\"\nversion = \"0.1.0\"\n")
+	repo.Commit("chore: initial commit")
+	repo.Chdir()
+
+	settings := config.DefaultSettings()
+	settings.Detection.Recursive = true
+	settings.Detection.MaxDepth = 2
+	manager := NewManager()
+	manager.SetSettings(settings)
+	if err := manager.DetectVersionFiles("."); err != nil {
+		t.Fatalf("DetectVersionFiles failed: %v", err)
+	}
+
+	var paths []string
+	for _, file := range manager.ProjectFiles {
+		paths = append(paths, filepath.ToSlash(file.Path))
+	}
+	if expected := []string{"Cargo.toml", "crates/core/Cargo.toml"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+	if manager.CurrentVersion.String() != "1.2.0" {
+		t.Errorf("Expected 1.2.0 from the root, got %s", manager.CurrentVersion)
+	}
+	if result := manager.ValidateVersionSync(); !result.Success || len(result.Warnings) > 0 {
+		t.Errorf("Expected the version files in sync, got %+v", result)
+	}
+}
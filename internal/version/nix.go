@@ -0,0 +1,126 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// nixVersionRe matches a version attribute, e.g. version = "1.2.0";
+	nixVersionRe = regexp.MustCompile(`(?m)^(\s*version\s*=\s*")([^"]+)(")`)
+	// nixHashRe matches the fixed-output dependency hashes of Go and Rust derivations
+	nixHashRe = regexp.MustCompile(`((?:vendorHash|cargoHash)\s*=\s*)("[^"]*"|(?:pkgs\.)?lib\.fakeHash)`)
+	// nixGotHashRe finds the real hash in a hash mismatch error
+	nixGotHashRe = regexp.MustCompile(`got:\s+(sha256-[A-Za-z0-9+/=]+)`)
+)
+
+func extractNixVersion(filePath, content string) (*semver.Version, error) {
+	matches := nixVersionRe.FindStringSubmatch(content)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("no version attribute found in %s", filePath)
+	}
+	return semver.NewVersion(matches[2])
+}
+
+// updateNixVersion replaces every version attribute holding the current version, so
+// versions of pinned dependencies in the same file are left alone
+func updateNixVersion(filePath, content, newVersion string) (string, error) {
+	current := nixVersionRe.FindStringSubmatch(content)
+	if len(current) < 3 {
+		return "", fmt.Errorf("no version attribute found in %s", filePath)
+	}
+
+	return nixVersionRe.ReplaceAllStringFunc(content, func(match string) string {
+		parts := nixVersionRe.FindStringSubmatch(match)
+		if parts[2] != current[2] {
+			return match
+		}
+		return parts[1] + newVersion + parts[3]
+	}), nil
+}
+
+// UpdateNixHashes recomputes the vendorHash or cargoHash of the managed Nix files:
+// the hash is cleared, the package is built and the hash Nix reports is written back.
+// Files without either hash are skipped. A file with several hashes, e.g. of two
+// derivations, is refused, since the one hash Nix reports can't be told apart.
+func (m *Manager) UpdateNixHashes() error {
+	for _, file := range m.ProjectFiles {
+		if file.Type != Nix {
+			continue
+		}
+
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return err
+		}
+		found, err := nixHashAttribute(file.Path, string(content))
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		cleared := nixHashRe.ReplaceAllString(string(content), `${1}""`)
+		if err := os.WriteFile(file.Path, []byte(cleared), 0644); err != nil {
+			return err
+		}
+
+		hash, err := nixBuildHash(file.Path)
+		if err != nil {
+			// Put the old hash back so a failed build doesn't leave the file broken
+			if restoreErr := os.WriteFile(file.Path, content, 0644); restoreErr != nil {
+				return fmt.Errorf("%v; restoring %s also failed: %v", err, file.Path, restoreErr)
+			}
+			return err
+		}
+
+		updated := nixHashRe.ReplaceAllString(string(content), `${1}"`+hash+`"`)
+		if err := os.WriteFile(file.Path, []byte(updated), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nixHashAttribute reports whether a Nix file has a dependency hash to recompute,
+// failing when it has more than one
+func nixHashAttribute(filePath, content string) (bool, error) {
+	hashes := nixHashRe.FindAllString(content, -1)
+	if len(hashes) > 1 {
+		return false, fmt.Errorf("%s has %d dependency hashes; nix.update_hashes recomputes a single one, update them by hand", filePath, len(hashes))
+	}
+	return len(hashes) == 1, nil
+}
+
+// nixBuildHash builds the package of a Nix file with an empty dependency hash and
+// returns the hash from the resulting mismatch error
+func nixBuildHash(filePath string) (string, error) {
+	var cmd *exec.Cmd
+	if filepath.Base(filePath) == "flake.nix" {
+		cmd = exec.Command("nix", "build", "--no-link", ".")
+	} else {
+		cmd = exec.Command("nix-build", "--no-out-link", filepath.Base(filePath))
+	}
+	cmd.Dir = filepath.Dir(filePath)
+
+	output, err := cmd.CombinedOutput()
+	if matches := nixGotHashRe.FindSubmatch(output); len(matches) >= 2 {
+		return string(matches[1]), nil
+	}
+	if err == nil {
+		return "", fmt.Errorf("building %s with an empty hash succeeded, so the new hash is unknown", filePath)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) > 5 {
+		lines = lines[len(lines)-5:]
+	}
+	return "", fmt.Errorf("unable to recompute the dependency hash of %s: %v\n%s", filePath, err, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,92 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpdateNixVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "flake package",
+			content:  "{\n  packages.default = buildGoModule {\n    pname = \"app\";\n    version = \"1.2.0\";\n  };\n}\n",
+			expected: "{\n  packages.default = buildGoModule {\n    pname = \"app\";\n    version = \"1.3.0\";\n  };\n}\n",
+		},
+		{
+			name:     "pinned dependency left alone",
+			content:  "version = \"1.2.0\";\ndep = fetchurl {\n  version = \"0.9.1\";\n};\ndocs = {\n  version = \"1.2.0\";\n};\n",
+			expected: "version = \"1.3.0\";\ndep = fetchurl {\n  version = \"0.9.1\";\n};\ndocs = {\n  version = \"1.3.0\";\n};\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractNixVersion("flake.nix", tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated, err := updateNixVersion("flake.nix", tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+
+	if _, err := updateNixVersion("default.nix", "{ pname = \"app\"; }\n", "1.3.0"); err == nil {
+		t.Errorf("Expected an error without a version attribute")
+	}
+}
+
+func TestNixHashAttribute(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		found     bool
+		expectErr bool
+	}{
+		{"vendor hash", `vendorHash = "sha256-abc=";`, true, false},
+		{"cargo fake hash", `cargoHash = lib.fakeHash;`, true, false},
+		{"pkgs fake hash", `vendorHash = pkgs.lib.fakeHash;`, true, false},
+		{"empty hash", `vendorHash = "";`, true, false},
+		{"none", `src = ./.;`, false, false},
+		{"two derivations", "cli = buildGoModule { vendorHash = \"sha256-a=\"; };\nsrv = buildRustPackage { cargoHash = \"sha256-b=\"; };", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := nixHashAttribute("flake.nix", tt.content)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+				return
+			}
+			if err != nil || found != tt.found {
+				t.Errorf("Expected found=%v, got %v (%v)", tt.found, found, err)
+			}
+			if found {
+				cleared := nixHashRe.ReplaceAllString(tt.content, `${1}""`)
+				if !strings.HasSuffix(strings.TrimSuffix(cleared, ";"), `= ""`) {
+					t.Errorf("Expected the hash cleared, got %q", cleared)
+				}
+			}
+		})
+	}
+}
+
+func TestNixGotHash(t *testing.T) {
+	output := `error: hash mismatch in fixed-output derivation '/nix/store/abc-app-1.3.0-go-modules.drv':
+         specified: sha256-AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=
+            got:    sha256-3mE5x0pVw1GZkQJ+FhZ/9b0WnHq1yqv6b1YzYtQeR0A=`
+	matches := nixGotHashRe.FindStringSubmatch(output)
+	if len(matches) < 2 || matches[1] != "sha256-3mE5x0pVw1GZkQJ+FhZ/9b0WnHq1yqv6b1YzYtQeR0A=" {
+		t.Errorf("Expected the got hash, got %v", matches)
+	}
+}
@@ -0,0 +1,172 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+)
+
+var (
+	// tomlSectionRe matches a TOML table header or an array of tables header
+	tomlSectionRe = regexp.MustCompile(`^\s*(\[\[?)([^\[\]]+)\]`)
+	// tomlVersionRe matches a string version key, keeping its quotes
+	tomlVersionRe = regexp.MustCompile(`^(\s*version\s*=\s*)(["'])([^"']*)(["'])`)
+	// pythonNameSeparatorRe matches the runs of separators PEP 503 normalizes to "-"
+	pythonNameSeparatorRe = regexp.MustCompile(`[-_.]+`)
+)
+
+// pyprojectVersionSections are the tables a pyproject.toml can hold a static version in:
+// PEP 621 metadata, used by uv, PDM, Hatch and Poetry 2, Poetry's own table and the
+// table of PDM releases before PEP 621
+var pyprojectVersionSections = []string{"tool.poetry", "project", "tool.pdm"}
+
+func extractPyprojectVersion(filePath, content string) (*semver.Version, error) {
+	var config struct {
+		Project struct {
+			Version string `toml:"version"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Version string `toml:"version"`
+			} `toml:"poetry"`
+			PDM struct {
+				// Version is a table for dynamic versions, e.g. { source = "scm" }
+				Version any `toml:"version"`
+			} `toml:"pdm"`
+		} `toml:"tool"`
+	}
+
+	err := toml.Unmarshal([]byte(content), &config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Tool.Poetry.Version != "" {
+		return semver.NewVersion(config.Tool.Poetry.Version)
+	}
+	if config.Project.Version != "" {
+		return semver.NewVersion(config.Project.Version)
+	}
+	if version, ok := config.Tool.PDM.Version.(string); ok && version != "" {
+		return semver.NewVersion(version)
+	}
+	return nil, fmt.Errorf("no version found in %s", filePath)
+}
+
+// updatePyprojectVersion replaces the static version in every table that has one. The
+// uv.lock next to the file is refreshed by the manager once the file is written, see
+// refreshUvLock.
+func updatePyprojectVersion(filePath, content, newVersion string) (string, error) {
+	lines := strings.Split(content, "\n")
+	section := ""
+	updated := false
+	for i, line := range lines {
+		if matches := tomlSectionRe.FindStringSubmatch(line); len(matches) >= 3 {
+			// Arrays of tables never hold the project version
+			section = ""
+			if matches[1] == "[" {
+				section = strings.TrimSpace(matches[2])
+			}
+			continue
+		}
+		if isPyprojectVersionSection(section) && tomlVersionRe.MatchString(line) {
+			lines[i] = tomlVersionRe.ReplaceAllString(line, "${1}${2}"+newVersion+"${4}")
+			updated = true
+		}
+	}
+	if !updated {
+		return "", fmt.Errorf("no version found in %s", filePath)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// refreshUvLock refreshes the project's own entry in a uv.lock next to a pyproject.toml
+// after its version was written. Projects without a PEP 621 name are skipped.
+func refreshUvLock(pyprojectPath, content, newVersion string) error {
+	var config struct {
+		Project struct {
+			Name string `toml:"name"`
+		} `toml:"project"`
+	}
+	if err := toml.Unmarshal([]byte(content), &config); err != nil || config.Project.Name == "" {
+		return nil
+	}
+	return updateUvLock(filepath.Join(filepath.Dir(pyprojectPath), "uv.lock"), config.Project.Name, newVersion)
+}
+
+func isPyprojectVersionSection(section string) bool {
+	for _, s := range pyprojectVersionSections {
+		if section == s {
+			return true
+		}
+	}
+	return false
+}
+
+// updateUvLock sets the version of the project's own package in uv.lock, the editable
+// or virtual package with the project's name, as `uv lock` would. A missing lock file
+// is skipped.
+func updateUvLock(lockPath, name, newVersion string) error {
+	content, err := os.ReadFile(lockPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	normalized := normalizePythonName(name)
+	lines := strings.Split(string(content), "\n")
+	for start := 0; start < len(lines); start++ {
+		if strings.TrimSpace(lines[start]) != "[[package]]" {
+			continue
+		}
+		end := start + 1
+		for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), "[") {
+			end++
+		}
+		if updateUvLockPackage(lines[start+1:end], normalized, newVersion) {
+			return os.WriteFile(lockPath, []byte(strings.Join(lines, "\n")), 0644)
+		}
+	}
+	return fmt.Errorf("%s has no entry for %s; run uv lock", lockPath, name)
+}
+
+// updateUvLockPackage updates the version of one [[package]] block if it is the
+// project itself, reporting whether it was
+func updateUvLockPackage(block []string, name, newVersion string) bool {
+	isProject := false
+	versionLine := -1
+	for i, line := range block {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "name":
+			if normalizePythonName(strings.Trim(value, `"`)) != name {
+				return false
+			}
+		case "version":
+			versionLine = i
+		case "source":
+			isProject = strings.Contains(value, `editable = "."`) || strings.Contains(value, `virtual = "."`)
+		}
+	}
+	if !isProject || versionLine < 0 {
+		return false
+	}
+	block[versionLine] = tomlVersionRe.ReplaceAllString(block[versionLine], "${1}${2}"+newVersion+"${4}")
+	return true
+}
+
+// normalizePythonName normalizes a distribution name as PEP 503 does, e.g. My_Pkg to my-pkg
+func normalizePythonName(name string) string {
+	return pythonNameSeparatorRe.ReplaceAllString(strings.ToLower(name), "-")
+}
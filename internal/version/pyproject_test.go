@@ -0,0 +1,129 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPyprojectVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "pep 621",
+			content:  "[project]\nname = \"app\"\nversion = \"1.2.0\"\n\n[tool.ruff]\nversion = \"0.4.0\"\n",
+			expected: "[project]\nname = \"app\"\nversion = \"1.3.0\"\n\n[tool.ruff]\nversion = \"0.4.0\"\n",
+		},
+		{
+			name:     "poetry with single quotes",
+			content:  "[tool.poetry]\nname = 'app'\nversion = '1.2.0'\n\n[tool.poetry.dependencies]\nversion = '9.9.9'\n",
+			expected: "[tool.poetry]\nname = 'app'\nversion = '1.3.0'\n\n[tool.poetry.dependencies]\nversion = '9.9.9'\n",
+		},
+		{
+			name:     "pdm static",
+			content:  "[tool.pdm]\nversion = \"1.2.0\"\n",
+			expected: "[tool.pdm]\nversion = \"1.3.0\"\n",
+		},
+		{
+			name:     "array of tables doesn't end the section",
+			content:  "[project]\nversion = \"1.2.0\"\n[[tool.mypy.overrides]]\nversion = \"3.0.0\"\n",
+			expected: "[project]\nversion = \"1.3.0\"\n[[tool.mypy.overrides]]\nversion = \"3.0.0\"\n",
+		},
+		{
+			name:      "pdm dynamic version",
+			content:   "[project]\nname = \"app\"\ndynamic = [\"version\"]\n\n[tool.pdm]\nversion = { source = \"scm\" }\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractPyprojectVersion("pyproject.toml", tt.content)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected no static version, got %s", version)
+				}
+				if _, err := updatePyprojectVersion("pyproject.toml", tt.content, "1.3.0"); err == nil {
+					t.Errorf("Expected the update to fail")
+				}
+				return
+			}
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+
+			updated, err := updatePyprojectVersion("pyproject.toml", tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestUpdateUvLockPackage(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   string
+		updated bool
+	}{
+		{"editable", "name = \"my-app\"\nversion = \"1.2.0\"\nsource = { editable = \".\" }", true},
+		{"virtual", "name = \"my-app\"\nversion = \"1.2.0\"\nsource = { virtual = \".\" }", true},
+		{"normalized name", "name = \"My_App\"\nversion = \"1.2.0\"\nsource = { editable = \".\" }", true},
+		{"registry package of the same name", "name = \"my-app\"\nversion = \"1.2.0\"\nsource = { registry = \"https://pypi.org/simple\" }", false},
+		{"workspace member", "name = \"my-app\"\nversion = \"1.2.0\"\nsource = { editable = \"packages/app\" }", false},
+		{"other package", "name = \"requests\"\nversion = \"1.2.0\"\nsource = { editable = \".\" }", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := strings.Split(tt.block, "\n")
+			if updated := updateUvLockPackage(block, "my-app", "1.3.0"); updated != tt.updated {
+				t.Fatalf("Expected updated=%v, got %v", tt.updated, updated)
+			}
+			if tt.updated && block[1] != "version = \"1.3.0\"" {
+				t.Errorf("Expected the version line updated, got %q", block[1])
+			}
+		})
+	}
+}
+
+func TestUvLockWrittenWithPyproject(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := filepath.Join(dir, "pyproject.toml")
+	lock := filepath.Join(dir, "uv.lock")
+	content := "[project]\nname = \"my_app\"\nversion = \"1.2.0\"\n"
+	lockContent := "version = 1\n\n[[package]]\nname = \"idna\"\nversion = \"1.2.0\"\nsource = { registry = \"https://pypi.org/simple\" }\n\n" +
+		"[[package]]\nname = \"my-app\"\nversion = \"1.2.0\"\nsource = { editable = \".\" }\n"
+	for path, data := range map[string]string{pyproject: content, lock: lockContent} {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Computing the new content leaves the lock alone
+	if _, err := updatePyprojectVersion(pyproject, content, "1.3.0"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(lock); string(data) != lockContent {
+		t.Errorf("Expected uv.lock untouched by the content update, got:\n%s", data)
+	}
+
+	manager := NewManager()
+	manager.ProjectFiles = []ProjectFile{{Path: pyproject, Type: Python}}
+	if err := manager.UpdateAllVersions("1.3.0"); err != nil {
+		t.Fatalf("UpdateAllVersions failed: %v", err)
+	}
+	data, _ := os.ReadFile(lock)
+	expected := strings.Replace(lockContent, "name = \"my-app\"\nversion = \"1.2.0\"", "name = \"my-app\"\nversion = \"1.3.0\"", 1)
+	if string(data) != expected {
+		t.Errorf("Expected the project's lock entry at 1.3.0, got:\n%s", data)
+	}
+}
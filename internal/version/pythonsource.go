@@ -0,0 +1,43 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// pythonVersionRe matches a module-level __version__ assignment, e.g.
+	// __version__ = "1.2.3" or __version__: str = '1.2.3'
+	pythonVersionRe = regexp.MustCompile(`(?m)^(__version__\s*(?::\s*str\s*)?=\s*)(["'])([^"']+)(["'])`)
+	// pythonVersionInfoRe matches a __version_info__ tuple of integers, e.g. (1, 2, 3)
+	pythonVersionInfoRe = regexp.MustCompile(`(?m)^(__version_info__\s*(?::[^=\n]+)?=\s*\()\s*\d+\s*,\s*\d+\s*,\s*\d+\s*(\))`)
+)
+
+func extractPythonSourceVersion(filePath, content string) (*semver.Version, error) {
+	matches := pythonVersionRe.FindStringSubmatch(content)
+	if len(matches) < 4 {
+		return nil, fmt.Errorf("no __version__ found in %s", filePath)
+	}
+	return semver.NewVersion(matches[3])
+}
+
+// updatePythonSourceVersion replaces __version__, keeping its quote style, and a
+// __version_info__ tuple if the module has one
+func updatePythonSourceVersion(filePath, content, newVersion string) (string, error) {
+	if !pythonVersionRe.MatchString(content) {
+		return "", fmt.Errorf("no __version__ found in %s", filePath)
+	}
+	content = pythonVersionRe.ReplaceAllString(content, "${1}${2}"+newVersion+"${4}")
+
+	parsed, err := semver.NewVersion(newVersion)
+	if err != nil {
+		return "", err
+	}
+	info := strings.Join([]string{
+		fmt.Sprint(parsed.Major()), fmt.Sprint(parsed.Minor()), fmt.Sprint(parsed.Patch()),
+	}, ", ")
+	return pythonVersionInfoRe.ReplaceAllString(content, "${1}"+info+"${2}"), nil
+}
@@ -0,0 +1,68 @@
+package version
+
+import "testing"
+
+func TestPythonSourceVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "double quotes",
+			content:  "\"\"\"My package.\"\"\"\n\n__version__ = \"1.2.0\"\n",
+			expected: "\"\"\"My package.\"\"\"\n\n__version__ = \"1.3.0\"\n",
+		},
+		{
+			name:     "annotated with single quotes",
+			content:  "__version__: str = '1.2.0'\n",
+			expected: "__version__: str = '1.3.0'\n",
+		},
+		{
+			name:     "version info tuple",
+			content:  "__version__ = \"1.2.0\"\n__version_info__ = (1, 2, 0)\n",
+			expected: "__version__ = \"1.3.0\"\n__version_info__ = (1, 3, 0)\n",
+		},
+		{
+			name:     "annotated version info tuple",
+			content:  "__version__ = \"1.2.0\"\n__version_info__: tuple[int, int, int] = (1,2,0)\n",
+			expected: "__version__ = \"1.3.0\"\n__version_info__: tuple[int, int, int] = (1, 3, 0)\n",
+		},
+		{
+			name:     "derived version info left alone",
+			content:  "__version__ = \"1.2.0\"\n__version_info__ = tuple(int(p) for p in __version__.split(\".\"))\n",
+			expected: "__version__ = \"1.3.0\"\n__version_info__ = tuple(int(p) for p in __version__.split(\".\"))\n",
+		},
+		{
+			name:     "indented assignments left alone",
+			content:  "__version__ = \"1.2.0\"\n\ndef legacy():\n    __version__ = \"0.1.0\"\n",
+			expected: "__version__ = \"1.3.0\"\n\ndef legacy():\n    __version__ = \"0.1.0\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractPythonSourceVersion("src/pkg/__init__.py", tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated, err := updatePythonSourceVersion("src/pkg/__init__.py", tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestPythonSourceVersionMissing(t *testing.T) {
+	content := "from importlib.metadata import version\n\n__version__ = version(\"pkg\")\n"
+	if version, err := extractPythonSourceVersion("pkg/__init__.py", content); err == nil {
+		t.Errorf("Expected no literal version, got %s", version)
+	}
+	if _, err := updatePythonSourceVersion("pkg/__init__.py", content, "1.3.0"); err == nil {
+		t.Error("Expected the update to fail without a literal __version__")
+	}
+}
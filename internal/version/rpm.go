@@ -0,0 +1,41 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// rpmVersionRe matches the Version: tag of a spec file
+	rpmVersionRe = regexp.MustCompile(`(?m)^(Version:\s*)(\S+)`)
+	// rpmReleaseRe matches a numeric Release: tag, keeping a suffix such as %{?dist};
+	// %autorelease is left alone
+	rpmReleaseRe = regexp.MustCompile(`(?m)^(Release:\s*)(\d+)(.*)$`)
+)
+
+func extractSpecVersion(filePath, content string) (*semver.Version, error) {
+	matches := rpmVersionRe.FindStringSubmatch(content)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("no Version: tag found in %s", filePath)
+	}
+	return semver.NewVersion(matches[2])
+}
+
+// updateSpecVersion sets Version: and resets a numeric Release: to 1 for the new upstream version
+func updateSpecVersion(content, newVersion string) string {
+	content = rpmVersionRe.ReplaceAllString(content, "${1}"+newVersion)
+	return rpmReleaseRe.ReplaceAllString(content, "${1}1${3}")
+}
+
+// SpecFiles returns the managed RPM spec files
+func (m *Manager) SpecFiles() []string {
+	var paths []string
+	for _, file := range m.ProjectFiles {
+		if file.Type == RPM {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,112 @@
+package version
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"bump-tui/internal/git"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// rustVersionConstRe matches string constants named like a version, e.g.
+	// pub const VERSION: &str = "1.2.3";
+	rustVersionConstRe = regexp.MustCompile(`(?m)^(\s*(?:pub(?:\([^)]*\))?\s+)?(?:const|static)\s+[A-Z0-9_]*VERSION[A-Z0-9_]*\s*:\s*&(?:'static\s+)?str\s*=\s*")([^"]+)(")`)
+	// rustRuntimeVersionRe matches reading CARGO_PKG_VERSION from the environment at run time
+	rustRuntimeVersionRe = regexp.MustCompile(`env::var(?:_os)?\(\s*"CARGO_PKG_VERSION"\s*\)`)
+)
+
+func extractRustSourceVersion(filePath, content string) (*semver.Version, error) {
+	matches := rustVersionConstRe.FindStringSubmatch(content)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("no version constant found in %s", filePath)
+	}
+	return semver.NewVersion(matches[2])
+}
+
+// updateRustSourceVersion replaces every version constant holding the current version,
+// so constants for other versions, like a minimum supported protocol, are left alone
+func updateRustSourceVersion(filePath, content, newVersion string) (string, error) {
+	current := rustVersionConstRe.FindStringSubmatch(content)
+	if len(current) < 3 {
+		return "", fmt.Errorf("no version constant found in %s", filePath)
+	}
+
+	return rustVersionConstRe.ReplaceAllStringFunc(content, func(match string) string {
+		parts := rustVersionConstRe.FindStringSubmatch(match)
+		if parts[2] != current[2] {
+			return match
+		}
+		return parts[1] + newVersion + parts[3]
+	}), nil
+}
+
+// ValidateRustSources scans the src directory of every managed crate for versions the
+// release wouldn't update: version constants hardcoded in files not listed in .bump,
+// and CARGO_PKG_VERSION read with env::var, which Cargo only sets at compile time.
+// It returns nil when no Cargo.toml is managed.
+func (m *Manager) ValidateRustSources() *git.ValidationResult {
+	if !m.HasProjectType(Rust) {
+		return nil
+	}
+
+	result := &git.ValidationResult{
+		Step:    git.ValidationStep{Name: "rust_sources", Description: "Checking Rust sources for hardcoded versions..."},
+		Success: true,
+	}
+
+	managed := make(map[string]bool)
+	for _, file := range m.ProjectFiles {
+		if file.Type == RustSource {
+			managed[filepath.Clean(file.Path)] = true
+		}
+	}
+
+	for _, file := range m.ProjectFiles {
+		if file.Type != Rust {
+			continue
+		}
+		src := filepath.Join(filepath.Dir(file.Path), "src")
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".rs" || managed[filepath.Clean(path)] {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			result.Warnings = append(result.Warnings, rustSourceWarnings(path, string(content))...)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Unable to scan %s: %v", src, err))
+		}
+	}
+	return result
+}
+
+// rustSourceWarnings lists the hardcoded version constants and run-time
+// CARGO_PKG_VERSION reads of one source file
+func rustSourceWarnings(path, content string) []string {
+	var warnings []string
+	for i, line := range strings.Split(content, "\n") {
+		if matches := rustVersionConstRe.FindStringSubmatch(line); len(matches) >= 3 {
+			if _, err := semver.NewVersion(matches[2]); err == nil {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s:%d hardcodes version %s; use env!(\"CARGO_PKG_VERSION\") or list the file in .bump",
+					path, i+1, matches[2]))
+			}
+		}
+		if rustRuntimeVersionRe.MatchString(line) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s:%d reads CARGO_PKG_VERSION at run time, where it is unset; use env!(\"CARGO_PKG_VERSION\")",
+				path, i+1))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,75 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRustSourceVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "pub const",
+			content:  "pub const VERSION: &str = \"1.2.0\";\n",
+			expected: "pub const VERSION: &str = \"1.3.0\";\n",
+		},
+		{
+			name:     "static with lifetime and crate visibility",
+			content:  "pub(crate) static APP_VERSION: &'static str = \"1.2.0\";\n",
+			expected: "pub(crate) static APP_VERSION: &'static str = \"1.3.0\";\n",
+		},
+		{
+			name:     "constants for other versions left alone",
+			content:  "const VERSION: &str = \"1.2.0\";\nconst MIN_PROTOCOL_VERSION: &str = \"0.9.0\";\nconst CLIENT_VERSION: &str = \"1.2.0\";\n",
+			expected: "const VERSION: &str = \"1.3.0\";\nconst MIN_PROTOCOL_VERSION: &str = \"0.9.0\";\nconst CLIENT_VERSION: &str = \"1.3.0\";\n",
+		},
+		{
+			name:     "non-version constants and strings left alone",
+			content:  "const NAME: &str = \"1.2.0\";\nconst VERSION: &str = \"1.2.0\";\nlet v = \"1.2.0\";\n",
+			expected: "const NAME: &str = \"1.2.0\";\nconst VERSION: &str = \"1.3.0\";\nlet v = \"1.2.0\";\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractRustSourceVersion("src/version.rs", tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated, err := updateRustSourceVersion("src/version.rs", tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+
+	if _, err := updateRustSourceVersion("src/lib.rs", "pub const NAME: &str = \"app\";\n", "1.3.0"); err == nil {
+		t.Error("Expected an error without a version constant")
+	}
+}
+
+func TestRustSourceWarnings(t *testing.T) {
+	content := strings.Join([]string{
+		"pub const VERSION: &str = \"1.2.0\";",
+		"pub const BUILD_VERSION: &str = \"dev\";",
+		"pub const PKG: &str = env!(\"CARGO_PKG_VERSION\");",
+		"let v = std::env::var(\"CARGO_PKG_VERSION\").unwrap();",
+	}, "\n")
+
+	warnings := rustSourceWarnings("src/lib.rs", content)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "src/lib.rs:1 hardcodes version 1.2.0") {
+		t.Errorf("Expected the hardcoded constant on line 1, got %q", warnings[0])
+	}
+	if !strings.HasPrefix(warnings[1], "src/lib.rs:4 reads CARGO_PKG_VERSION at run time") {
+		t.Errorf("Expected the run-time read on line 4, got %q", warnings[1])
+	}
+}
@@ -0,0 +1,76 @@
+package version
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// setupPyVersionRe matches the version keyword argument of setup(), e.g. version="1.2.3",
+	setupPyVersionRe = regexp.MustCompile(`(\bversion\s*=\s*)(["'])([^"']+)(["'])`)
+	// setupCfgSectionRe matches an INI section header
+	setupCfgSectionRe = regexp.MustCompile(`^\s*\[([^\]]+)\]`)
+	// setupCfgVersionRe matches a literal version option, keeping its spacing and trailing text
+	setupCfgVersionRe = regexp.MustCompile(`^(\s*version\s*[=:]\s*)([0-9][^\s;#]*)(.*)$`)
+)
+
+// isSetuptoolsFile reports whether a Python version file is a legacy setup.py or setup.cfg
+func isSetuptoolsFile(filePath string) bool {
+	name := filepath.Base(filePath)
+	return name == "setup.py" || name == "setup.cfg"
+}
+
+// setupCfgVersionLine returns the index of the version line in the [metadata] section,
+// or -1. Versions read with attr: or file: directives aren't literal and don't match.
+func setupCfgVersionLine(lines []string) int {
+	section := ""
+	for i, line := range lines {
+		if matches := setupCfgSectionRe.FindStringSubmatch(line); len(matches) >= 2 {
+			section = strings.TrimSpace(matches[1])
+			continue
+		}
+		if section == "metadata" && setupCfgVersionRe.MatchString(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+func extractSetuptoolsVersion(filePath, content string) (*semver.Version, error) {
+	if filepath.Base(filePath) == "setup.py" {
+		matches := setupPyVersionRe.FindStringSubmatch(content)
+		if len(matches) < 4 {
+			return nil, fmt.Errorf("no literal version argument found in %s", filePath)
+		}
+		return semver.NewVersion(matches[3])
+	}
+
+	lines := strings.Split(content, "\n")
+	i := setupCfgVersionLine(lines)
+	if i < 0 {
+		return nil, fmt.Errorf("no literal [metadata] version found in %s", filePath)
+	}
+	return semver.NewVersion(setupCfgVersionRe.FindStringSubmatch(lines[i])[2])
+}
+
+func updateSetuptoolsVersion(filePath, content, newVersion string) (string, error) {
+	if filepath.Base(filePath) == "setup.py" {
+		loc := setupPyVersionRe.FindStringSubmatchIndex(content)
+		if loc == nil {
+			return "", fmt.Errorf("no literal version argument found in %s", filePath)
+		}
+		return content[:loc[6]] + newVersion + content[loc[7]:], nil
+	}
+
+	lines := strings.Split(content, "\n")
+	i := setupCfgVersionLine(lines)
+	if i < 0 {
+		return "", fmt.Errorf("no literal [metadata] version found in %s", filePath)
+	}
+	lines[i] = setupCfgVersionRe.ReplaceAllString(lines[i], "${1}"+newVersion+"${3}")
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,83 @@
+package version
+
+import "testing"
+
+func TestSetuptoolsVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "setup.py keyword argument",
+			path:     "setup.py",
+			content:  "from setuptools import setup\n\nsetup(\n    name=\"app\",\n    version=\"1.2.0\",\n    python_requires=\">=3.8\",\n)\n",
+			expected: "from setuptools import setup\n\nsetup(\n    name=\"app\",\n    version=\"1.3.0\",\n    python_requires=\">=3.8\",\n)\n",
+		},
+		{
+			name:     "setup.py single quotes and spacing",
+			path:     "setup.py",
+			content:  "setup(name='app', version = '1.2.0')\n",
+			expected: "setup(name='app', version = '1.3.0')\n",
+		},
+		{
+			name:     "setup.cfg metadata",
+			path:     "setup.cfg",
+			content:  "[metadata]\nname = app\nversion = 1.2.0\n\n[options]\npython_requires = >=3.8\n",
+			expected: "[metadata]\nname = app\nversion = 1.3.0\n\n[options]\npython_requires = >=3.8\n",
+		},
+		{
+			name:     "setup.cfg colon and trailing comment",
+			path:     "setup.cfg",
+			content:  "[metadata]\nversion: 1.2.0 # keep in sync\n",
+			expected: "[metadata]\nversion: 1.3.0 # keep in sync\n",
+		},
+		{
+			name:     "setup.cfg other sections left alone",
+			path:     "setup.cfg",
+			content:  "[bumpversion]\nversion = 0.9.0\n\n[metadata]\nversion = 1.2.0\n",
+			expected: "[bumpversion]\nversion = 0.9.0\n\n[metadata]\nversion = 1.3.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractSetuptoolsVersion(tt.path, tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated, err := updateSetuptoolsVersion(tt.path, tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestSetuptoolsVersionNotLiteral(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{"setup.cfg attr directive", "setup.cfg", "[metadata]\nname = app\nversion = attr: app.__version__\n"},
+		{"setup.cfg file directive", "setup.cfg", "[metadata]\nversion = file: VERSION\n"},
+		{"setup.cfg outside metadata", "setup.cfg", "[bumpversion]\nversion = 1.2.0\n"},
+		{"setup.py computed version", "setup.py", "setup(name=\"app\", version=get_version())\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if version, err := extractSetuptoolsVersion(tt.path, tt.content); err == nil {
+				t.Errorf("Expected no literal version, got %s", version)
+			}
+			if _, err := updateSetuptoolsVersion(tt.path, tt.content, "1.3.0"); err == nil {
+				t.Error("Expected the update to fail")
+			}
+		})
+	}
+}
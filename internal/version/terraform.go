@@ -0,0 +1,49 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// terraformModuleNameRe matches a provider_meta module_name ending in the module
+	// version, e.g. "blueprints/terraform/my-module/v1.2.0" or "my-module:1.2.0"
+	terraformModuleNameRe = regexp.MustCompile(`(module_name\s*=\s*"[^"]*?[:/]v?)(\d+\.\d+\.\d+[^"]*)(")`)
+	// terraformModuleVersionRe matches a module_version attribute or local
+	terraformModuleVersionRe = regexp.MustCompile(`(module_version\s*=\s*")([^"]+)(")`)
+)
+
+// isTerraformFile reports whether a file name can hold a Terraform module version
+func isTerraformFile(fileName string) bool {
+	return fileName == "versions.tf" || fileName == "main.tf" || fileName == ".terraform-version"
+}
+
+func extractTerraformVersion(filePath, content string) (*semver.Version, error) {
+	// .terraform-version style files hold nothing but the version
+	if !strings.HasSuffix(filePath, ".tf") {
+		version := strings.TrimSpace(content)
+		if version == "" {
+			return nil, fmt.Errorf("%s is empty", filePath)
+		}
+		return semver.NewVersion(version)
+	}
+
+	for _, re := range []*regexp.Regexp{terraformModuleVersionRe, terraformModuleNameRe} {
+		if matches := re.FindStringSubmatch(content); len(matches) >= 3 {
+			return semver.NewVersion(matches[2])
+		}
+	}
+	return nil, fmt.Errorf("no module_version or provider_meta module_name version found in %s", filePath)
+}
+
+func updateTerraformVersion(filePath, content, newVersion string) string {
+	if !strings.HasSuffix(filePath, ".tf") {
+		return newVersion + "\n"
+	}
+
+	content = terraformModuleVersionRe.ReplaceAllString(content, "${1}"+newVersion+"${3}")
+	return terraformModuleNameRe.ReplaceAllString(content, "${1}"+newVersion+"${3}")
+}
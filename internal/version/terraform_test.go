@@ -0,0 +1,84 @@
+package version
+
+import "testing"
+
+func TestTerraformVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		version  string
+		expected string
+	}{
+		{
+			name:     "module_version local",
+			path:     "versions.tf",
+			content:  "locals {\n  module_version = \"1.2.0\"\n}\n\nterraform {\n  required_version = \">= 1.5.0\"\n}\n",
+			version:  "1.2.0",
+			expected: "locals {\n  module_version = \"1.3.0\"\n}\n\nterraform {\n  required_version = \">= 1.5.0\"\n}\n",
+		},
+		{
+			name:     "provider_meta module_name with path",
+			path:     "versions.tf",
+			content:  "terraform {\n  provider_meta \"google\" {\n    module_name = \"blueprints/terraform/my-module/v1.2.0\"\n  }\n}\n",
+			version:  "1.2.0",
+			expected: "terraform {\n  provider_meta \"google\" {\n    module_name = \"blueprints/terraform/my-module/v1.3.0\"\n  }\n}\n",
+		},
+		{
+			name:     "provider_meta module_name with colon",
+			path:     "main.tf",
+			content:  "module_name = \"my-module:1.2.0\"\n",
+			version:  "1.2.0",
+			expected: "module_name = \"my-module:1.3.0\"\n",
+		},
+		{
+			name:     "both kept in sync",
+			path:     "versions.tf",
+			content:  "module_version = \"1.2.0\"\nmodule_name = \"blueprints/my-module/v1.2.0\"\n",
+			version:  "1.2.0",
+			expected: "module_version = \"1.3.0\"\nmodule_name = \"blueprints/my-module/v1.3.0\"\n",
+		},
+		{
+			name:     "terraform-version file",
+			path:     ".terraform-version",
+			content:  "1.2.0\n",
+			version:  "1.2.0",
+			expected: "1.3.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractTerraformVersion(tt.path, tt.content)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if version.String() != tt.version {
+				t.Errorf("Expected version %s, got %s", tt.version, version)
+			}
+			if updated := updateTerraformVersion(tt.path, tt.content, "1.3.0"); updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestTerraformVersionMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{"required_version only", "versions.tf", "terraform {\n  required_version = \">= 1.5.0\"\n}\n"},
+		{"module_name without version", "main.tf", "module_name = \"blueprints/my-module\"\n"},
+		{"empty terraform-version", ".terraform-version", "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if version, err := extractTerraformVersion(tt.path, tt.content); err == nil {
+				t.Errorf("Expected no version, got %s", version)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// fileState is what a version file looked like before the update, so verification
+// only holds it to what it was: files that didn't parse or carry a version are not
+// expected to afterwards
+type fileState struct {
+	parses  bool
+	version *semver.Version
+}
+
+// inspectVersionFile records whether a version file parses and which version it holds
+func (m *Manager) inspectVersionFile(file ProjectFile) fileState {
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return fileState{}
+	}
+	state := fileState{parses: syntaxError(file.Path, content) == nil}
+	if version, err := m.extractVersionFromFile(file.Path, file.Type); err == nil {
+		state.version = version
+	}
+	return state
+}
+
+// verifyVersionFile re-parses a version file after the update and checks that it
+// still parses and now holds newVersion, catching replacements that corrupted it
+func (m *Manager) verifyVersionFile(file ProjectFile, before fileState, newVersion string) error {
+	// Go versions live in git tags, which are created later
+	if file.Type == Go {
+		return nil
+	}
+
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return err
+	}
+	if before.parses {
+		if err := syntaxError(file.Path, content); err != nil {
+			return fmt.Errorf("no longer parses: %v", err)
+		}
+	}
+	if before.version == nil {
+		return nil
+	}
+
+	expected, err := semver.NewVersion(newVersion)
+	if err != nil {
+		return fmt.Errorf("invalid version %s: %v", newVersion, err)
+	}
+	version, err := m.extractVersionFromFile(file.Path, file.Type)
+	if err != nil {
+		return fmt.Errorf("the version can no longer be read: %v", err)
+	}
+	if !version.Equal(expected) {
+		return fmt.Errorf("holds version %s instead of %s", version, expected)
+	}
+	return nil
+}
+
+// syntaxError parses TOML and JSON files, the formats a stray replacement can break
+// without the version extraction noticing
+func syntaxError(path string, content []byte) error {
+	var parsed any
+	switch filepath.Ext(path) {
+	case ".toml":
+		return toml.Unmarshal(content, &parsed)
+	case ".json":
+		return json.Unmarshal(content, &parsed)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// vscodeManifest holds the package.json fields of a VS Code extension
+type vscodeManifest struct {
+	Version string `json:"version"`
+	Engines struct {
+		VSCode string `json:"vscode"`
+	} `json:"engines"`
+}
+
+func extractVSCodeVersion(filePath, content string) (*semver.Version, error) {
+	var manifest vscodeManifest
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Engines.VSCode == "" {
+		return nil, fmt.Errorf("%s is not a VS Code extension manifest (no engines.vscode)", filePath)
+	}
+	if manifest.Version == "" {
+		return nil, fmt.Errorf("no version found in %s", filePath)
+	}
+	return semver.NewVersion(manifest.Version)
+}
+
+// updateVSCodeVersion replaces the top-level version in place, keeping the manifest's
+// formatting and key order; vsce rejects prerelease or build suffixes, so none are expected
+func updateVSCodeVersion(filePath, content, newVersion string) (string, error) {
+	start, end := topLevelJSONString(content, "version")
+	if start < 0 {
+		return "", fmt.Errorf("no version found in %s", filePath)
+	}
+	return content[:start] + newVersion + content[end:], nil
+}
+
+// topLevelJSONString returns the byte range of the string value of a key in the
+// top-level object of a JSON document, excluding the quotes, or -1, -1
+func topLevelJSONString(content, key string) (int, int) {
+	depth := 0
+	expectValue := false
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '{', '[':
+			depth++
+			expectValue = false
+		case '}', ']':
+			depth--
+		case '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(content) {
+				return -1, -1
+			}
+			if depth == 1 && expectValue {
+				return i + 1, end
+			}
+			// A top-level key is a string followed by a colon
+			expectValue = false
+			if depth == 1 && content[i+1:end] == key {
+				j := end + 1
+				for j < len(content) && (content[j] == ' ' || content[j] == '\t' || content[j] == '\n' || content[j] == '\r') {
+					j++
+				}
+				expectValue = j < len(content) && content[j] == ':'
+			}
+			i = end
+		case ',':
+			expectValue = false
+		}
+	}
+	return -1, -1
+}
@@ -0,0 +1,81 @@
+package version
+
+import "testing"
+
+func TestVSCodeVersionRoundTrip(t *testing.T) {
+	content := `{
+  "name": "my-extension",
+  "engines": { "vscode": "^1.85.0" },
+  "contributes": { "configuration": { "properties": { "version": { "type": "string", "default": "1.2.0" } } } },
+  "version": "1.2.0",
+  "dependencies": { "semver": "1.2.0" }
+}
+`
+	expected := `{
+  "name": "my-extension",
+  "engines": { "vscode": "^1.85.0" },
+  "contributes": { "configuration": { "properties": { "version": { "type": "string", "default": "1.2.0" } } } },
+  "version": "1.3.0",
+  "dependencies": { "semver": "1.2.0" }
+}
+`
+
+	version, err := extractVSCodeVersion("package.json", content)
+	if err != nil || version.String() != "1.2.0" {
+		t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+	}
+	updated, err := updateVSCodeVersion("package.json", content, "1.3.0")
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, updated)
+	}
+}
+
+func TestVSCodeVersionMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not an extension", `{"name": "lib", "version": "1.2.0"}`},
+		{"no version", `{"name": "ext", "engines": {"vscode": "^1.85.0"}}`},
+		{"invalid json", `{"version": "1.2.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if version, err := extractVSCodeVersion("package.json", tt.content); err == nil {
+				t.Errorf("Expected no version, got %s", version)
+			}
+		})
+	}
+}
+
+func TestTopLevelJSONString(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"top level", `{"version": "1.2.0"}`, "1.2.0"},
+		{"nested key skipped", `{"a": {"version": "9.9.9"}, "version": "1.2.0"}`, "1.2.0"},
+		{"key inside a value", `{"description": "version", "version": "1.2.0"}`, "1.2.0"},
+		{"escaped quotes", `{"title": "say \"version\"", "version": "1.2.0"}`, "1.2.0"},
+		{"only nested", `{"a": {"version": "9.9.9"}}`, ""},
+		{"not a string", `{"version": 1}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := topLevelJSONString(tt.content, "version")
+			got := ""
+			if start >= 0 {
+				got = tt.content[start:end]
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
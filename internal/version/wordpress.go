@@ -0,0 +1,75 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// wpStableTagRe matches the Stable tag field of a plugin readme.txt
+	wpStableTagRe = regexp.MustCompile(`(?mi)^(Stable tag:\s*)(\S+)`)
+	// wpPluginHeaderRe identifies the main plugin file by its header comment
+	wpPluginHeaderRe = regexp.MustCompile(`(?mi)^[ \t/*#@]*Plugin Name:`)
+	// wpVersionHeaderRe matches the Version field of the plugin header, e.g. " * Version: 1.2.0"
+	wpVersionHeaderRe = regexp.MustCompile(`(?mi)^([ \t/*#@]*Version:\s*)(\S+)`)
+)
+
+// wordPressPluginFiles returns the PHP files in projectRoot that carry a plugin header
+func wordPressPluginFiles(projectRoot string) ([]string, error) {
+	candidates, err := filepath.Glob(filepath.Join(projectRoot, "*.php"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, path := range candidates {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// WordPress only reads the header from the first 8 KB of the file
+		if len(content) > 8192 {
+			content = content[:8192]
+		}
+		if wpPluginHeaderRe.Match(content) {
+			files = append(files, filepath.Base(path))
+		}
+	}
+	return files, nil
+}
+
+func extractWordPressVersion(filePath, content string) (*semver.Version, error) {
+	re := wpVersionHeaderRe
+	if strings.EqualFold(filepath.Base(filePath), "readme.txt") {
+		re = wpStableTagRe
+	} else if !wpPluginHeaderRe.MatchString(content) {
+		return nil, fmt.Errorf("%s has no Plugin Name header", filePath)
+	}
+
+	matches := re.FindStringSubmatch(content)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("no version found in %s", filePath)
+	}
+	return semver.NewVersion(matches[2])
+}
+
+// updateWordPressVersion updates the Stable tag of readme.txt or the Version header
+// of the main plugin file. Only the first match is replaced, so "Requires at least"
+// or "Tested up to" values and version checks in PHP code are left alone.
+func updateWordPressVersion(filePath, content, newVersion string) (string, error) {
+	re := wpVersionHeaderRe
+	if strings.EqualFold(filepath.Base(filePath), "readme.txt") {
+		re = wpStableTagRe
+	}
+
+	loc := re.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("no version found in %s", filePath)
+	}
+	return content[:loc[4]] + newVersion + content[loc[5]:], nil
+}
@@ -0,0 +1,95 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWordPressVersionRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "readme stable tag",
+			path:     "readme.txt",
+			content:  "=== My Plugin ===\nRequires at least: 6.0\nTested up to: 6.5.2\nStable tag: 1.2.0\n\n== Changelog ==\n= 1.2.0 =\n",
+			expected: "=== My Plugin ===\nRequires at least: 6.0\nTested up to: 6.5.2\nStable tag: 1.3.0\n\n== Changelog ==\n= 1.2.0 =\n",
+		},
+		{
+			name:     "plugin header",
+			path:     "my-plugin.php",
+			content:  "<?php\n/**\n * Plugin Name: My Plugin\n * Version: 1.2.0\n * Requires PHP: 7.4\n */\n\nif ( version_compare( $wp_version, '1.2.0' ) ) {}\n",
+			expected: "<?php\n/**\n * Plugin Name: My Plugin\n * Version: 1.3.0\n * Requires PHP: 7.4\n */\n\nif ( version_compare( $wp_version, '1.2.0' ) ) {}\n",
+		},
+		{
+			name:     "line comment header",
+			path:     "my-plugin.php",
+			content:  "<?php\n// Plugin Name: My Plugin\n// Version: 1.2.0\n",
+			expected: "<?php\n// Plugin Name: My Plugin\n// Version: 1.3.0\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := extractWordPressVersion(tt.path, tt.content)
+			if err != nil || version.String() != "1.2.0" {
+				t.Fatalf("Expected 1.2.0, got %v (%v)", version, err)
+			}
+			updated, err := updateWordPressVersion(tt.path, tt.content, "1.3.0")
+			if err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+			if updated != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestWordPressVersionMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		// The readme only counts through its Stable tag, not a Version-like header
+		{"readme without stable tag", "readme.txt", "=== My Plugin ===\nVersion: 1.2.0\n"},
+		{"php without plugin header", "functions.php", "<?php\n/*\n * Version: 1.2.0\n */\n"},
+		{"header without version", "my-plugin.php", "<?php\n/*\n * Plugin Name: My Plugin\n */\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if version, err := extractWordPressVersion(tt.path, tt.content); err == nil {
+				t.Errorf("Expected no version, got %s", version)
+			}
+		})
+	}
+}
+
+func TestWordPressPluginFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"my-plugin.php": "<?php\n/**\n * Plugin Name: My Plugin\n * Version: 1.2.0\n */\n",
+		"uninstall.php": "<?php\ndelete_option( 'my_plugin' );\n",
+		"readme.txt":    "Stable tag: 1.2.0\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plugins, err := wordPressPluginFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plugins, []string{"my-plugin.php"}) {
+		t.Errorf("Expected only the file with a plugin header, got %v", plugins)
+	}
+}
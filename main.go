@@ -1,13 +1,24 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
 
+	"bump-tui/internal/ci"
+	"bump-tui/internal/cli"
+	"bump-tui/internal/config"
+	"bump-tui/internal/crash"
+	"bump-tui/internal/git"
 	"bump-tui/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
 )
 
 var (
@@ -17,8 +28,53 @@ var (
 )
 
 func main() {
+	environment, ciSettings := setupCI()
+
+	// `bump-tui train` checks the release train cadence, e.g. from a scheduled CI job
+	if len(os.Args) > 1 && os.Args[1] == "train" {
+		runTrain(os.Args[2:])
+		return
+	}
+	// `bump-tui multi` releases the repositories listed in a manifest together
+	if len(os.Args) > 1 && os.Args[1] == "multi" {
+		runMulti(os.Args[2:])
+		return
+	}
+	// `bump-tui preview` reports what the next release would contain, e.g. for a pull request comment
+	if len(os.Args) > 1 && os.Args[1] == "preview" {
+		runPreview(os.Args[2:])
+		return
+	}
+	// `bump-tui validate` runs the repository checks alone, e.g. in CI or an editor
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+	// `bump-tui commit` composes a conventional commit from prompts
+	if len(os.Args) > 1 && os.Args[1] == "commit" {
+		runCommit()
+		return
+	}
+	// `bump-tui hooks` installs git hooks that validate before pushing and lint commit messages
+	if len(os.Args) > 1 && os.Args[1] == "hooks" {
+		runHooks(os.Args[2:])
+		return
+	}
+
 	var showVersion = flag.Bool("version", false, "Show version information")
 	var showHelp = flag.Bool("help", false, "Show help information")
+	var since = flag.String("since", "", "Generate the changelog from commits after this ref instead of the last tag")
+	var until = flag.String("until", "", "Generate the changelog from commits up to this ref instead of HEAD")
+	var noTTY = flag.Bool("no-tty", false, "Use plain prompts instead of the TUI")
+	var bump = flag.String("bump", "", "Release without asking for the bump type: major, minor, patch or auto")
+	var yes = flag.Bool("yes", false, "Answer yes to every confirmation")
+	var forceWithLease = flag.Bool("force-with-lease", false, "Replace an existing remote release branch in the pull-request workflow")
+	var noVerify = flag.Bool("no-verify", false, "Skip git hooks for the release commit and pushes")
+	var tagOnly = flag.Bool("tag-only", false, "Only create and push a tag for the new version at HEAD, without touching files")
+	var changelogOnly = flag.String("changelog-only", "", "Write the changelog entry for an existing tag without bumping, committing or tagging")
+	var notesOut = flag.String("notes-out", "", "Also write the changelog entry to this path; {{.Version}} and {{.Tag}} are expanded")
+	var auto = flag.Bool("auto", false, "Release headlessly, inferring the bump from conventional commits (same as -bump auto -yes)")
+	var rehearse = flag.Bool("rehearse", false, "Run the release in a temporary local clone and show the commits, tags and pushes it would make")
 	flag.Parse()
 
 	if *showVersion {
@@ -33,16 +89,54 @@ func main() {
 		fmt.Println("")
 		fmt.Println("Usage:")
 		fmt.Println("  bump-tui [flags]")
+		fmt.Println("  bump-tui train [-auto]  Report whether a release train is due; -auto releases it")
+		fmt.Println("  bump-tui multi [-manifest file] [-bump type] [-yes]")
+		fmt.Println("                          Release the repositories in bump-repos.toml together")
+		fmt.Println("  bump-tui preview [-format md|json] [-bump type]")
+		fmt.Println("                          Report the next release without changing anything")
+		fmt.Println("  bump-tui validate [-json] [-quick]")
+		fmt.Println("                          Run the repository checks; exits 1 when one blocks a release")
+		fmt.Println("  bump-tui hooks install [-commit-msg] [-no-pre-push] [-force] [-print lefthook|pre-commit]")
+		fmt.Println("                          Validate before pushing (and lint commit messages) with git hooks")
+		fmt.Println("  bump-tui hooks uninstall")
+		fmt.Println("                          Remove the hooks written by hooks install")
+		fmt.Println("  bump-tui commit          Compose a conventional commit for the staged changes")
 		fmt.Println("")
 		fmt.Println("Flags:")
 		fmt.Println("  -version    Show version information")
 		fmt.Println("  -help       Show this help message")
+		fmt.Println("  -since ref  Start the changelog after this tag, branch or commit")
+		fmt.Println("  -until ref  End the changelog at this tag, branch or commit")
+		fmt.Println("  -no-tty     Use plain prompts instead of the TUI")
+		fmt.Println("  -bump type  Release without asking: major, minor, patch or auto")
+		fmt.Println("  -yes        Answer yes to every confirmation")
+		fmt.Println("  -auto       Release headlessly with the bump inferred from commits")
+		fmt.Println("  -no-verify  Skip git hooks for the release commit and pushes")
+		fmt.Println("  -tag-only   Only tag and push HEAD; no version files, changelog or commit")
+		fmt.Println("  -rehearse   Run the release in a temporary clone and show what it would create")
+		fmt.Println("  -changelog-only version")
+		fmt.Println("              Write the changelog entry for an existing tag only")
+		fmt.Println("  -notes-out path")
+		fmt.Println("              Also write the entry to this file (headless mode), e.g. release-notes/{{.Tag}}.md")
+		fmt.Println("  -force-with-lease")
+		fmt.Println("              Replace an existing remote release branch (pull-request workflow)")
+		fmt.Println("")
+		fmt.Println("The TUI is replaced by plain prompts when stdin or stdout is not a terminal.")
+		fmt.Println("In CI (CI=true or GITHUB_ACTIONS=true) bump-tui runs headless, like -auto, unless")
+		fmt.Println("ci.headless is off in .bump.toml.")
 		fmt.Println("")
 		fmt.Println("Supported project types:")
-		fmt.Println("  • Rust (Cargo.toml)")
-		fmt.Println("  • Python (pyproject.toml)")
+		fmt.Println("  • Rust (Cargo.toml, version constants in .rs files listed in .bump)")
+		fmt.Println("  • Python (pyproject.toml, setup.py, setup.cfg, __version__ in modules listed in .bump)")
 		fmt.Println("  • C++ (CMakeLists.txt)")
 		fmt.Println("  • PlatformIO (platformio.ini, library.json, library.properties)")
+		fmt.Println("  • OpenAPI/Swagger specs (openapi.yaml, swagger.json, ...)")
+		fmt.Println("  • Protobuf (.proto files with a version option)")
+		fmt.Println("  • Terraform modules (versions.tf, main.tf, .terraform-version)")
+		fmt.Println("  • Nix (flake.nix, default.nix)")
+		fmt.Println("  • RPM (*.spec)")
+		fmt.Println("  • WordPress plugins (readme.txt, plugin header)")
+		fmt.Println("  • VS Code extensions (package.json)")
 		fmt.Println("")
 		fmt.Println("Requirements:")
 		fmt.Println("  • Git repository")
@@ -50,7 +144,8 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Enable debug logging if DEBUG env var is set
+	// Enable debug logging if DEBUG env var is set; otherwise keep log output off the TUI
+	log.SetOutput(io.Discard)
 	if len(os.Getenv("DEBUG")) > 0 {
 		f, err := tea.LogToFile("debug.log", "debug")
 		if err != nil {
@@ -64,14 +159,268 @@ func main() {
 		}()
 	}
 
+	if *auto {
+		*bump = "auto"
+		*yes = true
+	}
+	// CI runs unattended: plain output, no confirmations and the bump inferred from
+	// the commits unless one is given
+	if environment.Active() && ciSettings.Headless {
+		*noTTY = true
+		*yes = true
+		if *bump == "" && *changelogOnly == "" {
+			*bump = "auto"
+		}
+	}
+
+	// Fall back to plain prompts when escape sequences would corrupt the output
+	// (pipes, CI, some IDE terminals) or when running headless
+	isTerminal := term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+	if *noTTY || !isTerminal || *bump != "" || *changelogOnly != "" || *notesOut != "" || *rehearse {
+		prompter := cli.NewPrompter(cli.Options{
+			Since:          *since,
+			Until:          *until,
+			Bump:           *bump,
+			Yes:            *yes,
+			ForceWithLease: *forceWithLease,
+			NoVerify:       *noVerify,
+			TagOnly:        *tagOnly,
+			ChangelogOnly:  *changelogOnly,
+			NotesOut:       *notesOut,
+			Rehearse:       *rehearse,
+		}, os.Stdin, os.Stdout)
+		if err := prompter.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Start the TUI
+	programOptions := []tea.ProgramOption{
+		// Signals and panics are handled by runTUI so a running release is rolled back or reported
+		tea.WithoutSignalHandler(),
+		tea.WithoutCatchPanics(),
+	}
+	// CI logs keep whatever is printed, so stay on the main screen there
+	if !environment.Active() {
+		programOptions = append(programOptions, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	}
 	p := tea.NewProgram(
-		models.NewMainModel(),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
+		models.NewMainModel(models.Options{
+			Version:        version,
+			Since:          *since,
+			Until:          *until,
+			ForceWithLease: *forceWithLease,
+			NoVerify:       *noVerify,
+			TagOnly:        *tagOnly,
+		}),
+		programOptions...,
 	)
+	runTUI(p)
+}
+
+// setupCI detects a CI environment and sets the git identity and GitHub token for it
+// from the ci settings. A broken settings file is reported later, when the project
+// is loaded; the defaults apply until then.
+func setupCI() (ci.Environment, config.CISettings) {
+	environment := ci.Detect(os.Getenv)
+	settings := config.DefaultSettings().CI
+	if !environment.Active() {
+		return environment, settings
+	}
+	if loaded, err := config.LoadSettings("."); err == nil {
+		settings = loaded.CI
+	}
+	if err := environment.Configure(settings); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to configure %s: %v\n", environment.Name, err)
+	}
+	return environment, settings
+}
+
+// runTUI runs the program, forwarding SIGINT, SIGTERM and SIGHUP to the model so a
+// running release is rolled back before exiting. A panic restores the terminal, writes
+// a crash report and prints how to recover the repository.
+func runTUI(p *tea.Program) {
+	fd := int(os.Stdin.Fd())
+	terminalState, err := term.GetState(fd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	go func() {
+		for range signals {
+			p.Send(models.InterruptMsg{})
+		}
+	}()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		p.Kill()
+		restoreTerminal(fd, terminalState)
+
+		report, ok := r.(*crash.Report)
+		if !ok {
+			report = crash.NewReport(r, debug.Stack(), nil, git.NewManager(), "")
+		}
+		fmt.Fprintf(os.Stderr, "bump-tui crashed: %v\n\n", report.Value)
+		for _, line := range report.Recovery {
+			fmt.Fprintln(os.Stderr, line)
+		}
+		if path, err := crash.Write(os.TempDir(), report); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n\n%s", err, report)
+		} else {
+			fmt.Fprintf(os.Stderr, "\nCrash report with the stack trace: %s\n", path)
+		}
+		os.Exit(2)
+	}()
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		log.Fatal(err)
 	}
+	// Report what happened to a release the user quit during
+	if model, ok := final.(models.MainModel); ok && model.QuitNote() != "" {
+		fmt.Println(model.QuitNote())
+	}
+}
+
+// restoreTerminal leaves the alternate screen, turns off mouse reporting, shows the
+// cursor and leaves raw mode after the program stopped without tearing down
+func restoreTerminal(fd int, state *term.State) {
+	fmt.Fprint(os.Stdout, "\x1b[?1002l\x1b[?1006l\x1b[?25h\x1b[?1049l")
+	if err := term.Restore(fd, state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to restore the terminal: %v\n", err)
+	}
+}
+
+// runTrain reports whether a release is due on the configured train cadence and,
+// with -auto, releases it headlessly
+func runTrain(args []string) {
+	flags := flag.NewFlagSet("train", flag.ExitOnError)
+	var auto = flags.Bool("auto", false, "Release headlessly when a train is due")
+	var noVerify = flags.Bool("no-verify", false, "Skip git hooks for the release commit and pushes")
+	_ = flags.Parse(args)
+
+	log.SetOutput(io.Discard)
+	prompter := cli.NewPrompter(cli.Options{NoVerify: *noVerify}, os.Stdin, os.Stdout)
+	if err := prompter.RunTrain(*auto); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runPreview writes a report of the next release to stdout; progress goes to stderr
+func runPreview(args []string) {
+	flags := flag.NewFlagSet("preview", flag.ExitOnError)
+	var format = flags.String("format", cli.PreviewMarkdown, "Report format: md or json")
+	var bump = flags.String("bump", "auto", "Bump type: major, minor, patch or auto")
+	var since = flags.String("since", "", "Generate the changelog from commits after this ref instead of the last tag")
+	var until = flags.String("until", "", "Generate the changelog from commits up to this ref instead of HEAD")
+	_ = flags.Parse(args)
+
+	log.SetOutput(io.Discard)
+	prompter := cli.NewPrompter(cli.Options{Bump: *bump, Since: *since, Until: *until}, os.Stdin, os.Stderr)
+	if err := prompter.RunPreview(*format, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runValidate runs the repository validation and exits with 1 when it blocks a release
+func runValidate(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var jsonOutput = flags.Bool("json", false, "Write the findings as JSON with their severity, code and suggested fix")
+	var quick = flags.Bool("quick", false, "Run only the local checks, without the network (e.g. in a pre-push hook)")
+	_ = flags.Parse(args)
+
+	log.SetOutput(io.Discard)
+	// Keep stdout for the report in JSON mode
+	progress := os.Stdout
+	if *jsonOutput {
+		progress = os.Stderr
+	}
+	prompter := cli.NewPrompter(cli.Options{Quick: *quick}, os.Stdin, progress)
+	if err := prompter.RunValidate(*jsonOutput, os.Stdout); err != nil {
+		if !errors.Is(err, cli.ErrValidationFailed) || !*jsonOutput {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// runMulti releases every repository in a manifest together with all-or-nothing
+// semantics up to the push
+func runMulti(args []string) {
+	flags := flag.NewFlagSet("multi", flag.ExitOnError)
+	var manifest = flags.String("manifest", config.ManifestFileName, "Manifest listing the repositories to release")
+	var bump = flags.String("bump", "", "Bump type for every repository: major, minor, patch or auto")
+	var yes = flags.Bool("yes", false, "Answer yes to every confirmation")
+	var noVerify = flags.Bool("no-verify", false, "Skip git hooks for the release commits and pushes")
+	_ = flags.Parse(args)
+
+	log.SetOutput(io.Discard)
+	opts := cli.Options{Bump: *bump, Yes: *yes, NoVerify: *noVerify}
+	if err := cli.RunMulti(*manifest, opts, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runCommit() {
+	log.SetOutput(io.Discard)
+	prompter := cli.NewPrompter(cli.Options{}, os.Stdin, os.Stdout)
+	if err := prompter.RunCommit(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runHooks(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: bump-tui hooks install|uninstall|commit-msg")
+		os.Exit(2)
+	}
+
+	var err error
+	switch args[0] {
+	case "install":
+		flags := flag.NewFlagSet("hooks install", flag.ExitOnError)
+		var commitMsg = flags.Bool("commit-msg", false, "Also reject commit messages that aren't conventional commits")
+		var noPrePush = flags.Bool("no-pre-push", false, "Don't validate the repository before pushing")
+		var force = flags.Bool("force", false, "Replace existing hooks that weren't installed by bump-tui")
+		var manager = flags.String("print", "", "Print the configuration for a hook manager (lefthook or pre-commit) instead")
+		_ = flags.Parse(args[1:])
+
+		opts := cli.HookOptions{PrePush: !*noPrePush, CommitMsg: *commitMsg, Force: *force}
+		if *manager != "" {
+			var snippet string
+			if snippet, err = cli.HookConfig(*manager, opts); err == nil {
+				fmt.Print(snippet)
+			}
+		} else {
+			err = cli.InstallHooks(opts, os.Stdout)
+		}
+	case "uninstall":
+		err = cli.UninstallHooks(os.Stdout)
+	case "commit-msg":
+		// Run by the commit-msg hook with the file holding the message
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "Usage: bump-tui hooks commit-msg <file>")
+			os.Exit(2)
+		}
+		err = cli.CheckCommitMessage(args[1])
+	default:
+		err = fmt.Errorf("unknown hooks command %q", args[0])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 }